@@ -0,0 +1,503 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/khifile"
+)
+
+const inspectCommandName = "inspect"
+
+// inspectSubcommand is a subcommand of `inspect` reading a .khi file and printing its content.
+type inspectSubcommand struct {
+	description string
+	run         func(args []string, stdout io.Writer) error
+}
+
+var inspectSubcommands = map[string]inspectSubcommand{}
+
+var inspectSubcommandOrder = []string{"timelines", "revisions", "logs", "manifest", "snapshot", "diff"}
+
+func init() {
+	inspectSubcommands["timelines"] = inspectSubcommand{description: "List resources and their timeline statistics.", run: runInspectTimelines}
+	inspectSubcommands["revisions"] = inspectSubcommand{description: "List revisions of a resource.", run: runInspectRevisions}
+	inspectSubcommands["logs"] = inspectSubcommand{description: "List logs filtered with resource, time range and severity.", run: runInspectLogs}
+	inspectSubcommands["manifest"] = inspectSubcommand{description: "Print the manifest of a resource at a specific time.", run: runInspectManifest}
+	inspectSubcommands["snapshot"] = inspectSubcommand{description: "Export manifests of every resource existing at a specific time.", run: runInspectSnapshot}
+	inspectSubcommands["diff"] = inspectSubcommand{description: "Compare resources and error logs of two .khi files.", run: runInspectDiff}
+}
+
+// runInspectCommand runs `inspect` subcommands with the arguments after `inspect` and returns the exit code.
+func runInspectCommand(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		printInspectUsage(stderr)
+		return 2
+	}
+	subcommand, found := inspectSubcommands[args[0]]
+	if !found {
+		fmt.Fprintf(stderr, "unknown subcommand %q\n\n", args[0])
+		printInspectUsage(stderr)
+		return 2
+	}
+	err := subcommand.run(args[1:], stdout)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "%s %s: %v\n", inspectCommandName, args[0], err)
+		return 1
+	}
+	return 0
+}
+
+func printInspectUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s <subcommand> [flags] <file.khi>\n\nSubcommands:\n", inspectCommandName)
+	for _, name := range inspectSubcommandOrder {
+		fmt.Fprintf(w, "  %-10s %s\n", name, inspectSubcommands[name].description)
+	}
+}
+
+// inspectFlagSet holds the flags shared among `inspect` subcommands.
+type inspectFlagSet struct {
+	*flag.FlagSet
+	output *string
+}
+
+func newInspectFlagSet(name string) *inspectFlagSet {
+	fs := flag.NewFlagSet(fmt.Sprintf("%s %s", inspectCommandName, name), flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s %s [flags] <file.khi>\n", inspectCommandName, name)
+		fs.PrintDefaults()
+	}
+	return &inspectFlagSet{
+		FlagSet: fs,
+		output:  fs.String("output", "text", "Output format. `text` or `json`."),
+	}
+}
+
+// parseAndOpen parses the given arguments and opens the .khi file given as the positional argument.
+func (f *inspectFlagSet) parseAndOpen(args []string) (*khifile.Reader, error) {
+	readers, err := f.parseAndOpenN(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return readers[0], nil
+}
+
+// parseAndOpenN parses the given arguments and opens the .khi files given as exactly n positional arguments.
+func (f *inspectFlagSet) parseAndOpenN(args []string, n int) ([]*khifile.Reader, error) {
+	if err := f.Parse(args); err != nil {
+		return nil, err
+	}
+	if *f.output != "text" && *f.output != "json" {
+		return nil, fmt.Errorf("unsupported output format %q", *f.output)
+	}
+	if f.NArg() != n {
+		f.Usage()
+		return nil, fmt.Errorf("exactly %d .khi file path(s) are required", n)
+	}
+	readers := []*khifile.Reader{}
+	for _, filePath := range f.Args() {
+		reader, err := khifile.Open(filePath)
+		if err != nil {
+			for _, opened := range readers {
+				opened.Close()
+			}
+			return nil, err
+		}
+		readers = append(readers, reader)
+	}
+	return readers, nil
+}
+
+func (f *inspectFlagSet) isJSON() bool {
+	return *f.output == "json"
+}
+
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+type timelineOutput struct {
+	Path          string    `json:"path"`
+	Relationship  string    `json:"relationship"`
+	RevisionCount int       `json:"revisionCount"`
+	EventCount    int       `json:"eventCount"`
+	FirstRevision time.Time `json:"firstRevision,omitzero"`
+	LastRevision  time.Time `json:"lastRevision,omitzero"`
+	LastState     string    `json:"lastState,omitempty"`
+}
+
+func runInspectTimelines(args []string, stdout io.Writer) error {
+	fs := newInspectFlagSet("timelines")
+	prefix := fs.String("prefix", "", "Only list resources with the path starting with this prefix (e.g `core/v1#pod#kube-system`).")
+	reader, err := fs.parseAndOpen(args)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	outputs := []timelineOutput{}
+	for _, entry := range reader.Timelines(*prefix) {
+		output := timelineOutput{
+			Path:          entry.Resource.FullResourcePath,
+			Relationship:  enum.ParentRelationships[entry.Resource.Relationship].EnumKeyName,
+			RevisionCount: len(entry.Timeline.Revisions),
+			EventCount:    len(entry.Timeline.Events),
+		}
+		if len(entry.Timeline.Revisions) > 0 {
+			first := entry.Timeline.Revisions[0]
+			last := entry.Timeline.Revisions[len(entry.Timeline.Revisions)-1]
+			output.FirstRevision = first.ChangeTime
+			output.LastRevision = last.ChangeTime
+			output.LastState = enum.RevisionStates[last.State].Label
+		}
+		outputs = append(outputs, output)
+	}
+	if fs.isJSON() {
+		return writeJSON(stdout, outputs)
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "PATH\tREVISIONS\tEVENTS\tLAST CHANGE\tLAST STATE")
+	for _, output := range outputs {
+		lastChange := "-"
+		if !output.LastRevision.IsZero() {
+			lastChange = output.LastRevision.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\n", output.Path, output.RevisionCount, output.EventCount, lastChange, output.LastState)
+	}
+	return tw.Flush()
+}
+
+type revisionOutput struct {
+	ChangeTime time.Time `json:"changeTime"`
+	Verb       string    `json:"verb"`
+	State      string    `json:"state"`
+	Requestor  string    `json:"requestor"`
+	LogID      string    `json:"logId"`
+}
+
+func runInspectRevisions(args []string, stdout io.Writer) error {
+	fs := newInspectFlagSet("revisions")
+	resourcePath := fs.String("resource", "", "(Required) The resource path of the timeline (e.g `core/v1#pod#default#nginx`).")
+	reader, err := fs.parseAndOpen(args)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if *resourcePath == "" {
+		return fmt.Errorf("--resource is required")
+	}
+	timeline, found := reader.TimelineOf(*resourcePath)
+	if !found {
+		return fmt.Errorf("no timeline was found for the resource %s", *resourcePath)
+	}
+
+	outputs := []revisionOutput{}
+	for _, revision := range timeline.Revisions {
+		requestor, err := reader.ReadString(revision.Requestor)
+		if err != nil {
+			return err
+		}
+		outputs = append(outputs, revisionOutput{
+			ChangeTime: revision.ChangeTime,
+			Verb:       enum.RevisionVerbs[revision.Verb].Label,
+			State:      enum.RevisionStates[revision.State].Label,
+			Requestor:  requestor,
+			LogID:      revision.Log,
+		})
+	}
+	if fs.isJSON() {
+		return writeJSON(stdout, outputs)
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tVERB\tSTATE\tREQUESTOR")
+	for _, output := range outputs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", output.ChangeTime.Format(time.RFC3339), output.Verb, output.State, output.Requestor)
+	}
+	return tw.Flush()
+}
+
+type logOutput struct {
+	Timestamp time.Time `json:"timestamp"`
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Severity  string    `json:"severity"`
+	Summary   string    `json:"summary"`
+	Body      string    `json:"body,omitempty"`
+}
+
+func runInspectLogs(args []string, stdout io.Writer) error {
+	fs := newInspectFlagSet("logs")
+	resourcePath := fs.String("resource", "", "Only list logs associated with this resource or its subresources (e.g `core/v1#pod#default#nginx`).")
+	since := fs.String("since", "", "Only list logs at or after this time. Accepts RFC3339 time or a duration before the end of the inspection range (e.g `15m`).")
+	until := fs.String("until", "", "Only list logs at or before this time. Accepts the same format as --since.")
+	severity := fs.String("severity", "", "Only list logs with this severity or higher (e.g `ERROR`).")
+	withBody := fs.Bool("body", false, "Include the log body in the output.")
+	reader, err := fs.parseAndOpen(args)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	filter := khifile.LogFilter{ResourcePath: *resourcePath}
+	reference := inspectionEndTime(reader)
+	if filter.Since, err = parseInspectTime(*since, reference); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if filter.Until, err = parseInspectTime(*until, reference); err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+	if filter.MinSeverity, err = parseSeverity(*severity); err != nil {
+		return err
+	}
+
+	outputs := []logOutput{}
+	for _, l := range reader.FilterLogs(filter) {
+		summary, err := reader.ReadString(l.Summary)
+		if err != nil {
+			return err
+		}
+		output := logOutput{
+			Timestamp: l.Timestamp,
+			ID:        l.ID,
+			Type:      enum.LogTypes[l.Type].Label,
+			Severity:  enum.Severities[l.Severity].Label,
+			Summary:   summary,
+		}
+		if *withBody {
+			if output.Body, err = reader.ReadString(l.Body); err != nil {
+				return err
+			}
+		}
+		outputs = append(outputs, output)
+	}
+	if fs.isJSON() {
+		return writeJSON(stdout, outputs)
+	}
+	for _, output := range outputs {
+		fmt.Fprintf(stdout, "%s %-7s %-20s %s\n", output.Timestamp.Format(time.RFC3339Nano), output.Severity, output.Type, output.Summary)
+		if output.Body != "" {
+			fmt.Fprintf(stdout, "%s\n---\n", strings.TrimRight(output.Body, "\n"))
+		}
+	}
+	return nil
+}
+
+func runInspectManifest(args []string, stdout io.Writer) error {
+	fs := newInspectFlagSet("manifest")
+	resourcePath := fs.String("resource", "", "(Required) The resource path of the timeline (e.g `core/v1#pod#default#nginx`).")
+	at := fs.String("at", "", "The time to get the manifest at. Accepts the same format as `logs --since`. Uses the end of the inspection range when omitted.")
+	reader, err := fs.parseAndOpen(args)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if *resourcePath == "" {
+		return fmt.Errorf("--resource is required")
+	}
+	atTime := inspectionEndTime(reader)
+	if *at != "" {
+		if atTime, err = parseInspectTime(*at, atTime); err != nil {
+			return fmt.Errorf("invalid --at: %w", err)
+		}
+	}
+	timeline, found := reader.TimelineOf(*resourcePath)
+	if !found {
+		return fmt.Errorf("no timeline was found for the resource %s", *resourcePath)
+	}
+	revision := khifile.RevisionAt(timeline, atTime)
+	if revision == nil {
+		return fmt.Errorf("the resource %s has no revision at or before %s", *resourcePath, atTime.Format(time.RFC3339))
+	}
+	body, err := reader.ReadString(revision.Body)
+	if err != nil {
+		return err
+	}
+	if fs.isJSON() {
+		return writeJSON(stdout, map[string]any{
+			"changeTime": revision.ChangeTime,
+			"state":      enum.RevisionStates[revision.State].Label,
+			"manifest":   body,
+		})
+	}
+	fmt.Fprintf(stdout, "# %s at %s (changed at %s, %s)\n", *resourcePath, atTime.Format(time.RFC3339), revision.ChangeTime.Format(time.RFC3339), enum.RevisionStates[revision.State].Label)
+	fmt.Fprintln(stdout, strings.TrimRight(body, "\n"))
+	return nil
+}
+
+type snapshotOutput struct {
+	Path       string    `json:"path"`
+	ChangeTime time.Time `json:"changeTime"`
+	State      string    `json:"state"`
+	Manifest   string    `json:"manifest"`
+}
+
+func runInspectSnapshot(args []string, stdout io.Writer) error {
+	fs := newInspectFlagSet("snapshot")
+	at := fs.String("at", "", "The time to take the snapshot at. Accepts the same format as `logs --since`. Uses the end of the inspection range when omitted.")
+	outputDir := fs.String("output-dir", "", "Write each manifest as `<namespace>/<kind>.<group>/<name>.yaml` under this directory instead of printing a multi-document YAML.")
+	reader, err := fs.parseAndOpen(args)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	atTime := inspectionEndTime(reader)
+	if *at != "" {
+		if atTime, err = parseInspectTime(*at, atTime); err != nil {
+			return fmt.Errorf("invalid --at: %w", err)
+		}
+	}
+	entries, err := reader.Snapshot(atTime)
+	if err != nil {
+		return err
+	}
+	if *outputDir != "" {
+		if err := khifile.WriteSnapshotDirectory(*outputDir, entries); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Wrote %d manifests at %s to %s\n", len(entries), atTime.Format(time.RFC3339), *outputDir)
+		return nil
+	}
+	if fs.isJSON() {
+		outputs := []snapshotOutput{}
+		for _, entry := range entries {
+			outputs = append(outputs, snapshotOutput{
+				Path:       entry.ResourcePath,
+				ChangeTime: entry.ChangeTime,
+				State:      enum.RevisionStates[entry.State].Label,
+				Manifest:   entry.Manifest,
+			})
+		}
+		return writeJSON(stdout, outputs)
+	}
+	return khifile.WriteSnapshotYAML(stdout, entries)
+}
+
+func runInspectDiff(args []string, stdout io.Writer) error {
+	fs := newInspectFlagSet("diff")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s diff [flags] <base.khi> <target.khi>\n", inspectCommandName)
+		fs.PrintDefaults()
+	}
+	readers, err := fs.parseAndOpenN(args, 2)
+	if err != nil {
+		return err
+	}
+	for _, reader := range readers {
+		defer reader.Close()
+	}
+	comparison, err := khifile.Compare(readers[0], readers[1])
+	if err != nil {
+		return err
+	}
+	if fs.isJSON() {
+		return writeJSON(stdout, comparison)
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Resources only in base (%d):\n", len(comparison.OnlyInBase))
+	for _, resource := range comparison.OnlyInBase {
+		fmt.Fprintf(tw, "  %s\t%d revisions\t%s\n", resource.ResourcePath, resource.Base.RevisionCount, formatStates(resource.Base))
+	}
+	fmt.Fprintf(tw, "\nResources only in target (%d):\n", len(comparison.OnlyInTarget))
+	for _, resource := range comparison.OnlyInTarget {
+		fmt.Fprintf(tw, "  %s\t%d revisions\t%s\n", resource.ResourcePath, resource.Target.RevisionCount, formatStates(resource.Target))
+	}
+	fmt.Fprintf(tw, "\nChanged resources (%d):\n", len(comparison.Changed))
+	fmt.Fprintln(tw, "  PATH\tREVISIONS\tEVENTS\tSTATES (BASE)\tSTATES (TARGET)")
+	for _, resource := range comparison.Changed {
+		fmt.Fprintf(tw, "  %s\t%d -> %d (%+d)\t%d -> %d\t%s\t%s\n", resource.ResourcePath, resource.Base.RevisionCount, resource.Target.RevisionCount, resource.RevisionCountDelta, resource.Base.EventCount, resource.Target.EventCount, formatStates(resource.Base), formatStates(resource.Target))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "\nNew error logs (%d):\n", len(comparison.NewErrorLogs))
+	for _, l := range comparison.NewErrorLogs {
+		fmt.Fprintf(stdout, "  %5d %-7s %s (first at %s)\n", l.Count, l.Severity, l.Summary, l.FirstTimestamp.Format(time.RFC3339))
+		for _, path := range l.ResourcePaths {
+			fmt.Fprintf(stdout, "          %s\n", path)
+		}
+	}
+	return nil
+}
+
+// formatStates returns the state distribution of the timeline like `Existing=2,Deleted=1` ordered by the revision state.
+func formatStates(stats *khifile.TimelineStats) string {
+	states := []string{}
+	for state := enum.RevisionState(0); state < enum.RevisionState(len(enum.RevisionStates)); state++ {
+		metadata, found := enum.RevisionStates[state]
+		if !found {
+			continue
+		}
+		if count := stats.States[metadata.EnumKeyName]; count > 0 {
+			states = append(states, fmt.Sprintf("%s=%d", strings.TrimPrefix(metadata.EnumKeyName, "RevisionState"), count))
+		}
+	}
+	if len(states) == 0 {
+		return "-"
+	}
+	return strings.Join(states, ",")
+}
+
+// inspectionEndTime returns the end of the inspected time range. It falls back to the timestamp of the last log when the header doesn't have it.
+func inspectionEndTime(reader *khifile.Reader) time.Time {
+	if header, err := reader.Header(); err == nil && header.EndTimeUnixSeconds != 0 {
+		return time.Unix(header.EndTimeUnixSeconds, 0).UTC()
+	}
+	if len(reader.History.Logs) > 0 {
+		return reader.History.Logs[len(reader.History.Logs)-1].Timestamp
+	}
+	return time.Now()
+}
+
+// parseInspectTime parses a time in RFC3339 or a duration before the reference time. It returns the zero time for an empty string.
+func parseInspectTime(value string, reference time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither RFC3339 time nor duration", value)
+	}
+	return reference.Add(-duration), nil
+}
+
+// parseSeverity returns the severity matching the given label case insensitively. It returns SeverityUnknown for an empty string.
+func parseSeverity(label string) (enum.Severity, error) {
+	if label == "" {
+		return enum.SeverityUnknown, nil
+	}
+	for severity, metadata := range enum.Severities {
+		if strings.EqualFold(metadata.Label, label) {
+			return severity, nil
+		}
+	}
+	return enum.SeverityUnknown, fmt.Errorf("unknown severity %q", label)
+}
@@ -0,0 +1,575 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/khifile"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/testkhifile"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	testPodPath       = resourcepath.NameLayerGeneralItem("core/v1", "pod", "default", "nginx")
+	testConfigMapPath = resourcepath.NameLayerGeneralItem("core/v1", "configmap", "default", "config")
+	testNodePath      = resourcepath.NameLayerGeneralItem("core/v1", "node", "cluster-scope", "node-1")
+	testSecretPath    = resourcepath.NameLayerGeneralItem("core/v1", "secret", "default", "secret")
+)
+
+const (
+	testPodManifest       = "apiVersion: v1\nkind: Pod\nmetadata:\n  name: nginx\n  namespace: default\n"
+	testConfigMapManifest = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n  namespace: default\n"
+)
+
+// testBaseEntries are the entries of the .khi file used as the base inspection. The inspection ends at testkhifile.BaseTime + 1h.
+// The pod is created at 0m and deleted at 10m, the configmap is created at 5m and the node has an error event at 20m.
+func testBaseEntries() []testkhifile.Entry {
+	return []testkhifile.Entry{
+		{
+			InsertID: "pod-create",
+			Summary:  "create nginx",
+			Path:     testPodPath,
+			Revision: &history.StagingResourceRevision{Verb: enum.RevisionVerbCreate, Body: testPodManifest, Requestor: "user@example.com", State: enum.RevisionStateExisting},
+		},
+		{
+			InsertID: "configmap-create",
+			Summary:  "create config",
+			Offset:   5 * time.Minute,
+			Path:     testConfigMapPath,
+			Revision: &history.StagingResourceRevision{Verb: enum.RevisionVerbCreate, Body: testConfigMapManifest, Requestor: "user@example.com", State: enum.RevisionStateExisting},
+		},
+		{
+			InsertID: "pod-delete",
+			Summary:  "delete nginx",
+			Offset:   10 * time.Minute,
+			Path:     testPodPath,
+			Revision: &history.StagingResourceRevision{Verb: enum.RevisionVerbDelete, Body: testPodManifest, Requestor: "system:serviceaccount:kube-system:gc", State: enum.RevisionStateDeleted},
+		},
+		{
+			InsertID: "node-error",
+			Severity: "ERROR",
+			Summary:  "node is not ready",
+			Offset:   20 * time.Minute,
+			Path:     testNodePath,
+		},
+	}
+}
+
+// writeTestKHIFile writes a .khi file containing the given entries to a temporary directory and returns its path. The inspection is from testkhifile.BaseTime to 1h after it.
+func writeTestKHIFile(t *testing.T, name string, entries []testkhifile.Entry) string {
+	t.Helper()
+	data := testkhifile.Generate(t, &inspectionmetadata.HeaderMetadata{
+		InspectionName:       name,
+		StartTimeUnixSeconds: testkhifile.BaseTime.Unix(),
+		EndTimeUnixSeconds:   testkhifile.BaseTime.Add(time.Hour).Unix(),
+	}, entries)
+	filePath := filepath.Join(t.TempDir(), name+".khi")
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		t.Fatalf("failed to write the .khi file: %v", err)
+	}
+	return filePath
+}
+
+// testLogID returns the ID of the log with the given insertId in the .khi file. Log IDs are assigned from a counter shared in the process.
+func testLogID(t *testing.T, filePath string, insertID string) string {
+	t.Helper()
+	reader, err := khifile.Open(filePath)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", filePath, err)
+	}
+	defer reader.Close()
+	for _, l := range reader.History.Logs {
+		if l.DisplayId == insertID {
+			return l.ID
+		}
+	}
+	t.Fatalf("no log with the insertId %s was found in %s", insertID, filePath)
+	return ""
+}
+
+type inspectCommandTestCase struct {
+	name       string
+	args       []string
+	wantCode   int
+	wantStdout string
+	// wantStderr is a substring expected in the standard error output. The standard error output must be empty when it's empty.
+	wantStderr string
+}
+
+func runInspectCommandTestCases(t *testing.T, testCases []inspectCommandTestCase) {
+	t.Helper()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			gotCode := runInspectCommand(tc.args, &stdout, &stderr)
+			if gotCode != tc.wantCode {
+				t.Errorf("runInspectCommand(%v) = %d, want %d\nstderr: %s", tc.args, gotCode, tc.wantCode, stderr.String())
+			}
+			if diff := cmp.Diff(tc.wantStdout, stdout.String()); diff != "" {
+				t.Errorf("stdout mismatch (-want +got):\n%s", diff)
+			}
+			if tc.wantStderr == "" && stderr.Len() > 0 {
+				t.Errorf("stderr = %q, want empty", stderr.String())
+			}
+			if tc.wantStderr != "" && !bytes.Contains(stderr.Bytes(), []byte(tc.wantStderr)) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), tc.wantStderr)
+			}
+		})
+	}
+}
+
+// testTargetEntries are the entries of the .khi file compared with the base inspection in diff.
+// The pod is not deleted, the configmap is replaced with a secret and the pod has a new error log.
+func testTargetEntries() []testkhifile.Entry {
+	return []testkhifile.Entry{
+		{
+			InsertID: "pod-create",
+			Summary:  "create nginx",
+			Path:     testPodPath,
+			Revision: &history.StagingResourceRevision{Verb: enum.RevisionVerbCreate, Body: testPodManifest, State: enum.RevisionStateExisting},
+		},
+		{
+			InsertID: "secret-create",
+			Summary:  "create secret",
+			Offset:   5 * time.Minute,
+			Path:     testSecretPath,
+			Revision: &history.StagingResourceRevision{Verb: enum.RevisionVerbCreate, Body: "apiVersion: v1\nkind: Secret\n", State: enum.RevisionStateExisting},
+		},
+		{
+			InsertID: "node-error",
+			Severity: "ERROR",
+			Summary:  "node is not ready",
+			Offset:   20 * time.Minute,
+			Path:     testNodePath,
+		},
+		{
+			InsertID: "pod-error",
+			Severity: "ERROR",
+			Summary:  "back-off restarting failed container",
+			Offset:   30 * time.Minute,
+			Path:     testPodPath,
+		},
+	}
+}
+
+func TestRunInspectCommand(t *testing.T) {
+	file := writeTestKHIFile(t, "base", testBaseEntries())
+	runInspectCommandTestCases(t, []inspectCommandTestCase{
+		{
+			name:       "without subcommand",
+			args:       []string{},
+			wantCode:   2,
+			wantStderr: "Usage: inspect <subcommand> [flags] <file.khi>",
+		},
+		{
+			name:       "unknown subcommand",
+			args:       []string{"foo", file},
+			wantCode:   2,
+			wantStderr: `unknown subcommand "foo"`,
+		},
+		{
+			name:     "help flag",
+			args:     []string{"timelines", "--help"},
+			wantCode: 0,
+		},
+		{
+			name:       "unsupported output format",
+			args:       []string{"timelines", "--output", "yaml", file},
+			wantCode:   1,
+			wantStderr: `inspect timelines: unsupported output format "yaml"`,
+		},
+		{
+			name:       "without file",
+			args:       []string{"timelines"},
+			wantCode:   1,
+			wantStderr: "inspect timelines: exactly 1 .khi file path(s) are required",
+		},
+		{
+			name:       "missing file",
+			args:       []string{"timelines", filepath.Join(t.TempDir(), "missing.khi")},
+			wantCode:   1,
+			wantStderr: "failed to open",
+		},
+	})
+}
+
+func TestInspectTimelines(t *testing.T) {
+	file := writeTestKHIFile(t, "base", testBaseEntries())
+	runInspectCommandTestCases(t, []inspectCommandTestCase{
+		{
+			name: "text with prefix",
+			args: []string{"timelines", "--prefix", "core/v1#pod", file},
+			wantStdout: `PATH                       REVISIONS  EVENTS  LAST CHANGE           LAST STATE
+core/v1#pod#default#nginx  2          0       2024-01-01T00:10:00Z  Resource is deleted
+`,
+		},
+		{
+			name: "json",
+			args: []string{"timelines", "--output", "json", file},
+			wantStdout: `[
+  {
+    "path": "core/v1#node#cluster-scope#node-1",
+    "relationship": "RelationshipChild",
+    "revisionCount": 0,
+    "eventCount": 1
+  },
+  {
+    "path": "core/v1#pod#default#nginx",
+    "relationship": "RelationshipChild",
+    "revisionCount": 2,
+    "eventCount": 0,
+    "firstRevision": "2024-01-01T00:00:00Z",
+    "lastRevision": "2024-01-01T00:10:00Z",
+    "lastState": "Resource is deleted"
+  },
+  {
+    "path": "core/v1#configmap#default#config",
+    "relationship": "RelationshipChild",
+    "revisionCount": 1,
+    "eventCount": 0,
+    "firstRevision": "2024-01-01T00:05:00Z",
+    "lastRevision": "2024-01-01T00:05:00Z",
+    "lastState": "Resource is existing"
+  }
+]
+`,
+		},
+		{
+			name:       "json with unmatched prefix",
+			args:       []string{"timelines", "--output", "json", "--prefix", "apps/v1", file},
+			wantStdout: "[]\n",
+		},
+	})
+}
+
+func TestInspectRevisions(t *testing.T) {
+	file := writeTestKHIFile(t, "base", testBaseEntries())
+	runInspectCommandTestCases(t, []inspectCommandTestCase{
+		{
+			name: "text",
+			args: []string{"revisions", "--resource", testPodPath.Path, file},
+			wantStdout: `TIME                  VERB    STATE                 REQUESTOR
+2024-01-01T00:00:00Z  Create  Resource is existing  user@example.com
+2024-01-01T00:10:00Z  Delete  Resource is deleted   system:serviceaccount:kube-system:gc
+`,
+		},
+		{
+			name: "json",
+			args: []string{"revisions", "--output", "json", "--resource", testConfigMapPath.Path, file},
+			wantStdout: fmt.Sprintf(`[
+  {
+    "changeTime": "2024-01-01T00:05:00Z",
+    "verb": "Create",
+    "state": "Resource is existing",
+    "requestor": "user@example.com",
+    "logId": %q
+  }
+]
+`, testLogID(t, file, "configmap-create")),
+		},
+		{
+			name:       "without resource",
+			args:       []string{"revisions", file},
+			wantCode:   1,
+			wantStderr: "inspect revisions: --resource is required",
+		},
+		{
+			name:       "unknown resource",
+			args:       []string{"revisions", "--resource", "core/v1#pod#default#foo", file},
+			wantCode:   1,
+			wantStderr: "no timeline was found for the resource core/v1#pod#default#foo",
+		},
+	})
+}
+
+func TestInspectLogs(t *testing.T) {
+	file := writeTestKHIFile(t, "base", testBaseEntries())
+	runInspectCommandTestCases(t, []inspectCommandTestCase{
+		{
+			name: "all logs",
+			args: []string{"logs", file},
+			wantStdout: `2024-01-01T00:00:00Z INFO    unknown              create nginx
+2024-01-01T00:05:00Z INFO    unknown              create config
+2024-01-01T00:10:00Z INFO    unknown              delete nginx
+2024-01-01T00:20:00Z ERROR   unknown              node is not ready
+`,
+		},
+		{
+			name: "resource prefix",
+			args: []string{"logs", "--resource", "core/v1#pod", file},
+			wantStdout: `2024-01-01T00:00:00Z INFO    unknown              create nginx
+2024-01-01T00:10:00Z INFO    unknown              delete nginx
+`,
+		},
+		{
+			name: "time range with RFC3339 and duration",
+			args: []string{"logs", "--since", "2024-01-01T00:05:00Z", "--until", "50m", file},
+			wantStdout: `2024-01-01T00:05:00Z INFO    unknown              create config
+2024-01-01T00:10:00Z INFO    unknown              delete nginx
+`,
+		},
+		{
+			name: "severity with body",
+			args: []string{"logs", "--severity", "error", "--body", file},
+			wantStdout: `2024-01-01T00:20:00Z ERROR   unknown              node is not ready
+insertId: node-error
+severity: ERROR
+timestamp: "2024-01-01T00:20:00Z"
+---
+`,
+		},
+		{
+			name: "json",
+			args: []string{"logs", "--output", "json", "--resource", testConfigMapPath.Path, file},
+			wantStdout: fmt.Sprintf(`[
+  {
+    "timestamp": "2024-01-01T00:05:00Z",
+    "id": %q,
+    "type": "unknown",
+    "severity": "INFO",
+    "summary": "create config"
+  }
+]
+`, testLogID(t, file, "configmap-create")),
+		},
+		{
+			name:       "invalid since",
+			args:       []string{"logs", "--since", "yesterday", file},
+			wantCode:   1,
+			wantStderr: `inspect logs: invalid --since: "yesterday" is neither RFC3339 time nor duration`,
+		},
+		{
+			name:       "unknown severity",
+			args:       []string{"logs", "--severity", "CRITICAL", file},
+			wantCode:   1,
+			wantStderr: `inspect logs: unknown severity "CRITICAL"`,
+		},
+	})
+}
+
+func TestInspectManifest(t *testing.T) {
+	file := writeTestKHIFile(t, "base", testBaseEntries())
+	runInspectCommandTestCases(t, []inspectCommandTestCase{
+		{
+			name: "text at the given time",
+			args: []string{"manifest", "--resource", testPodPath.Path, "--at", "2024-01-01T00:05:00Z", file},
+			wantStdout: `# core/v1#pod#default#nginx at 2024-01-01T00:05:00Z (changed at 2024-01-01T00:00:00Z, Resource is existing)
+apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+  namespace: default
+`,
+		},
+		{
+			name: "json at the end of the inspection",
+			args: []string{"manifest", "--resource", testPodPath.Path, "--output", "json", file},
+			wantStdout: `{
+  "changeTime": "2024-01-01T00:10:00Z",
+  "manifest": "apiVersion: v1\nkind: Pod\nmetadata:\n  name: nginx\n  namespace: default\n",
+  "state": "Resource is deleted"
+}
+`,
+		},
+		{
+			name:       "before the first revision",
+			args:       []string{"manifest", "--resource", testConfigMapPath.Path, "--at", "2024-01-01T00:01:00Z", file},
+			wantCode:   1,
+			wantStderr: "the resource core/v1#configmap#default#config has no revision at or before 2024-01-01T00:01:00Z",
+		},
+		{
+			name:       "without resource",
+			args:       []string{"manifest", file},
+			wantCode:   1,
+			wantStderr: "inspect manifest: --resource is required",
+		},
+		{
+			name:       "invalid at",
+			args:       []string{"manifest", "--resource", testPodPath.Path, "--at", "now", file},
+			wantCode:   1,
+			wantStderr: "inspect manifest: invalid --at",
+		},
+	})
+}
+
+func TestInspectSnapshot(t *testing.T) {
+	file := writeTestKHIFile(t, "base", testBaseEntries())
+	runInspectCommandTestCases(t, []inspectCommandTestCase{
+		{
+			name: "multi-document YAML at a duration before the end",
+			args: []string{"snapshot", "--at", "55m", file},
+			wantStdout: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: default
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+  namespace: default
+`,
+		},
+		{
+			name: "json at the end of the inspection",
+			args: []string{"snapshot", "--output", "json", file},
+			wantStdout: `[
+  {
+    "path": "core/v1#configmap#default#config",
+    "changeTime": "2024-01-01T00:05:00Z",
+    "state": "Resource is existing",
+    "manifest": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n  namespace: default\n"
+  }
+]
+`,
+		},
+		{
+			name:       "invalid at",
+			args:       []string{"snapshot", "--at", "now", file},
+			wantCode:   1,
+			wantStderr: "inspect snapshot: invalid --at",
+		},
+	})
+}
+
+func TestInspectSnapshot_OutputDir(t *testing.T) {
+	file := writeTestKHIFile(t, "base", testBaseEntries())
+	outputDir := t.TempDir()
+	runInspectCommandTestCases(t, []inspectCommandTestCase{
+		{
+			name:       "output directory",
+			args:       []string{"snapshot", "--at", "2024-01-01T00:07:00Z", "--output-dir", outputDir, file},
+			wantStdout: fmt.Sprintf("Wrote 2 manifests at 2024-01-01T00:07:00Z to %s\n", outputDir),
+		},
+	})
+	for filePath, want := range map[string]string{
+		"default/configmap.core/config.yaml": testConfigMapManifest,
+		"default/pod.core/nginx.yaml":        testPodManifest,
+	} {
+		got, err := os.ReadFile(filepath.Join(outputDir, filePath))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", filePath, err)
+		}
+		if diff := cmp.Diff(want, string(got)); diff != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", filePath, diff)
+		}
+	}
+}
+
+func TestInspectDiff(t *testing.T) {
+	base := writeTestKHIFile(t, "base", testBaseEntries())
+	target := writeTestKHIFile(t, "target", testTargetEntries())
+	runInspectCommandTestCases(t, []inspectCommandTestCase{
+		{
+			name: "text",
+			args: []string{"diff", base, target},
+			wantStdout: `Resources only in base (1):
+  core/v1#configmap#default#config  1 revisions  Existing=1
+
+Resources only in target (1):
+  core/v1#secret#default#secret  1 revisions  Existing=1
+
+Changed resources (1):
+  PATH                       REVISIONS    EVENTS  STATES (BASE)         STATES (TARGET)
+  core/v1#pod#default#nginx  2 -> 1 (-1)  0 -> 1  Existing=1,Deleted=1  Existing=1
+
+New error logs (1):
+      1 ERROR   back-off restarting failed container (first at 2024-01-01T00:30:00Z)
+          core/v1#pod#default#nginx
+`,
+		},
+		{
+			name: "same file",
+			args: []string{"diff", base, base},
+			wantStdout: `Resources only in base (0):
+
+Resources only in target (0):
+
+Changed resources (0):
+  PATH  REVISIONS  EVENTS  STATES (BASE)  STATES (TARGET)
+
+New error logs (0):
+`,
+		},
+		{
+			name:       "single file",
+			args:       []string{"diff", base},
+			wantCode:   1,
+			wantStderr: "inspect diff: exactly 2 .khi file path(s) are required",
+		},
+	})
+}
+
+func TestParseInspectTime(t *testing.T) {
+	reference := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "empty", value: ""},
+		{name: "RFC3339", value: "2024-01-01T00:30:00+09:00", want: time.Date(2024, 1, 1, 0, 30, 0, 0, time.FixedZone("", 9*60*60))},
+		{name: "duration", value: "15m", want: time.Date(2024, 1, 1, 0, 45, 0, 0, time.UTC)},
+		{name: "invalid", value: "yesterday", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseInspectTime(tc.value, reference)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseInspectTime(%q) returned error %v, wantErr %v", tc.value, err, tc.wantErr)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("parseInspectTime(%q) = %v, want %v", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	testCases := []struct {
+		label   string
+		want    enum.Severity
+		wantErr bool
+	}{
+		{label: "", want: enum.SeverityUnknown},
+		{label: "error", want: enum.SeverityError},
+		{label: "WARNING", want: enum.SeverityWarning},
+		{label: "Fatal", want: enum.SeverityFatal},
+		{label: "CRITICAL", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.label, func(t *testing.T) {
+			got, err := parseSeverity(tc.label)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseSeverity(%q) returned error %v, wantErr %v", tc.label, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("parseSeverity(%q) = %v, want %v", tc.label, got, tc.want)
+			}
+		})
+	}
+}
@@ -22,8 +22,10 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/GoogleCloudPlatform/khi/pkg/common/errorreport"
 	coreinit "github.com/GoogleCloudPlatform/khi/pkg/core/init"
@@ -31,10 +33,12 @@ import (
 	"github.com/GoogleCloudPlatform/khi/pkg/lifecycle"
 	"github.com/GoogleCloudPlatform/khi/pkg/parameters"
 	"github.com/GoogleCloudPlatform/khi/pkg/server"
+	"github.com/GoogleCloudPlatform/khi/pkg/server/auditwebhook"
 	"github.com/GoogleCloudPlatform/khi/pkg/server/upload"
 	"github.com/gin-gonic/gin"
 
 	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
 
 	_ "github.com/GoogleCloudPlatform/khi/pkg/core/init/default"
 )
@@ -84,6 +88,10 @@ func main() {
 
 func run() int {
 	defer errorreport.CheckAndReportPanic()
+	// `inspect` subcommands only read a .khi file and don't need the initialization for the server or job mode.
+	if len(os.Args) > 1 && os.Args[1] == inspectCommandName {
+		return runInspectCommand(os.Args[2:], os.Stdout, os.Stderr)
+	}
 	defer func() {
 		err := coreinit.CallInitExtension(func(e coreinit.InitExtension) error {
 			return e.BeforeTerminate()
@@ -132,6 +140,14 @@ func run() int {
 	if err != nil {
 		slog.Error(fmt.Sprintf("Failed to construct the inspection server due to unexpected error\n%v", err))
 	}
+	if *parameters.Common.AuditLogSeverityPolicyFile != "" {
+		policy, err := ossclusterk8s_contract.LoadAuditLogSeverityPolicy(*parameters.Common.AuditLogSeverityPolicyFile)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Failed to load the audit log severity policy\n%v", err))
+			return 1
+		}
+		inspectionServer.AddRunContextOption(coreinspection.RunContextOptionFromValue(ossclusterk8s_contract.AuditLogSeverityPolicyContextKey, policy))
+	}
 	err = coreinit.CallInitExtension(func(e coreinit.InitExtension) error {
 		return e.ConfigureInspectionTaskServer(inspectionServer)
 	})
@@ -165,6 +181,33 @@ func run() int {
 
 		upload.DefaultUploadFileStore = upload.NewUploadFileStore(upload.NewLocalUploadFileStoreProvider(uploadFileStoreFolder))
 
+		if *parameters.Server.PersistInspections {
+			err = inspectionServer.EnablePersistence(ioconfig.DataDestination)
+			if err != nil {
+				slog.Error(fmt.Sprintf("Failed to restore inspections from %s\n%v", ioconfig.DataDestination, err))
+				return 1
+			}
+		}
+		inspectionServer.StartRetentionJanitor(context.Background(), coreinspection.RetentionPolicy{
+			MaxAge:        time.Duration(*parameters.Server.InspectionRetentionMaxAgeHours) * time.Hour,
+			MaxTotalBytes: int64(*parameters.Server.InspectionRetentionMaxTotalBytes),
+			MaxCount:      *parameters.Server.InspectionRetentionMaxCount,
+		}, time.Minute)
+
+		var auditWebhookArchiveStore *auditwebhook.ArchiveStore
+		if *parameters.Server.AuditWebhook {
+			auditWebhookArchiveStore, err = auditwebhook.NewArchiveStore(filepath.Join(ioconfig.DataDestination, "audit-webhook"), auditwebhook.ArchiveStoreOptions{
+				MaxArchiveSizeInBytes: int64(*parameters.Server.AuditWebhookArchiveMaxSizeInBytes),
+				MaxArchiveAge:         time.Duration(*parameters.Server.AuditWebhookArchiveMaxAgeMinutes) * time.Minute,
+				MaxArchiveCount:       *parameters.Server.AuditWebhookArchiveMaxCount,
+			})
+			if err != nil {
+				slog.Error(fmt.Sprintf("Failed to prepare the audit webhook archives\n%v", err))
+				return 1
+			}
+			inspectionServer.AddRunContextOption(coreinspection.RunContextOptionFromValue(ossclusterk8s_contract.AuditWebhookArchiveStoreContextKey, auditWebhookArchiveStore))
+		}
+
 		err = coreinit.CallInitExtension(func(e coreinit.InitExtension) error {
 			return e.ConfigureKHIWebServerFactory(server.DefaultServerFactory)
 		})
@@ -174,11 +217,14 @@ func run() int {
 		}
 
 		config := server.ServerConfig{
-			ViewerMode:       *parameters.Server.ViewerMode,
-			StaticFolderPath: *parameters.Server.FrontendAssetFolder,
-			ResourceMonitor:  &server.ResourceMonitorImpl{},
-			ServerBasePath:   *parameters.Server.BasePath,
-			UploadFileStore:  upload.DefaultUploadFileStore,
+			ViewerMode:                        *parameters.Server.ViewerMode,
+			StaticFolderPath:                  *parameters.Server.FrontendAssetFolder,
+			ResourceMonitor:                   &server.ResourceMonitorImpl{},
+			ServerBasePath:                    *parameters.Server.BasePath,
+			UploadFileStore:                   upload.DefaultUploadFileStore,
+			AuditWebhookArchiveStore:          auditWebhookArchiveStore,
+			AuditWebhookToken:                 *parameters.Server.AuditWebhookToken,
+			AuditWebhookMaxRequestSizeInBytes: int64(*parameters.Server.AuditWebhookMaxRequestSizeInBytes),
 		}
 		engine, err := server.DefaultServerFactory.CreateInstance(serverMode)
 		if err != nil {
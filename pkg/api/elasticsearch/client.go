@@ -0,0 +1,304 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package elasticsearch provides a client of the search API of Elasticsearch and OpenSearch.
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/httpclient"
+)
+
+// maxErrorBodySizeInBytes is the maximum size of the response body included in errors.
+const maxErrorBodySizeInBytes = 1024
+
+// closePointInTimeTimeout is the timeout to close a point in time after the search. The context of the search can be already cancelled at the time.
+const closePointInTimeTimeout = 10 * time.Second
+
+// Distribution is the kind of the search engine. Elasticsearch and OpenSearch have different APIs for the point in time.
+type Distribution string
+
+const (
+	DistributionElasticsearch Distribution = "elasticsearch"
+	DistributionOpenSearch    Distribution = "opensearch"
+)
+
+// Hit is a document returned from the search API.
+type Hit struct {
+	Index  string          `json:"_index"`
+	ID     string          `json:"_id"`
+	Source json.RawMessage `json:"_source"`
+	// Sort is the values of the sort fields of the document used as the search_after of the next page.
+	Sort []any `json:"sort"`
+}
+
+// basicAuthHeaderProvider adds the header of the basic authentication.
+type basicAuthHeaderProvider struct {
+	username string
+	password string
+}
+
+// AddHeader implements httpclient.HTTPHeaderProvider.
+func (b *basicAuthHeaderProvider) AddHeader(req *http.Request) error {
+	req.SetBasicAuth(b.username, b.password)
+	return nil
+}
+
+// apiKeyHeaderProvider adds the header of the API key authentication.
+type apiKeyHeaderProvider struct {
+	apiKey string
+}
+
+// AddHeader implements httpclient.HTTPHeaderProvider.
+func (a *apiKeyHeaderProvider) AddHeader(req *http.Request) error {
+	req.Header.Set("Authorization", "ApiKey "+a.apiKey)
+	return nil
+}
+
+var _ httpclient.HTTPHeaderProvider = (*basicAuthHeaderProvider)(nil)
+var _ httpclient.HTTPHeaderProvider = (*apiKeyHeaderProvider)(nil)
+
+// Credentials are the credentials to call the API. The basic authentication is used when Username is set, and the API key authentication is used when APIKey is set.
+type Credentials struct {
+	Username string
+	Password string
+	APIKey   string
+}
+
+// Client calls the search API of Elasticsearch or OpenSearch.
+type Client struct {
+	baseURL string
+	client  httpclient.HTTPClient[*http.Response]
+}
+
+// NewClient returns a Client for the cluster at the given base URL like `https://localhost:9200`.
+func NewClient(baseURL string, credentials Credentials) (*Client, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Elasticsearch URL %q: %w", baseURL, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid Elasticsearch URL %q: it must be an absolute http or https URL", baseURL)
+	}
+	if parsed.User != nil {
+		return nil, fmt.Errorf("invalid Elasticsearch URL %q: it must not include credentials", parsed.Redacted())
+	}
+	client := httpclient.NewBasicHttpClient()
+	if credentials.Username != "" {
+		client = client.WithHeaderProvider(&basicAuthHeaderProvider{username: credentials.Username, password: credentials.Password})
+	}
+	if credentials.APIKey != "" {
+		client = client.WithHeaderProvider(&apiKeyHeaderProvider{apiKey: credentials.APIKey})
+	}
+	return &Client{
+		baseURL: strings.TrimSuffix(parsed.String(), "/"),
+		client:  client,
+	}, nil
+}
+
+type infoResponse struct {
+	Version struct {
+		Distribution string `json:"distribution"`
+		Number       string `json:"number"`
+	} `json:"version"`
+}
+
+// Distribution returns the kind of the search engine from the root API.
+func (c *Client) Distribution(ctx context.Context) (Distribution, error) {
+	var response infoResponse
+	if err := c.call(ctx, http.MethodGet, "/", nil, nil, &response); err != nil {
+		return "", err
+	}
+	if response.Version.Distribution == string(DistributionOpenSearch) {
+		return DistributionOpenSearch, nil
+	}
+	return DistributionElasticsearch, nil
+}
+
+// OpenPointInTime opens a point in time of the indices matching the index pattern and returns its ID.
+func (c *Client) OpenPointInTime(ctx context.Context, distribution Distribution, index string, keepAlive time.Duration) (string, error) {
+	params := url.Values{}
+	params.Set("keep_alive", formatKeepAlive(keepAlive))
+	if distribution == DistributionOpenSearch {
+		var response struct {
+			PitID string `json:"pit_id"`
+		}
+		if err := c.call(ctx, http.MethodPost, fmt.Sprintf("/%s/_search/point_in_time", url.PathEscape(index)), params, nil, &response); err != nil {
+			return "", err
+		}
+		return response.PitID, nil
+	}
+	var response struct {
+		ID string `json:"id"`
+	}
+	if err := c.call(ctx, http.MethodPost, fmt.Sprintf("/%s/_pit", url.PathEscape(index)), params, nil, &response); err != nil {
+		return "", err
+	}
+	return response.ID, nil
+}
+
+// ClosePointInTime closes the point in time opened with OpenPointInTime.
+func (c *Client) ClosePointInTime(ctx context.Context, distribution Distribution, pitID string) error {
+	if distribution == DistributionOpenSearch {
+		return c.call(ctx, http.MethodDelete, "/_search/point_in_time", nil, map[string]any{"pit_id": []string{pitID}}, nil)
+	}
+	return c.call(ctx, http.MethodDelete, "/_pit", nil, map[string]any{"id": pitID}, nil)
+}
+
+// SearchRequest is the body of a search request with a point in time.
+type SearchRequest struct {
+	Size        int            `json:"size"`
+	Query       map[string]any `json:"query"`
+	PIT         PointInTime    `json:"pit"`
+	Sort        []any          `json:"sort"`
+	SearchAfter []any          `json:"search_after,omitempty"`
+	// TrackTotalHits is always false because the count of total hits is not used and counting it is slow.
+	TrackTotalHits bool `json:"track_total_hits"`
+}
+
+// PointInTime specifies the point in time used in a search request.
+type PointInTime struct {
+	ID        string `json:"id"`
+	KeepAlive string `json:"keep_alive"`
+}
+
+// SearchResponse is the response of a search request.
+type SearchResponse struct {
+	// PitID is the ID of the point in time to use in the next request. It can be different from the ID in the request.
+	PitID string `json:"pit_id"`
+	Hits  struct {
+		Hits []*Hit `json:"hits"`
+	} `json:"hits"`
+}
+
+// Search calls the search API with the point in time given in the request.
+func (c *Client) Search(ctx context.Context, request *SearchRequest) (*SearchResponse, error) {
+	var response SearchResponse
+	if err := c.call(ctx, http.MethodPost, "/_search", nil, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// SearchAll searches documents matching the query in the indices matching the index pattern and calls handle for each document in the ascending order of the timestamp field.
+// Documents are read by pages with pageSize documents with search_after on a point in time, so documents indexed during the search are not returned and each document is returned only once.
+func (c *Client) SearchAll(ctx context.Context, index string, query map[string]any, timestampField string, pageSize int, handle func(hit *Hit) error) error {
+	const keepAlive = time.Minute
+	distribution, err := c.Distribution(ctx)
+	if err != nil {
+		return err
+	}
+	pitID, err := c.OpenPointInTime(ctx, distribution, index, keepAlive)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), closePointInTimeTimeout)
+		defer cancel()
+		// The point in time expires after the keep alive even when it failed to close.
+		_ = c.ClosePointInTime(closeCtx, distribution, pitID)
+	}()
+
+	request := &SearchRequest{
+		Size:  pageSize,
+		Query: query,
+		Sort: []any{
+			map[string]any{timestampField: map[string]any{"order": "asc"}},
+			map[string]any{tiebreakerField(distribution): map[string]any{"order": "asc"}},
+		},
+	}
+	for {
+		request.PIT = PointInTime{ID: pitID, KeepAlive: formatKeepAlive(keepAlive)}
+		response, err := c.Search(ctx, request)
+		if err != nil {
+			return err
+		}
+		if response.PitID != "" {
+			pitID = response.PitID
+		}
+		for _, hit := range response.Hits.Hits {
+			if err := handle(hit); err != nil {
+				return err
+			}
+		}
+		if len(response.Hits.Hits) < pageSize {
+			return nil
+		}
+		lastHit := response.Hits.Hits[len(response.Hits.Hits)-1]
+		if len(lastHit.Sort) == 0 {
+			return fmt.Errorf("search response has no sort values to read the next page")
+		}
+		request.SearchAfter = lastHit.Sort
+	}
+}
+
+// tiebreakerField returns the field to sort documents with the same timestamp in a stable order.
+// Elasticsearch provides `_shard_doc` for searches with a point in time, but OpenSearch doesn't support it.
+func tiebreakerField(distribution Distribution) string {
+	if distribution == DistributionOpenSearch {
+		return "_id"
+	}
+	return "_shard_doc"
+}
+
+func formatKeepAlive(keepAlive time.Duration) string {
+	return fmt.Sprintf("%ds", int(keepAlive.Seconds()))
+}
+
+func (c *Client) call(ctx context.Context, method string, path string, params url.Values, body any, result any) error {
+	requestURL := c.baseURL + path
+	if len(params) > 0 {
+		requestURL += "?" + params.Encode()
+	}
+	var bodyReader io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, requestURL, bodyReader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	response, err := c.client.DoWithContext(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to call Elasticsearch API %s %s: %w", method, path, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		errorBody, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySizeInBytes))
+		return fmt.Errorf("Elasticsearch API %s %s returned %s: %s", method, path, response.Status, strings.TrimSpace(string(errorBody)))
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to read the response of Elasticsearch API %s %s: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package loki provides a client of the Grafana Loki HTTP API.
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/httpclient"
+)
+
+// maxErrorBodySizeInBytes is the maximum size of the response body included in errors.
+const maxErrorBodySizeInBytes = 1024
+
+// defaultMaxEntriesLimitPerQuery is the default of max_entries_limit_per_query in Loki. Queries with a larger limit are rejected by Loki.
+const defaultMaxEntriesLimitPerQuery = 5000
+
+// Entry is a log line returned from Loki.
+type Entry struct {
+	Timestamp time.Time
+	// Labels are the labels of the stream including the labels extracted in the query pipeline.
+	Labels map[string]string
+	Line   string
+}
+
+// ID returns a string identifying the entry. Entries with the same timestamp, labels and line have the same ID.
+func (e *Entry) ID() string {
+	hash := fnv.New64a()
+	hash.Write([]byte(labelsString(e.Labels)))
+	hash.Write([]byte{0})
+	hash.Write([]byte(e.Line))
+	return fmt.Sprintf("%d-%016x", e.Timestamp.UnixNano(), hash.Sum64())
+}
+
+// tenantHeaderProvider adds the header to specify the tenant on multi-tenant Loki.
+type tenantHeaderProvider struct {
+	tenantID string
+}
+
+// AddHeader implements httpclient.HTTPHeaderProvider.
+func (t *tenantHeaderProvider) AddHeader(req *http.Request) error {
+	req.Header.Set("X-Scope-OrgID", t.tenantID)
+	return nil
+}
+
+var _ httpclient.HTTPHeaderProvider = (*tenantHeaderProvider)(nil)
+
+// Client calls the Loki HTTP API.
+type Client struct {
+	baseURL string
+	client  httpclient.HTTPClient[*http.Response]
+	// maxEntriesLimit is the largest limit used to read entries at a single timestamp.
+	maxEntriesLimit int
+}
+
+// NewClient returns a Client for the Loki at the given base URL like `http://localhost:3100`.
+// tenantID is sent as the X-Scope-OrgID header when it's not empty.
+func NewClient(baseURL string, tenantID string) (*Client, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Loki URL %q: %w", baseURL, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid Loki URL %q: it must be an absolute http or https URL", baseURL)
+	}
+	client := httpclient.NewBasicHttpClient()
+	if tenantID != "" {
+		client = client.WithHeaderProvider(&tenantHeaderProvider{tenantID: tenantID})
+	}
+	return &Client{
+		baseURL:         strings.TrimSuffix(parsed.String(), "/"),
+		client:          client,
+		maxEntriesLimit: defaultMaxEntriesLimitPerQuery,
+	}, nil
+}
+
+type queryRangeResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Stream map[string]string `json:"stream"`
+			// Values are the pairs of the timestamp in nanoseconds and the line. Recent versions of Loki can add the structured metadata as the 3rd element.
+			Values [][]json.RawMessage `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// QueryRange runs the LogQL log query for logs in [start, end) and returns at most limit entries from the oldest.
+// Entries from all the streams are sorted by timestamp.
+func (c *Client) QueryRange(ctx context.Context, query string, start time.Time, end time.Time, limit int) ([]*Entry, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", strconv.FormatInt(start.UnixNano(), 10))
+	params.Set("end", strconv.FormatInt(end.UnixNano(), 10))
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("direction", "forward")
+
+	var response queryRangeResponse
+	if err := c.get(ctx, "/loki/api/v1/query_range", params, &response); err != nil {
+		return nil, err
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("query %q returned the status %q", query, response.Status)
+	}
+	if response.Data.ResultType != "streams" {
+		return nil, fmt.Errorf("query %q returned %q results, it must be a log query", query, response.Data.ResultType)
+	}
+	var entries []*Entry
+	for _, stream := range response.Data.Result {
+		for _, value := range stream.Values {
+			if len(value) < 2 {
+				return nil, fmt.Errorf("invalid value in the query_range response")
+			}
+			var timestampStr, line string
+			if err := json.Unmarshal(value[0], &timestampStr); err != nil {
+				return nil, fmt.Errorf("invalid timestamp in the query_range response: %w", err)
+			}
+			if err := json.Unmarshal(value[1], &line); err != nil {
+				return nil, fmt.Errorf("invalid line in the query_range response: %w", err)
+			}
+			timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid timestamp in the query_range response: %w", err)
+			}
+			entries = append(entries, &Entry{
+				Timestamp: time.Unix(0, timestamp).UTC(),
+				Labels:    stream.Stream,
+				Line:      line,
+			})
+		}
+	}
+	slices.SortStableFunc(entries, func(a, b *Entry) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	return entries, nil
+}
+
+// QueryRangeAll runs the LogQL log query for logs in [start, end) and calls handle for each entry from the oldest.
+// Logs are read by pages with pageSize entries. The next page starts from the timestamp of the last entry in the previous page, and the entries already handled at the timestamp are skipped.
+// When a page has only entries at its start time, all the entries at the time are read at once with a larger limit.
+func (c *Client) QueryRangeAll(ctx context.Context, query string, start time.Time, end time.Time, pageSize int, handle func(entry *Entry) error) error {
+	// handledAtStart holds the IDs of entries handled at the start time of the current page.
+	handledAtStart := map[string]struct{}{}
+	for {
+		entries, err := c.QueryRange(ctx, query, start, end, pageSize)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			id := entry.ID()
+			if entry.Timestamp.Equal(start) {
+				if _, found := handledAtStart[id]; found {
+					continue
+				}
+				handledAtStart[id] = struct{}{}
+			}
+			if err := handle(entry); err != nil {
+				return err
+			}
+		}
+		if len(entries) < pageSize {
+			return nil
+		}
+
+		lastTimestamp := entries[len(entries)-1].Timestamp
+		if lastTimestamp.Equal(start) {
+			// All the entries in the page have the same timestamp. The next page would return the same entries, so read all the entries at the timestamp at once.
+			if err := c.queryRangeAllAtTimestamp(ctx, query, start, pageSize*2, handledAtStart, handle); err != nil {
+				return err
+			}
+			start = start.Add(time.Nanosecond)
+			handledAtStart = map[string]struct{}{}
+			continue
+		}
+		start = lastTimestamp
+		handledAtStart = map[string]struct{}{}
+		for _, entry := range entries {
+			if entry.Timestamp.Equal(start) {
+				handledAtStart[entry.ID()] = struct{}{}
+			}
+		}
+	}
+}
+
+// queryRangeAllAtTimestamp calls handle for the entries at the timestamp not included in handled. The limit is doubled until all the entries at the timestamp are returned.
+// It returns an error when the timestamp has entries more than the limit reaching maxEntriesLimit, because Loki rejects queries with a larger limit.
+func (c *Client) queryRangeAllAtTimestamp(ctx context.Context, query string, timestamp time.Time, limit int, handled map[string]struct{}, handle func(entry *Entry) error) error {
+	limit = min(limit, c.maxEntriesLimit)
+	for {
+		entries, err := c.QueryRange(ctx, query, timestamp, timestamp.Add(time.Nanosecond), limit)
+		if err != nil {
+			return err
+		}
+		if len(entries) == limit {
+			if limit >= c.maxEntriesLimit {
+				return fmt.Errorf("at least %d log entries are found at the single timestamp %s, exceeding the maximum number of entries per query. Narrow down the query to read these logs", limit, timestamp.Format(time.RFC3339Nano))
+			}
+			limit = min(limit*2, c.maxEntriesLimit)
+			continue
+		}
+		for _, entry := range entries {
+			if _, found := handled[entry.ID()]; found {
+				continue
+			}
+			if err := handle(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+type labelValuesResponse struct {
+	Status string   `json:"status"`
+	Data   []string `json:"data"`
+}
+
+// LabelValues returns the values of the label in [start, end). query is an optional stream selector to limit the streams.
+func (c *Client) LabelValues(ctx context.Context, label string, query string, start time.Time, end time.Time) ([]string, error) {
+	params := url.Values{}
+	params.Set("start", strconv.FormatInt(start.UnixNano(), 10))
+	params.Set("end", strconv.FormatInt(end.UnixNano(), 10))
+	if query != "" {
+		params.Set("query", query)
+	}
+	var response labelValuesResponse
+	if err := c.get(ctx, fmt.Sprintf("/loki/api/v1/label/%s/values", url.PathEscape(label)), params, &response); err != nil {
+		return nil, err
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("label values of %q returned the status %q", label, response.Status)
+	}
+	return response.Data, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, params url.Values, result any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s?%s", c.baseURL, path, params.Encode()), nil)
+	if err != nil {
+		return err
+	}
+	response, err := c.client.DoWithContext(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to call Loki API %s: %w", path, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySizeInBytes))
+		return fmt.Errorf("Loki API %s returned %s: %s", path, response.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to read the response of Loki API %s: %w", path, err)
+	}
+	return nil
+}
+
+func labelsString(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	var builder strings.Builder
+	for _, key := range keys {
+		builder.WriteString(key)
+		builder.WriteString("=")
+		builder.WriteString(strconv.Quote(labels[key]))
+		builder.WriteString(",")
+	}
+	return builder.String()
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loki
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/lokistub"
+	"github.com/google/go-cmp/cmp"
+)
+
+var testBaseTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestNewClient(t *testing.T) {
+	testCases := []struct {
+		name    string
+		baseURL string
+		wantErr bool
+	}{
+		{name: "http URL", baseURL: "http://localhost:3100"},
+		{name: "https URL with a path", baseURL: "https://example.com/loki/"},
+		{name: "URL without scheme", baseURL: "localhost:3100", wantErr: true},
+		{name: "unsupported scheme", baseURL: "ftp://localhost:3100", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewClient(tc.baseURL, "")
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewClient(%q) returned error %v, wantErr %v", tc.baseURL, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_QueryRangeAll(t *testing.T) {
+	var entries []lokistub.Entry
+	for i := 0; i < 10; i++ {
+		entries = append(entries, lokistub.Entry{Timestamp: testBaseTime.Add(time.Duration(i) * time.Second), Line: fmt.Sprintf("a-%d", i)})
+	}
+	// Entries sharing the same timestamp over the page boundary.
+	for i := 0; i < 4; i++ {
+		entries = append(entries, lokistub.Entry{Timestamp: testBaseTime.Add(20 * time.Second), Line: fmt.Sprintf("same-%d", i)})
+	}
+	server := lokistub.NewServer(
+		lokistub.Stream{Labels: map[string]string{"job": "foo"}, Entries: entries},
+		lokistub.Stream{Labels: map[string]string{"job": "bar"}, Entries: []lokistub.Entry{
+			{Timestamp: testBaseTime.Add(1500 * time.Millisecond), Line: "b-0"},
+			{Timestamp: testBaseTime.Add(20 * time.Second), Line: "b-1"},
+		}},
+	)
+	defer server.Close()
+
+	testCases := []struct {
+		name      string
+		query     string
+		start     time.Time
+		end       time.Time
+		pageSize  int
+		wantLines []string
+	}{
+		{
+			name:      "single page",
+			query:     `{job="bar"}`,
+			start:     testBaseTime,
+			end:       testBaseTime.Add(time.Minute),
+			pageSize:  100,
+			wantLines: []string{"b-0", "b-1"},
+		},
+		{
+			name:      "multiple pages across streams",
+			query:     `{job=~"foo|bar"} |~ "^(a|b)-"`,
+			start:     testBaseTime,
+			end:       testBaseTime.Add(5 * time.Second),
+			pageSize:  2,
+			wantLines: []string{"a-0", "a-1", "b-0", "a-2", "a-3", "a-4"},
+		},
+		{
+			name:      "entries at the same timestamp over pages",
+			query:     `{job=~"foo|bar"}`,
+			start:     testBaseTime.Add(9 * time.Second),
+			end:       testBaseTime.Add(time.Minute),
+			pageSize:  2,
+			wantLines: []string{"a-9", "same-0", "same-1", "same-2", "same-3", "b-1"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := NewClient(server.URL, "")
+			if err != nil {
+				t.Fatalf("NewClient() returned an unexpected error: %v", err)
+			}
+			gotLines := []string{}
+			err = client.QueryRangeAll(t.Context(), tc.query, tc.start, tc.end, tc.pageSize, func(entry *Entry) error {
+				gotLines = append(gotLines, entry.Line)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("QueryRangeAll() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantLines, gotLines); diff != "" {
+				t.Errorf("QueryRangeAll() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_QueryRangeAllWithTooManyEntriesAtTimestamp(t *testing.T) {
+	var entries []lokistub.Entry
+	for i := 0; i < 5; i++ {
+		entries = append(entries, lokistub.Entry{Timestamp: testBaseTime, Line: fmt.Sprintf("same-%d", i)})
+	}
+
+	testCases := []struct {
+		name            string
+		maxEntriesLimit int
+		wantErr         bool
+	}{
+		{
+			name:            "entries fit in the maximum limit",
+			maxEntriesLimit: 6,
+		},
+		{
+			name:            "entries exceed the maximum limit",
+			maxEntriesLimit: 4,
+			wantErr:         true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := lokistub.NewServer(lokistub.Stream{Labels: map[string]string{"job": "foo"}, Entries: entries})
+			defer server.Close()
+			client, err := NewClient(server.URL, "")
+			if err != nil {
+				t.Fatalf("NewClient() returned an unexpected error: %v", err)
+			}
+			client.maxEntriesLimit = tc.maxEntriesLimit
+			lineCount := 0
+			err = client.QueryRangeAll(t.Context(), `{job="foo"}`, testBaseTime, testBaseTime.Add(time.Minute), 2, func(entry *Entry) error {
+				lineCount++
+				return nil
+			})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("QueryRangeAll() returned error %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && lineCount != len(entries) {
+				t.Errorf("QueryRangeAll() handled %d entries, want %d", lineCount, len(entries))
+			}
+			for _, request := range server.Requests() {
+				limit, err := strconv.Atoi(request.URL.Query().Get("limit"))
+				if err != nil {
+					t.Fatalf("failed to parse the limit of the request %s: %v", request.URL, err)
+				}
+				if limit > tc.maxEntriesLimit {
+					t.Errorf("QueryRange() was called with the limit %d exceeding %d", limit, tc.maxEntriesLimit)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_QueryRangeWithInvalidQuery(t *testing.T) {
+	server := lokistub.NewServer()
+	defer server.Close()
+	client, err := NewClient(server.URL, "")
+	if err != nil {
+		t.Fatalf("NewClient() returned an unexpected error: %v", err)
+	}
+	_, err = client.QueryRange(t.Context(), `job="foo"`, testBaseTime, testBaseTime.Add(time.Hour), 10)
+	if err == nil {
+		t.Errorf("QueryRange() returned no error, want an error")
+	}
+}
+
+func TestClient_LabelValues(t *testing.T) {
+	server := lokistub.NewServer(
+		lokistub.Stream{Labels: map[string]string{"cluster": "foo", "namespace": "kube-system"}},
+		lokistub.Stream{Labels: map[string]string{"cluster": "foo", "namespace": "default"}},
+		lokistub.Stream{Labels: map[string]string{"cluster": "bar", "namespace": "bar-ns"}},
+	)
+	defer server.Close()
+	client, err := NewClient(server.URL, "test-tenant")
+	if err != nil {
+		t.Fatalf("NewClient() returned an unexpected error: %v", err)
+	}
+
+	got, err := client.LabelValues(t.Context(), "namespace", `{cluster="foo"}`, testBaseTime, testBaseTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("LabelValues() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"default", "kube-system"}, got); diff != "" {
+		t.Errorf("LabelValues() mismatch (-want +got):\n%s", diff)
+	}
+	requests := server.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+	if tenant := requests[0].Header.Get("X-Scope-OrgID"); tenant != "test-tenant" {
+		t.Errorf("X-Scope-OrgID header = %q, want %q", tenant, "test-tenant")
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	// tarHeaderSize is the size of a tar header block.
+	tarHeaderSize = 512
+	// tarMagicOffset is the offset of the magic field in a tar header.
+	tarMagicOffset = 257
+)
+
+var (
+	tarMagic = []byte("ustar")
+	zipMagic = []byte("PK\x03\x04")
+)
+
+// WalkFiles calls fn for each file in the source.
+// When the source is a tar archive or a zip archive, fn is called for each regular file in the archive. Otherwise fn is called once for the source itself with the given name.
+// The source and the files in the archive are decompressed when they are compressed with gzip or zstd. Hidden files like `.DS_Store` or `__MACOSX/._foo` are skipped.
+// Zip archives are only supported when the source implements io.ReaderAt and the size is given.
+func WalkFiles(name string, source io.Reader, size int64, fn func(name string, reader io.Reader) error) error {
+	buffered := bufio.NewReader(source)
+	if head, _ := buffered.Peek(len(zipMagic)); bytes.Equal(head, zipMagic) {
+		readerAt, ok := source.(io.ReaderAt)
+		if !ok || size <= 0 {
+			return fmt.Errorf("zip archive %s can't be read from a stream", name)
+		}
+		return walkZipFiles(readerAt, size, fn)
+	}
+
+	decompressed, _, err := NewReader(buffered)
+	if err != nil {
+		return err
+	}
+	defer decompressed.Close()
+	bufferedDecompressed := bufio.NewReader(decompressed)
+	head, err := bufferedDecompressed.Peek(tarHeaderSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if len(head) == tarHeaderSize && bytes.HasPrefix(head[tarMagicOffset:], tarMagic) {
+		return walkTarFiles(bufferedDecompressed, fn)
+	}
+	return fn(name, bufferedDecompressed)
+}
+
+// SizeOf returns the size of the source in bytes when it's a file. It returns 0 when the size is unknown.
+func SizeOf(source io.Reader) int64 {
+	file, ok := source.(interface{ Stat() (os.FileInfo, error) })
+	if !ok {
+		return 0
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		return 0
+	}
+	return stat.Size()
+}
+
+func walkTarFiles(source io.Reader, fn func(name string, reader io.Reader) error) error {
+	reader := tar.NewReader(source)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the tar archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg || isHiddenFile(header.Name) {
+			continue
+		}
+		if err := callWithDecompressedReader(header.Name, reader, fn); err != nil {
+			return err
+		}
+	}
+}
+
+func walkZipFiles(source io.ReaderAt, size int64, fn func(name string, reader io.Reader) error) error {
+	reader, err := zip.NewReader(source, size)
+	if err != nil {
+		return fmt.Errorf("failed to read the zip archive: %w", err)
+	}
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() || isHiddenFile(file.Name) {
+			continue
+		}
+		fileReader, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open %s in the zip archive: %w", file.Name, err)
+		}
+		err = callWithDecompressedReader(file.Name, fileReader, fn)
+		fileReader.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func callWithDecompressedReader(name string, source io.Reader, fn func(name string, reader io.Reader) error) error {
+	decompressed, _, err := NewReader(source)
+	if err != nil {
+		return fmt.Errorf("failed to decompress %s: %w", name, err)
+	}
+	defer decompressed.Close()
+	return fn(name, decompressed)
+}
+
+// isHiddenFile returns true when the file or one of its parent folders starts with `.` or is `__MACOSX` folder added by archivers.
+func isHiddenFile(name string) bool {
+	for _, segment := range strings.Split(path.Clean(name), "/") {
+		if segment == "__MACOSX" || (strings.HasPrefix(segment, ".") && segment != "." && segment != "..") {
+			return true
+		}
+	}
+	return false
+}
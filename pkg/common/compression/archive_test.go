@@ -0,0 +1,147 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testArchiveFile struct {
+	name    string
+	content []byte
+}
+
+func tarBytes(t *testing.T, files []testArchiveFile) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := tar.NewWriter(&buf)
+	if err := writer.WriteHeader(&tar.Header{Name: "logs/", Typeflag: tar.TypeDir, Mode: 0755}); err != nil {
+		t.Fatalf("failed to write a tar header: %v", err)
+	}
+	for _, file := range files {
+		if err := writer.WriteHeader(&tar.Header{Name: file.name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(file.content))}); err != nil {
+			t.Fatalf("failed to write a tar header: %v", err)
+		}
+		if _, err := writer.Write(file.content); err != nil {
+			t.Fatalf("failed to write a tar file: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close tar: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func zipBytes(t *testing.T, files []testArchiveFile) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, file := range files {
+		fileWriter, err := writer.Create(file.name)
+		if err != nil {
+			t.Fatalf("failed to create a zip entry: %v", err)
+		}
+		if _, err := fileWriter.Write(file.content); err != nil {
+			t.Fatalf("failed to write a zip entry: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestWalkFiles(t *testing.T) {
+	archiveFiles := []testArchiveFile{
+		{name: "logs/audit.log", content: []byte("current\n")},
+		{name: "logs/audit-2024-01-01T00-00-00.000.log.gz", content: gzipBytes(t, []byte("rotated\n"))},
+		{name: "logs/.DS_Store", content: []byte("hidden")},
+		{name: "__MACOSX/logs/._audit.log", content: []byte("hidden")},
+	}
+	wantArchiveFiles := map[string]string{
+		"logs/audit.log": "current\n",
+		"logs/audit-2024-01-01T00-00-00.000.log.gz": "rotated\n",
+	}
+	testCases := []struct {
+		desc   string
+		source []byte
+		want   map[string]string
+	}{
+		{
+			desc:   "plain file",
+			source: []byte("plain\n"),
+			want:   map[string]string{"audit.log": "plain\n"},
+		},
+		{
+			desc:   "gzip file",
+			source: gzipBytes(t, []byte("compressed\n")),
+			want:   map[string]string{"audit.log": "compressed\n"},
+		},
+		{
+			desc:   "tar",
+			source: tarBytes(t, archiveFiles),
+			want:   wantArchiveFiles,
+		},
+		{
+			desc:   "tar.gz",
+			source: gzipBytes(t, tarBytes(t, archiveFiles)),
+			want:   wantArchiveFiles,
+		},
+		{
+			desc:   "tar.zst",
+			source: zstdBytes(t, tarBytes(t, archiveFiles)),
+			want:   wantArchiveFiles,
+		},
+		{
+			desc:   "zip",
+			source: zipBytes(t, archiveFiles),
+			want:   wantArchiveFiles,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := map[string]string{}
+			err := WalkFiles("audit.log", bytes.NewReader(tc.source), int64(len(tc.source)), func(name string, reader io.Reader) error {
+				content, err := io.ReadAll(reader)
+				if err != nil {
+					return err
+				}
+				got[name] = string(content)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("WalkFiles() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("files mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWalkFiles_ZipFromStream(t *testing.T) {
+	source := zipBytes(t, []testArchiveFile{{name: "audit.log", content: []byte("foo")}})
+	err := WalkFiles("audit.zip", io.MultiReader(bytes.NewReader(source)), 0, func(name string, reader io.Reader) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "can't be read from a stream") {
+		t.Errorf("WalkFiles() returned %v, want an error for a zip archive from a stream", err)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compression provides readers transparently decompressing uploaded files.
+package compression
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Format is a compression format of a stream detected from its leading magic bytes.
+type Format string
+
+const (
+	// FormatNone is the format of streams not compressed with any known format.
+	FormatNone Format = "none"
+	// FormatGzip is the format of gzip compressed streams.
+	FormatGzip Format = "gzip"
+	// FormatZstd is the format of zstd compressed streams.
+	FormatZstd Format = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// DetectFormat returns the compression format of the stream from its leading bytes without consuming them.
+func DetectFormat(reader *bufio.Reader) (Format, error) {
+	head, err := reader.Peek(len(zstdMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return FormatNone, err
+	}
+	switch {
+	case bytes.HasPrefix(head, gzipMagic):
+		return FormatGzip, nil
+	case bytes.HasPrefix(head, zstdMagic):
+		return FormatZstd, nil
+	default:
+		return FormatNone, nil
+	}
+}
+
+// NewReader returns a reader decompressing the source when it is compressed with gzip or zstd. Uncompressed sources are read as is.
+// Callers must close the returned reader. Closing it doesn't close the source.
+func NewReader(source io.Reader) (io.ReadCloser, Format, error) {
+	buffered := bufio.NewReader(source)
+	format, err := DetectFormat(buffered)
+	if err != nil {
+		return nil, FormatNone, fmt.Errorf("failed to read the header of the stream: %w", err)
+	}
+	switch format {
+	case FormatGzip:
+		reader, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, format, fmt.Errorf("failed to read the gzip header: %w", err)
+		}
+		return reader, format, nil
+	case FormatZstd:
+		decoder, err := zstd.NewReader(buffered, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, format, fmt.Errorf("failed to read the zstd header: %w", err)
+		}
+		return decoder.IOReadCloser(), format, nil
+	default:
+		return io.NopCloser(buffered), format, nil
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/klauspost/compress/zstd"
+)
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		t.Fatalf("failed to write gzip: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close gzip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func zstdBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("failed to create zstd writer: %v", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		t.Fatalf("failed to write zstd: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close zstd: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestNewReader(t *testing.T) {
+	content := []byte("{\"foo\":\"bar\"}\n{\"foo\":\"baz\"}\n")
+	testCases := []struct {
+		desc       string
+		source     []byte
+		wantFormat Format
+		want       []byte
+	}{
+		{
+			desc:       "plain text",
+			source:     content,
+			wantFormat: FormatNone,
+			want:       content,
+		},
+		{
+			desc:       "empty",
+			source:     []byte{},
+			wantFormat: FormatNone,
+			want:       []byte{},
+		},
+		{
+			desc:       "shorter than magic",
+			source:     []byte("{"),
+			wantFormat: FormatNone,
+			want:       []byte("{"),
+		},
+		{
+			desc:       "gzip",
+			source:     gzipBytes(t, content),
+			wantFormat: FormatGzip,
+			want:       content,
+		},
+		{
+			desc:       "concatenated gzip members",
+			source:     append(gzipBytes(t, content[:14]), gzipBytes(t, content[14:])...),
+			wantFormat: FormatGzip,
+			want:       content,
+		},
+		{
+			desc:       "zstd",
+			source:     zstdBytes(t, content),
+			wantFormat: FormatZstd,
+			want:       content,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			reader, format, err := NewReader(bytes.NewReader(tc.source))
+			if err != nil {
+				t.Fatalf("NewReader() returned an unexpected error: %v", err)
+			}
+			defer reader.Close()
+			if format != tc.wantFormat {
+				t.Errorf("format = %q, want %q", format, tc.wantFormat)
+			}
+			got, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("failed to read: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("content mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewReader_BrokenGzipHeader(t *testing.T) {
+	if _, _, err := NewReader(bytes.NewReader([]byte{0x1f, 0x8b, 0x00})); err == nil {
+		t.Errorf("NewReader() succeeded for a broken gzip header, want an error")
+	}
+}
@@ -32,6 +32,7 @@ import (
 type FileFormTaskBuilder struct {
 	FormTaskBuilderBase[upload.UploadResult]
 	verifier upload.UploadFileVerifier
+	optional bool
 }
 
 func NewFileFormTaskBuilder(id taskid.TaskImplementationID[upload.UploadResult], priority int, label string, verifier upload.UploadFileVerifier) *FileFormTaskBuilder {
@@ -53,6 +54,12 @@ func (b *FileFormTaskBuilder) WithDescription(description string) *FileFormTaskB
 	return b
 }
 
+// WithOptional makes the file not required to run the inspection. The task returns the result with UploadStatusWaiting when no file is uploaded.
+func (b *FileFormTaskBuilder) WithOptional() *FileFormTaskBuilder {
+	b.optional = true
+	return b
+}
+
 func (b *FileFormTaskBuilder) Build(labelOpts ...common_task.LabelOpt) common_task.Task[upload.UploadResult] {
 	return common_task.NewTask(b.FormTaskBuilderBase.id, b.FormTaskBuilderBase.dependencies, func(ctx context.Context) (upload.UploadResult, error) {
 		metadata := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
@@ -73,7 +80,7 @@ func (b *FileFormTaskBuilder) Build(labelOpts ...common_task.LabelOpt) common_ta
 		}
 		b.FormTaskBuilderBase.SetupBaseFormField(&field.ParameterFormFieldBase)
 
-		field = setFormHintsFromUploadResult(uploadResult, field)
+		field = setFormHintsFromUploadResult(uploadResult, field, b.optional)
 		formFields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
 		if !found {
 			return upload.UploadResult{}, fmt.Errorf("failed to get form fields from metadata")
@@ -89,7 +96,8 @@ func (b *FileFormTaskBuilder) Build(labelOpts ...common_task.LabelOpt) common_ta
 
 // setFormHintsFromUploadResult sets the appropriate hint and hint type on a form field
 // based on the upload result status and any errors encountered during the upload process.
-func setFormHintsFromUploadResult(result upload.UploadResult, field inspectionmetadata.FileParameterFormField) inspectionmetadata.FileParameterFormField {
+// A waiting optional field has no hint to allow users to run the inspection without uploading a file.
+func setFormHintsFromUploadResult(result upload.UploadResult, field inspectionmetadata.FileParameterFormField, optional bool) inspectionmetadata.FileParameterFormField {
 	switch {
 	case result.UploadError != nil:
 		field.Hint = result.UploadError.Error()
@@ -97,6 +105,7 @@ func setFormHintsFromUploadResult(result upload.UploadResult, field inspectionme
 	case result.VerificationError != nil:
 		field.Hint = result.VerificationError.Error()
 		field.HintType = inspectionmetadata.Error
+	case result.Status == upload.UploadStatusWaiting && optional:
 	case result.Status == upload.UploadStatusWaiting:
 		field.Hint = "Waiting a file to be uploaded."
 		field.HintType = inspectionmetadata.Error
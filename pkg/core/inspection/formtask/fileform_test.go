@@ -60,6 +60,7 @@ func TestSetFormHintsFromUploadResult(t *testing.T) {
 	testCases := []struct {
 		name          string
 		uploadResult  upload.UploadResult
+		optional      bool
 		expectedField inspectionmetadata.FileParameterFormField
 	}{
 		{
@@ -122,6 +123,48 @@ func TestSetFormHintsFromUploadResult(t *testing.T) {
 				Status: upload.UploadStatusWaiting,
 			},
 		},
+		{
+			name: "waiting status case with optional field",
+			uploadResult: upload.UploadResult{
+				Status:            upload.UploadStatusWaiting,
+				UploadError:       nil,
+				VerificationError: nil,
+			},
+			optional: true,
+			expectedField: inspectionmetadata.FileParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:       "test-field",
+					Type:     inspectionmetadata.File,
+					Label:    "Test File Field",
+					Priority: 0,
+					HintType: inspectionmetadata.None,
+					Hint:     "",
+				},
+				Token:  mockToken,
+				Status: upload.UploadStatusWaiting,
+			},
+		},
+		{
+			name: "verification error case with optional field",
+			uploadResult: upload.UploadResult{
+				Status:            upload.UploadStatusWaiting,
+				UploadError:       nil,
+				VerificationError: errors.New("invalid file format"),
+			},
+			optional: true,
+			expectedField: inspectionmetadata.FileParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:       "test-field",
+					Type:     inspectionmetadata.File,
+					Label:    "Test File Field",
+					Priority: 0,
+					HintType: inspectionmetadata.Error,
+					Hint:     "invalid file format",
+				},
+				Token:  mockToken,
+				Status: upload.UploadStatusWaiting,
+			},
+		},
 		{
 			name: "processing status case",
 			uploadResult: upload.UploadResult{
@@ -166,7 +209,7 @@ func TestSetFormHintsFromUploadResult(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := setFormHintsFromUploadResult(tc.uploadResult, baseField)
+			result := setFormHintsFromUploadResult(tc.uploadResult, baseField, tc.optional)
 
 			if result.Hint != tc.expectedField.Hint || result.HintType != tc.expectedField.HintType {
 				t.Errorf("setFormHintsFromUploadResult() unexpected result:\nwant: (hint=%s, hintType=%v)\ngot: (hint=%s, hintType=%v)",
@@ -0,0 +1,680 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcpqueryutil
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LoggingFilter is a Cloud Logging query parsed to evaluate log entries without the Cloud Logging API, like logs exported to files.
+// Log entries are given in the JSON representation of LogEntry, the format of `gcloud logging read --format=json` or log sinks.
+//
+// Only the subset of the logging query language used by the query tasks is supported:
+// * Comparisons with `=`, `!=`, `:`, `=~`, `!~`, `<`, `<=`, `>` and `>=` on field paths including quoted segments like `labels."compute.googleapis.com/resource_name"`
+// * Value lists like `field:("a" OR "b")`
+// * `AND`, `OR`, `NOT`, `-` and parentheses. Terms without operators are joined with AND, and OR has higher precedence than AND as Cloud Logging does.
+// * `LOG_ID("...")` function
+// * Global restrictions with bare values matching any string field containing the value
+// * Comments starting with `--`
+type LoggingFilter struct {
+	root filterExpression
+}
+
+// ParseLoggingFilter parses the given Cloud Logging query.
+func ParseLoggingFilter(filter string) (*LoggingFilter, error) {
+	tokens, err := tokenizeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	parser := &filterParser{tokens: tokens}
+	root, err := parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos < len(parser.tokens) {
+		return nil, fmt.Errorf("unexpected token %q in the filter", parser.tokens[parser.pos].text)
+	}
+	return &LoggingFilter{root: root}, nil
+}
+
+// Match returns true when the given log entry in the JSON representation matches the filter.
+func (f *LoggingFilter) Match(entry map[string]any) bool {
+	return f.root.match(entry)
+}
+
+// TimestampRange returns the range of timestamps possibly matching the filter, read from the comparisons on `timestamp` at the top level of the filter.
+// The range is inclusive and the zero time is returned for the bound not restricted by the filter.
+func (f *LoggingFilter) TimestampRange() (begin time.Time, end time.Time) {
+	terms := []filterExpression{f.root}
+	if and, ok := f.root.(*andFilterExpression); ok {
+		terms = and.children
+	}
+	for _, term := range terms {
+		comparison, ok := term.(*comparisonFilterExpression)
+		if !ok || len(comparison.path) != 1 || comparison.path[0] != "timestamp" || len(comparison.values) != 1 {
+			continue
+		}
+		t, err := parseFilterTime(comparison.values[0].text)
+		if err != nil {
+			continue
+		}
+		switch comparison.operator {
+		case ">", ">=":
+			if begin.IsZero() || t.After(begin) {
+				begin = t
+			}
+		case "<", "<=":
+			if end.IsZero() || t.Before(end) {
+				end = t
+			}
+		}
+	}
+	return begin, end
+}
+
+type filterExpression interface {
+	match(entry map[string]any) bool
+}
+
+type andFilterExpression struct {
+	children []filterExpression
+}
+
+func (a *andFilterExpression) match(entry map[string]any) bool {
+	for _, child := range a.children {
+		if !child.match(entry) {
+			return false
+		}
+	}
+	return true
+}
+
+type orFilterExpression struct {
+	children []filterExpression
+}
+
+func (o *orFilterExpression) match(entry map[string]any) bool {
+	for _, child := range o.children {
+		if child.match(entry) {
+			return true
+		}
+	}
+	return false
+}
+
+type notFilterExpression struct {
+	child filterExpression
+}
+
+func (n *notFilterExpression) match(entry map[string]any) bool {
+	return !n.child.match(entry)
+}
+
+// filterValue is a value on the right hand side of a comparison.
+type filterValue struct {
+	text  string
+	regex *regexp.Regexp
+}
+
+type comparisonFilterExpression struct {
+	path     []string
+	operator string
+	values   []filterValue
+	// allValues is true when the values are joined with AND. Any of values needs to match otherwise.
+	allValues bool
+}
+
+func (c *comparisonFilterExpression) match(entry map[string]any) bool {
+	fieldValues := lookupFilterFieldValues(entry, c.path)
+	if len(fieldValues) == 0 {
+		return false
+	}
+	for _, fieldValue := range fieldValues {
+		matchedCount := 0
+		for _, value := range c.values {
+			if c.compare(fieldValue, value) {
+				matchedCount++
+				if !c.allValues {
+					return true
+				}
+			}
+		}
+		if c.allValues && matchedCount == len(c.values) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *comparisonFilterExpression) compare(fieldValue any, value filterValue) bool {
+	_, isObject := fieldValue.(map[string]any)
+	if c.operator == ":" && value.text == "" {
+		// `field:""` only tests the existence of the field.
+		return true
+	}
+	if isObject {
+		return false
+	}
+	text := filterScalarToString(fieldValue)
+	isSeverity := len(c.path) == 1 && c.path[0] == "severity"
+	switch c.operator {
+	case ":":
+		return strings.Contains(strings.ToLower(text), strings.ToLower(value.text))
+	case "=":
+		if isSeverity {
+			return strings.EqualFold(text, value.text)
+		}
+		return text == value.text
+	case "=~":
+		return value.regex.MatchString(text)
+	case "<", "<=", ">", ">=":
+		order := compareFilterValues(text, value.text, isSeverity)
+		switch c.operator {
+		case "<":
+			return order < 0
+		case "<=":
+			return order <= 0
+		case ">":
+			return order > 0
+		default:
+			return order >= 0
+		}
+	}
+	return false
+}
+
+type logIDFilterExpression struct {
+	logID string
+}
+
+func (l *logIDFilterExpression) match(entry map[string]any) bool {
+	logName, _ := entry["logName"].(string)
+	_, logID, found := strings.Cut(logName, "/logs/")
+	if !found {
+		return false
+	}
+	return unescapeLogID(logID) == l.logID
+}
+
+// globalFilterExpression matches when any field of the log entry contains the value.
+type globalFilterExpression struct {
+	text string
+}
+
+func (g *globalFilterExpression) match(entry map[string]any) bool {
+	return containsFilterText(entry, strings.ToLower(g.text))
+}
+
+func containsFilterText(node any, lowerText string) bool {
+	switch v := node.(type) {
+	case map[string]any:
+		for _, child := range v {
+			if containsFilterText(child, lowerText) {
+				return true
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if containsFilterText(child, lowerText) {
+				return true
+			}
+		}
+	case nil:
+	default:
+		return strings.Contains(strings.ToLower(filterScalarToString(v)), lowerText)
+	}
+	return false
+}
+
+// lookupFilterFieldValues returns the values at the given path. Values in arrays are flattened as Cloud Logging matches any of repeated fields.
+func lookupFilterFieldValues(node any, path []string) []any {
+	if array, ok := node.([]any); ok {
+		var result []any
+		for _, element := range array {
+			result = append(result, lookupFilterFieldValues(element, path)...)
+		}
+		return result
+	}
+	if len(path) == 0 {
+		if node == nil {
+			return nil
+		}
+		return []any{node}
+	}
+	object, ok := node.(map[string]any)
+	if !ok {
+		return nil
+	}
+	child, found := object[path[0]]
+	if !found {
+		return nil
+	}
+	return lookupFilterFieldValues(child, path[1:])
+}
+
+func filterScalarToString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// severityOrder is the order of LogSeverity used in comparisons like `severity>=WARNING`.
+var severityOrder = map[string]int{
+	"DEFAULT":   0,
+	"DEBUG":     100,
+	"INFO":      200,
+	"NOTICE":    300,
+	"WARNING":   400,
+	"ERROR":     500,
+	"CRITICAL":  600,
+	"ALERT":     700,
+	"EMERGENCY": 800,
+}
+
+// compareFilterValues compares values as severities, timestamps or numbers when both values can be read as them, otherwise as strings.
+func compareFilterValues(a string, b string, isSeverity bool) int {
+	if isSeverity {
+		orderA, foundA := severityOrder[strings.ToUpper(a)]
+		orderB, foundB := severityOrder[strings.ToUpper(b)]
+		if foundA && foundB {
+			return orderA - orderB
+		}
+	}
+	if timeA, err := parseFilterTime(a); err == nil {
+		if timeB, err := parseFilterTime(b); err == nil {
+			return timeA.Compare(timeB)
+		}
+	}
+	if numberA, err := strconv.ParseFloat(a, 64); err == nil {
+		if numberB, err := strconv.ParseFloat(b, 64); err == nil {
+			switch {
+			case numberA < numberB:
+				return -1
+			case numberA > numberB:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// filterTimeLayouts are the layouts of timestamps accepted in filters. The second one is the layout used in TimeRangeQuerySection.
+var filterTimeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05-0700", "2006-01-02T15:04:05Z0700", "2006-01-02"}
+
+func parseFilterTime(value string) (time.Time, error) {
+	for _, layout := range filterTimeLayouts {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a timestamp", value)
+}
+
+func unescapeLogID(logID string) string {
+	unescaped, err := url.PathUnescape(logID)
+	if err != nil {
+		return logID
+	}
+	return unescaped
+}
+
+type filterTokenType int
+
+const (
+	filterTokenWord filterTokenType = iota
+	filterTokenString
+	filterTokenLeftParen
+	filterTokenRightParen
+	filterTokenOperator
+	filterTokenMinus
+)
+
+type filterToken struct {
+	tokenType filterTokenType
+	text      string
+	// path is the segments of the field path when the token is a word. A quoted segment is kept as a single segment even if it contains dots.
+	path []string
+}
+
+// filterOperators are the comparison operators in the order to try matching.
+var filterOperators = []string{"=~", "!~", "!=", ">=", "<=", "=", ":", ">", "<"}
+
+const filterWordDelimiters = "()\"=!:<>~"
+
+func tokenizeFilter(filter string) ([]filterToken, error) {
+	var tokens []filterToken
+	i := 0
+	for i < len(filter) {
+		c := filter[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(filter[i:], "--"):
+			lineEnd := strings.IndexByte(filter[i:], '\n')
+			if lineEnd == -1 {
+				i = len(filter)
+			} else {
+				i += lineEnd
+			}
+		case c == '(':
+			tokens = append(tokens, filterToken{tokenType: filterTokenLeftParen, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, filterToken{tokenType: filterTokenRightParen, text: ")"})
+			i++
+		case c == '"':
+			text, next, err := readFilterString(filter, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, filterToken{tokenType: filterTokenString, text: text})
+			i = next
+		case c == '-' && i+1 < len(filter) && !strings.ContainsRune(" \t\r\n", rune(filter[i+1])):
+			tokens = append(tokens, filterToken{tokenType: filterTokenMinus, text: "-"})
+			i++
+		default:
+			operatorFound := false
+			for _, operator := range filterOperators {
+				if strings.HasPrefix(filter[i:], operator) {
+					tokens = append(tokens, filterToken{tokenType: filterTokenOperator, text: operator})
+					i += len(operator)
+					operatorFound = true
+					break
+				}
+			}
+			if operatorFound {
+				continue
+			}
+			token, next, err := readFilterWord(filter, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token)
+			i = next
+		}
+	}
+	return tokens, nil
+}
+
+// readFilterString reads a quoted string starting at the given position. Escape sequences other than `\"` and `\\` are kept as they are to pass them to regular expressions.
+func readFilterString(filter string, start int) (string, int, error) {
+	var builder strings.Builder
+	i := start + 1
+	for i < len(filter) {
+		c := filter[i]
+		switch {
+		case c == '\\' && i+1 < len(filter):
+			next := filter[i+1]
+			if next == '"' || next == '\\' {
+				builder.WriteByte(next)
+			} else {
+				builder.WriteByte(c)
+				builder.WriteByte(next)
+			}
+			i += 2
+		case c == '"':
+			return builder.String(), i + 1, nil
+		default:
+			builder.WriteByte(c)
+			i++
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string in the filter at %d", start)
+}
+
+// readFilterWord reads a bare word like a field path, a keyword or an unquoted value starting at the given position.
+func readFilterWord(filter string, start int) (filterToken, int, error) {
+	var path []string
+	var segment strings.Builder
+	i := start
+	for i < len(filter) {
+		c := filter[i]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || strings.IndexByte(filterWordDelimiters, c) != -1 {
+			if c == '"' && i > start && filter[i-1] == '.' {
+				quoted, next, err := readFilterString(filter, i)
+				if err != nil {
+					return filterToken{}, 0, err
+				}
+				path = append(path, quoted)
+				i = next
+				continue
+			}
+			break
+		}
+		if c == '.' {
+			if segment.Len() > 0 {
+				path = append(path, segment.String())
+				segment.Reset()
+			}
+		} else {
+			segment.WriteByte(c)
+		}
+		i++
+	}
+	if segment.Len() > 0 {
+		path = append(path, segment.String())
+	}
+	if i == start {
+		return filterToken{}, 0, fmt.Errorf("unexpected character %q in the filter at %d", filter[i], i)
+	}
+	return filterToken{tokenType: filterTokenWord, text: filter[start:i], path: path}, i, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() *filterToken {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *filterParser) peekKeyword(keyword string) bool {
+	token := p.peek()
+	return token != nil && token.tokenType == filterTokenWord && token.text == keyword
+}
+
+func (p *filterParser) parseAnd() (filterExpression, error) {
+	var children []filterExpression
+	for {
+		token := p.peek()
+		if token == nil || token.tokenType == filterTokenRightParen {
+			break
+		}
+		if p.peekKeyword("AND") {
+			p.pos++
+			continue
+		}
+		child, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return &andFilterExpression{children: children}, nil
+}
+
+func (p *filterParser) parseOr() (filterExpression, error) {
+	first, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	children := []filterExpression{first}
+	for p.peekKeyword("OR") {
+		p.pos++
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+	if len(children) == 1 {
+		return first, nil
+	}
+	return &orFilterExpression{children: children}, nil
+}
+
+func (p *filterParser) parseUnary() (filterExpression, error) {
+	token := p.peek()
+	if token != nil && (token.tokenType == filterTokenMinus || p.peekKeyword("NOT")) {
+		p.pos++
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notFilterExpression{child: child}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (filterExpression, error) {
+	token := p.peek()
+	if token == nil {
+		return nil, fmt.Errorf("unexpected end of the filter")
+	}
+	p.pos++
+	switch token.tokenType {
+	case filterTokenLeftParen:
+		expression, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(filterTokenRightParen); err != nil {
+			return nil, err
+		}
+		return expression, nil
+	case filterTokenString:
+		return &globalFilterExpression{text: token.text}, nil
+	case filterTokenWord:
+		next := p.peek()
+		if next != nil && next.tokenType == filterTokenLeftParen {
+			return p.parseFunction(token)
+		}
+		if next != nil && next.tokenType == filterTokenOperator {
+			p.pos++
+			return p.parseComparison(token.path, next.text)
+		}
+		return &globalFilterExpression{text: token.text}, nil
+	}
+	return nil, fmt.Errorf("unexpected token %q in the filter", token.text)
+}
+
+func (p *filterParser) parseFunction(name *filterToken) (filterExpression, error) {
+	if !strings.EqualFold(name.text, "LOG_ID") {
+		return nil, fmt.Errorf("unsupported function %q in the filter", name.text)
+	}
+	p.pos++ // (
+	argument := p.peek()
+	if argument == nil || argument.tokenType != filterTokenString {
+		return nil, fmt.Errorf("LOG_ID requires a quoted log ID")
+	}
+	p.pos++
+	if err := p.expect(filterTokenRightParen); err != nil {
+		return nil, err
+	}
+	return &logIDFilterExpression{logID: unescapeLogID(argument.text)}, nil
+}
+
+func (p *filterParser) parseComparison(path []string, operator string) (filterExpression, error) {
+	negate := false
+	switch operator {
+	case "!=":
+		operator, negate = "=", true
+	case "!~":
+		operator, negate = "=~", true
+	}
+	comparison := &comparisonFilterExpression{path: path, operator: operator}
+	token := p.peek()
+	if token != nil && token.tokenType == filterTokenLeftParen {
+		p.pos++
+		junction := ""
+		for {
+			value, err := p.parseValue(operator)
+			if err != nil {
+				return nil, err
+			}
+			comparison.values = append(comparison.values, value)
+			if !p.peekKeyword("OR") && !p.peekKeyword("AND") {
+				break
+			}
+			if junction != "" && !p.peekKeyword(junction) {
+				return nil, fmt.Errorf("mixing AND and OR in a value list is not supported")
+			}
+			junction = p.peek().text
+			p.pos++
+		}
+		if err := p.expect(filterTokenRightParen); err != nil {
+			return nil, err
+		}
+		comparison.allValues = junction == "AND"
+	} else {
+		value, err := p.parseValue(operator)
+		if err != nil {
+			return nil, err
+		}
+		comparison.values = []filterValue{value}
+	}
+	if negate {
+		return &notFilterExpression{child: comparison}, nil
+	}
+	return comparison, nil
+}
+
+func (p *filterParser) parseValue(operator string) (filterValue, error) {
+	token := p.peek()
+	if token == nil || (token.tokenType != filterTokenString && token.tokenType != filterTokenWord) {
+		return filterValue{}, fmt.Errorf("a value is expected after the operator %q", operator)
+	}
+	p.pos++
+	value := filterValue{text: token.text}
+	if operator == "=~" {
+		regex, err := regexp.Compile(token.text)
+		if err != nil {
+			return filterValue{}, fmt.Errorf("invalid regular expression %q in the filter: %w", token.text, err)
+		}
+		value.regex = regex
+	}
+	return value, nil
+}
+
+func (p *filterParser) expect(tokenType filterTokenType) error {
+	token := p.peek()
+	if token == nil || token.tokenType != tokenType {
+		return fmt.Errorf("unexpected token in the filter at token %d", p.pos)
+	}
+	p.pos++
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcpqueryutil
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testAuditLogEntry = `{
+	"insertId": "foo",
+	"logName": "projects/test-project/logs/cloudaudit.googleapis.com%2Factivity",
+	"timestamp": "2026-01-01T10:00:00.123456Z",
+	"severity": "NOTICE",
+	"resource": {
+		"type": "k8s_cluster",
+		"labels": {"cluster_name": "test-cluster", "project_id": "test-project"}
+	},
+	"labels": {"compute.googleapis.com/resource_name": "node-1"},
+	"protoPayload": {
+		"methodName": "io.k8s.core.v1.pods.create",
+		"resourceName": "core/v1/namespaces/default/pods/nginx",
+		"status": {"code": 0},
+		"authorizationInfo": [{"permission": "io.k8s.core.v1.pods.create"}]
+	}
+}`
+
+func TestLoggingFilterMatch(t *testing.T) {
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(testAuditLogEntry), &entry); err != nil {
+		t.Fatalf("failed to parse the test log entry: %v", err)
+	}
+	testCases := []struct {
+		name   string
+		filter string
+		want   bool
+	}{
+		{name: "empty filter", filter: "", want: true},
+		{name: "equal", filter: `resource.type="k8s_cluster"`, want: true},
+		{name: "equal without quotes", filter: `resource.type=k8s_cluster`, want: true},
+		{name: "not equal", filter: `resource.type!="k8s_cluster"`, want: false},
+		{name: "has with different case", filter: `protoPayload.methodName:"PODS"`, want: true},
+		{name: "has empty string on existing field", filter: `protoPayload.status:""`, want: true},
+		{name: "has empty string on missing field", filter: `protoPayload.response:""`, want: false},
+		{name: "value list with OR", filter: `resource.labels.cluster_name:("foo" OR "test-cluster")`, want: true},
+		{name: "value list not matching", filter: `resource.labels.cluster_name:("foo" OR "bar")`, want: false},
+		{name: "value list with AND", filter: `protoPayload.methodName:("pods" AND "create")`, want: true},
+		{name: "negated value list", filter: `-protoPayload.methodName:("deployments" OR "services")`, want: true},
+		{name: "regex", filter: `protoPayload.methodName=~"\.(pods|services)\."`, want: true},
+		{name: "negated regex", filter: `-protoPayload.methodName=~"\.(pods|services)\."`, want: false},
+		{name: "not regex operator", filter: `protoPayload.methodName!~"\.deployments\."`, want: true},
+		{name: "quoted path segment", filter: `labels."compute.googleapis.com/resource_name"=("node-1" OR "node-2")`, want: true},
+		{name: "repeated field", filter: `protoPayload.authorizationInfo.permission="io.k8s.core.v1.pods.create"`, want: true},
+		{name: "number", filter: `protoPayload.status.code=0`, want: true},
+		{name: "severity comparison", filter: `severity>=INFO`, want: true},
+		{name: "severity comparison not matching", filter: `severity>=WARNING`, want: false},
+		{name: "severity equal ignoring case", filter: `severity=notice`, want: true},
+		{name: "log id", filter: `LOG_ID("cloudaudit.googleapis.com/activity") OR LOG_ID("cloudaudit.googleapis.com/data_access")`, want: true},
+		{name: "log id not matching", filter: `log_id("events")`, want: false},
+		{name: "global restriction", filter: `"nginx"`, want: true},
+		{name: "global restriction not matching", filter: `"apache"`, want: false},
+		{name: "implicit AND", filter: "resource.type=\"k8s_cluster\"\nseverity=ERROR", want: false},
+		{name: "explicit AND with NOT", filter: `resource.type="k8s_cluster" AND NOT severity=ERROR`, want: true},
+		{name: "OR has higher precedence than implicit AND", filter: `severity=ERROR OR severity=NOTICE resource.type="gce_instance"`, want: false},
+		{name: "parentheses with NOT", filter: `(resource.labels.cluster_name="foo" OR NOT (resource.type="gce_instance"))`, want: true},
+		{
+			name: "time range",
+			filter: `timestamp >= "2026-01-01T09:00:00+0000"
+timestamp < "2026-01-01T10:00:00+0000"`,
+			want: false,
+		},
+		{
+			name: "generated query with comments",
+			filter: `resource.type="k8s_cluster"
+-- Filter out the logs from the other clusters
+resource.labels.cluster_name="test-cluster"
+protoPayload.methodName: ("create" OR "update" OR "patch" OR "delete")
+-- No namespace filter
+timestamp >= "2026-01-01T09:00:00+0000"
+timestamp < "2026-01-01T11:00:00+0000"`,
+			want: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := ParseLoggingFilter(tc.filter)
+			if err != nil {
+				t.Fatalf("ParseLoggingFilter(%q) returned an unexpected error: %v", tc.filter, err)
+			}
+			got := filter.Match(entry)
+			if got != tc.want {
+				t.Errorf("Match() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseLoggingFilterWithInvalidFilter(t *testing.T) {
+	testCases := []struct {
+		name   string
+		filter string
+	}{
+		{name: "unclosed string", filter: `resource.type="k8s_cluster`},
+		{name: "unclosed parenthesis", filter: `(resource.type="k8s_cluster"`},
+		{name: "missing value", filter: `resource.type=`},
+		{name: "invalid regex", filter: `protoPayload.methodName=~"("`},
+		{name: "mixed value list", filter: `resource.type:("a" OR "b" AND "c")`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseLoggingFilter(tc.filter)
+			if err == nil {
+				t.Errorf("ParseLoggingFilter(%q) returned no error, want an error", tc.filter)
+			}
+		})
+	}
+}
+
+func TestLoggingFilterTimestampRange(t *testing.T) {
+	testCases := []struct {
+		name      string
+		filter    string
+		wantBegin time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "generated time range",
+			filter:    "resource.type=\"k8s_cluster\"\n" + TimeRangeQuerySection(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC), false),
+			wantBegin: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "only begin",
+			filter:    `timestamp > "2026-01-01T09:00:00Z"`,
+			wantBegin: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "timestamp inside OR is not used",
+			filter: `timestamp > "2026-01-01T09:00:00Z" OR severity=ERROR`,
+		},
+		{
+			name:   "no timestamp",
+			filter: `resource.type="k8s_cluster"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := ParseLoggingFilter(tc.filter)
+			if err != nil {
+				t.Fatalf("ParseLoggingFilter(%q) returned an unexpected error: %v", tc.filter, err)
+			}
+			gotBegin, gotEnd := filter.TimestampRange()
+			if diff := cmp.Diff(tc.wantBegin.UTC(), gotBegin.UTC()); diff != "" {
+				t.Errorf("begin mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantEnd.UTC(), gotEnd.UTC()); diff != "" {
+				t.Errorf("end mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
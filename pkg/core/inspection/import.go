@@ -0,0 +1,108 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/model/khifile"
+)
+
+// ImportInspection validates the .khi file read from the given source and registers it as a finished inspection with the HeaderMetadata in the file.
+// The file is copied into the data destination folder. It returns the ID of the imported inspection.
+// The imported inspection is treated as created at the time of the import by the retention policy. The inspect time in the header is only shown to users.
+// The returned error wraps khifile.ErrInvalidFormat when the source is not a valid .khi file.
+func (s *InspectionTaskServer) ImportInspection(source io.Reader) (string, error) {
+	if err := os.MkdirAll(s.ioConfig.DataDestination, 0755); err != nil {
+		return "", fmt.Errorf("failed to create the data destination folder: %w", err)
+	}
+	tmpFile, err := os.CreateTemp(s.ioConfig.DataDestination, "import-*.khi.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temporary file to import: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+	_, err = io.Copy(tmpFile, source)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write the imported file: %w", err)
+	}
+
+	reader, err := khifile.Open(tmpPath)
+	if err != nil {
+		return "", err
+	}
+	header, err := reader.Header()
+	reader.Close()
+	if err != nil {
+		return "", errors.Join(khifile.ErrInvalidFormat, err)
+	}
+	header.FileSize = int(reader.Size())
+
+	// Reserve the ID and register the inspection under the same lock not to share the ID with concurrent imports or new inspections.
+	s.inspectionsLock.Lock()
+	id := s.unusedInspectionID()
+	resultPath := filepath.Join(s.ioConfig.DataDestination, id+".khi")
+	if err := os.Rename(tmpPath, resultPath); err != nil {
+		s.inspectionsLock.Unlock()
+		return "", fmt.Errorf("failed to move the imported file: %w", err)
+	}
+	manifest := &InspectionManifest{
+		ID:                      id,
+		InspectionType:          s.inspectionTypeIDFromName(header.InspectionType),
+		Features:                []string{},
+		RequestValues:           map[string]any{},
+		Header:                  header,
+		ResultPath:              resultPath,
+		Status:                  InspectionStatusDone,
+		CreationTimeUnixSeconds: time.Now().Unix(),
+	}
+	// Write the manifest before registering the inspection not to list an inspection disappearing after restarting the server.
+	if s.persistenceFolder != "" {
+		if err := writeInspectionManifest(s.persistenceFolder, manifest); err != nil {
+			s.inspectionsLock.Unlock()
+			os.Remove(resultPath)
+			return "", fmt.Errorf("failed to persist the manifest of the imported inspection: %w", err)
+		}
+	}
+	_, err = s.restoreInspectionLocked(manifest)
+	s.inspectionsLock.Unlock()
+	if err != nil {
+		os.Remove(resultPath)
+		if s.persistenceFolder != "" {
+			os.Remove(inspectionManifestPath(s.persistenceFolder, id))
+		}
+		return "", err
+	}
+	return id, nil
+}
+
+// inspectionTypeIDFromName returns the ID of the registered inspection type with the given name written in the header of .khi files.
+// It returns the name as is when no inspection type has the name, e.g. the file was generated with an inspection type not available on this server.
+func (s *InspectionTaskServer) inspectionTypeIDFromName(name string) string {
+	for _, inspectionType := range s.inspectionTypes {
+		if inspectionType.Name == name {
+			return inspectionType.Id
+		}
+	}
+	return name
+}
@@ -0,0 +1,247 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/typedmap"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/logger"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/khifile"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	"github.com/google/go-cmp/cmp"
+)
+
+// minimalKHIFile returns a .khi file only containing the JSON block without any binary chunk.
+func minimalKHIFile(jsonBlock string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(khifile.Magic)
+	binary.Write(&buf, binary.LittleEndian, uint32(len(jsonBlock)))
+	buf.WriteString(jsonBlock)
+	return buf.Bytes()
+}
+
+func TestInspectionTaskServer_ImportInspection(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	validFile := minimalKHIFile(`{"metadata":{"header":{"inspectionType":"Test Inspection","inspectionName":"incident-1","startTimeUnixSeconds":100,"endTimeUnixSeconds":200,"inspectTimeUnixSeconds":300}}}`)
+	testCases := []struct {
+		desc            string
+		data            []byte
+		persistence     bool
+		wantErr         bool
+		wantInvalidFile bool
+		wantHeader      *inspectionmetadata.HeaderMetadata
+	}{
+		{
+			desc: "valid file",
+			data: validFile,
+			wantHeader: &inspectionmetadata.HeaderMetadata{
+				InspectionType:         "Test Inspection",
+				InspectionName:         "incident-1",
+				StartTimeUnixSeconds:   100,
+				EndTimeUnixSeconds:     200,
+				InspectTimeUnixSeconds: 300,
+				FileSize:               len(validFile),
+			},
+		},
+		{
+			desc:        "valid file with persistence",
+			data:        validFile,
+			persistence: true,
+			wantHeader: &inspectionmetadata.HeaderMetadata{
+				InspectionType:         "Test Inspection",
+				InspectionName:         "incident-1",
+				StartTimeUnixSeconds:   100,
+				EndTimeUnixSeconds:     200,
+				InspectTimeUnixSeconds: 300,
+				FileSize:               len(validFile),
+			},
+		},
+		{
+			desc:            "without magic",
+			data:            []byte("NOT A KHI FILE"),
+			wantErr:         true,
+			wantInvalidFile: true,
+		},
+		{
+			desc:            "broken JSON block",
+			data:            minimalKHIFile(`{"metadata":`),
+			wantErr:         true,
+			wantInvalidFile: true,
+		},
+		{
+			desc:            "without header metadata",
+			data:            minimalKHIFile(`{"metadata":{}}`),
+			wantErr:         true,
+			wantInvalidFile: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dataFolder := t.TempDir()
+			server := newPersistenceTestServer(t, &inspectioncore_contract.IOConfig{DataDestination: dataFolder, TemporaryFolder: t.TempDir()})
+			if tc.persistence {
+				if err := server.EnablePersistence(dataFolder); err != nil {
+					t.Fatalf("EnablePersistence failed: %v", err)
+				}
+			}
+
+			id, err := server.ImportInspection(bytes.NewReader(tc.data))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("ImportInspection succeeded, want an error")
+				}
+				if got := errors.Is(err, khifile.ErrInvalidFormat); got != tc.wantInvalidFile {
+					t.Errorf("errors.Is(err, khifile.ErrInvalidFormat) = %v, want %v", got, tc.wantInvalidFile)
+				}
+				if len(server.GetAllRunners()) != 0 {
+					t.Errorf("an inspection was registered for the invalid file")
+				}
+				entries, _ := os.ReadDir(dataFolder)
+				if len(entries) != 0 {
+					t.Errorf("files are left in the data destination: %v", entries)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImportInspection failed: %v", err)
+			}
+
+			runner := server.GetInspection(id)
+			if runner == nil {
+				t.Fatalf("imported inspection %s was not registered", id)
+			}
+			metadata, err := runner.GetCurrentMetadata()
+			if err != nil {
+				t.Fatalf("GetCurrentMetadata failed: %v", err)
+			}
+			header, found := typedmap.Get(metadata, inspectionmetadata.HeaderMetadataKey)
+			if !found {
+				t.Fatalf("header metadata was not found")
+			}
+			if diff := cmp.Diff(tc.wantHeader, header); diff != "" {
+				t.Errorf("header mismatch (-want +got):\n%s", diff)
+			}
+			result, err := runner.Result()
+			if err != nil {
+				t.Fatalf("Result failed: %v", err)
+			}
+			reader, err := result.ResultStore.GetRangeReader(0, int64(len(tc.data)))
+			if err != nil {
+				t.Fatalf("GetRangeReader failed: %v", err)
+			}
+			defer reader.Close()
+			got, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("failed to read the result: %v", err)
+			}
+			if !bytes.Equal(got, tc.data) {
+				t.Errorf("the result is not the same as the imported file")
+			}
+			// The inspection type name in the header is resolved to the registered inspection type.
+			if _, err := runner.FeatureList(); err != nil {
+				t.Errorf("the inspection type of the imported inspection was not resolved: %v", err)
+			}
+			_, err = os.Stat(filepath.Join(dataFolder, id+".manifest.json"))
+			if gotManifest := err == nil; gotManifest != tc.persistence {
+				t.Errorf("manifest existence = %v, want %v", gotManifest, tc.persistence)
+			}
+		})
+	}
+}
+
+func TestInspectionTaskServer_ImportInspectionConcurrently(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	const concurrency = 10
+	validFile := minimalKHIFile(`{"metadata":{"header":{"inspectionType":"Test Inspection","inspectionName":"incident-1","startTimeUnixSeconds":100,"endTimeUnixSeconds":200,"inspectTimeUnixSeconds":300}}}`)
+	server := newPersistenceTestServer(t, &inspectioncore_contract.IOConfig{DataDestination: t.TempDir(), TemporaryFolder: t.TempDir()})
+
+	var wg sync.WaitGroup
+	ids := make(chan string, concurrency*2)
+	errs := make(chan error, concurrency*2)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			id, err := server.ImportInspection(bytes.NewReader(validFile))
+			if err != nil {
+				errs <- err
+				return
+			}
+			ids <- id
+		}()
+		go func() {
+			defer wg.Done()
+			id, err := server.CreateInspection("test-inspection")
+			if err != nil {
+				errs <- err
+				return
+			}
+			ids <- id
+		}()
+	}
+	wg.Wait()
+	close(ids)
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("unexpected error: %v", err)
+	}
+	seen := map[string]struct{}{}
+	for id := range ids {
+		if _, found := seen[id]; found {
+			t.Errorf("inspection ID %s was used more than once", id)
+		}
+		seen[id] = struct{}{}
+	}
+	if got := len(server.GetAllRunners()); got != concurrency*2 {
+		t.Errorf("got %d inspections, want %d", got, concurrency*2)
+	}
+}
+
+func TestInspectionTaskServer_ImportInspectionWithManifestWriteFailure(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	validFile := minimalKHIFile(`{"metadata":{"header":{"inspectionType":"Test Inspection","inspectionName":"incident-1","inspectTimeUnixSeconds":300}}}`)
+	dataFolder := t.TempDir()
+	persistenceFolder := filepath.Join(t.TempDir(), "manifests")
+	server := newPersistenceTestServer(t, &inspectioncore_contract.IOConfig{DataDestination: dataFolder, TemporaryFolder: t.TempDir()})
+	if err := server.EnablePersistence(persistenceFolder); err != nil {
+		t.Fatalf("EnablePersistence failed: %v", err)
+	}
+	// Writing the manifest fails after the folder is removed.
+	if err := os.RemoveAll(persistenceFolder); err != nil {
+		t.Fatalf("failed to remove the persistence folder: %v", err)
+	}
+
+	if _, err := server.ImportInspection(bytes.NewReader(validFile)); err == nil {
+		t.Fatalf("ImportInspection succeeded, want an error")
+	}
+
+	if len(server.GetAllRunners()) != 0 {
+		t.Errorf("an inspection was registered without its manifest")
+	}
+	entries, _ := os.ReadDir(dataFolder)
+	if len(entries) != 0 {
+		t.Errorf("files are left in the data destination: %v", entries)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// inspectionManifestSuffix is the file name suffix of inspection manifests in the persistence folder.
+const inspectionManifestSuffix = ".manifest.json"
+
+// Status values of InspectionManifest. These are the same as the status given to lifecycle.NotifyInspectionEnd.
+const (
+	InspectionStatusDone   = "done"
+	InspectionStatusError  = "error"
+	InspectionStatusCancel = "cancel"
+)
+
+// InspectionManifest is the summary of a finished inspection persisted to restore it after restarting the server.
+type InspectionManifest struct {
+	ID             string                             `json:"id"`
+	InspectionType string                             `json:"inspectionType"`
+	Features       []string                           `json:"features"`
+	RequestValues  map[string]any                     `json:"requestValues"`
+	Header         *inspectionmetadata.HeaderMetadata `json:"header"`
+	// ResultPath is the path of the .khi file. It is empty when the inspection didn't finish successfully.
+	ResultPath string `json:"resultPath"`
+	// Status is one of InspectionStatusDone, InspectionStatusError or InspectionStatusCancel.
+	Status string `json:"status"`
+	// CreationTimeUnixSeconds is the time when the inspection was created on this server, or imported for inspections imported from .khi files.
+	// The retention policy uses this time. InspectTimeUnixSeconds in the header is used when it is 0 for manifests written before this field was added.
+	CreationTimeUnixSeconds int64 `json:"creationTimeUnixSeconds,omitempty"`
+}
+
+// EnablePersistence makes the server write a manifest of each finished inspection in the given folder, and restores inspections from the manifests already in the folder.
+// Restored inspections are read-only and can't be run again.
+func (s *InspectionTaskServer) EnablePersistence(folder string) error {
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return fmt.Errorf("failed to create the folder for inspection manifests: %w", err)
+	}
+	s.persistenceFolder = folder
+	manifestPaths, err := filepath.Glob(filepath.Join(folder, "*"+inspectionManifestSuffix))
+	if err != nil {
+		return err
+	}
+	for _, manifestPath := range manifestPaths {
+		manifest, err := readInspectionManifest(manifestPath)
+		if err != nil {
+			slog.Warn("ignoring an invalid inspection manifest", "path", manifestPath, "error", err)
+			continue
+		}
+		if _, err := s.RestoreInspection(manifest); err != nil {
+			slog.Warn("failed to restore an inspection", "path", manifestPath, "error", err)
+		}
+	}
+	return nil
+}
+
+// RestoreInspection registers a finished inspection described in the manifest as a read-only inspection and returns its ID.
+func (s *InspectionTaskServer) RestoreInspection(manifest *InspectionManifest) (string, error) {
+	if manifest.ID == "" {
+		return "", fmt.Errorf("inspection ID is empty")
+	}
+	s.inspectionsLock.Lock()
+	defer s.inspectionsLock.Unlock()
+	return s.restoreInspectionLocked(manifest)
+}
+
+// restoreInspectionLocked registers the inspection described in the manifest. Callers must hold inspectionsLock.
+func (s *InspectionTaskServer) restoreInspectionLocked(manifest *InspectionManifest) (string, error) {
+	if _, found := s.inspections[manifest.ID]; found {
+		return "", fmt.Errorf("inspection %s already exists", manifest.ID)
+	}
+	if manifest.Status == InspectionStatusDone {
+		if _, err := os.Stat(manifest.ResultPath); err != nil {
+			return "", fmt.Errorf("the result of inspection %s is not available: %w", manifest.ID, err)
+		}
+	}
+	runner := NewInspectionRunner(s, s.ioConfig, manifest.ID, s.runContextOptions...)
+	if err := runner.restore(manifest); err != nil {
+		return "", err
+	}
+	s.inspections[manifest.ID] = runner
+	return manifest.ID, nil
+}
+
+// restore makes the runner a finished inspection described in the manifest.
+func (i *InspectionTaskRunner) restore(manifest *InspectionManifest) error {
+	if err := i.SetInspectionType(manifest.InspectionType); err == nil {
+		// Features are only informational for restored inspections. Ignore features removed after the inspection.
+		i.enabledFeatures = map[string]bool{}
+		for _, feature := range manifest.Features {
+			i.enabledFeatures[feature] = true
+		}
+	} else {
+		i.currentInspectionType = manifest.InspectionType
+	}
+	i.requestValues = manifest.RequestValues
+
+	header := manifest.Header
+	if header == nil {
+		header = &inspectionmetadata.HeaderMetadata{}
+	}
+	if manifest.CreationTimeUnixSeconds != 0 {
+		i.inspectionCreationTime = time.Unix(manifest.CreationTimeUnixSeconds, 0)
+	} else if header.InspectTimeUnixSeconds != 0 {
+		i.inspectionCreationTime = time.Unix(header.InspectTimeUnixSeconds, 0)
+	}
+	emptyTaskSet, err := coretask.NewTaskSet([]coretask.UntypedTask{})
+	if err != nil {
+		return err
+	}
+	metadata := typedmap.NewTypedMap()
+	i.addCommonMetadata(context.Background(), metadata, header, emptyTaskSet)
+	progress, _ := typedmap.Get(metadata, inspectionmetadata.ProgressMetadataKey)
+	var runner *completedTaskRunner
+	switch manifest.Status {
+	case InspectionStatusDone:
+		progress.MarkDone()
+		runner = newCompletedTaskRunner(inspectioncore_contract.NewFileSystemInspectionResultRepository(manifest.ResultPath), nil)
+	case InspectionStatusCancel:
+		progress.MarkCancelled()
+		runner = newCompletedTaskRunner(nil, context.Canceled)
+	case InspectionStatusError:
+		progress.MarkError()
+		runner = newCompletedTaskRunner(nil, fmt.Errorf("inspection %s was finished with an error before restarting the server", manifest.ID))
+	default:
+		return fmt.Errorf("unknown inspection status %q", manifest.Status)
+	}
+	i.runner = runner
+	i.metadata = metadata.AsReadonly()
+	i.cancel = func() {}
+	close(i.runComplete)
+	return nil
+}
+
+// persistManifest writes the manifest of the finished inspection when the persistence is enabled on the server.
+func (i *InspectionTaskRunner) persistManifest(status string, resultStore inspectioncore_contract.Store) error {
+	if i.inspectionServer.persistenceFolder == "" || i.deleted.Load() {
+		return nil
+	}
+	header, _ := typedmap.Get(i.metadata, inspectionmetadata.HeaderMetadataKey)
+	features := []string{}
+	for feature, enabled := range i.enabledFeatures {
+		if enabled {
+			features = append(features, feature)
+		}
+	}
+	slices.Sort(features)
+	manifest := &InspectionManifest{
+		ID:                      i.ID,
+		InspectionType:          i.currentInspectionType,
+		Features:                features,
+		RequestValues:           i.requestValues,
+		Header:                  header,
+		Status:                  status,
+		CreationTimeUnixSeconds: i.inspectionCreationTime.Unix(),
+	}
+	if fileStore, ok := resultStore.(*inspectioncore_contract.FileSystemStore); ok && status == InspectionStatusDone {
+		manifest.ResultPath = fileStore.FilePath()
+	}
+	return writeInspectionManifest(i.inspectionServer.persistenceFolder, manifest)
+}
+
+func inspectionManifestPath(folder string, inspectionID string) string {
+	return filepath.Join(folder, inspectionID+inspectionManifestSuffix)
+}
+
+func readInspectionManifest(manifestPath string) (*InspectionManifest, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &InspectionManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, err
+	}
+	if manifest.ID != strings.TrimSuffix(filepath.Base(manifestPath), inspectionManifestSuffix) {
+		return nil, fmt.Errorf("inspection ID %q doesn't match with the file name", manifest.ID)
+	}
+	return manifest, nil
+}
+
+// writeInspectionManifest writes the manifest via a temporary file not to leave a partially written manifest.
+func writeInspectionManifest(folder string, manifest *InspectionManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	manifestPath := inspectionManifestPath(folder, manifest.ID)
+	tmpPath := manifestPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, manifestPath)
+}
+
+// completedTaskRunner is a coretask.TaskRunner of a restored inspection returning the result given at the construction.
+type completedTaskRunner struct {
+	result *typedmap.ReadonlyTypedMap
+	err    error
+	done   chan interface{}
+}
+
+var _ coretask.TaskRunner = (*completedTaskRunner)(nil)
+
+func newCompletedTaskRunner(resultStore inspectioncore_contract.Store, err error) *completedTaskRunner {
+	done := make(chan interface{})
+	close(done)
+	runner := &completedTaskRunner{err: err, done: done}
+	if err == nil {
+		result := typedmap.NewTypedMap()
+		typedmap.Set(result, typedmap.NewTypedKey[inspectioncore_contract.Store](inspectioncore_contract.SerializerTaskID.ReferenceIDString()), resultStore)
+		runner.result = result.AsReadonly()
+	}
+	return runner
+}
+
+// Run implements coretask.TaskRunner.
+func (c *completedTaskRunner) Run(ctx context.Context) error {
+	return fmt.Errorf("restored inspection can't be run again")
+}
+
+// Wait implements coretask.TaskRunner.
+func (c *completedTaskRunner) Wait() <-chan interface{} {
+	return c.done
+}
+
+// Result implements coretask.TaskRunner.
+func (c *completedTaskRunner) Result() (*typedmap.ReadonlyTypedMap, error) {
+	return c.result, c.err
+}
+
+// Tasks implements coretask.TaskRunner.
+func (c *completedTaskRunner) Tasks() []coretask.UntypedTask {
+	return []coretask.UntypedTask{}
+}
+
+// AddInterceptor implements coretask.TaskRunner.
+func (c *completedTaskRunner) AddInterceptor(interceptor coretask.Interceptor) {}
@@ -0,0 +1,198 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/typedmap"
+	coreinspection "github.com/GoogleCloudPlatform/khi/pkg/core/inspection"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/logger"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newPersistenceTestServer returns a server with an inspection type running only a dummy feature task and the serializer.
+func newPersistenceTestServer(t *testing.T, ioConfig *inspectioncore_contract.IOConfig) *coreinspection.InspectionTaskServer {
+	t.Helper()
+	server, err := coreinspection.NewServer(ioConfig)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	if err := server.AddInspectionType(coreinspection.InspectionType{Id: "test-inspection", Name: "Test Inspection"}); err != nil {
+		t.Fatalf("AddInspectionType failed: %v", err)
+	}
+	dummyTask := coretask.NewTask(
+		taskid.NewDefaultImplementationID[any]("dummy-task"),
+		nil,
+		func(ctx context.Context) (any, error) {
+			return "success", nil
+		},
+		coretask.WithLabelValue(inspectioncore_contract.LabelKeyInspectionTypes, []string{"test-inspection"}),
+		coretask.WithLabelValue(inspectioncore_contract.LabelKeyInspectionDefaultFeatureFlag, true),
+		coretask.WithLabelValue(inspectioncore_contract.LabelKeyInspectionFeatureFlag, true),
+		coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+	)
+	if err := server.AddTask(dummyTask); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	return server
+}
+
+func TestInspectionTaskServer_EnablePersistence(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	dataFolder := t.TempDir()
+	ioConfig := &inspectioncore_contract.IOConfig{
+		DataDestination: dataFolder,
+		TemporaryFolder: t.TempDir(),
+	}
+
+	server := newPersistenceTestServer(t, ioConfig)
+	if err := server.EnablePersistence(dataFolder); err != nil {
+		t.Fatalf("EnablePersistence failed: %v", err)
+	}
+	inspectionID, err := server.CreateInspection("test-inspection")
+	if err != nil {
+		t.Fatalf("CreateInspection failed: %v", err)
+	}
+	runner := server.GetInspection(inspectionID)
+	if err := runner.Run(context.Background(), &inspectioncore_contract.InspectionRequest{Values: map[string]any{"foo": "bar"}}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	<-runner.Wait()
+	if _, err := os.Stat(filepath.Join(dataFolder, inspectionID+".manifest.json")); err != nil {
+		t.Fatalf("the manifest was not written: %v", err)
+	}
+
+	// Simulate the server restart.
+	restartedServer := newPersistenceTestServer(t, ioConfig)
+	if err := restartedServer.EnablePersistence(dataFolder); err != nil {
+		t.Fatalf("EnablePersistence failed: %v", err)
+	}
+	restored := restartedServer.GetInspection(inspectionID)
+	if restored == nil {
+		t.Fatalf("inspection %s was not restored", inspectionID)
+	}
+	if !restored.Started() {
+		t.Errorf("restored inspection is not marked as started")
+	}
+	result, err := restored.Result()
+	if err != nil {
+		t.Fatalf("Result of the restored inspection returned an error: %v", err)
+	}
+	gotSize, err := result.ResultStore.GetInspectionResultSizeInBytes()
+	if err != nil {
+		t.Fatalf("GetInspectionResultSizeInBytes failed: %v", err)
+	}
+	wantResult, _ := runner.Result()
+	wantSize, _ := wantResult.ResultStore.GetInspectionResultSizeInBytes()
+	if gotSize != wantSize {
+		t.Errorf("restored result size = %d, want %d", gotSize, wantSize)
+	}
+	metadata, err := restored.GetCurrentMetadata()
+	if err != nil {
+		t.Fatalf("GetCurrentMetadata failed: %v", err)
+	}
+	progress, found := typedmap.Get(metadata, inspectionmetadata.ProgressMetadataKey)
+	if !found {
+		t.Fatalf("progress metadata was not found")
+	}
+	if progress.Phase != inspectionmetadata.TaskPhaseDone {
+		t.Errorf("restored progress phase = %s, want %s", progress.Phase, inspectionmetadata.TaskPhaseDone)
+	}
+	if err := restored.Run(context.Background(), &inspectioncore_contract.InspectionRequest{}); err == nil {
+		t.Errorf("Run on the restored inspection succeeded, want an error")
+	}
+
+	newID, err := restartedServer.CreateInspection("test-inspection")
+	if err != nil {
+		t.Fatalf("CreateInspection failed: %v", err)
+	}
+	if newID == inspectionID {
+		t.Errorf("CreateInspection returned the ID %s used by the restored inspection", newID)
+	}
+}
+
+func TestInspectionTaskServer_RestoreInspection(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	resultPath := filepath.Join(t.TempDir(), "result.khi")
+	if err := os.WriteFile(resultPath, []byte("KHI"), 0644); err != nil {
+		t.Fatalf("failed to write the result file: %v", err)
+	}
+	testCases := []struct {
+		desc      string
+		manifest  *coreinspection.InspectionManifest
+		wantErr   bool
+		wantPhase inspectionmetadata.TaskProgressPhase
+	}{
+		{
+			desc:      "done",
+			manifest:  &coreinspection.InspectionManifest{ID: "foo", InspectionType: "test-inspection", ResultPath: resultPath, Status: coreinspection.InspectionStatusDone},
+			wantPhase: inspectionmetadata.TaskPhaseDone,
+		},
+		{
+			desc:      "error",
+			manifest:  &coreinspection.InspectionManifest{ID: "foo", InspectionType: "test-inspection", Status: coreinspection.InspectionStatusError},
+			wantPhase: inspectionmetadata.TaskPhaseError,
+		},
+		{
+			desc:      "inspection type removed after the inspection",
+			manifest:  &coreinspection.InspectionManifest{ID: "foo", InspectionType: "removed-inspection", ResultPath: resultPath, Status: coreinspection.InspectionStatusDone},
+			wantPhase: inspectionmetadata.TaskPhaseDone,
+		},
+		{
+			desc:     "missing result file",
+			manifest: &coreinspection.InspectionManifest{ID: "foo", InspectionType: "test-inspection", ResultPath: resultPath + ".missing", Status: coreinspection.InspectionStatusDone},
+			wantErr:  true,
+		},
+		{
+			desc:     "unknown status",
+			manifest: &coreinspection.InspectionManifest{ID: "foo", InspectionType: "test-inspection", Status: "unknown"},
+			wantErr:  true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			server := newPersistenceTestServer(t, &inspectioncore_contract.IOConfig{DataDestination: t.TempDir(), TemporaryFolder: t.TempDir()})
+			id, err := server.RestoreInspection(tc.manifest)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("RestoreInspection succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RestoreInspection failed: %v", err)
+			}
+			metadata, err := server.GetInspection(id).GetCurrentMetadata()
+			if err != nil {
+				t.Fatalf("GetCurrentMetadata failed: %v", err)
+			}
+			progress, found := typedmap.Get(metadata, inspectionmetadata.ProgressMetadataKey)
+			if !found {
+				t.Fatalf("progress metadata was not found")
+			}
+			if diff := cmp.Diff(tc.wantPhase, progress.Phase); diff != "" {
+				t.Errorf("progress phase mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -36,3 +36,30 @@ func ReportProgressFromArraySync[T any](progress *inspectionmetadata.TaskProgres
 	}
 	return nil
 }
+
+// ReportProgressFromBytes updates the progress with the ratio of the processed bytes to the total bytes.
+// The progress is marked as indeterminate when the total size is unknown (0 or less).
+func ReportProgressFromBytes(progress *inspectionmetadata.TaskProgressMetadata, processedBytes int64, totalBytes int64) {
+	if totalBytes <= 0 {
+		progress.MarkIndeterminate()
+		progress.Message = fmt.Sprintf("%s processed", formatBytes(processedBytes))
+		return
+	}
+	progress.Update(min(float32(processedBytes)/float32(totalBytes), 1), fmt.Sprintf("%s/%s", formatBytes(processedBytes), formatBytes(totalBytes)))
+}
+
+// formatBytes returns the human readable representation of the given size in bytes.
+func formatBytes(sizeInBytes int64) string {
+	const unit = 1024
+	if sizeInBytes < unit {
+		return fmt.Sprintf("%d B", sizeInBytes)
+	}
+	value := float64(sizeInBytes) / unit
+	for _, suffix := range []string{"KiB", "MiB", "GiB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f TiB", value)
+}
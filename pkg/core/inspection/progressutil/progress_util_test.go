@@ -130,3 +130,58 @@ func TestReportProgressFromArraySync(t *testing.T) {
 		})
 	}
 }
+
+func TestReportProgressFromBytes(t *testing.T) {
+	tests := []struct {
+		name              string
+		processedBytes    int64
+		totalBytes        int64
+		wantPercentage    float32
+		wantMessage       string
+		wantIndeterminate bool
+	}{
+		{
+			name:           "bytes",
+			processedBytes: 512,
+			totalBytes:     1024,
+			wantPercentage: 0.5,
+			wantMessage:    "512 B/1.0 KiB",
+		},
+		{
+			name:           "gibibytes",
+			processedBytes: 3 * 1024 * 1024 * 1024 / 2,
+			totalBytes:     3 * 1024 * 1024 * 1024,
+			wantPercentage: 0.5,
+			wantMessage:    "1.5 GiB/3.0 GiB",
+		},
+		{
+			name:           "processed bytes exceeding the total",
+			processedBytes: 2048,
+			totalBytes:     1024,
+			wantPercentage: 1,
+			wantMessage:    "2.0 KiB/1.0 KiB",
+		},
+		{
+			name:              "unknown total",
+			processedBytes:    5 * 1024 * 1024,
+			totalBytes:        0,
+			wantMessage:       "5.0 MiB processed",
+			wantIndeterminate: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			progress := inspectionmetadata.NewTaskProgressMetadata("test")
+			ReportProgressFromBytes(progress, tc.processedBytes, tc.totalBytes)
+			if progress.Percentage != tc.wantPercentage {
+				t.Errorf("Percentage = %f, want %f", progress.Percentage, tc.wantPercentage)
+			}
+			if progress.Message != tc.wantMessage {
+				t.Errorf("Message = %q, want %q", progress.Message, tc.wantMessage)
+			}
+			if progress.Indeterminate != tc.wantIndeterminate {
+				t.Errorf("Indeterminate = %v, want %v", progress.Indeterminate, tc.wantIndeterminate)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// ErrInspectionNotFound is returned when the given inspection ID is not registered in the server.
+var ErrInspectionNotFound = errors.New("inspection not found")
+
+// cancelWaitTimeout is the maximum duration to wait a running inspection to finish after cancelling it on deletion.
+var cancelWaitTimeout = 30 * time.Second
+
+// RetentionPolicy is the condition to delete finished inspections. Zero values of each field mean no limit with the field.
+type RetentionPolicy struct {
+	// MaxAge is the maximum age of inspections from their creation.
+	MaxAge time.Duration
+	// MaxTotalBytes is the maximum total size of the result files. Older inspections are deleted first.
+	MaxTotalBytes int64
+	// MaxCount is the maximum count of finished inspections. Older inspections are deleted first.
+	MaxCount int
+}
+
+// Enabled returns true if any limit is set in the policy.
+func (p RetentionPolicy) Enabled() bool {
+	return p.MaxAge > 0 || p.MaxTotalBytes > 0 || p.MaxCount > 0
+}
+
+// DeleteInspection cancels the inspection if it's running, removes it from the server and deletes its result, manifest and temporary files.
+func (s *InspectionTaskServer) DeleteInspection(inspectionID string) error {
+	s.inspectionsLock.Lock()
+	runner, found := s.inspections[inspectionID]
+	if !found {
+		s.inspectionsLock.Unlock()
+		return fmt.Errorf("inspection %s was not found: %w", inspectionID, ErrInspectionNotFound)
+	}
+	delete(s.inspections, inspectionID)
+	s.inspectionsLock.Unlock()
+	return runner.deleteFiles()
+}
+
+// deleteFiles stops the inspection and deletes the files related to the inspection.
+func (i *InspectionTaskRunner) deleteFiles() error {
+	i.deleted.Store(true)
+	if i.Started() && !i.Finished() && i.cancel != nil {
+		i.cancel()
+		select {
+		case <-i.runComplete:
+		case <-time.After(cancelWaitTimeout):
+			slog.Warn("inspection didn't finish after cancellation. Deleting its files anyway", "inspectionID", i.ID)
+		}
+	}
+	filePaths := []string{filepath.Join(i.ioconfig.DataDestination, i.ID+".khi")}
+	if result, err := i.Result(); err == nil {
+		if fileStore, ok := result.ResultStore.(*inspectioncore_contract.FileSystemStore); ok {
+			filePaths = append(filePaths, fileStore.FilePath())
+		}
+	}
+	if i.inspectionServer.persistenceFolder != "" {
+		filePaths = append(filePaths, inspectionManifestPath(i.inspectionServer.persistenceFolder, i.ID))
+	}
+	errs := []error{}
+	for _, filePath := range filePaths {
+		if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	if err := os.RemoveAll(i.temporaryFolder()); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// resultSizeInBytes returns the size of the result file. It returns 0 when the inspection has no result.
+func (i *InspectionTaskRunner) resultSizeInBytes() int64 {
+	result, err := i.Result()
+	if err != nil {
+		return 0
+	}
+	size, err := result.ResultStore.GetInspectionResultSizeInBytes()
+	if err != nil {
+		return 0
+	}
+	return int64(size)
+}
+
+// EnforceRetention deletes finished inspections violating the policy and returns the deleted inspection IDs.
+// Inspections are kept from the newest one, and running inspections are never deleted.
+func (s *InspectionTaskServer) EnforceRetention(now time.Time, policy RetentionPolicy) []string {
+	finished := []*InspectionTaskRunner{}
+	for _, runner := range s.GetAllRunners() {
+		if runner.Finished() {
+			finished = append(finished, runner)
+		}
+	}
+	slices.SortFunc(finished, func(a, b *InspectionTaskRunner) int {
+		return b.CreationTime().Compare(a.CreationTime())
+	})
+	deleted := []string{}
+	keptCount := 0
+	var keptBytes int64
+	for _, runner := range finished {
+		size := runner.resultSizeInBytes()
+		expired := policy.MaxAge > 0 && now.Sub(runner.CreationTime()) > policy.MaxAge
+		tooMany := policy.MaxCount > 0 && keptCount >= policy.MaxCount
+		tooLarge := policy.MaxTotalBytes > 0 && keptBytes+size > policy.MaxTotalBytes
+		if !expired && !tooMany && !tooLarge {
+			keptCount++
+			keptBytes += size
+			continue
+		}
+		if err := s.DeleteInspection(runner.ID); err != nil && !errors.Is(err, ErrInspectionNotFound) {
+			slog.Warn("failed to delete an inspection by the retention policy", "inspectionID", runner.ID, "error", err)
+			continue
+		}
+		deleted = append(deleted, runner.ID)
+	}
+	return deleted
+}
+
+// StartRetentionJanitor enforces the retention policy every interval in background until the context is cancelled.
+func (s *InspectionTaskServer) StartRetentionJanitor(ctx context.Context, policy RetentionPolicy, interval time.Duration) {
+	if !policy.Enabled() {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if deleted := s.EnforceRetention(time.Now(), policy); len(deleted) > 0 {
+				slog.InfoContext(ctx, "deleted inspections by the retention policy", "inspectionIDs", deleted)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	coreinspection "github.com/GoogleCloudPlatform/khi/pkg/core/inspection"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/logger"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	"github.com/google/go-cmp/cmp"
+)
+
+var retentionTestNow = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// restoreTestInspection registers a finished inspection created at the given time with a result file of the given size.
+func restoreTestInspection(t *testing.T, server *coreinspection.InspectionTaskServer, ioConfig *inspectioncore_contract.IOConfig, id string, createdAt time.Time, size int) {
+	t.Helper()
+	resultPath := filepath.Join(ioConfig.DataDestination, id+".khi")
+	if err := os.WriteFile(resultPath, make([]byte, size), 0644); err != nil {
+		t.Fatalf("failed to write the result file: %v", err)
+	}
+	_, err := server.RestoreInspection(&coreinspection.InspectionManifest{
+		ID:             id,
+		InspectionType: "test-inspection",
+		Header:         &inspectionmetadata.HeaderMetadata{InspectTimeUnixSeconds: createdAt.Unix()},
+		ResultPath:     resultPath,
+		Status:         coreinspection.InspectionStatusDone,
+	})
+	if err != nil {
+		t.Fatalf("RestoreInspection failed: %v", err)
+	}
+}
+
+func TestInspectionTaskServer_DeleteInspection(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	dataFolder := t.TempDir()
+	ioConfig := &inspectioncore_contract.IOConfig{
+		DataDestination: dataFolder,
+		TemporaryFolder: t.TempDir(),
+	}
+	server := newPersistenceTestServer(t, ioConfig)
+	if err := server.EnablePersistence(dataFolder); err != nil {
+		t.Fatalf("EnablePersistence failed: %v", err)
+	}
+	restoreTestInspection(t, server, ioConfig, "foo", retentionTestNow, 10)
+	manifestPath := filepath.Join(dataFolder, "foo.manifest.json")
+	if err := os.WriteFile(manifestPath, []byte("{}"), 0644); err != nil {
+		t.Fatalf("failed to write the manifest: %v", err)
+	}
+	temporaryFolder := filepath.Join(ioConfig.TemporaryFolder, "khi-foo")
+	if err := os.MkdirAll(temporaryFolder, 0755); err != nil {
+		t.Fatalf("failed to create the temporary folder: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(temporaryFolder, "khi-c-chunk"), []byte("chunk"), 0644); err != nil {
+		t.Fatalf("failed to write the temporary file: %v", err)
+	}
+
+	if err := server.DeleteInspection("foo"); err != nil {
+		t.Fatalf("DeleteInspection failed: %v", err)
+	}
+
+	if server.GetInspection("foo") != nil {
+		t.Errorf("the deleted inspection is still registered")
+	}
+	for _, path := range []string{filepath.Join(dataFolder, "foo.khi"), manifestPath, temporaryFolder} {
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s was not deleted: %v", path, err)
+		}
+	}
+	if err := server.DeleteInspection("foo"); !errors.Is(err, coreinspection.ErrInspectionNotFound) {
+		t.Errorf("DeleteInspection for a missing inspection returned %v, want ErrInspectionNotFound", err)
+	}
+}
+
+func TestInspectionTaskServer_EnforceRetention(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	type testInspection struct {
+		id   string
+		age  time.Duration
+		size int
+	}
+	inspections := []testInspection{
+		{id: "newest", age: time.Hour, size: 100},
+		{id: "middle", age: 2 * time.Hour, size: 100},
+		{id: "oldest", age: 3 * time.Hour, size: 100},
+	}
+	testCases := []struct {
+		desc        string
+		policy      coreinspection.RetentionPolicy
+		wantDeleted []string
+	}{
+		{
+			desc:        "no limit",
+			policy:      coreinspection.RetentionPolicy{},
+			wantDeleted: []string{},
+		},
+		{
+			desc:        "max age",
+			policy:      coreinspection.RetentionPolicy{MaxAge: 90 * time.Minute},
+			wantDeleted: []string{"middle", "oldest"},
+		},
+		{
+			desc:        "max count",
+			policy:      coreinspection.RetentionPolicy{MaxCount: 2},
+			wantDeleted: []string{"oldest"},
+		},
+		{
+			desc:        "max total bytes",
+			policy:      coreinspection.RetentionPolicy{MaxTotalBytes: 150},
+			wantDeleted: []string{"middle", "oldest"},
+		},
+		{
+			desc:        "combined",
+			policy:      coreinspection.RetentionPolicy{MaxAge: 150 * time.Minute, MaxCount: 3, MaxTotalBytes: 1000},
+			wantDeleted: []string{"oldest"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ioConfig := &inspectioncore_contract.IOConfig{
+				DataDestination: t.TempDir(),
+				TemporaryFolder: t.TempDir(),
+			}
+			server := newPersistenceTestServer(t, ioConfig)
+			for _, inspection := range inspections {
+				restoreTestInspection(t, server, ioConfig, inspection.id, retentionTestNow.Add(-inspection.age), inspection.size)
+			}
+
+			gotDeleted := server.EnforceRetention(retentionTestNow, tc.policy)
+			slices.Sort(gotDeleted)
+
+			if diff := cmp.Diff(tc.wantDeleted, gotDeleted); diff != "" {
+				t.Errorf("deleted inspections mismatch (-want +got):\n%s", diff)
+			}
+			for _, id := range tc.wantDeleted {
+				if server.GetInspection(id) != nil {
+					t.Errorf("inspection %s is still registered", id)
+				}
+			}
+		})
+	}
+}
+
+func TestInspectionTaskServer_EnforceRetentionWithImportedInspection(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	testCases := []struct {
+		desc        string
+		policy      coreinspection.RetentionPolicy
+		wantDeleted []string
+	}{
+		{
+			desc:        "max age",
+			policy:      coreinspection.RetentionPolicy{MaxAge: 90 * time.Minute},
+			wantDeleted: []string{"old-local"},
+		},
+		{
+			desc:        "max count",
+			policy:      coreinspection.RetentionPolicy{MaxCount: 1},
+			wantDeleted: []string{"new-local", "old-local"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ioConfig := &inspectioncore_contract.IOConfig{
+				DataDestination: t.TempDir(),
+				TemporaryFolder: t.TempDir(),
+			}
+			server := newPersistenceTestServer(t, ioConfig)
+			now := time.Now()
+			restoreTestInspection(t, server, ioConfig, "old-local", now.Add(-2*time.Hour), 100)
+			restoreTestInspection(t, server, ioConfig, "new-local", now.Add(-time.Hour), 100)
+			// The capture was inspected long before it is imported.
+			importedID, err := server.ImportInspection(bytes.NewReader(minimalKHIFile(`{"metadata":{"header":{"inspectionType":"Test Inspection","inspectionName":"incident-1","inspectTimeUnixSeconds":300}}}`)))
+			if err != nil {
+				t.Fatalf("ImportInspection failed: %v", err)
+			}
+
+			gotDeleted := server.EnforceRetention(now.Add(time.Minute), tc.policy)
+			slices.Sort(gotDeleted)
+
+			if diff := cmp.Diff(tc.wantDeleted, gotDeleted); diff != "" {
+				t.Errorf("deleted inspections mismatch (-want +got):\n%s", diff)
+			}
+			if server.GetInspection(importedID) == nil {
+				t.Errorf("the imported inspection was deleted")
+			}
+		})
+	}
+}
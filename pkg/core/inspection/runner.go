@@ -21,8 +21,10 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/GoogleCloudPlatform/khi/pkg/common/filter"
@@ -66,6 +68,10 @@ type InspectionTaskRunner struct {
 	inspectionCreationTime time.Time
 	interceptors           []InspectionInterceptor
 	runComplete            chan (struct{})
+	// requestValues is the values of the inspection request given to Run. It's persisted in the inspection manifest.
+	requestValues map[string]any
+	// deleted is set when the inspection is deleted from the server not to write its manifest after the deletion.
+	deleted atomic.Bool
 }
 
 // NewInspectionRunner creates a new InspectionTaskRunner.
@@ -106,13 +112,23 @@ func (i *InspectionTaskRunner) addDefaultRunContextOptions() {
 		RunContextOptionFromValue(inspectioncore_contract.GlobalSharedMap, inspectionRunnerGlobalSharedMap),
 		RunContextOptionFromValue(inspectioncore_contract.CurrentIOConfig, i.ioconfig),
 		RunContextOptionFromFunc(inspectioncore_contract.CurrentHistoryBuilder, func(ctx context.Context, mode inspectioncore_contract.InspectionTaskModeType) (*history.Builder, error) {
-			return history.NewBuilder(i.ioconfig.TemporaryFolder), nil
+			if mode == inspectioncore_contract.TaskModeRun {
+				if err := os.MkdirAll(i.temporaryFolder(), 0755); err != nil {
+					return nil, err
+				}
+			}
+			return history.NewBuilder(i.temporaryFolder()), nil
 		}),
 	}
 
 	i.runContextOptions = append(i.runContextOptions, defaultRunContextOptions...)
 }
 
+// temporaryFolder returns the folder used for the temporary files of this inspection. It is removed after the run.
+func (i *InspectionTaskRunner) temporaryFolder() string {
+	return filepath.Join(i.ioconfig.TemporaryFolder, "khi-"+i.ID)
+}
+
 // AddInterceptors adds interceptors to the runner.
 func (i *InspectionTaskRunner) AddInterceptors(interceptors ...InspectionInterceptor) {
 	i.interceptors = append(i.interceptors, interceptors...)
@@ -123,6 +139,21 @@ func (i *InspectionTaskRunner) Started() bool {
 	return i.runner != nil
 }
 
+// Finished returns true if the inspection has been started and finished regardless of its result.
+func (i *InspectionTaskRunner) Finished() bool {
+	select {
+	case <-i.runComplete:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreationTime returns the time when the inspection was created.
+func (i *InspectionTaskRunner) CreationTime() time.Time {
+	return i.inspectionCreationTime
+}
+
 // SetInspectionType sets the type of inspection and initializes the available tasks.
 // It filters the root task set from the server to get tasks relevant to the specified inspectionType.
 func (i *InspectionTaskRunner) SetInspectionType(inspectionType string) error {
@@ -257,6 +288,7 @@ func (i *InspectionTaskRunner) Run(ctx context.Context, req *inspectioncore_cont
 		return err
 	}
 	i.runner = runner
+	i.requestValues = req.Values
 
 	runCtx, err := i.withRunContextValues(ctx, i.runner, inspectioncore_contract.TaskModeRun, req.Values)
 	if err != nil {
@@ -307,18 +339,19 @@ func (i *InspectionTaskRunner) Run(ctx context.Context, req *inspectioncore_cont
 		}
 		status := ""
 		resultSize := 0
+		var resultStore inspectioncore_contract.Store
 		if result, err := i.runner.Result(); err != nil {
 			if errors.Is(cancelableCtx.Err(), context.Canceled) {
 				progress.MarkCancelled()
-				status = "cancel"
+				status = InspectionStatusCancel
 			} else {
 				progress.MarkError()
-				status = "error"
+				status = InspectionStatusError
 			}
 			slog.WarnContext(runCtx, fmt.Sprintf("task %s was finished with an error\n%s", i.ID, err))
 		} else {
 			progress.MarkDone()
-			status = "done"
+			status = InspectionStatusDone
 
 			history, found := typedmap.Get(result, typedmap.NewTypedKey[inspectioncore_contract.Store](inspectioncore_contract.SerializerTaskID.ReferenceIDString()))
 			if !found {
@@ -327,12 +360,19 @@ func (i *InspectionTaskRunner) Run(ctx context.Context, req *inspectioncore_cont
 			if history == nil {
 				slog.ErrorContext(runCtx, "Failed to get the serializer result. Result is nil!")
 			} else {
+				resultStore = history
 				resultSize, err = history.GetInspectionResultSizeInBytes()
 				if err != nil {
 					slog.ErrorContext(runCtx, fmt.Sprintf("Failed to get the serialized result size\n%s", err))
 				}
 			}
 		}
+		if err := i.persistManifest(status, resultStore); err != nil {
+			slog.ErrorContext(runCtx, fmt.Sprintf("Failed to write the inspection manifest\n%s", err))
+		}
+		if err := os.RemoveAll(i.temporaryFolder()); err != nil {
+			slog.WarnContext(runCtx, fmt.Sprintf("Failed to remove the temporary folder\n%s", err))
+		}
 		lifecycle.Default.NotifyInspectionEnd(khictx.MustGetValue(runCtx, inspectioncore_contract.InspectionTaskRunID), currentInspectionType.Name, status, resultSize)
 	}()
 	return nil
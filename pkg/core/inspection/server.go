@@ -17,6 +17,7 @@ package coreinspection
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/GoogleCloudPlatform/khi/pkg/common/idgenerator"
 	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
@@ -64,12 +65,15 @@ type InspectionTaskServer struct {
 	inspectionTypes []*InspectionType
 	// inspections are generated inspection task runers
 	inspections           map[string]*InspectionTaskRunner
+	inspectionsLock       sync.RWMutex
 	inspectionIDGenerator idgenerator.IDGenerator
 
 	ioConfig *inspectioncore_contract.IOConfig
 
 	runContextOptions      []RunContextOption
 	inspectionIntercepters []InspectionInterceptor
+	// persistenceFolder is the folder to write inspection manifests. Manifests are not written when it's empty.
+	persistenceFolder string
 }
 
 func NewServer(ioConfig *inspectioncore_contract.IOConfig) (*InspectionTaskServer, error) {
@@ -124,7 +128,9 @@ func (s *InspectionTaskServer) AddInspectionInterceptor(interceptor InspectionIn
 
 // CreateInspection generates an inspection and returns inspection ID
 func (s *InspectionTaskServer) CreateInspection(inspectionType string) (string, error) {
-	id := s.inspectionIDGenerator.Generate()
+	s.inspectionsLock.Lock()
+	defer s.inspectionsLock.Unlock()
+	id := s.unusedInspectionID()
 	inspectionRunner := NewInspectionRunner(s, s.ioConfig, id, s.runContextOptions...)
 	inspectionRunner.AddInterceptors(s.inspectionIntercepters...)
 	err := inspectionRunner.SetInspectionType(inspectionType)
@@ -135,8 +141,20 @@ func (s *InspectionTaskServer) CreateInspection(inspectionType string) (string,
 	return inspectionRunner.ID, nil
 }
 
+// unusedInspectionID returns an inspection ID not used by any registered inspection. Callers must hold inspectionsLock.
+func (s *InspectionTaskServer) unusedInspectionID() string {
+	id := s.inspectionIDGenerator.Generate()
+	// Restored or imported inspections may already use IDs from the generator.
+	for s.inspections[id] != nil {
+		id = s.inspectionIDGenerator.Generate()
+	}
+	return id
+}
+
 // Inspection returns an instance of an Inspection queried with given inspection ID.
 func (s *InspectionTaskServer) GetInspection(inspectionID string) *InspectionTaskRunner {
+	s.inspectionsLock.RLock()
+	defer s.inspectionsLock.RUnlock()
 	return s.inspections[inspectionID]
 }
 
@@ -154,6 +172,8 @@ func (s *InspectionTaskServer) GetInspectionType(inspectionTypeId string) *Inspe
 }
 
 func (s *InspectionTaskServer) GetAllRunners() []*InspectionTaskRunner {
+	s.inspectionsLock.RLock()
+	defer s.inspectionsLock.RUnlock()
 	inspections := []*InspectionTaskRunner{}
 	for _, value := range s.inspections {
 		inspections = append(inspections, value)
@@ -38,14 +38,24 @@ import (
 // Later parser tasks usually process logs from older to newer with grouped by resource, thus it can't be done in parallel.
 // The process of extracting log fields must not depend on the other logs and it can be done in parallel.
 func NewFieldSetReadTask(taskId taskid.TaskImplementationID[[]*log.Log], logTask taskid.TaskReference[[]*log.Log], fieldSetReaders []log.FieldSetReader, labelOpts ...coretask.LabelOpt) coretask.Task[[]*log.Log] {
-	return NewProgressReportableInspectionTask(taskId, []taskid.UntypedTaskReference{
+	return NewFieldSetReadTaskWithReaderFactory(taskId, logTask, nil, func(ctx context.Context, logs []*log.Log) []log.FieldSetReader {
+		return fieldSetReaders
+	}, labelOpts...)
+}
+
+// NewFieldSetReadTaskWithReaderFactory creates a task same as NewFieldSetReadTask but the FieldSetReaders are instanciated with all the logs before reading fields.
+// This is used when the fields of a log depend on the other logs, e.g. the stages of a request written as separate logs. The factory can aggregate such information from the logs and give it to the FieldSetReaders.
+// The factory can also read the results of the tasks given in dependencies from the context to configure the FieldSetReaders.
+func NewFieldSetReadTaskWithReaderFactory(taskId taskid.TaskImplementationID[[]*log.Log], logTask taskid.TaskReference[[]*log.Log], dependencies []taskid.UntypedTaskReference, fieldSetReadersFactory func(ctx context.Context, logs []*log.Log) []log.FieldSetReader, labelOpts ...coretask.LabelOpt) coretask.Task[[]*log.Log] {
+	return NewProgressReportableInspectionTask(taskId, append([]taskid.UntypedTaskReference{
 		logTask,
-	}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, progress *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+	}, dependencies...), func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, progress *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
 		if taskMode != inspectioncore_contract.TaskModeRun {
 			return []*log.Log{}, nil
 		}
 
 		logs := coretask.GetTaskResult(ctx, logTask)
+		fieldSetReaders := fieldSetReadersFactory(ctx, logs)
 		concurrency := 16
 		pool := worker.NewPool(concurrency)
 		completed := atomic.Uint64{}
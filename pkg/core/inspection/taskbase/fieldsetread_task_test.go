@@ -16,10 +16,12 @@ package inspectiontaskbase
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
 	inspectiontest "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/test"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
 	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
 	tasktest "github.com/GoogleCloudPlatform/khi/pkg/core/task/test"
 	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
@@ -196,3 +198,63 @@ bar: "world"`,
 		})
 	}
 }
+
+// testFieldSetFooCountReader reads the count of logs with the same foo value as the Bar field.
+type testFieldSetFooCountReader struct {
+	countByFoo map[string]int
+}
+
+// FieldSetKind implements log.FieldSetReader.
+func (t *testFieldSetFooCountReader) FieldSetKind() string {
+	return "test-bar"
+}
+
+// Read implements log.FieldSetReader.
+func (t *testFieldSetFooCountReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	return &testFieldSetBar{
+		Bar: fmt.Sprintf("%d", t.countByFoo[reader.ReadStringOrDefault("foo", "")]),
+	}, nil
+}
+
+var _ log.FieldSetReader = (*testFieldSetFooCountReader)(nil)
+
+func TestNewFieldSetReadTaskWithReaderFactory(t *testing.T) {
+	logs := []*log.Log{}
+	for _, logYaml := range []string{`foo: "a"`, `foo: "b"`, `foo: "a"`} {
+		l, err := log.NewLogFromYAMLString(logYaml)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		logs = append(logs, l)
+	}
+
+	testSourceTaskID := taskid.NewDefaultImplementationID[[]*log.Log]("source")
+	testOffsetTaskID := taskid.NewDefaultImplementationID[int]("offset")
+	testTaskID := taskid.NewDefaultImplementationID[[]*log.Log]("dest")
+	fieldSetReadTask := NewFieldSetReadTaskWithReaderFactory(testTaskID, testSourceTaskID.Ref(), []taskid.UntypedTaskReference{testOffsetTaskID.Ref()}, func(ctx context.Context, logs []*log.Log) []log.FieldSetReader {
+		countByFoo := map[string]int{}
+		for _, l := range logs {
+			countByFoo[l.ReadStringOrDefault("foo", "")]++
+		}
+		offset := coretask.GetTaskResult(ctx, testOffsetTaskID.Ref())
+		for foo := range countByFoo {
+			countByFoo[foo] += offset
+		}
+		return []log.FieldSetReader{&testFieldSetFooCountReader{countByFoo: countByFoo}}
+	})
+
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	_, _, err := inspectiontest.RunInspectionTask(ctx, fieldSetReadTask, inspectioncore_contract.TaskModeRun, map[string]any{}, tasktest.NewTaskDependencyValuePair(testSourceTaskID.Ref(), logs), tasktest.NewTaskDependencyValuePair(testOffsetTaskID.Ref(), 10))
+	if err != nil {
+		t.Fatalf("RunInspectionTask returned an unexpected error: %v", err)
+	}
+
+	want := []string{"12", "11", "12"}
+	got := []string{}
+	for _, l := range logs {
+		got = append(got, log.MustGetFieldSet(l, &testFieldSetBar{}).Bar)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("bar fieldset mismatch (-want +got):\n%s", diff)
+	}
+}
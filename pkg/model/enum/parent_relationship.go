@@ -17,22 +17,28 @@ package enum
 type ParentRelationship int
 
 const (
-	RelationshipChild                 ParentRelationship = 0
-	RelationshipResourceCondition     ParentRelationship = 1
-	RelationshipOperation             ParentRelationship = 2
-	RelationshipEndpointSlice         ParentRelationship = 3
-	RelationshipContainer             ParentRelationship = 4
-	RelationshipNodeComponent         ParentRelationship = 5
-	RelationshipOwnerReference        ParentRelationship = 6
-	RelationshipPodBinding            ParentRelationship = 7 // Deprecated and replaced by PodPhase
-	RelationshipNetworkEndpointGroup  ParentRelationship = 8
-	RelationshipManagedInstanceGroup  ParentRelationship = 9
-	RelationshipControlPlaneComponent ParentRelationship = 10
-	RelationshipSerialPort            ParentRelationship = 11
-	RelationshipAirflowTaskInstance   ParentRelationship = 12
-	RelationshipCSMAccessLog          ParentRelationship = 13 // Added since 0.49
-	RelationshipPodPhase              ParentRelationship = 14 // Added since 0.50
-	relationshipUnusedEnd                                     // Add items above. This field is used for counting items in this enum to test.
+	RelationshipChild                   ParentRelationship = 0
+	RelationshipResourceCondition       ParentRelationship = 1
+	RelationshipOperation               ParentRelationship = 2
+	RelationshipEndpointSlice           ParentRelationship = 3
+	RelationshipContainer               ParentRelationship = 4
+	RelationshipNodeComponent           ParentRelationship = 5
+	RelationshipOwnerReference          ParentRelationship = 6
+	RelationshipPodBinding              ParentRelationship = 7 // Deprecated and replaced by PodPhase
+	RelationshipNetworkEndpointGroup    ParentRelationship = 8
+	RelationshipManagedInstanceGroup    ParentRelationship = 9
+	RelationshipControlPlaneComponent   ParentRelationship = 10
+	RelationshipSerialPort              ParentRelationship = 11
+	RelationshipAirflowTaskInstance     ParentRelationship = 12
+	RelationshipCSMAccessLog            ParentRelationship = 13 // Added since 0.49
+	RelationshipPodPhase                ParentRelationship = 14 // Added since 0.50
+	RelationshipLeaseHolder             ParentRelationship = 15
+	RelationshipHorizontalPodAutoscaler ParentRelationship = 16
+	RelationshipNodeSchedulability      ParentRelationship = 17
+	RelationshipVolume                  ParentRelationship = 18
+	RelationshipVolumeAttachment        ParentRelationship = 19
+	RelationshipJobRun                  ParentRelationship = 20
+	relationshipUnusedEnd                                  // Add items above. This field is used for counting items in this enum to test.
 )
 
 // EnumParentRelationshipLength is the count of ParentRelationship enum elements.
@@ -576,4 +582,209 @@ var ParentRelationships = map[ParentRelationship]ParentRelationshipFrontendMetad
 			},
 		},
 	},
+	RelationshipLeaseHolder: {
+		Visible:              true,
+		EnumKeyName:          "RelationshipLeaseHolder",
+		Label:                "leader",
+		LongName:             "Lease holder",
+		LabelColor:           mustHexToHDRColor4("#FFFFFF"),
+		LabelBackgroundColor: mustHexToHDRColor4("#7755CC"),
+		Hint:                 "Leader election status from .spec.holderIdentity of the Lease",
+		SortPriority:         7500,
+		Description:          "Timelines of this type show the holder tenures of a Lease used for leader election. The timeline is placed under the Lease and also under the Pod holding the Lease when the holder identity can be resolved to a Pod.",
+		GeneratableEvents: []GeneratableEventInfo{
+			{
+				SourceLogType: LogTypeAudit,
+				Description:   "The holder renewed the Lease later than .spec.leaseDurationSeconds after its previous renewal",
+			},
+		},
+		GeneratableRevisions: []GeneratableRevisionInfo{
+			{
+				State:         RevisionStateLeaseHeld,
+				SourceLogType: LogTypeAudit,
+				Description:   "The Lease is held by the holder",
+			},
+			{
+				State:         RevisionStateLeaseNotHeld,
+				SourceLogType: LogTypeAudit,
+				Description:   "The Lease is not held by the holder",
+			},
+		},
+	},
+	RelationshipHorizontalPodAutoscaler: {
+		Visible:              true,
+		EnumKeyName:          "RelationshipHorizontalPodAutoscaler",
+		Label:                "hpa",
+		LongName:             "HorizontalPodAutoscaler replicas",
+		LabelColor:           mustHexToHDRColor4("#FFFFFF"),
+		LabelBackgroundColor: mustHexToHDRColor4("#2288AA"),
+		Hint:                 "Scaling decisions of the HorizontalPodAutoscaler from .status.currentReplicas and .status.desiredReplicas",
+		SortPriority:         7600,
+		Description:          "Timelines of this type show the replica counts decided by a HorizontalPodAutoscaler. The timeline is placed under the HorizontalPodAutoscaler and also under the workload referenced from its .spec.scaleTargetRef.",
+		GeneratableRevisions: []GeneratableRevisionInfo{
+			{
+				State:         RevisionStateAutoscalerStable,
+				SourceLogType: LogTypeAudit,
+				Description:   "The current replica count matches the desired replica count",
+			},
+			{
+				State:         RevisionStateAutoscalerScalingUp,
+				SourceLogType: LogTypeAudit,
+				Description:   "The desired replica count is larger than the current replica count",
+			},
+			{
+				State:         RevisionStateAutoscalerScalingDown,
+				SourceLogType: LogTypeAudit,
+				Description:   "The desired replica count is smaller than the current replica count",
+			},
+			{
+				State:         RevisionStateAutoscalerUnableToScale,
+				SourceLogType: LogTypeAudit,
+				Description:   "The AbleToScale or ScalingActive condition of the HorizontalPodAutoscaler is False",
+			},
+		},
+	},
+	RelationshipNodeSchedulability: {
+		Visible:              true,
+		EnumKeyName:          "RelationshipNodeSchedulability",
+		Label:                "sched",
+		LongName:             "Node schedulability",
+		LabelColor:           mustHexToHDRColor4("#FFFFFF"),
+		LabelBackgroundColor: mustHexToHDRColor4("#AA5500"),
+		Hint:                 "Node schedulability from .spec.unschedulable and .spec.taints",
+		SortPriority:         1502,
+		Description:          "Timelines of this type show whether new pods can be scheduled on the node and whether the running pods are evicted by NoExecute taints. Pod evictions from the node are shown as events on this timeline.",
+		GeneratableEvents: []GeneratableEventInfo{
+			{
+				SourceLogType: LogTypeAudit,
+				Description:   "A pod on the node is evicted through the pods/eviction subresource",
+			},
+		},
+		GeneratableRevisions: []GeneratableRevisionInfo{
+			{
+				State:         RevisionStateNodeSchedulable,
+				SourceLogType: LogTypeAudit,
+				Description:   "The node is schedulable",
+			},
+			{
+				State:         RevisionStateNodeCordoned,
+				SourceLogType: LogTypeAudit,
+				Description:   "The node is cordoned with spec.unschedulable or the node.kubernetes.io/unschedulable taint",
+			},
+			{
+				State:         RevisionStateNodeNoExecuteTainted,
+				SourceLogType: LogTypeAudit,
+				Description:   "The node is tainted with NoExecute effect",
+			},
+		},
+	},
+	RelationshipVolume: {
+		Visible:              true,
+		EnumKeyName:          "RelationshipVolume",
+		Label:                "volume",
+		LongName:             "Volume phase",
+		LabelColor:           mustHexToHDRColor4("#FFFFFF"),
+		LabelBackgroundColor: mustHexToHDRColor4("#886633"),
+		Hint:                 "Volume state from .status.phase of the PersistentVolumeClaim or PersistentVolume",
+		SortPriority:         7700,
+		Description:          "Timelines of this type show the phase of a PersistentVolumeClaim or a PersistentVolume. The phase of a claim is also shown under the Pods using the claim, and the phase of a volume is also shown under the claim bound to the volume with .spec.claimRef.",
+		GeneratableRevisions: []GeneratableRevisionInfo{
+			{
+				State:         RevisionStateVolumePending,
+				SourceLogType: LogTypeAudit,
+				Description:   "The claim is waiting for a volume to be provisioned or bound, or the volume is not available yet",
+			},
+			{
+				State:         RevisionStateVolumeAvailable,
+				SourceLogType: LogTypeAudit,
+				Description:   "The volume is not bound to any claim yet",
+			},
+			{
+				State:         RevisionStateVolumeBound,
+				SourceLogType: LogTypeAudit,
+				Description:   "The claim and the volume are bound",
+			},
+			{
+				State:         RevisionStateVolumeReleased,
+				SourceLogType: LogTypeAudit,
+				Description:   "The claim bound to the volume was deleted but the volume is not reclaimed yet",
+			},
+			{
+				State:         RevisionStateVolumeLost,
+				SourceLogType: LogTypeAudit,
+				Description:   "The volume bound to the claim was lost",
+			},
+			{
+				State:         RevisionStateVolumeFailed,
+				SourceLogType: LogTypeAudit,
+				Description:   "The volume failed its reclamation",
+			},
+		},
+	},
+	RelationshipVolumeAttachment: {
+		Visible:              true,
+		EnumKeyName:          "RelationshipVolumeAttachment",
+		Label:                "attach",
+		LongName:             "Volume attachment",
+		LabelColor:           mustHexToHDRColor4("#FFFFFF"),
+		LabelBackgroundColor: mustHexToHDRColor4("#665588"),
+		Hint:                 "Volume attachment state from .status.attached of the VolumeAttachment",
+		SortPriority:         7800,
+		Description:          "Timelines of this type show whether a volume is attached to a node. The timeline is placed under the Node and also under the PersistentVolume attached.",
+		GeneratableRevisions: []GeneratableRevisionInfo{
+			{
+				State:         RevisionStateVolumeAttached,
+				SourceLogType: LogTypeAudit,
+				Description:   "The volume is attached to the node",
+			},
+			{
+				State:         RevisionStateVolumeDetached,
+				SourceLogType: LogTypeAudit,
+				Description:   "The volume is not attached to the node",
+			},
+			{
+				State:         RevisionStateVolumeAttachError,
+				SourceLogType: LogTypeAudit,
+				Description:   "The last attach or detach operation of the volume failed",
+			},
+		},
+	},
+	RelationshipJobRun: {
+		Visible:              true,
+		EnumKeyName:          "RelationshipJobRun",
+		Label:                "run",
+		LongName:             "Job run",
+		LabelColor:           mustHexToHDRColor4("#FFFFFF"),
+		LabelBackgroundColor: mustHexToHDRColor4("#AA6622"),
+		Hint:                 "Job run state from .status of the Job",
+		SortPriority:         7900,
+		Description:          "Timelines of this type show the run state of a Job derived from the active/succeeded/failed pod counts and the conditions. The timeline is placed under the Job and also under the CronJob owning the Job.",
+		GeneratableRevisions: []GeneratableRevisionInfo{
+			{
+				State:         RevisionStateJobPending,
+				SourceLogType: LogTypeAudit,
+				Description:   "The Job is created but no pod is running yet",
+			},
+			{
+				State:         RevisionStateJobRunning,
+				SourceLogType: LogTypeAudit,
+				Description:   "The Job has active pods",
+			},
+			{
+				State:         RevisionStateJobSucceeded,
+				SourceLogType: LogTypeAudit,
+				Description:   "The Job has the Complete condition",
+			},
+			{
+				State:         RevisionStateJobFailed,
+				SourceLogType: LogTypeAudit,
+				Description:   "The Job has the Failed condition, e.g. because of backoffLimit or activeDeadlineSeconds",
+			},
+			{
+				State:         RevisionStateJobSuspended,
+				SourceLogType: LogTypeAudit,
+				Description:   "The Job has the Suspended condition",
+			},
+		},
+	},
 }
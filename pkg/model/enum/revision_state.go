@@ -79,6 +79,34 @@ const (
 	RevisionStateContainerStatusNotAvailable RevisionState = 40 // Added since 0.50
 	RevisionStateContainerStarted            RevisionState = 41 // Added since 0.50
 
+	RevisionStateLeaseHeld    RevisionState = 42
+	RevisionStateLeaseNotHeld RevisionState = 43
+
+	RevisionStateAutoscalerStable        RevisionState = 44
+	RevisionStateAutoscalerScalingUp     RevisionState = 45
+	RevisionStateAutoscalerScalingDown   RevisionState = 46
+	RevisionStateAutoscalerUnableToScale RevisionState = 47
+
+	RevisionStateNodeSchedulable      RevisionState = 48
+	RevisionStateNodeCordoned         RevisionState = 49
+	RevisionStateNodeNoExecuteTainted RevisionState = 50
+
+	RevisionStateVolumePending     RevisionState = 51
+	RevisionStateVolumeAvailable   RevisionState = 52
+	RevisionStateVolumeBound       RevisionState = 53
+	RevisionStateVolumeReleased    RevisionState = 54
+	RevisionStateVolumeLost        RevisionState = 55
+	RevisionStateVolumeFailed      RevisionState = 56
+	RevisionStateVolumeAttached    RevisionState = 57
+	RevisionStateVolumeDetached    RevisionState = 58
+	RevisionStateVolumeAttachError RevisionState = 59
+
+	RevisionStateJobPending   RevisionState = 60
+	RevisionStateJobRunning   RevisionState = 61
+	RevisionStateJobSucceeded RevisionState = 62
+	RevisionStateJobFailed    RevisionState = 63
+	RevisionStateJobSuspended RevisionState = 64
+
 	revisionStateUnusedEnd // Adds items above. This value is used for counting items in this enum to test.
 )
 
@@ -398,4 +426,168 @@ var RevisionStates = map[RevisionState]RevisionStateFrontendMetadata{
 		Style:           RevisionStateStylePartialInfo,
 		Icon:            "siren_question",
 	},
+	RevisionStateLeaseHeld: {
+		EnumKeyName:     "RevisionStateLeaseHeld",
+		BackgroundColor: mustHexToHDRColor4("#004400"),
+		CSSSelector:     "lease_held",
+		Label:           "Lease is held",
+		Icon:            "key",
+	},
+	RevisionStateLeaseNotHeld: {
+		EnumKeyName:     "RevisionStateLeaseNotHeld",
+		BackgroundColor: mustHexToHDRColor4("#666666"),
+		CSSSelector:     "lease_not_held",
+		Label:           "Lease is not held",
+		Icon:            "key_off",
+		Style:           RevisionStateStyleDeleted,
+	},
+	RevisionStateAutoscalerStable: {
+		EnumKeyName:     "RevisionStateAutoscalerStable",
+		BackgroundColor: mustHexToHDRColor4("#004400"),
+		CSSSelector:     "autoscaler_stable",
+		Label:           "Replicas are at the desired count",
+		Icon:            "check_circle",
+	},
+	RevisionStateAutoscalerScalingUp: {
+		EnumKeyName:     "RevisionStateAutoscalerScalingUp",
+		BackgroundColor: mustHexToHDRColor4("#0077AA"),
+		CSSSelector:     "autoscaler_scaling_up",
+		Label:           "Scaling up to the desired replicas",
+		Icon:            "trending_up",
+	},
+	RevisionStateAutoscalerScalingDown: {
+		EnumKeyName:     "RevisionStateAutoscalerScalingDown",
+		BackgroundColor: mustHexToHDRColor4("#6644AA"),
+		CSSSelector:     "autoscaler_scaling_down",
+		Label:           "Scaling down to the desired replicas",
+		Icon:            "trending_down",
+	},
+	RevisionStateAutoscalerUnableToScale: {
+		EnumKeyName:     "RevisionStateAutoscalerUnableToScale",
+		BackgroundColor: mustHexToHDRColor4("#997700"),
+		CSSSelector:     "autoscaler_unable_to_scale",
+		Label:           "Autoscaler is not able to scale",
+		Icon:            "warning",
+	},
+	RevisionStateNodeSchedulable: {
+		EnumKeyName:     "RevisionStateNodeSchedulable",
+		BackgroundColor: mustHexToHDRColor4("#004400"),
+		CSSSelector:     "node_schedulable",
+		Label:           "Node is schedulable",
+		Icon:            "check_circle",
+	},
+	RevisionStateNodeCordoned: {
+		EnumKeyName:     "RevisionStateNodeCordoned",
+		BackgroundColor: mustHexToHDRColor4("#997700"),
+		CSSSelector:     "node_cordoned",
+		Label:           "Node is cordoned",
+		Icon:            "block",
+	},
+	RevisionStateNodeNoExecuteTainted: {
+		EnumKeyName:     "RevisionStateNodeNoExecuteTainted",
+		BackgroundColor: mustHexToHDRColor4("#AA3300"),
+		CSSSelector:     "node_noexecute_tainted",
+		Label:           "Node is tainted with NoExecute",
+		Icon:            "logout",
+	},
+	RevisionStateVolumePending: {
+		EnumKeyName:     "RevisionStateVolumePending",
+		BackgroundColor: mustHexToHDRColor4("#666666"),
+		CSSSelector:     "volume_pending",
+		Label:           "Volume is pending",
+		Icon:            "hourglass_empty",
+	},
+	RevisionStateVolumeAvailable: {
+		EnumKeyName:     "RevisionStateVolumeAvailable",
+		BackgroundColor: mustHexToHDRColor4("#4444ff"),
+		CSSSelector:     "volume_available",
+		Label:           "Volume is available",
+		Icon:            "inventory_2",
+	},
+	RevisionStateVolumeBound: {
+		EnumKeyName:     "RevisionStateVolumeBound",
+		BackgroundColor: mustHexToHDRColor4("#004400"),
+		CSSSelector:     "volume_bound",
+		Label:           "Volume is bound",
+		Icon:            "link",
+	},
+	RevisionStateVolumeReleased: {
+		EnumKeyName:     "RevisionStateVolumeReleased",
+		BackgroundColor: mustHexToHDRColor4("#113333"),
+		CSSSelector:     "volume_released",
+		Label:           "Volume is released",
+		Icon:            "link_off",
+		Style:           RevisionStateStyleDeleted,
+	},
+	RevisionStateVolumeLost: {
+		EnumKeyName:     "RevisionStateVolumeLost",
+		BackgroundColor: mustHexToHDRColor4("#331111"),
+		CSSSelector:     "volume_lost",
+		Label:           "Volume is lost",
+		Icon:            "error",
+	},
+	RevisionStateVolumeFailed: {
+		EnumKeyName:     "RevisionStateVolumeFailed",
+		BackgroundColor: mustHexToHDRColor4("#331111"),
+		CSSSelector:     "volume_failed",
+		Label:           "Volume is failed",
+		Icon:            "error",
+	},
+	RevisionStateVolumeAttached: {
+		EnumKeyName:     "RevisionStateVolumeAttached",
+		BackgroundColor: mustHexToHDRColor4("#004400"),
+		CSSSelector:     "volume_attached",
+		Label:           "Volume is attached",
+		Icon:            "hard_drive",
+	},
+	RevisionStateVolumeDetached: {
+		EnumKeyName:     "RevisionStateVolumeDetached",
+		BackgroundColor: mustHexToHDRColor4("#666666"),
+		CSSSelector:     "volume_detached",
+		Label:           "Volume is not attached",
+		Icon:            "eject",
+	},
+	RevisionStateVolumeAttachError: {
+		EnumKeyName:     "RevisionStateVolumeAttachError",
+		BackgroundColor: mustHexToHDRColor4("#AA3300"),
+		CSSSelector:     "volume_attach_error",
+		Label:           "Volume attach or detach operation failed",
+		Icon:            "error",
+	},
+	RevisionStateJobPending: {
+		EnumKeyName:     "RevisionStateJobPending",
+		BackgroundColor: mustHexToHDRColor4("#666666"),
+		CSSSelector:     "job_pending",
+		Label:           "Job is pending",
+		Icon:            "hourglass_empty",
+	},
+	RevisionStateJobRunning: {
+		EnumKeyName:     "RevisionStateJobRunning",
+		BackgroundColor: mustHexToHDRColor4("#004000"),
+		CSSSelector:     "job_running",
+		Label:           "Job is running",
+		Icon:            "directions_run",
+	},
+	RevisionStateJobSucceeded: {
+		EnumKeyName:     "RevisionStateJobSucceeded",
+		BackgroundColor: mustHexToHDRColor4("#113333"),
+		CSSSelector:     "job_succeeded",
+		Label:           "Job succeeded",
+		Icon:            "check_circle",
+		Style:           RevisionStateStyleDeleted,
+	},
+	RevisionStateJobFailed: {
+		EnumKeyName:     "RevisionStateJobFailed",
+		BackgroundColor: mustHexToHDRColor4("#AA3300"),
+		CSSSelector:     "job_failed",
+		Label:           "Job failed",
+		Icon:            "error",
+	},
+	RevisionStateJobSuspended: {
+		EnumKeyName:     "RevisionStateJobSuspended",
+		BackgroundColor: mustHexToHDRColor4("#444444"),
+		CSSSelector:     "job_suspended",
+		Label:           "Job is suspended",
+		Icon:            "pause_circle",
+	},
 }
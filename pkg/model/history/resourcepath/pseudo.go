@@ -72,6 +72,14 @@ func NodeSerialport(nodeName, port string) ResourcePath {
 	return node
 }
 
+// NodeSchedulability returns a ResourcePath for the pseudo schedulability timeline under nodes.
+func NodeSchedulability(nodeName string) ResourcePath {
+	node := Node(nodeName)
+	node.Path = fmt.Sprintf("%s#schedulability", node.Path)
+	node.ParentRelationship = enum.RelationshipNodeSchedulability
+	return node
+}
+
 // NodeBinding returns a ResourcePath for the pseudo binding timeline under nodes.
 func NodeBinding(nodeName string, podNamespace string, podName string) ResourcePath {
 	if podName == "" {
@@ -189,3 +197,115 @@ func PodPhase(nodeName string, podNamespace, podName, uid string) ResourcePath {
 		ParentRelationship: enum.RelationshipPodPhase,
 	}
 }
+
+// LeaseHolder returns a ResourcePath for the pseudo leader timeline under leases.
+func LeaseHolder(leaseNamespace string, leaseName string) ResourcePath {
+	lease := Lease(leaseNamespace, leaseName)
+	lease.Path = fmt.Sprintf("%s#leader", lease.Path)
+	lease.ParentRelationship = enum.RelationshipLeaseHolder
+	return lease
+}
+
+// PodLeaseHolder returns a ResourcePath for the pseudo leader timeline under the pod holding the lease.
+func PodLeaseHolder(leaseNamespace string, leaseName string, podNamespace string, podName string) ResourcePath {
+	if leaseName == "" {
+		leaseName = nonSpecifiedPlaceholder
+	}
+	if leaseNamespace == "" {
+		leaseNamespace = nonSpecifiedPlaceholder
+	}
+	pod := Pod(podNamespace, podName)
+	pod.Path = fmt.Sprintf("%s#%s(%s)[lease]", pod.Path, leaseName, leaseNamespace)
+	pod.ParentRelationship = enum.RelationshipLeaseHolder
+	return pod
+}
+
+// HorizontalPodAutoscalerReplicas returns a ResourcePath for the pseudo replicas timeline under the given HorizontalPodAutoscaler.
+func HorizontalPodAutoscalerReplicas(autoscaler ResourcePath) ResourcePath {
+	return ResourcePath{
+		Path:               fmt.Sprintf("%s#replicas", autoscaler.Path),
+		ParentRelationship: enum.RelationshipHorizontalPodAutoscaler,
+	}
+}
+
+// ScaleTargetAutoscaler returns a ResourcePath for the pseudo HorizontalPodAutoscaler timeline under the workload scaled by it.
+func ScaleTargetAutoscaler(scaleTarget ResourcePath, autoscalerName string) ResourcePath {
+	if autoscalerName == "" {
+		autoscalerName = nonSpecifiedPlaceholder
+	}
+	return ResourcePath{
+		Path:               fmt.Sprintf("%s#%s[hpa]", scaleTarget.Path, autoscalerName),
+		ParentRelationship: enum.RelationshipHorizontalPodAutoscaler,
+	}
+}
+
+// VolumePhase returns a ResourcePath for the pseudo phase timeline under the given PersistentVolumeClaim or PersistentVolume.
+func VolumePhase(volumeOwner ResourcePath) ResourcePath {
+	return ResourcePath{
+		Path:               fmt.Sprintf("%s#phase", volumeOwner.Path),
+		ParentRelationship: enum.RelationshipVolume,
+	}
+}
+
+// PodVolumeClaim returns a ResourcePath for the pseudo volume timeline under the pod using the PersistentVolumeClaim.
+func PodVolumeClaim(podNamespace string, podName string, claimName string) ResourcePath {
+	if claimName == "" {
+		claimName = nonSpecifiedPlaceholder
+	}
+	pod := Pod(podNamespace, podName)
+	pod.Path = fmt.Sprintf("%s#%s[pvc]", pod.Path, claimName)
+	pod.ParentRelationship = enum.RelationshipVolume
+	return pod
+}
+
+// VolumeClaimBoundVolume returns a ResourcePath for the pseudo volume timeline under the PersistentVolumeClaim bound to the PersistentVolume.
+func VolumeClaimBoundVolume(claimNamespace string, claimName string, volumeName string) ResourcePath {
+	if volumeName == "" {
+		volumeName = nonSpecifiedPlaceholder
+	}
+	claim := PersistentVolumeClaim(claimNamespace, claimName)
+	claim.Path = fmt.Sprintf("%s#%s[pv]", claim.Path, volumeName)
+	claim.ParentRelationship = enum.RelationshipVolume
+	return claim
+}
+
+// NodeVolumeAttachment returns a ResourcePath for the pseudo volume attachment timeline under nodes.
+func NodeVolumeAttachment(nodeName string, volumeName string) ResourcePath {
+	if volumeName == "" {
+		volumeName = nonSpecifiedPlaceholder
+	}
+	node := Node(nodeName)
+	node.Path = fmt.Sprintf("%s#%s[attachment]", node.Path, volumeName)
+	node.ParentRelationship = enum.RelationshipVolumeAttachment
+	return node
+}
+
+// PersistentVolumeAttachment returns a ResourcePath for the pseudo volume attachment timeline under the PersistentVolume attached to the node.
+func PersistentVolumeAttachment(volumeName string, nodeName string) ResourcePath {
+	if nodeName == "" {
+		nodeName = nonSpecifiedPlaceholder
+	}
+	volume := PersistentVolume(volumeName)
+	volume.Path = fmt.Sprintf("%s#%s[attachment]", volume.Path, nodeName)
+	volume.ParentRelationship = enum.RelationshipVolumeAttachment
+	return volume
+}
+
+// JobRun returns a ResourcePath for the pseudo run state timeline under the given Job.
+func JobRun(job ResourcePath) ResourcePath {
+	return ResourcePath{
+		Path:               fmt.Sprintf("%s#run", job.Path),
+		ParentRelationship: enum.RelationshipJobRun,
+	}
+}
+
+// CronJobRun returns a ResourcePath for the pseudo run state timeline of a Job under the CronJob owning the Job.
+func CronJobRun(cronJob ResourcePath, jobName string) ResourcePath {
+	if jobName == "" {
+		jobName = nonSpecifiedPlaceholder
+	}
+	return ResourcePath{
+		Path:               fmt.Sprintf("%s#%s[run]", cronJob.Path, jobName),
+		ParentRelationship: enum.RelationshipJobRun,
+	}
+}
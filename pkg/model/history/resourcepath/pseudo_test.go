@@ -178,6 +178,29 @@ func TestNodeSerialport(t *testing.T) {
 	}
 }
 
+func TestNodeSchedulability(t *testing.T) {
+	testCases := []struct {
+		name     string
+		nodeName string
+		expected string
+	}{
+		{"All specified", "my-node", "core/v1#node#cluster-scope#my-node#schedulability"},
+		{"Empty nodeName", "", "core/v1#node#cluster-scope#unknown#schedulability"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := NodeSchedulability(tc.nodeName)
+			if result.Path != tc.expected {
+				t.Errorf("NodeSchedulability(%v).Path = %v, want %v", tc.nodeName, result.Path, tc.expected)
+			}
+			if result.ParentRelationship != enum.RelationshipNodeSchedulability {
+				t.Errorf("NodeSchedulability(%v).ParentRelationship = %v, want %v", tc.nodeName, result.ParentRelationship, enum.RelationshipNodeSchedulability)
+			}
+		})
+	}
+}
+
 func TestNodeBinding(t *testing.T) {
 	expectedParentRelationship := enum.RelationshipPodBinding
 	testCases := []struct {
@@ -381,3 +404,257 @@ func TestOwnerSubresource(t *testing.T) {
 		})
 	}
 }
+
+func TestLeaseHolder(t *testing.T) {
+	expectedParentRelationship := enum.RelationshipLeaseHolder
+	testCases := []struct {
+		name           string
+		leaseNamespace string
+		leaseName      string
+		expected       string
+	}{
+		{"All specified", "kube-system", "kube-scheduler", "coordination.k8s.io/v1#lease#kube-system#kube-scheduler#leader"},
+		{"Empty namespace", "", "kube-scheduler", "coordination.k8s.io/v1#lease#unknown#kube-scheduler#leader"},
+		{"Empty name", "kube-system", "", "coordination.k8s.io/v1#lease#kube-system#unknown#leader"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := LeaseHolder(tc.leaseNamespace, tc.leaseName)
+			if result.Path != tc.expected {
+				t.Errorf("LeaseHolder(%v,%v).Path = %v, want %v", tc.leaseNamespace, tc.leaseName, result.Path, tc.expected)
+			}
+			if result.ParentRelationship != expectedParentRelationship {
+				t.Errorf("LeaseHolder(%v,%v).ParentRelationship = %v, want %v", tc.leaseNamespace, tc.leaseName, result.ParentRelationship, expectedParentRelationship)
+			}
+		})
+	}
+}
+
+func TestPodLeaseHolder(t *testing.T) {
+	expectedParentRelationship := enum.RelationshipLeaseHolder
+	testCases := []struct {
+		name           string
+		leaseNamespace string
+		leaseName      string
+		podNamespace   string
+		podName        string
+		expected       string
+	}{
+		{"All specified", "kube-system", "kube-scheduler", "kube-system", "kube-scheduler-node-1", "core/v1#pod#kube-system#kube-scheduler-node-1#kube-scheduler(kube-system)[lease]"},
+		{"Empty lease name", "kube-system", "", "kube-system", "kube-scheduler-node-1", "core/v1#pod#kube-system#kube-scheduler-node-1#unknown(kube-system)[lease]"},
+		{"Empty lease namespace", "", "kube-scheduler", "kube-system", "kube-scheduler-node-1", "core/v1#pod#kube-system#kube-scheduler-node-1#kube-scheduler(unknown)[lease]"},
+		{"Empty pod name", "kube-system", "kube-scheduler", "kube-system", "", "core/v1#pod#kube-system#unknown#kube-scheduler(kube-system)[lease]"},
+		{"All empty", "", "", "", "", "core/v1#pod#unknown#unknown#unknown(unknown)[lease]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := PodLeaseHolder(tc.leaseNamespace, tc.leaseName, tc.podNamespace, tc.podName)
+			if result.Path != tc.expected {
+				t.Errorf("PodLeaseHolder(%v,%v,%v,%v).Path = %v, want %v", tc.leaseNamespace, tc.leaseName, tc.podNamespace, tc.podName, result.Path, tc.expected)
+			}
+			if result.ParentRelationship != expectedParentRelationship {
+				t.Errorf("PodLeaseHolder(%v,%v,%v,%v).ParentRelationship = %v, want %v", tc.leaseNamespace, tc.leaseName, tc.podNamespace, tc.podName, result.ParentRelationship, expectedParentRelationship)
+			}
+		})
+	}
+}
+
+func TestHorizontalPodAutoscalerReplicas(t *testing.T) {
+	autoscaler := NameLayerGeneralItem("autoscaling/v2", "horizontalpodautoscaler", "default", "web")
+	result := HorizontalPodAutoscalerReplicas(autoscaler)
+	expected := "autoscaling/v2#horizontalpodautoscaler#default#web#replicas"
+	if result.Path != expected {
+		t.Errorf("HorizontalPodAutoscalerReplicas(%v).Path = %v, want %v", autoscaler.Path, result.Path, expected)
+	}
+	if result.ParentRelationship != enum.RelationshipHorizontalPodAutoscaler {
+		t.Errorf("HorizontalPodAutoscalerReplicas(%v).ParentRelationship = %v, want %v", autoscaler.Path, result.ParentRelationship, enum.RelationshipHorizontalPodAutoscaler)
+	}
+}
+
+func TestScaleTargetAutoscaler(t *testing.T) {
+	expectedParentRelationship := enum.RelationshipHorizontalPodAutoscaler
+	scaleTarget := NameLayerGeneralItem("apps/v1", "deployment", "default", "web")
+	testCases := []struct {
+		name           string
+		autoscalerName string
+		expected       string
+	}{
+		{"All specified", "web-hpa", "apps/v1#deployment#default#web#web-hpa[hpa]"},
+		{"Empty autoscalerName", "", "apps/v1#deployment#default#web#unknown[hpa]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ScaleTargetAutoscaler(scaleTarget, tc.autoscalerName)
+			if result.Path != tc.expected {
+				t.Errorf("ScaleTargetAutoscaler(%v,%v).Path = %v, want %v", scaleTarget.Path, tc.autoscalerName, result.Path, tc.expected)
+			}
+			if result.ParentRelationship != expectedParentRelationship {
+				t.Errorf("ScaleTargetAutoscaler(%v,%v).ParentRelationship = %v, want %v", scaleTarget.Path, tc.autoscalerName, result.ParentRelationship, expectedParentRelationship)
+			}
+		})
+	}
+}
+
+func TestVolumePhase(t *testing.T) {
+	testCases := []struct {
+		name     string
+		owner    ResourcePath
+		expected string
+	}{
+		{"PersistentVolumeClaim", PersistentVolumeClaim("default", "data-web-0"), "core/v1#persistentvolumeclaim#default#data-web-0#phase"},
+		{"PersistentVolume", PersistentVolume("pv-1"), "core/v1#persistentvolume#cluster-scope#pv-1#phase"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := VolumePhase(tc.owner)
+			if result.Path != tc.expected {
+				t.Errorf("VolumePhase(%v).Path = %v, want %v", tc.owner.Path, result.Path, tc.expected)
+			}
+			if result.ParentRelationship != enum.RelationshipVolume {
+				t.Errorf("VolumePhase(%v).ParentRelationship = %v, want %v", tc.owner.Path, result.ParentRelationship, enum.RelationshipVolume)
+			}
+		})
+	}
+}
+
+func TestPodVolumeClaim(t *testing.T) {
+	testCases := []struct {
+		name         string
+		podNamespace string
+		podName      string
+		claimName    string
+		expected     string
+	}{
+		{"All specified", "default", "web-0", "data-web-0", "core/v1#pod#default#web-0#data-web-0[pvc]"},
+		{"Empty claimName", "default", "web-0", "", "core/v1#pod#default#web-0#unknown[pvc]"},
+		{"All empty", "", "", "", "core/v1#pod#unknown#unknown#unknown[pvc]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := PodVolumeClaim(tc.podNamespace, tc.podName, tc.claimName)
+			if result.Path != tc.expected {
+				t.Errorf("PodVolumeClaim(%v,%v,%v).Path = %v, want %v", tc.podNamespace, tc.podName, tc.claimName, result.Path, tc.expected)
+			}
+			if result.ParentRelationship != enum.RelationshipVolume {
+				t.Errorf("PodVolumeClaim(%v,%v,%v).ParentRelationship = %v, want %v", tc.podNamespace, tc.podName, tc.claimName, result.ParentRelationship, enum.RelationshipVolume)
+			}
+		})
+	}
+}
+
+func TestVolumeClaimBoundVolume(t *testing.T) {
+	testCases := []struct {
+		name           string
+		claimNamespace string
+		claimName      string
+		volumeName     string
+		expected       string
+	}{
+		{"All specified", "default", "data-web-0", "pv-1", "core/v1#persistentvolumeclaim#default#data-web-0#pv-1[pv]"},
+		{"Empty volumeName", "default", "data-web-0", "", "core/v1#persistentvolumeclaim#default#data-web-0#unknown[pv]"},
+		{"All empty", "", "", "", "core/v1#persistentvolumeclaim#unknown#unknown#unknown[pv]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := VolumeClaimBoundVolume(tc.claimNamespace, tc.claimName, tc.volumeName)
+			if result.Path != tc.expected {
+				t.Errorf("VolumeClaimBoundVolume(%v,%v,%v).Path = %v, want %v", tc.claimNamespace, tc.claimName, tc.volumeName, result.Path, tc.expected)
+			}
+			if result.ParentRelationship != enum.RelationshipVolume {
+				t.Errorf("VolumeClaimBoundVolume(%v,%v,%v).ParentRelationship = %v, want %v", tc.claimNamespace, tc.claimName, tc.volumeName, result.ParentRelationship, enum.RelationshipVolume)
+			}
+		})
+	}
+}
+
+func TestNodeVolumeAttachment(t *testing.T) {
+	testCases := []struct {
+		name       string
+		nodeName   string
+		volumeName string
+		expected   string
+	}{
+		{"All specified", "node-1", "pv-1", "core/v1#node#cluster-scope#node-1#pv-1[attachment]"},
+		{"Empty volumeName", "node-1", "", "core/v1#node#cluster-scope#node-1#unknown[attachment]"},
+		{"All empty", "", "", "core/v1#node#cluster-scope#unknown#unknown[attachment]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := NodeVolumeAttachment(tc.nodeName, tc.volumeName)
+			if result.Path != tc.expected {
+				t.Errorf("NodeVolumeAttachment(%v,%v).Path = %v, want %v", tc.nodeName, tc.volumeName, result.Path, tc.expected)
+			}
+			if result.ParentRelationship != enum.RelationshipVolumeAttachment {
+				t.Errorf("NodeVolumeAttachment(%v,%v).ParentRelationship = %v, want %v", tc.nodeName, tc.volumeName, result.ParentRelationship, enum.RelationshipVolumeAttachment)
+			}
+		})
+	}
+}
+
+func TestPersistentVolumeAttachment(t *testing.T) {
+	testCases := []struct {
+		name       string
+		volumeName string
+		nodeName   string
+		expected   string
+	}{
+		{"All specified", "pv-1", "node-1", "core/v1#persistentvolume#cluster-scope#pv-1#node-1[attachment]"},
+		{"Empty nodeName", "pv-1", "", "core/v1#persistentvolume#cluster-scope#pv-1#unknown[attachment]"},
+		{"All empty", "", "", "core/v1#persistentvolume#cluster-scope#unknown#unknown[attachment]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := PersistentVolumeAttachment(tc.volumeName, tc.nodeName)
+			if result.Path != tc.expected {
+				t.Errorf("PersistentVolumeAttachment(%v,%v).Path = %v, want %v", tc.volumeName, tc.nodeName, result.Path, tc.expected)
+			}
+			if result.ParentRelationship != enum.RelationshipVolumeAttachment {
+				t.Errorf("PersistentVolumeAttachment(%v,%v).ParentRelationship = %v, want %v", tc.volumeName, tc.nodeName, result.ParentRelationship, enum.RelationshipVolumeAttachment)
+			}
+		})
+	}
+}
+
+func TestJobRun(t *testing.T) {
+	job := NameLayerGeneralItem("batch/v1", "job", "default", "backup-28000000")
+	result := JobRun(job)
+	expected := "batch/v1#job#default#backup-28000000#run"
+	if result.Path != expected {
+		t.Errorf("JobRun(%v).Path = %v, want %v", job.Path, result.Path, expected)
+	}
+	if result.ParentRelationship != enum.RelationshipJobRun {
+		t.Errorf("JobRun(%v).ParentRelationship = %v, want %v", job.Path, result.ParentRelationship, enum.RelationshipJobRun)
+	}
+}
+
+func TestCronJobRun(t *testing.T) {
+	cronJob := NameLayerGeneralItem("batch/v1", "cronjob", "default", "backup")
+	testCases := []struct {
+		name     string
+		jobName  string
+		expected string
+	}{
+		{"All specified", "backup-28000000", "batch/v1#cronjob#default#backup#backup-28000000[run]"},
+		{"Empty jobName", "", "batch/v1#cronjob#default#backup#unknown[run]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := CronJobRun(cronJob, tc.jobName)
+			if result.Path != tc.expected {
+				t.Errorf("CronJobRun(%v,%v).Path = %v, want %v", cronJob.Path, tc.jobName, result.Path, tc.expected)
+			}
+			if result.ParentRelationship != enum.RelationshipJobRun {
+				t.Errorf("CronJobRun(%v,%v).ParentRelationship = %v, want %v", cronJob.Path, tc.jobName, result.ParentRelationship, enum.RelationshipJobRun)
+			}
+		})
+	}
+}
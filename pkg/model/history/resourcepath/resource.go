@@ -71,3 +71,33 @@ func EndpointSlice(namespace string, name string) ResourcePath {
 	}
 	return NameLayerGeneralItem("discovery.k8s.io/v1", "endpointslice", namespace, name)
 }
+
+// Lease returns a ResourcePath for the coordination.k8s.io/v1 Lease resource.
+func Lease(namespace string, name string) ResourcePath {
+	if namespace == "" {
+		namespace = nonSpecifiedPlaceholder
+	}
+	if name == "" {
+		name = nonSpecifiedPlaceholder
+	}
+	return NameLayerGeneralItem("coordination.k8s.io/v1", "lease", namespace, name)
+}
+
+// PersistentVolumeClaim returns a ResourcePath for the core/v1 PersistentVolumeClaim resource.
+func PersistentVolumeClaim(namespace string, name string) ResourcePath {
+	if namespace == "" {
+		namespace = nonSpecifiedPlaceholder
+	}
+	if name == "" {
+		name = nonSpecifiedPlaceholder
+	}
+	return NameLayerGeneralItem("core/v1", "persistentvolumeclaim", namespace, name)
+}
+
+// PersistentVolume returns a ResourcePath for the core/v1 PersistentVolume resource.
+func PersistentVolume(name string) ResourcePath {
+	if name == "" {
+		name = nonSpecifiedPlaceholder
+	}
+	return NameLayerGeneralItem("core/v1", "persistentvolume", "cluster-scope", name)
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package khifile
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+)
+
+// Comparison is the result of Compare between a base and a target inspection.
+type Comparison struct {
+	// OnlyInBase is the list of resources found only in the base inspection.
+	OnlyInBase []*ResourceComparison `json:"onlyInBase"`
+	// OnlyInTarget is the list of resources found only in the target inspection.
+	OnlyInTarget []*ResourceComparison `json:"onlyInTarget"`
+	// Changed is the list of resources found in both inspections with different revision count, event count or state distribution.
+	Changed []*ResourceComparison `json:"changed"`
+	// NewErrorLogs is the list of error or higher severity log summaries found only in the target inspection.
+	NewErrorLogs []*LogSummaryCount `json:"newErrorLogs"`
+}
+
+// ResourceComparison is the statistics of a resource timeline in the base and the target inspection.
+type ResourceComparison struct {
+	ResourcePath string `json:"path"`
+	// Base is the statistics in the base inspection. It is nil when the resource doesn't exist in the base.
+	Base *TimelineStats `json:"base,omitempty"`
+	// Target is the statistics in the target inspection. It is nil when the resource doesn't exist in the target.
+	Target *TimelineStats `json:"target,omitempty"`
+	// RevisionCountDelta is the revision count in the target minus the one in the base.
+	RevisionCountDelta int `json:"revisionCountDelta"`
+}
+
+// TimelineStats is the statistics of a resource timeline.
+type TimelineStats struct {
+	RevisionCount int `json:"revisionCount"`
+	EventCount    int `json:"eventCount"`
+	// States is the number of revisions for each revision state keyed by the EnumKeyName of enum.RevisionState.
+	States map[string]int `json:"states"`
+}
+
+// LogSummaryCount is a log summary and the logs sharing it.
+type LogSummaryCount struct {
+	Summary        string    `json:"summary"`
+	Severity       string    `json:"severity"`
+	Count          int       `json:"count"`
+	FirstTimestamp time.Time `json:"firstTimestamp"`
+	// ResourcePaths is the sorted list of resources associated with the logs.
+	ResourcePaths []string `json:"resourcePaths"`
+}
+
+// Compare aligns resources of the two inspections by their FullResourcePath and returns their differences.
+// Each list in the returned Comparison is sorted by resource path, and NewErrorLogs is sorted by the count in descending order.
+func Compare(base, target *Reader) (*Comparison, error) {
+	baseStats := timelineStatsByPath(base)
+	targetStats := timelineStatsByPath(target)
+	result := &Comparison{
+		OnlyInBase:   []*ResourceComparison{},
+		OnlyInTarget: []*ResourceComparison{},
+		Changed:      []*ResourceComparison{},
+	}
+	for _, path := range slices.Sorted(maps.Keys(baseStats)) {
+		baseStat := baseStats[path]
+		targetStat, found := targetStats[path]
+		if !found {
+			result.OnlyInBase = append(result.OnlyInBase, &ResourceComparison{ResourcePath: path, Base: baseStat, RevisionCountDelta: -baseStat.RevisionCount})
+			continue
+		}
+		if baseStat.RevisionCount == targetStat.RevisionCount && baseStat.EventCount == targetStat.EventCount && maps.Equal(baseStat.States, targetStat.States) {
+			continue
+		}
+		result.Changed = append(result.Changed, &ResourceComparison{
+			ResourcePath:       path,
+			Base:               baseStat,
+			Target:             targetStat,
+			RevisionCountDelta: targetStat.RevisionCount - baseStat.RevisionCount,
+		})
+	}
+	for _, path := range slices.Sorted(maps.Keys(targetStats)) {
+		if _, found := baseStats[path]; found {
+			continue
+		}
+		targetStat := targetStats[path]
+		result.OnlyInTarget = append(result.OnlyInTarget, &ResourceComparison{ResourcePath: path, Target: targetStat, RevisionCountDelta: targetStat.RevisionCount})
+	}
+
+	baseErrors, err := errorLogSummaries(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read error logs in the base inspection: %w", err)
+	}
+	targetErrors, err := errorLogSummaries(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read error logs in the target inspection: %w", err)
+	}
+	result.NewErrorLogs = []*LogSummaryCount{}
+	for summary, count := range targetErrors {
+		if _, found := baseErrors[summary]; !found {
+			result.NewErrorLogs = append(result.NewErrorLogs, count)
+		}
+	}
+	slices.SortFunc(result.NewErrorLogs, func(a, b *LogSummaryCount) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Summary, b.Summary)
+	})
+	return result, nil
+}
+
+func timelineStatsByPath(reader *Reader) map[string]*TimelineStats {
+	result := map[string]*TimelineStats{}
+	for _, entry := range reader.Timelines("") {
+		stats := &TimelineStats{
+			RevisionCount: len(entry.Timeline.Revisions),
+			EventCount:    len(entry.Timeline.Events),
+			States:        map[string]int{},
+		}
+		for _, revision := range entry.Timeline.Revisions {
+			stats.States[enum.RevisionStates[revision.State].EnumKeyName]++
+		}
+		result[entry.Resource.FullResourcePath] = stats
+	}
+	return result
+}
+
+// errorLogSummaries groups logs with error or higher severity by their summaries.
+func errorLogSummaries(reader *Reader) (map[string]*LogSummaryCount, error) {
+	logResources := map[string][]string{}
+	for _, entry := range reader.Timelines("") {
+		for _, revision := range entry.Timeline.Revisions {
+			logResources[revision.Log] = append(logResources[revision.Log], entry.Resource.FullResourcePath)
+		}
+		for _, event := range entry.Timeline.Events {
+			logResources[event.Log] = append(logResources[event.Log], entry.Resource.FullResourcePath)
+		}
+	}
+	result := map[string]*LogSummaryCount{}
+	for _, l := range reader.FilterLogs(LogFilter{MinSeverity: enum.SeverityError}) {
+		summary, err := reader.ReadString(l.Summary)
+		if err != nil {
+			return nil, err
+		}
+		count, found := result[summary]
+		if !found {
+			count = &LogSummaryCount{
+				Summary:        summary,
+				Severity:       enum.Severities[l.Severity].Label,
+				FirstTimestamp: l.Timestamp,
+				ResourcePaths:  []string{},
+			}
+			result[summary] = count
+		}
+		count.Count++
+		for _, path := range logResources[l.ID] {
+			if !slices.Contains(count.ResourcePaths, path) {
+				count.ResourcePaths = append(count.ResourcePaths, path)
+			}
+		}
+	}
+	for _, count := range result {
+		slices.Sort(count.ResourcePaths)
+	}
+	return result, nil
+}
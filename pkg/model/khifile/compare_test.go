@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package khifile
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/testkhifile"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompare(t *testing.T) {
+	podPath := resourcepath.NameLayerGeneralItem("core/v1", "pod", "default", "nginx")
+	configMapPath := resourcepath.NameLayerGeneralItem("core/v1", "configmap", "default", "config")
+	secretPath := resourcepath.NameLayerGeneralItem("core/v1", "secret", "default", "secret")
+	nodePath := resourcepath.NameLayerGeneralItem("core/v1", "node", "cluster-scope", "node-1")
+	existing := func() *history.StagingResourceRevision {
+		return &history.StagingResourceRevision{Verb: enum.RevisionVerbUpdate, State: enum.RevisionStateExisting}
+	}
+	baseData := testkhifile.Generate(t, nil, []testkhifile.Entry{
+		{InsertID: "b1", Path: podPath, Revision: existing()},
+		{InsertID: "b2", Path: configMapPath, Revision: existing()},
+		{InsertID: "b3", Path: nodePath, Severity: "ERROR", Summary: "known error"},
+	})
+	targetData := testkhifile.Generate(t, nil, []testkhifile.Entry{
+		{InsertID: "t1", Path: podPath, Revision: existing()},
+		{InsertID: "t2", Offset: time.Minute, Path: podPath, Revision: &history.StagingResourceRevision{Verb: enum.RevisionVerbDelete, State: enum.RevisionStateDeleted}},
+		{InsertID: "t3", Path: secretPath, Revision: existing()},
+		{InsertID: "t4", Path: nodePath, Severity: "ERROR", Summary: "known error"},
+		{InsertID: "t5", Offset: time.Minute, Path: nodePath, Severity: "ERROR", Summary: "new error"},
+		{InsertID: "t6", Offset: 2 * time.Minute, Path: podPath, Severity: "ERROR", Summary: "new error"},
+		{InsertID: "t7", Path: podPath, Summary: "new info"},
+	})
+	base, err := NewReader(bytes.NewReader(baseData), int64(len(baseData)))
+	if err != nil {
+		t.Fatalf("NewReader() returned an unexpected error: %v", err)
+	}
+	target, err := NewReader(bytes.NewReader(targetData), int64(len(targetData)))
+	if err != nil {
+		t.Fatalf("NewReader() returned an unexpected error: %v", err)
+	}
+
+	got, err := Compare(base, target)
+	if err != nil {
+		t.Fatalf("Compare() returned an unexpected error: %v", err)
+	}
+	want := &Comparison{
+		OnlyInBase: []*ResourceComparison{
+			{
+				ResourcePath:       configMapPath.Path,
+				Base:               &TimelineStats{RevisionCount: 1, States: map[string]int{"RevisionStateExisting": 1}},
+				RevisionCountDelta: -1,
+			},
+		},
+		OnlyInTarget: []*ResourceComparison{
+			{
+				ResourcePath:       secretPath.Path,
+				Target:             &TimelineStats{RevisionCount: 1, States: map[string]int{"RevisionStateExisting": 1}},
+				RevisionCountDelta: 1,
+			},
+		},
+		Changed: []*ResourceComparison{
+			{
+				ResourcePath:       nodePath.Path,
+				Base:               &TimelineStats{EventCount: 1, States: map[string]int{}},
+				Target:             &TimelineStats{EventCount: 2, States: map[string]int{}},
+				RevisionCountDelta: 0,
+			},
+			{
+				ResourcePath:       podPath.Path,
+				Base:               &TimelineStats{RevisionCount: 1, States: map[string]int{"RevisionStateExisting": 1}},
+				Target:             &TimelineStats{RevisionCount: 2, EventCount: 2, States: map[string]int{"RevisionStateExisting": 1, "RevisionStateDeleted": 1}},
+				RevisionCountDelta: 1,
+			},
+		},
+		NewErrorLogs: []*LogSummaryCount{
+			{
+				Summary:        "new error",
+				Severity:       "ERROR",
+				Count:          2,
+				FirstTimestamp: testkhifile.BaseTime.Add(time.Minute),
+				ResourcePaths:  []string{nodePath.Path, podPath.Path},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Compare() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package khifile
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+)
+
+// LogFilter is the set of conditions used in Reader.FilterLogs. Zero values of each field mean no filtering with the field.
+type LogFilter struct {
+	// ResourcePath limits logs to the ones associated with the resource or its descendants.
+	ResourcePath string
+	// Since limits logs to the ones at or after the time.
+	Since time.Time
+	// Until limits logs to the ones at or before the time.
+	Until time.Time
+	// MinSeverity limits logs to the ones with the severity equal to or higher than this value.
+	MinSeverity enum.Severity
+}
+
+// TimelineEntry is a pair of a resource and its timeline.
+type TimelineEntry struct {
+	Resource *history.Resource
+	Timeline *history.ResourceTimeline
+}
+
+// Timelines returns every resource associated with a timeline in the resource tree order.
+// When prefix is not empty, only the resources with the path starting with the prefix are returned.
+func (r *Reader) Timelines(prefix string) []TimelineEntry {
+	result := []TimelineEntry{}
+	r.WalkResources(func(resource *history.Resource) error {
+		if resource.Timeline == "" || !strings.HasPrefix(resource.FullResourcePath, prefix) {
+			return nil
+		}
+		if timeline, found := r.Timeline(resource.Timeline); found {
+			result = append(result, TimelineEntry{Resource: resource, Timeline: timeline})
+		}
+		return nil
+	})
+	return result
+}
+
+// FilterLogs returns logs matching the given filter sorted by their timestamps.
+func (r *Reader) FilterLogs(filter LogFilter) []*history.SerializableLog {
+	var logIDs map[string]struct{}
+	if filter.ResourcePath != "" {
+		logIDs = map[string]struct{}{}
+		for _, entry := range r.Timelines(filter.ResourcePath) {
+			if entry.Resource.FullResourcePath != filter.ResourcePath && !strings.HasPrefix(entry.Resource.FullResourcePath, filter.ResourcePath+"#") {
+				continue
+			}
+			for _, revision := range entry.Timeline.Revisions {
+				logIDs[revision.Log] = struct{}{}
+			}
+			for _, event := range entry.Timeline.Events {
+				logIDs[event.Log] = struct{}{}
+			}
+		}
+	}
+	result := []*history.SerializableLog{}
+	for _, l := range r.History.Logs {
+		if logIDs != nil {
+			if _, found := logIDs[l.ID]; !found {
+				continue
+			}
+		}
+		if !filter.Since.IsZero() && l.Timestamp.Before(filter.Since) {
+			continue
+		}
+		if !filter.Until.IsZero() && l.Timestamp.After(filter.Until) {
+			continue
+		}
+		if l.Severity < filter.MinSeverity {
+			continue
+		}
+		result = append(result, l)
+	}
+	slices.SortStableFunc(result, func(a, b *history.SerializableLog) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	return result
+}
+
+// RevisionAt returns the latest revision of the timeline changed at or before the given time.
+// It returns nil when the timeline has no revision before the time.
+func RevisionAt(timeline *history.ResourceTimeline, t time.Time) *history.ResourceRevision {
+	var result *history.ResourceRevision
+	for _, revision := range timeline.Revisions {
+		if revision.ChangeTime.After(t) {
+			continue
+		}
+		if result == nil || !revision.ChangeTime.Before(result.ChangeTime) {
+			result = revision
+		}
+	}
+	return result
+}
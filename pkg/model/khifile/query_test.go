@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package khifile
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFilterLogs(t *testing.T) {
+	data := generateTestKHIFile(t)
+	reader, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("NewReader() returned an unexpected error: %v", err)
+	}
+	testCases := []struct {
+		desc   string
+		filter LogFilter
+		want   []string
+	}{
+		{
+			desc:   "without filter",
+			filter: LogFilter{},
+			want:   []string{"foo", "bar"},
+		},
+		{
+			desc:   "with resource path",
+			filter: LogFilter{ResourcePath: "core/v1#node"},
+			want:   []string{"bar"},
+		},
+		{
+			desc:   "with resource path matching only the prefix of the name",
+			filter: LogFilter{ResourcePath: "core/v1#pod#default#ngi"},
+			want:   []string{},
+		},
+		{
+			desc:   "with since",
+			filter: LogFilter{Since: time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)},
+			want:   []string{"bar"},
+		},
+		{
+			desc:   "with until",
+			filter: LogFilter{Until: time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)},
+			want:   []string{"foo"},
+		},
+		{
+			desc:   "with severity",
+			filter: LogFilter{MinSeverity: enum.SeverityError},
+			want:   []string{"bar"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := []string{}
+			for _, l := range reader.FilterLogs(tc.filter) {
+				got = append(got, l.DisplayId)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FilterLogs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRevisionAt(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeline := &history.ResourceTimeline{
+		Revisions: []*history.ResourceRevision{
+			{Log: "1", ChangeTime: t1},
+			{Log: "2", ChangeTime: t1.Add(time.Minute)},
+			{Log: "3", ChangeTime: t1.Add(2 * time.Minute)},
+		},
+	}
+	testCases := []struct {
+		desc    string
+		at      time.Time
+		wantLog string
+	}{
+		{
+			desc:    "before the first revision",
+			at:      t1.Add(-time.Second),
+			wantLog: "",
+		},
+		{
+			desc:    "exactly at a revision",
+			at:      t1.Add(time.Minute),
+			wantLog: "2",
+		},
+		{
+			desc:    "between revisions",
+			at:      t1.Add(90 * time.Second),
+			wantLog: "2",
+		},
+		{
+			desc:    "after the last revision",
+			at:      t1.Add(time.Hour),
+			wantLog: "3",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := RevisionAt(timeline, tc.at)
+			gotLog := ""
+			if got != nil {
+				gotLog = got.Log
+			}
+			if gotLog != tc.wantLog {
+				t.Errorf("RevisionAt() returned the revision of log %q, want %q", gotLog, tc.wantLog)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package khifile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/binarychunk"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+)
+
+// Magic is the byte sequence every .khi file starts with.
+const Magic = "KHI"
+
+// ErrInvalidFormat is returned when the given source is not a valid .khi file.
+var ErrInvalidFormat = errors.New("invalid khi file format")
+
+// chunkLocation is the location of a gzip compressed binary chunk in the source.
+type chunkLocation struct {
+	offset int64
+	size   int64
+}
+
+// Reader reads a .khi file written by history.Builder.Finalize.
+// The History JSON is decoded eagerly, but binary chunks referenced from BinaryReference are decompressed lazily on the first access.
+// Only the most recently used chunk is kept decompressed. Reading strings across chunks alternately decompresses the chunks every time.
+type Reader struct {
+	// History is the decoded inspection data.
+	History *history.History
+
+	source io.ReaderAt
+	closer io.Closer
+	size   int64
+	chunks []chunkLocation
+
+	mu sync.Mutex
+	// lastChunk holds the most recently used chunk to avoid decompressing the same chunk repeatedly in sequential reads.
+	lastChunk      []byte
+	lastChunkIndex int
+
+	indexOnce     sync.Once
+	logs          map[string]*history.SerializableLog
+	timelines     map[string]*history.ResourceTimeline
+	resourcePaths map[string]*history.Resource
+}
+
+// Open opens the .khi file at the given path. Callers must call Close after using the returned Reader.
+func Open(filePath string) (*Reader, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat %s: %w", filePath, err)
+	}
+	reader, err := NewReader(file, stat.Size())
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	reader.closer = file
+	return reader, nil
+}
+
+// NewReader reads the header and the History JSON of a .khi file from the given source with the given size in bytes.
+func NewReader(source io.ReaderAt, size int64) (*Reader, error) {
+	header := make([]byte, len(Magic)+4)
+	if _, err := source.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("failed to read the file header: %w", errors.Join(ErrInvalidFormat, err))
+	}
+	if string(header[:len(Magic)]) != Magic {
+		return nil, fmt.Errorf("the file doesn't start with %q: %w", Magic, ErrInvalidFormat)
+	}
+	jsonSize := int64(binary.LittleEndian.Uint32(header[len(Magic):]))
+	jsonOffset := int64(len(header))
+	if jsonOffset+jsonSize > size {
+		return nil, fmt.Errorf("the JSON block size %d exceeds the file size %d: %w", jsonSize, size, ErrInvalidFormat)
+	}
+
+	h := &history.History{}
+	if err := json.NewDecoder(io.NewSectionReader(source, jsonOffset, jsonSize)).Decode(h); err != nil {
+		return nil, fmt.Errorf("failed to decode the history JSON: %w", errors.Join(ErrInvalidFormat, err))
+	}
+
+	chunks := []chunkLocation{}
+	sizeBuffer := make([]byte, 4)
+	for offset := jsonOffset + jsonSize; offset < size; {
+		if _, err := source.ReadAt(sizeBuffer, offset); err != nil {
+			return nil, fmt.Errorf("failed to read the size of binary chunk %d: %w", len(chunks), errors.Join(ErrInvalidFormat, err))
+		}
+		chunkSize := int64(binary.BigEndian.Uint32(sizeBuffer))
+		offset += 4
+		if offset+chunkSize > size {
+			return nil, fmt.Errorf("binary chunk %d exceeds the file size: %w", len(chunks), ErrInvalidFormat)
+		}
+		chunks = append(chunks, chunkLocation{offset: offset, size: chunkSize})
+		offset += chunkSize
+	}
+
+	return &Reader{
+		History:        h,
+		source:         source,
+		size:           size,
+		chunks:         chunks,
+		lastChunkIndex: -1,
+	}, nil
+}
+
+// Close releases the underlying file when the Reader was created with Open.
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// Size returns the size of the source in bytes.
+func (r *Reader) Size() int64 {
+	return r.size
+}
+
+// ChunkCount returns the count of binary chunks in the file.
+func (r *Reader) ChunkCount() int {
+	return len(r.chunks)
+}
+
+// Header returns the HeaderMetadata stored in the metadata of the file.
+func (r *Reader) Header() (*inspectionmetadata.HeaderMetadata, error) {
+	raw, found := r.History.Metadata[inspectionmetadata.HeaderMetadataKey.Key()]
+	if !found {
+		return nil, fmt.Errorf("header metadata was not found in the file")
+	}
+	// The metadata is decoded as map[string]any. Round trip it through JSON to convert it to the typed struct.
+	rawJSON, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encode the header metadata: %w", err)
+	}
+	header := &inspectionmetadata.HeaderMetadata{}
+	if err := json.Unmarshal(rawJSON, header); err != nil {
+		return nil, fmt.Errorf("failed to decode the header metadata: %w", err)
+	}
+	return header, nil
+}
+
+// Read returns the bytes pointed by the given BinaryReference. It returns nil without any error when ref is nil.
+func (r *Reader) Read(ref *binarychunk.BinaryReference) ([]byte, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	chunk, err := r.chunk(ref.Buffer)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Offset < 0 || ref.Length < 0 || ref.Offset+ref.Length > len(chunk) {
+		return nil, fmt.Errorf("reference (offset:%d, len:%d) is out of the range of buffer %d (size:%d)", ref.Offset, ref.Length, ref.Buffer, len(chunk))
+	}
+	return chunk[ref.Offset : ref.Offset+ref.Length], nil
+}
+
+// ReadString returns the string pointed by the given BinaryReference. It returns an empty string when ref is nil.
+func (r *Reader) ReadString(ref *binarychunk.BinaryReference) (string, error) {
+	data, err := r.Read(ref)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// chunk returns the decompressed binary chunk at the given index.
+func (r *Reader) chunk(index int) ([]byte, error) {
+	if index < 0 || index >= len(r.chunks) {
+		return nil, fmt.Errorf("buffer index %d is out of the range", index)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.lastChunkIndex == index {
+		return r.lastChunk, nil
+	}
+	location := r.chunks[index]
+	gzipReader, err := gzip.NewReader(io.NewSectionReader(r.source, location.offset, location.size))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open binary chunk %d: %w", index, err)
+	}
+	defer gzipReader.Close()
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(gzipReader); err != nil {
+		return nil, fmt.Errorf("failed to decompress binary chunk %d: %w", index, err)
+	}
+	chunk := buf.Bytes()
+	r.lastChunk = chunk
+	r.lastChunkIndex = index
+	return chunk, nil
+}
+
+func (r *Reader) buildIndex() {
+	r.indexOnce.Do(func() {
+		r.logs = make(map[string]*history.SerializableLog, len(r.History.Logs))
+		for _, l := range r.History.Logs {
+			r.logs[l.ID] = l
+		}
+		r.timelines = make(map[string]*history.ResourceTimeline, len(r.History.Timelines))
+		for _, timeline := range r.History.Timelines {
+			r.timelines[timeline.ID] = timeline
+		}
+		r.resourcePaths = map[string]*history.Resource{}
+		r.WalkResources(func(resource *history.Resource) error {
+			r.resourcePaths[resource.FullResourcePath] = resource
+			return nil
+		})
+	})
+}
+
+// Log returns the SerializableLog with the given log ID.
+func (r *Reader) Log(logID string) (*history.SerializableLog, bool) {
+	r.buildIndex()
+	l, found := r.logs[logID]
+	return l, found
+}
+
+// Timeline returns the ResourceTimeline with the given timeline ID.
+func (r *Reader) Timeline(timelineID string) (*history.ResourceTimeline, bool) {
+	r.buildIndex()
+	timeline, found := r.timelines[timelineID]
+	return timeline, found
+}
+
+// Resource returns the Resource at the given resource path (e.g `core/v1#pod#default#nginx`).
+func (r *Reader) Resource(resourcePath string) (*history.Resource, bool) {
+	r.buildIndex()
+	resource, found := r.resourcePaths[resourcePath]
+	return resource, found
+}
+
+// TimelineOf returns the ResourceTimeline associated to the given resource path.
+func (r *Reader) TimelineOf(resourcePath string) (*history.ResourceTimeline, bool) {
+	resource, found := r.Resource(resourcePath)
+	if !found || resource.Timeline == "" {
+		return nil, false
+	}
+	return r.Timeline(resource.Timeline)
+}
+
+// WalkResources calls fn for every resource in the resource tree in depth first order.
+// Walking stops when fn returns an error and the error is returned.
+func (r *Reader) WalkResources(fn func(resource *history.Resource) error) error {
+	var walk func(resources []*history.Resource) error
+	walk = func(resources []*history.Resource) error {
+		for _, resource := range resources {
+			if err := fn(resource); err != nil {
+				return err
+			}
+			if err := walk(resource.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(r.History.Resources)
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package khifile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/testkhifile"
+)
+
+// generateTestKHIFile returns a .khi file containing an INFO log with a pod revision and an ERROR log with an event on a node.
+func generateTestKHIFile(t *testing.T) []byte {
+	t.Helper()
+	return testkhifile.Generate(t, &inspectionmetadata.HeaderMetadata{InspectionName: "test inspection"}, []testkhifile.Entry{
+		{
+			InsertID: "foo",
+			Summary:  "summary of foo",
+			Path:     resourcepath.NameLayerGeneralItem("core/v1", "pod", "default", "nginx"),
+			Revision: &history.StagingResourceRevision{
+				Verb:      enum.RevisionVerbCreate,
+				Body:      "kind: Pod",
+				Requestor: "user@example.com",
+				State:     enum.RevisionStateExisting,
+			},
+		},
+		{
+			InsertID: "bar",
+			Severity: "ERROR",
+			Offset:   time.Hour,
+			Path:     resourcepath.NameLayerGeneralItem("core/v1", "node", "cluster-scope", "node-1"),
+		},
+	})
+}
+
+func TestNewReader(t *testing.T) {
+	data := generateTestKHIFile(t)
+	reader, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("NewReader() returned an unexpected error: %v", err)
+	}
+	if reader.ChunkCount() == 0 {
+		t.Errorf("ChunkCount() = 0, want more than 0")
+	}
+
+	header, err := reader.Header()
+	if err != nil {
+		t.Fatalf("Header() returned an unexpected error: %v", err)
+	}
+	if header.InspectionName != "test inspection" {
+		t.Errorf("Header().InspectionName = %q, want %q", header.InspectionName, "test inspection")
+	}
+
+	timeline, found := reader.TimelineOf("core/v1#pod#default#nginx")
+	if !found {
+		t.Fatalf("TimelineOf() didn't find the timeline of the pod")
+	}
+	if len(timeline.Revisions) != 1 {
+		t.Fatalf("len(timeline.Revisions) = %d, want 1", len(timeline.Revisions))
+	}
+	revision := timeline.Revisions[0]
+	testCases := []struct {
+		desc string
+		got  func() (string, error)
+		want string
+	}{
+		{
+			desc: "revision body",
+			got:  func() (string, error) { return reader.ReadString(revision.Body) },
+			want: "kind: Pod",
+		},
+		{
+			desc: "revision requestor",
+			got:  func() (string, error) { return reader.ReadString(revision.Requestor) },
+			want: "user@example.com",
+		},
+		{
+			desc: "log summary",
+			got: func() (string, error) {
+				l, found := reader.Log(revision.Log)
+				if !found {
+					return "", fmt.Errorf("log %s was not found", revision.Log)
+				}
+				return reader.ReadString(l.Summary)
+			},
+			want: "summary of foo",
+		},
+		{
+			desc: "nil reference",
+			got:  func() (string, error) { return reader.ReadString(nil) },
+			want: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tc.got()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewReader_InvalidFormat(t *testing.T) {
+	valid := generateTestKHIFile(t)
+	testCases := []struct {
+		desc string
+		data []byte
+	}{
+		{
+			desc: "empty",
+			data: []byte{},
+		},
+		{
+			desc: "wrong magic",
+			data: append([]byte("ABC"), valid[3:]...),
+		},
+		{
+			desc: "truncated json",
+			data: valid[:10],
+		},
+		{
+			desc: "truncated chunk",
+			data: valid[:len(valid)-1],
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := NewReader(bytes.NewReader(tc.data), int64(len(tc.data)))
+			if !errors.Is(err, ErrInvalidFormat) {
+				t.Errorf("NewReader() returned %v, want ErrInvalidFormat", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package khifile
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"gopkg.in/yaml.v3"
+)
+
+// SnapshotEntry is the manifest of a resource at the time given to Reader.Snapshot.
+type SnapshotEntry struct {
+	// ResourcePath is the resource path of the timeline the manifest was taken from.
+	ResourcePath string
+	APIVersion   string
+	Kind         string
+	// Namespace is the namespace in the resource path. It is `cluster-scope` for cluster scoped resources.
+	Namespace string
+	Name      string
+	// ChangeTime is the time of the revision the manifest was taken from.
+	ChangeTime time.Time
+	State      enum.RevisionState
+	Manifest   string
+}
+
+// manifestHeader is the subset of fields read from a revision body to check if it is a Kubernetes manifest.
+type manifestHeader struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+}
+
+// Snapshot returns the manifests of resources existing at the given time sorted by their resource paths.
+// It takes the latest revision at or before the time from each resource timeline and skips resources deleted at the time.
+// Timelines without a Kubernetes manifest (e.g subresources, pseudo resources or bodies of metadata level audit logs) are ignored.
+func (r *Reader) Snapshot(at time.Time) ([]*SnapshotEntry, error) {
+	result := []*SnapshotEntry{}
+	for _, entry := range r.Timelines("") {
+		fragments := strings.Split(entry.Resource.FullResourcePath, "#")
+		if len(fragments) != 4 || entry.Resource.Relationship != enum.RelationshipChild {
+			continue
+		}
+		revision := RevisionAt(entry.Timeline, at)
+		if revision == nil || revision.State == enum.RevisionStateDeleted {
+			continue
+		}
+		body, err := r.ReadString(revision.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the revision body of %s: %w", entry.Resource.FullResourcePath, err)
+		}
+		var header manifestHeader
+		if err := yaml.Unmarshal([]byte(body), &header); err != nil || header.APIVersion == "" || header.Kind == "" {
+			continue
+		}
+		result = append(result, &SnapshotEntry{
+			ResourcePath: entry.Resource.FullResourcePath,
+			APIVersion:   header.APIVersion,
+			Kind:         header.Kind,
+			Namespace:    fragments[2],
+			Name:         fragments[3],
+			ChangeTime:   revision.ChangeTime,
+			State:        revision.State,
+			Manifest:     body,
+		})
+	}
+	slices.SortFunc(result, func(a, b *SnapshotEntry) int {
+		return strings.Compare(a.ResourcePath, b.ResourcePath)
+	})
+	return result, nil
+}
+
+// WriteSnapshotYAML writes the given entries as a multi-document YAML.
+func WriteSnapshotYAML(w io.Writer, entries []*SnapshotEntry) error {
+	for i, entry := range entries {
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, strings.TrimRight(entry.Manifest, "\n")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteSnapshotDirectory writes each of the given entries as a YAML file at `<dir>/<namespace>/<kind>.<group>/<name>.yaml`.
+func WriteSnapshotDirectory(dir string, entries []*SnapshotEntry) error {
+	for _, entry := range entries {
+		filePath := filepath.Join(dir, entry.FilePath())
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return fmt.Errorf("failed to create the directory for %s: %w", entry.ResourcePath, err)
+		}
+		if err := os.WriteFile(filePath, []byte(strings.TrimRight(entry.Manifest, "\n")+"\n"), 0644); err != nil {
+			return fmt.Errorf("failed to write the manifest of %s: %w", entry.ResourcePath, err)
+		}
+	}
+	return nil
+}
+
+// FilePath returns the relative file path used for the entry in WriteSnapshotDirectory.
+func (e *SnapshotEntry) FilePath() string {
+	group := "core"
+	if slash := strings.LastIndex(e.APIVersion, "/"); slash >= 0 {
+		group = e.APIVersion[:slash]
+	}
+	kind := strings.ToLower(e.Kind) + "." + group
+	return filepath.Join(sanitizeFileName(e.Namespace), sanitizeFileName(kind), sanitizeFileName(e.Name)+".yaml")
+}
+
+func sanitizeFileName(name string) string {
+	return strings.NewReplacer("/", "_", "\\", "_").Replace(name)
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package khifile
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/testkhifile"
+	"github.com/google/go-cmp/cmp"
+)
+
+// generateSnapshotTestKHIFile returns a .khi file with a pod created at 0m and deleted at 10m, a configmap created at 5m and a pseudo resource without manifest.
+func generateSnapshotTestKHIFile(t *testing.T) []byte {
+	t.Helper()
+	podManifest := "apiVersion: v1\nkind: Pod\nmetadata:\n  name: nginx\n  namespace: default\n"
+	return testkhifile.Generate(t, nil, []testkhifile.Entry{
+		{
+			InsertID: "pod-create",
+			Path:     resourcepath.NameLayerGeneralItem("core/v1", "pod", "default", "nginx"),
+			Revision: &history.StagingResourceRevision{Verb: enum.RevisionVerbCreate, Body: podManifest, State: enum.RevisionStateExisting},
+		},
+		{
+			InsertID: "configmap-create",
+			Offset:   5 * time.Minute,
+			Path:     resourcepath.NameLayerGeneralItem("core/v1", "configmap", "default", "config"),
+			Revision: &history.StagingResourceRevision{Verb: enum.RevisionVerbCreate, Body: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n  namespace: default\n", State: enum.RevisionStateExisting},
+		},
+		{
+			InsertID: "pod-delete",
+			Offset:   10 * time.Minute,
+			Path:     resourcepath.NameLayerGeneralItem("core/v1", "pod", "default", "nginx"),
+			Revision: &history.StagingResourceRevision{Verb: enum.RevisionVerbDelete, Body: podManifest, State: enum.RevisionStateDeleted},
+		},
+		{
+			InsertID: "pseudo",
+			Path:     resourcepath.NameLayerGeneralItem("@Cluster", "controlplane", "cluster-scope", "foo"),
+			Revision: &history.StagingResourceRevision{Verb: enum.RevisionVerbCreate, Body: "# not a manifest", State: enum.RevisionStateExisting},
+		},
+	})
+}
+
+func TestSnapshot(t *testing.T) {
+	data := generateSnapshotTestKHIFile(t)
+	reader, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("NewReader() returned an unexpected error: %v", err)
+	}
+	testCases := []struct {
+		desc string
+		at   time.Time
+		want []string
+	}{
+		{
+			desc: "before any revision",
+			at:   testkhifile.BaseTime.Add(-time.Minute),
+			want: []string{},
+		},
+		{
+			desc: "after the pod creation",
+			at:   testkhifile.BaseTime.Add(time.Minute),
+			want: []string{"core/v1#pod#default#nginx"},
+		},
+		{
+			desc: "after the configmap creation",
+			at:   testkhifile.BaseTime.Add(5 * time.Minute),
+			want: []string{"core/v1#configmap#default#config", "core/v1#pod#default#nginx"},
+		},
+		{
+			desc: "after the pod deletion",
+			at:   testkhifile.BaseTime.Add(10 * time.Minute),
+			want: []string{"core/v1#configmap#default#config"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			entries, err := reader.Snapshot(tc.at)
+			if err != nil {
+				t.Fatalf("Snapshot() returned an unexpected error: %v", err)
+			}
+			got := []string{}
+			for _, entry := range entries {
+				got = append(got, entry.ResourcePath)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Snapshot() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteSnapshotYAML(t *testing.T) {
+	entries := []*SnapshotEntry{
+		{Manifest: "apiVersion: v1\nkind: Pod\n"},
+		{Manifest: "apiVersion: v1\nkind: ConfigMap"},
+	}
+	var buf bytes.Buffer
+	if err := WriteSnapshotYAML(&buf, entries); err != nil {
+		t.Fatalf("WriteSnapshotYAML() returned an unexpected error: %v", err)
+	}
+	want := "apiVersion: v1\nkind: Pod\n---\napiVersion: v1\nkind: ConfigMap\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteSnapshotYAML() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteSnapshotDirectory(t *testing.T) {
+	dir := t.TempDir()
+	entries := []*SnapshotEntry{
+		{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx", Manifest: "kind: Pod"},
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "nginx", Manifest: "kind: Deployment"},
+		{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Namespace: "cluster-scope", Name: "system:foo", Manifest: "kind: ClusterRole"},
+	}
+	if err := WriteSnapshotDirectory(dir, entries); err != nil {
+		t.Fatalf("WriteSnapshotDirectory() returned an unexpected error: %v", err)
+	}
+	testCases := []struct {
+		filePath string
+		want     string
+	}{
+		{filePath: "default/pod.core/nginx.yaml", want: "kind: Pod\n"},
+		{filePath: "default/deployment.apps/nginx.yaml", want: "kind: Deployment\n"},
+		{filePath: "cluster-scope/clusterrole.rbac.authorization.k8s.io/system:foo.yaml", want: "kind: ClusterRole\n"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.filePath, func(t *testing.T) {
+			got, err := os.ReadFile(filepath.Join(dir, tc.filePath))
+			if err != nil {
+				t.Fatalf("failed to read the written file: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("file content mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
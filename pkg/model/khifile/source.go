@@ -0,0 +1,48 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package khifile
+
+import (
+	"io"
+)
+
+// RangeReadable is a source of a .khi file readable with a range. The inspection result Store satisfies this interface.
+type RangeReadable interface {
+	GetRangeReader(start, maxLength int64) (io.ReadCloser, error)
+}
+
+// rangeReaderAt is an io.ReaderAt reading a RangeReadable.
+type rangeReaderAt struct {
+	source RangeReadable
+}
+
+// NewRangeReaderAt returns an io.ReaderAt reading the given RangeReadable. It can be passed to NewReader.
+func NewRangeReaderAt(source RangeReadable) io.ReaderAt {
+	return &rangeReaderAt{source: source}
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *rangeReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	reader, err := r.source.GetRangeReader(off, int64(len(p)))
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	n, err := io.ReadFull(reader, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
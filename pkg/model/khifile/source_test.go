@@ -0,0 +1,47 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package khifile
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+type bytesRangeReadable []byte
+
+// GetRangeReader implements RangeReadable.
+func (b bytesRangeReadable) GetRangeReader(start, maxLength int64) (io.ReadCloser, error) {
+	return io.NopCloser(io.NewSectionReader(bytes.NewReader(b), start, maxLength)), nil
+}
+
+func TestNewRangeReaderAt(t *testing.T) {
+	data := generateTestKHIFile(t)
+	reader, err := NewReader(NewRangeReaderAt(bytesRangeReadable(data)), int64(len(data)))
+	if err != nil {
+		t.Fatalf("NewReader() returned an unexpected error: %v", err)
+	}
+	timeline, found := reader.TimelineOf("core/v1#pod#default#nginx")
+	if !found {
+		t.Fatalf("TimelineOf() didn't find the timeline of the pod")
+	}
+	body, err := reader.ReadString(timeline.Revisions[0].Body)
+	if err != nil {
+		t.Fatalf("ReadString() returned an unexpected error: %v", err)
+	}
+	if body != "kind: Pod" {
+		t.Errorf("ReadString() = %q, want %q", body, "kind: Pod")
+	}
+}
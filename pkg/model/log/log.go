@@ -73,11 +73,16 @@ func NewLogFromYAMLString(yaml string) (*Log, error) {
 func NewLogWithFieldSetsForTest(fieldSets ...FieldSet) *Log {
 	log := NewLog(&structured.NodeReader{})
 	for _, fieldSet := range fieldSets {
-		typedmap.Set(log.fields, typedmap.NewTypedKey[FieldSet](fieldSet.Kind()), fieldSet)
+		log.SetFieldSet(fieldSet)
 	}
 	return log
 }
 
+// SetFieldSet keeps the given FieldSet in the log. This is used when the fields are already known without reading the log, like logs parsed from files by KHI.
+func (l *Log) SetFieldSet(fieldSet FieldSet) {
+	typedmap.Set(l.fields, typedmap.NewTypedKey[FieldSet](fieldSet.Kind()), fieldSet)
+}
+
 // SetFieldSetReader reads set of fields with the FieldSetReader and keep it in the log.
 func (l *Log) SetFieldSetReader(reader FieldSetReader) error {
 	fieldSet, err := reader.Read(l.NodeReader)
@@ -76,3 +76,20 @@ func TestGetField(t *testing.T) {
 		t.Errorf("GetField() must return same references on multiple calls, but returned different references")
 	}
 }
+
+func TestSetFieldSet(t *testing.T) {
+	yamlNode, err := structured.FromYAML(`test_field: foo`)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	l := NewLog(structured.NewNodeReader(yamlNode))
+	l.SetFieldSet(&TestFieldSet{TestField: "bar"})
+
+	f, err := GetFieldSet(l, &TestFieldSet{})
+	if err != nil {
+		t.Errorf("GetField() error = %v", err)
+	}
+	if f.TestField != "bar" {
+		t.Errorf("GetField() = %v, want %v", f.TestField, "bar")
+	}
+}
@@ -51,6 +51,15 @@ type AuthParameters struct {
 	//
 	// Deprecated: Passing raw access token string to parameter is deprecated and not recommended. It's recommended to authenticate with Application Default Credentials(ADC).
 	AccessToken *string
+
+	// ElasticsearchUsername is the username of the basic authentication used for requests to Elasticsearch or OpenSearch.
+	ElasticsearchUsername *string
+
+	// ElasticsearchPassword is the password of the basic authentication used for requests to Elasticsearch or OpenSearch.
+	ElasticsearchPassword *string
+
+	// ElasticsearchAPIKey is the API key used for requests to Elasticsearch.
+	ElasticsearchAPIKey *string
 }
 
 // PostProcess implements ParameterStore.
@@ -67,6 +76,9 @@ func (a *AuthParameters) PostProcess() error {
 	if *a.AccessToken != "" && a.OAuthEnabled() {
 		return fmt.Errorf("cannot use --access-token and OAuth parameters at the same time")
 	}
+	if a.ElasticsearchUsername != nil && a.ElasticsearchAPIKey != nil && *a.ElasticsearchUsername != "" && *a.ElasticsearchAPIKey != "" {
+		return fmt.Errorf("cannot use --elasticsearch-username and --elasticsearch-api-key at the same time")
+	}
 	if *a.AccessToken != "" {
 		slog.Warn("--access-token parameter is deprecated and not recommended after KHI supporting authentication via Application Default Credentials(ADC)")
 	}
@@ -83,6 +95,9 @@ func (a *AuthParameters) Prepare() error {
 	a.OAuthRedirectURI = flag.String("oauth-redirect-uri", "", "The callback URI for OAuth. This must be provided as full qualified URL.", "")
 	a.OAuthRedirectTargetServingPath = flag.String("oauth-redirect-target-serving-path", "/oauth/callback", "The path to serve the callback target.", "")
 	a.OAuthStateSuffix = flag.String("oauth-state-suffix", "", "The suffix added to the state parameter in OAuth. The state will be generated in the format of `<random-string><suffix>`.", "")
+	a.ElasticsearchUsername = flag.String("elasticsearch-username", "", "The username of the basic authentication used for requests to Elasticsearch or OpenSearch.", "KHI_ELASTICSEARCH_USERNAME")
+	a.ElasticsearchPassword = flag.String("elasticsearch-password", "", "The password of the basic authentication used for requests to Elasticsearch or OpenSearch.", "KHI_ELASTICSEARCH_PASSWORD")
+	a.ElasticsearchAPIKey = flag.String("elasticsearch-api-key", "", "The API key used for requests to Elasticsearch.", "KHI_ELASTICSEARCH_API_KEY")
 	return nil
 }
 
@@ -49,6 +49,9 @@ func TestAuthParameters(t *testing.T) {
 				OAuthRedirectURI:               testutil.P(""),
 				OAuthRedirectTargetServingPath: testutil.P("/oauth/callback"),
 				OAuthStateSuffix:               testutil.P(""),
+				ElasticsearchUsername:          testutil.P(""),
+				ElasticsearchPassword:          testutil.P(""),
+				ElasticsearchAPIKey:            testutil.P(""),
 			},
 			before: func() {
 				os.Args = []string{os.Args[0]}
@@ -245,6 +248,32 @@ func TestAuthParameters_PostProcess(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "valid: elasticsearch basic authentication",
+			params: &AuthParameters{
+				OAuthClientID:         testutil.P(""),
+				OAuthClientSecret:     testutil.P(""),
+				OAuthRedirectURI:      testutil.P(""),
+				AccessToken:           testutil.P(""),
+				ElasticsearchUsername: testutil.P("elastic"),
+				ElasticsearchPassword: testutil.P("changeme"),
+				ElasticsearchAPIKey:   testutil.P(""),
+			},
+			expectErr: false,
+		},
+		{
+			name: "invalid: elasticsearch username and API key",
+			params: &AuthParameters{
+				OAuthClientID:         testutil.P(""),
+				OAuthClientSecret:     testutil.P(""),
+				OAuthRedirectURI:      testutil.P(""),
+				AccessToken:           testutil.P(""),
+				ElasticsearchUsername: testutil.P("elastic"),
+				ElasticsearchPassword: testutil.P("changeme"),
+				ElasticsearchAPIKey:   testutil.P("key"),
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
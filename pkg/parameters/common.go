@@ -34,6 +34,8 @@ type CommonParameters struct {
 	UploadFileStoreFolder *string
 	// Version is the flag to show the version name and exit.
 	Version *bool
+	// AuditLogSeverityPolicyFile is the YAML file path of the policy deciding the severity of kube-apiserver audit logs read in the OSS Kubernetes inspection. The default policy is used when it is empty.
+	AuditLogSeverityPolicyFile *string
 }
 
 // PostProcess implements ParameterStore.
@@ -54,6 +56,7 @@ func (c *CommonParameters) Prepare() error {
 	c.TemporaryFolder = flag.String("temporary-folder", "/tmp", "The folder path where be used as a working directory to generate the final khi file.", "")
 	c.UploadFileStoreFolder = flag.String("upload-file-store-folder", "", "The folder path to store the uploaded log files. Use the concatinated path of `--data-destination-folder` and `/upload` when this value is not specified.", "")
 	c.Version = flag.Bool("version", false, "Show the version.", "")
+	c.AuditLogSeverityPolicyFile = flag.String("audit-log-severity-policy-file", "", "The YAML file path of the policy deciding the severity of kube-apiserver audit logs from their response status codes and annotations in the OSS Kubernetes inspection. The default policy is used when this value is not specified.", "KHI_AUDIT_LOG_SEVERITY_POLICY_FILE")
 	return nil
 }
 
@@ -32,10 +32,11 @@ func TestCommonParameters(t *testing.T) {
 		{
 			name: "default",
 			want: &CommonParameters{
-				DataDestinationFolder: testutil.P("./data"),
-				TemporaryFolder:       testutil.P("/tmp"),
-				Version:               testutil.P(false),
-				UploadFileStoreFolder: testutil.P("./data/upload"),
+				DataDestinationFolder:      testutil.P("./data"),
+				TemporaryFolder:            testutil.P("/tmp"),
+				Version:                    testutil.P(false),
+				UploadFileStoreFolder:      testutil.P("./data/upload"),
+				AuditLogSeverityPolicyFile: testutil.P(""),
 			},
 			before: func() {
 				os.Args = []string{os.Args[0]}
@@ -14,7 +14,11 @@
 
 package parameters
 
-import "github.com/GoogleCloudPlatform/khi/pkg/common/flag"
+import (
+	"errors"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/flag"
+)
 
 var Server *ServerParameters = &ServerParameters{}
 
@@ -33,6 +37,26 @@ type ServerParameters struct {
 	FrontendAssetFolder *string
 	// MaxUploadFileSizeInBytes is the maximum limit of uploaded file. Server returns 400 when the request exceeds it.
 	MaxUploadFileSizeInBytes *int
+	// PersistInspections enables writing a manifest of each finished inspection in the data destination folder and restoring them on startup.
+	PersistInspections *bool
+	// InspectionRetentionMaxAgeHours is the maximum age of finished inspections in hours. Older inspections are deleted. 0 means no limit.
+	InspectionRetentionMaxAgeHours *int
+	// InspectionRetentionMaxTotalBytes is the maximum total size of the result files of finished inspections. Older inspections are deleted first. 0 means no limit.
+	InspectionRetentionMaxTotalBytes *int
+	// InspectionRetentionMaxCount is the maximum count of finished inspections. Older inspections are deleted first. 0 means no limit.
+	InspectionRetentionMaxCount *int
+	// AuditWebhook enables the endpoint receiving audit events from kube-apiserver as an audit webhook backend. Received events are archived in the data destination folder.
+	AuditWebhook *bool
+	// AuditWebhookToken is the bearer token required on requests to the audit webhook endpoint. It must match the token of the user in the webhook kubeconfig of kube-apiserver.
+	AuditWebhookToken *string
+	// AuditWebhookMaxRequestSizeInBytes is the maximum size of a request body sent to the audit webhook endpoint. Server returns 413 when the request exceeds it.
+	AuditWebhookMaxRequestSizeInBytes *int
+	// AuditWebhookArchiveMaxSizeInBytes is the size of an audit webhook archive to start a new archive. 0 means no limit.
+	AuditWebhookArchiveMaxSizeInBytes *int
+	// AuditWebhookArchiveMaxAgeMinutes is the age of an audit webhook archive in minutes to start a new archive. 0 means no limit.
+	AuditWebhookArchiveMaxAgeMinutes *int
+	// AuditWebhookArchiveMaxCount is the maximum count of audit webhook archives. The oldest archives are deleted first. 0 means no limit.
+	AuditWebhookArchiveMaxCount *int
 }
 
 // PostProcess implements ParameterStore.
@@ -42,6 +66,9 @@ func (s *ServerParameters) PostProcess() error {
 		*s.FrontendResourceBasePath = *s.BasePath
 	}
 	ensureEndsWithSlash(s.FrontendResourceBasePath)
+	if *s.AuditWebhook && *s.AuditWebhookToken == "" {
+		return errors.New("--audit-webhook-token is required when --audit-webhook is set")
+	}
 	return nil
 }
 
@@ -54,6 +81,16 @@ func (s *ServerParameters) Prepare() error {
 	s.FrontendResourceBasePath = flag.String("frontend-resource-base-path", "", "Another base address only for frontend assets. If this value is not set, this uses `--base-path` value by default.", "KHI_FRONTEND_RESOURCE_PATH")
 	s.FrontendAssetFolder = flag.String("frontend-asset-folder", "", "The root folder of the assets used in frontend including index.html. If this value is not set, the assets embedded into the executable are used.", "KHI_FRONTEND_ASSET_FOLDER")
 	s.MaxUploadFileSizeInBytes = flag.Int("max-upload-file-size-in-bytes", 1024*1024*1024, "The maximum limit of uploaded file. Server returns 400 when the request exceeds it.", "")
+	s.PersistInspections = flag.Bool("persist-inspections", false, "Writes a manifest of each finished inspection in the data destination folder and restores them as completed inspections on startup. Inspections are kept only in memory and lost on restart when this is not specified.", "KHI_PERSIST_INSPECTIONS")
+	s.InspectionRetentionMaxAgeHours = flag.Int("inspection-retention-max-age-hours", 0, "The maximum age of finished inspections in hours. Older inspections and their files are deleted. 0 means no limit.", "KHI_INSPECTION_RETENTION_MAX_AGE_HOURS")
+	s.InspectionRetentionMaxTotalBytes = flag.Int("inspection-retention-max-total-bytes", 0, "The maximum total size of the result files of finished inspections. Older inspections and their files are deleted first. 0 means no limit.", "KHI_INSPECTION_RETENTION_MAX_TOTAL_BYTES")
+	s.InspectionRetentionMaxCount = flag.Int("inspection-retention-max-count", 0, "The maximum count of finished inspections. Older inspections and their files are deleted first. 0 means no limit.", "KHI_INSPECTION_RETENTION_MAX_COUNT")
+	s.AuditWebhook = flag.Bool("audit-webhook", false, "Serves the endpoint `<base-path>api/v3/audit-webhook` receiving audit events from kube-apiserver configured with `--audit-webhook-config-file`. Received events are archived in the data destination folder and readable from the OSS Kubernetes inspection.", "KHI_AUDIT_WEBHOOK")
+	s.AuditWebhookToken = flag.String("audit-webhook-token", "", "The bearer token required on requests to the audit webhook endpoint. Set the same token to the user of the webhook kubeconfig given to kube-apiserver with `--audit-webhook-config-file`.", "KHI_AUDIT_WEBHOOK_TOKEN")
+	s.AuditWebhookMaxRequestSizeInBytes = flag.Int("audit-webhook-max-request-size-in-bytes", 64*1024*1024, "The maximum size of a request body sent to the audit webhook endpoint. Server returns 413 when the request exceeds it.", "KHI_AUDIT_WEBHOOK_MAX_REQUEST_SIZE_IN_BYTES")
+	s.AuditWebhookArchiveMaxSizeInBytes = flag.Int("audit-webhook-archive-max-size-in-bytes", 64*1024*1024, "The size of an audit webhook archive to start a new archive. 0 means no limit.", "KHI_AUDIT_WEBHOOK_ARCHIVE_MAX_SIZE_IN_BYTES")
+	s.AuditWebhookArchiveMaxAgeMinutes = flag.Int("audit-webhook-archive-max-age-minutes", 60, "The age of an audit webhook archive in minutes to start a new archive. 0 means no limit.", "KHI_AUDIT_WEBHOOK_ARCHIVE_MAX_AGE_MINUTES")
+	s.AuditWebhookArchiveMaxCount = flag.Int("audit-webhook-archive-max-count", 168, "The maximum count of audit webhook archives. The oldest archives are deleted first. 0 means no limit.", "KHI_AUDIT_WEBHOOK_ARCHIVE_MAX_COUNT")
 	return nil
 }
 
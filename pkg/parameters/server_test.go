@@ -36,13 +36,23 @@ func TestServerParameters(t *testing.T) {
 			},
 			name: "default",
 			want: &ServerParameters{
-				ViewerMode:               testutil.P(false),
-				Port:                     testutil.P(8080),
-				Host:                     testutil.P("localhost"),
-				BasePath:                 testutil.P("/"),
-				FrontendResourceBasePath: testutil.P("/"),
-				FrontendAssetFolder:      testutil.P(""),
-				MaxUploadFileSizeInBytes: testutil.P(1024 * 1024 * 1024),
+				ViewerMode:                        testutil.P(false),
+				Port:                              testutil.P(8080),
+				Host:                              testutil.P("localhost"),
+				BasePath:                          testutil.P("/"),
+				FrontendResourceBasePath:          testutil.P("/"),
+				FrontendAssetFolder:               testutil.P(""),
+				MaxUploadFileSizeInBytes:          testutil.P(1024 * 1024 * 1024),
+				PersistInspections:                testutil.P(false),
+				InspectionRetentionMaxAgeHours:    testutil.P(0),
+				InspectionRetentionMaxTotalBytes:  testutil.P(0),
+				InspectionRetentionMaxCount:       testutil.P(0),
+				AuditWebhook:                      testutil.P(false),
+				AuditWebhookToken:                 testutil.P(""),
+				AuditWebhookMaxRequestSizeInBytes: testutil.P(64 * 1024 * 1024),
+				AuditWebhookArchiveMaxSizeInBytes: testutil.P(64 * 1024 * 1024),
+				AuditWebhookArchiveMaxAgeMinutes:  testutil.P(60),
+				AuditWebhookArchiveMaxCount:       testutil.P(168),
 			},
 		},
 		{
@@ -52,13 +62,23 @@ func TestServerParameters(t *testing.T) {
 			},
 			name: "FrontendResourceBasePath uses BasePath when not set",
 			want: &ServerParameters{
-				ViewerMode:               testutil.P(false),
-				Port:                     testutil.P(8080),
-				Host:                     testutil.P("localhost"),
-				BasePath:                 testutil.P("/foo/bar/"),
-				FrontendResourceBasePath: testutil.P("/foo/bar/"),
-				FrontendAssetFolder:      testutil.P(""),
-				MaxUploadFileSizeInBytes: testutil.P(1024 * 1024 * 1024),
+				ViewerMode:                        testutil.P(false),
+				Port:                              testutil.P(8080),
+				Host:                              testutil.P("localhost"),
+				BasePath:                          testutil.P("/foo/bar/"),
+				FrontendResourceBasePath:          testutil.P("/foo/bar/"),
+				FrontendAssetFolder:               testutil.P(""),
+				MaxUploadFileSizeInBytes:          testutil.P(1024 * 1024 * 1024),
+				PersistInspections:                testutil.P(false),
+				InspectionRetentionMaxAgeHours:    testutil.P(0),
+				InspectionRetentionMaxTotalBytes:  testutil.P(0),
+				InspectionRetentionMaxCount:       testutil.P(0),
+				AuditWebhook:                      testutil.P(false),
+				AuditWebhookToken:                 testutil.P(""),
+				AuditWebhookMaxRequestSizeInBytes: testutil.P(64 * 1024 * 1024),
+				AuditWebhookArchiveMaxSizeInBytes: testutil.P(64 * 1024 * 1024),
+				AuditWebhookArchiveMaxAgeMinutes:  testutil.P(60),
+				AuditWebhookArchiveMaxCount:       testutil.P(168),
 			},
 		},
 		{
@@ -68,13 +88,49 @@ func TestServerParameters(t *testing.T) {
 			},
 			name: "FrontendResourceBasePath should complement the last /",
 			want: &ServerParameters{
-				ViewerMode:               testutil.P(false),
-				Port:                     testutil.P(8080),
-				Host:                     testutil.P("localhost"),
-				BasePath:                 testutil.P("/foo/bar/"),
-				FrontendResourceBasePath: testutil.P("/foo/"),
-				FrontendAssetFolder:      testutil.P(""),
-				MaxUploadFileSizeInBytes: testutil.P(1024 * 1024 * 1024),
+				ViewerMode:                        testutil.P(false),
+				Port:                              testutil.P(8080),
+				Host:                              testutil.P("localhost"),
+				BasePath:                          testutil.P("/foo/bar/"),
+				FrontendResourceBasePath:          testutil.P("/foo/"),
+				FrontendAssetFolder:               testutil.P(""),
+				MaxUploadFileSizeInBytes:          testutil.P(1024 * 1024 * 1024),
+				PersistInspections:                testutil.P(false),
+				InspectionRetentionMaxAgeHours:    testutil.P(0),
+				InspectionRetentionMaxTotalBytes:  testutil.P(0),
+				InspectionRetentionMaxCount:       testutil.P(0),
+				AuditWebhook:                      testutil.P(false),
+				AuditWebhookToken:                 testutil.P(""),
+				AuditWebhookMaxRequestSizeInBytes: testutil.P(64 * 1024 * 1024),
+				AuditWebhookArchiveMaxSizeInBytes: testutil.P(64 * 1024 * 1024),
+				AuditWebhookArchiveMaxAgeMinutes:  testutil.P(60),
+				AuditWebhookArchiveMaxCount:       testutil.P(168),
+			},
+		},
+		{
+			before: func() {
+				os.Args = []string{os.Args[0], "--persist-inspections"}
+				flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+			},
+			name: "PersistInspections is enabled with the flag",
+			want: &ServerParameters{
+				ViewerMode:                        testutil.P(false),
+				Port:                              testutil.P(8080),
+				Host:                              testutil.P("localhost"),
+				BasePath:                          testutil.P("/"),
+				FrontendResourceBasePath:          testutil.P("/"),
+				FrontendAssetFolder:               testutil.P(""),
+				MaxUploadFileSizeInBytes:          testutil.P(1024 * 1024 * 1024),
+				PersistInspections:                testutil.P(true),
+				InspectionRetentionMaxAgeHours:    testutil.P(0),
+				InspectionRetentionMaxTotalBytes:  testutil.P(0),
+				InspectionRetentionMaxCount:       testutil.P(0),
+				AuditWebhook:                      testutil.P(false),
+				AuditWebhookToken:                 testutil.P(""),
+				AuditWebhookMaxRequestSizeInBytes: testutil.P(64 * 1024 * 1024),
+				AuditWebhookArchiveMaxSizeInBytes: testutil.P(64 * 1024 * 1024),
+				AuditWebhookArchiveMaxAgeMinutes:  testutil.P(60),
+				AuditWebhookArchiveMaxCount:       testutil.P(168),
 			},
 		},
 	}
@@ -96,3 +152,51 @@ func TestServerParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestServerParameters_PostProcess(t *testing.T) {
+	testCases := []struct {
+		name      string
+		params    *ServerParameters
+		expectErr bool
+	}{
+		{
+			name: "valid: audit webhook disabled",
+			params: &ServerParameters{
+				BasePath:                 testutil.P("/"),
+				FrontendResourceBasePath: testutil.P(""),
+				AuditWebhook:             testutil.P(false),
+				AuditWebhookToken:        testutil.P(""),
+			},
+			expectErr: false,
+		},
+		{
+			name: "valid: audit webhook with a token",
+			params: &ServerParameters{
+				BasePath:                 testutil.P("/"),
+				FrontendResourceBasePath: testutil.P(""),
+				AuditWebhook:             testutil.P(true),
+				AuditWebhookToken:        testutil.P("some-token"),
+			},
+			expectErr: false,
+		},
+		{
+			name: "invalid: audit webhook without a token",
+			params: &ServerParameters{
+				BasePath:                 testutil.P("/"),
+				FrontendResourceBasePath: testutil.P(""),
+				AuditWebhook:             testutil.P(true),
+				AuditWebhookToken:        testutil.P(""),
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.params.PostProcess()
+			if (err != nil) != tc.expectErr {
+				t.Errorf("PostProcess() error = %v, expectErr %v", err, tc.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auditwebhook receives kube-apiserver audit events sent to KHI as an audit webhook backend and keeps them in rotating compressed archives.
+package auditwebhook
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EventListKind is the kind of the requests sent from the audit webhook backend of kube-apiserver.
+	EventListKind = "EventList"
+	// EventListAPIVersion is the only supported API version of EventList.
+	EventListAPIVersion = "audit.k8s.io/v1"
+
+	archiveNamePrefix = "audit-"
+	archiveNameSuffix = ".jsonl.gz"
+	// archiveNameTimeFormat is the format of the time in archive names. Archive names are sorted in the order of their creation.
+	archiveNameTimeFormat = "20060102T150405.000000000Z"
+	// ArchiveTimeMargin is the maximum delay assumed between the timestamp of an event and the time when KHI receives it.
+	// kube-apiserver sends events in batches and retries failed requests, so events can be received later than their timestamps.
+	ArchiveTimeMargin = 5 * time.Minute
+)
+
+// ErrInvalidEventList is returned when the request body is not an EventList of audit.k8s.io/v1.
+var ErrInvalidEventList = errors.New("invalid audit event list")
+
+// ArchiveStoreOptions is the rotation and retention policy of archives.
+type ArchiveStoreOptions struct {
+	// MaxArchiveSizeInBytes is the size of an archive to start a new archive. 0 means no limit.
+	MaxArchiveSizeInBytes int64
+	// MaxArchiveAge is the age of an archive to start a new archive. 0 means no limit.
+	MaxArchiveAge time.Duration
+	// MaxArchiveCount is the maximum count of archives. The oldest archives are deleted when a new archive is started. 0 means no limit.
+	MaxArchiveCount int
+}
+
+// Archive is a gzip compressed JSONLine file containing audit events received between CreatedAt and UpdatedAt.
+type Archive struct {
+	// Name is the file name of the archive.
+	Name string
+	// CreatedAt is the time when the archive received the first events.
+	CreatedAt time.Time
+	// UpdatedAt is the time when the archive received the last events.
+	UpdatedAt time.Time
+	// SizeInBytes is the size of the archive when it was listed.
+	SizeInBytes int64
+
+	path string
+}
+
+// Open returns the reader of the archive. Only the events written before the archive was listed are read even when the archive receives events later.
+// The caller MUST close the returned ReadCloser.
+func (a *Archive) Open() (io.ReadCloser, error) {
+	file, err := os.Open(a.path)
+	if err != nil {
+		return nil, err
+	}
+	return &archiveReader{
+		SectionReader: io.NewSectionReader(file, 0, a.SizeInBytes),
+		file:          file,
+	}, nil
+}
+
+// archiveReader reads an archive to the size when it was listed.
+type archiveReader struct {
+	*io.SectionReader
+	file *os.File
+}
+
+// Close implements io.Closer.
+func (r *archiveReader) Close() error {
+	return r.file.Close()
+}
+
+// ArchiveStore appends audit events to archives in a folder.
+// Events in each request are appended as a gzip member, so readers can read archives receiving events without seeing partially written events.
+type ArchiveStore struct {
+	folder  string
+	options ArchiveStoreOptions
+	now     func() time.Time
+
+	mu sync.Mutex
+	// current is the archive receiving events. This is nil until the first events are received after the store is created.
+	current *Archive
+}
+
+// NewArchiveStore returns an ArchiveStore writing archives in the given folder. The folder is created when it doesn't exist.
+// Archives written before are kept and listed with the new archives, but new events are always appended to a new archive.
+func NewArchiveStore(folder string, options ArchiveStoreOptions) (*ArchiveStore, error) {
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the audit webhook archive folder %s: %w", folder, err)
+	}
+	return &ArchiveStore{
+		folder:  folder,
+		options: options,
+		now:     time.Now,
+	}, nil
+}
+
+// AppendEventList reads an EventList of audit.k8s.io/v1 in JSON from the reader and appends its events to the current archive.
+// It returns the count of appended events. ErrInvalidEventList is wrapped in the returned error when the source is not an EventList.
+func (s *ArchiveStore) AppendEventList(source io.Reader) (int, error) {
+	var eventList struct {
+		Kind       string            `json:"kind"`
+		APIVersion string            `json:"apiVersion"`
+		Items      []json.RawMessage `json:"items"`
+	}
+	if err := json.NewDecoder(source).Decode(&eventList); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidEventList, err)
+	}
+	if eventList.Kind != EventListKind || eventList.APIVersion != EventListAPIVersion {
+		return 0, fmt.Errorf("%w: expected %s of %s but got %q of %q", ErrInvalidEventList, EventListKind, EventListAPIVersion, eventList.Kind, eventList.APIVersion)
+	}
+	if len(eventList.Items) == 0 {
+		return 0, nil
+	}
+
+	var lines bytes.Buffer
+	for i, item := range eventList.Items {
+		if err := json.Compact(&lines, item); err != nil {
+			return 0, fmt.Errorf("%w: item %d is not a valid JSON: %w", ErrInvalidEventList, i, err)
+		}
+		lines.WriteByte('\n')
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.rotateIfNeeded(); err != nil {
+		return 0, err
+	}
+	if err := s.appendMember(lines.Bytes(), s.now()); err != nil {
+		return 0, err
+	}
+	return len(eventList.Items), nil
+}
+
+// List returns the archives possibly containing events with timestamps in the time range. Archives are sorted by their creation time.
+// Events can be received ArchiveTimeMargin later than their timestamps at most.
+func (s *ArchiveStore) List(startTime time.Time, endTime time.Time) ([]*Archive, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	archives, err := s.listArchives()
+	if err != nil {
+		return nil, err
+	}
+	result := []*Archive{}
+	for _, archive := range archives {
+		if archive.CreatedAt.After(endTime.Add(ArchiveTimeMargin)) || archive.UpdatedAt.Before(startTime) {
+			continue
+		}
+		result = append(result, archive)
+	}
+	return result, nil
+}
+
+// rotateIfNeeded starts a new archive when no archive received events since the store was created or the current archive exceeds the limits.
+func (s *ArchiveStore) rotateIfNeeded() error {
+	now := s.now().UTC()
+	if s.current != nil {
+		sizeExceeded := s.options.MaxArchiveSizeInBytes > 0 && s.current.SizeInBytes >= s.options.MaxArchiveSizeInBytes
+		ageExceeded := s.options.MaxArchiveAge > 0 && now.Sub(s.current.CreatedAt) >= s.options.MaxArchiveAge
+		if !sizeExceeded && !ageExceeded {
+			return nil
+		}
+	}
+
+	archives, err := s.listArchives()
+	if err != nil {
+		return err
+	}
+	// Archive names must be unique and sorted in the order of their creation.
+	if len(archives) > 0 {
+		if last := archives[len(archives)-1].CreatedAt; !now.After(last) {
+			now = last.Add(time.Nanosecond)
+		}
+	}
+	name := archiveNamePrefix + now.Format(archiveNameTimeFormat) + archiveNameSuffix
+	s.current = &Archive{
+		Name:      name,
+		CreatedAt: now,
+		path:      filepath.Join(s.folder, name),
+	}
+
+	if s.options.MaxArchiveCount > 0 {
+		// The new archive is not written yet. Keep MaxArchiveCount-1 archives to have MaxArchiveCount archives after writing it.
+		for len(archives) >= s.options.MaxArchiveCount {
+			if err := os.Remove(archives[0].path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to delete the audit webhook archive %s: %w", archives[0].Name, err)
+			}
+			archives = archives[1:]
+		}
+	}
+	return nil
+}
+
+// appendMember writes the lines as a gzip member at the end of the current archive.
+// The modification time of the archive is set to the receive time to find the archive by the time after restarting KHI.
+func (s *ArchiveStore) appendMember(lines []byte, receivedAt time.Time) error {
+	file, err := os.OpenFile(s.current.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open the audit webhook archive %s: %w", s.current.Name, err)
+	}
+	defer file.Close()
+	writer := gzip.NewWriter(file)
+	if _, err := writer.Write(lines); err != nil {
+		return fmt.Errorf("failed to write the audit webhook archive %s: %w", s.current.Name, err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to write the audit webhook archive %s: %w", s.current.Name, err)
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to get the size of the audit webhook archive %s: %w", s.current.Name, err)
+	}
+	if err := os.Chtimes(s.current.path, receivedAt, receivedAt); err != nil {
+		return fmt.Errorf("failed to set the modification time of the audit webhook archive %s: %w", s.current.Name, err)
+	}
+	s.current.SizeInBytes = stat.Size()
+	return nil
+}
+
+// listArchives returns all the archives in the folder sorted by their creation time. The caller must hold the lock.
+func (s *ArchiveStore) listArchives() ([]*Archive, error) {
+	entries, err := os.ReadDir(s.folder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit webhook archives in %s: %w", s.folder, err)
+	}
+	archives := []*Archive{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, archiveNamePrefix) || !strings.HasSuffix(name, archiveNameSuffix) {
+			continue
+		}
+		createdAt, err := time.Parse(archiveNameTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, archiveNamePrefix), archiveNameSuffix))
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the size of the audit webhook archive %s: %w", name, err)
+		}
+		archives = append(archives, &Archive{
+			Name:        name,
+			CreatedAt:   createdAt,
+			UpdatedAt:   info.ModTime(),
+			SizeInBytes: info.Size(),
+			path:        filepath.Join(s.folder, name),
+		})
+	}
+	slices.SortFunc(archives, func(a, b *Archive) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return archives, nil
+}
@@ -0,0 +1,315 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditwebhook
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func eventList(auditIDs ...string) string {
+	items := []string{}
+	for _, auditID := range auditIDs {
+		items = append(items, fmt.Sprintf(`{"kind": "Event", "auditID": %q, "stage": "ResponseComplete"}`, auditID))
+	}
+	return fmt.Sprintf(`{"kind":"EventList","apiVersion":"audit.k8s.io/v1","items":[%s]}`, strings.Join(items, ","))
+}
+
+func readArchive(t *testing.T, archive *Archive) string {
+	t.Helper()
+	reader, err := archive.Open()
+	if err != nil {
+		t.Fatalf("Open() returned an unexpected error: %v", err)
+	}
+	defer reader.Close()
+	decompressed, err := gzip.NewReader(reader)
+	if err != nil {
+		t.Fatalf("failed to decompress the archive: %v", err)
+	}
+	content, err := io.ReadAll(decompressed)
+	if err != nil {
+		t.Fatalf("failed to read the archive: %v", err)
+	}
+	return string(content)
+}
+
+// fakeClock returns the time set by tests.
+type fakeClock struct {
+	current time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.current
+}
+
+func newTestStore(t *testing.T, options ArchiveStoreOptions, clock *fakeClock) *ArchiveStore {
+	t.Helper()
+	store, err := NewArchiveStore(t.TempDir(), options)
+	if err != nil {
+		t.Fatalf("NewArchiveStore() returned an unexpected error: %v", err)
+	}
+	store.now = clock.now
+	return store
+}
+
+func TestArchiveStore_AppendEventList(t *testing.T) {
+	clock := &fakeClock{current: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store := newTestStore(t, ArchiveStoreOptions{}, clock)
+
+	for _, auditIDs := range [][]string{{"a", "b"}, {}, {"c"}} {
+		count, err := store.AppendEventList(strings.NewReader(eventList(auditIDs...)))
+		if err != nil {
+			t.Fatalf("AppendEventList() returned an unexpected error: %v", err)
+		}
+		if count != len(auditIDs) {
+			t.Errorf("AppendEventList() returned %d, want %d", count, len(auditIDs))
+		}
+		clock.current = clock.current.Add(time.Minute)
+	}
+
+	archives, err := store.List(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("List() returned an unexpected error: %v", err)
+	}
+	if len(archives) != 1 {
+		t.Fatalf("List() returned %d archives, want 1", len(archives))
+	}
+	if diff := cmp.Diff("audit-20260101T000000.000000000Z.jsonl.gz", archives[0].Name); diff != "" {
+		t.Errorf("archive name mismatch (-want +got):\n%s", diff)
+	}
+	if !archives[0].UpdatedAt.Equal(time.Date(2026, 1, 1, 0, 2, 0, 0, time.UTC)) {
+		t.Errorf("UpdatedAt = %v, want the time of the last request", archives[0].UpdatedAt)
+	}
+	want := `{"kind":"Event","auditID":"a","stage":"ResponseComplete"}
+{"kind":"Event","auditID":"b","stage":"ResponseComplete"}
+{"kind":"Event","auditID":"c","stage":"ResponseComplete"}
+`
+	if diff := cmp.Diff(want, readArchive(t, archives[0])); diff != "" {
+		t.Errorf("archive content mismatch (-want +got):\n%s", diff)
+	}
+
+	// Events received after listing must not be read from the listed archive.
+	if _, err := store.AppendEventList(strings.NewReader(eventList("d"))); err != nil {
+		t.Fatalf("AppendEventList() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, readArchive(t, archives[0])); diff != "" {
+		t.Errorf("archive content mismatch after appending events (-want +got):\n%s", diff)
+	}
+}
+
+func TestArchiveStore_AppendEventList_Invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{name: "not a JSON", body: "foo"},
+		{name: "wrong kind", body: `{"kind":"Event","apiVersion":"audit.k8s.io/v1"}`},
+		{name: "wrong apiVersion", body: `{"kind":"EventList","apiVersion":"audit.k8s.io/v1beta1","items":[]}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newTestStore(t, ArchiveStoreOptions{}, &fakeClock{current: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)})
+			_, err := store.AppendEventList(strings.NewReader(tc.body))
+			if !errors.Is(err, ErrInvalidEventList) {
+				t.Errorf("AppendEventList() returned %v, want ErrInvalidEventList", err)
+			}
+			archives, err := store.List(time.Time{}, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+			if err != nil {
+				t.Fatalf("List() returned an unexpected error: %v", err)
+			}
+			if len(archives) != 0 {
+				t.Errorf("List() returned %d archives, want no archive", len(archives))
+			}
+		})
+	}
+}
+
+func TestArchiveStore_Rotation(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options ArchiveStoreOptions
+		// interval is the time between requests.
+		interval  time.Duration
+		wantNames []string
+	}{
+		{
+			name:     "no limit",
+			options:  ArchiveStoreOptions{},
+			interval: time.Hour,
+			wantNames: []string{
+				"audit-20260101T000000.000000000Z.jsonl.gz",
+			},
+		},
+		{
+			name:     "rotated by age",
+			options:  ArchiveStoreOptions{MaxArchiveAge: 90 * time.Minute},
+			interval: time.Hour,
+			wantNames: []string{
+				"audit-20260101T000000.000000000Z.jsonl.gz",
+				"audit-20260101T020000.000000000Z.jsonl.gz",
+			},
+		},
+		{
+			name:     "rotated by size",
+			options:  ArchiveStoreOptions{MaxArchiveSizeInBytes: 1},
+			interval: time.Second,
+			wantNames: []string{
+				"audit-20260101T000000.000000000Z.jsonl.gz",
+				"audit-20260101T000001.000000000Z.jsonl.gz",
+				"audit-20260101T000002.000000000Z.jsonl.gz",
+				"audit-20260101T000003.000000000Z.jsonl.gz",
+			},
+		},
+		{
+			name:     "old archives are deleted",
+			options:  ArchiveStoreOptions{MaxArchiveSizeInBytes: 1, MaxArchiveCount: 2},
+			interval: time.Second,
+			wantNames: []string{
+				"audit-20260101T000002.000000000Z.jsonl.gz",
+				"audit-20260101T000003.000000000Z.jsonl.gz",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &fakeClock{current: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+			store := newTestStore(t, tc.options, clock)
+			for i := 0; i < 4; i++ {
+				if _, err := store.AppendEventList(strings.NewReader(eventList(fmt.Sprintf("id-%d", i)))); err != nil {
+					t.Fatalf("AppendEventList() returned an unexpected error: %v", err)
+				}
+				clock.current = clock.current.Add(tc.interval)
+			}
+			archives, err := store.List(time.Time{}, clock.current)
+			if err != nil {
+				t.Fatalf("List() returned an unexpected error: %v", err)
+			}
+			names := []string{}
+			for _, archive := range archives {
+				names = append(names, archive.Name)
+			}
+			if diff := cmp.Diff(tc.wantNames, names); diff != "" {
+				t.Errorf("archive names mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestArchiveStore_List(t *testing.T) {
+	clock := &fakeClock{current: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store := newTestStore(t, ArchiveStoreOptions{MaxArchiveAge: time.Hour}, clock)
+	// Archives receive events in [00:00, 00:30], [01:00, 01:30] and [02:00, 02:30].
+	for i := 0; i < 3; i++ {
+		for _, offset := range []time.Duration{0, 30 * time.Minute} {
+			clock.current = time.Date(2026, 1, 1, i, 0, 0, 0, time.UTC).Add(offset)
+			if _, err := store.AppendEventList(strings.NewReader(eventList(fmt.Sprintf("id-%d-%s", i, offset)))); err != nil {
+				t.Fatalf("AppendEventList() returned an unexpected error: %v", err)
+			}
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		startTime time.Time
+		endTime   time.Time
+		wantNames []string
+	}{
+		{
+			name:      "all",
+			startTime: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			endTime:   time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC),
+			wantNames: []string{
+				"audit-20260101T000000.000000000Z.jsonl.gz",
+				"audit-20260101T010000.000000000Z.jsonl.gz",
+				"audit-20260101T020000.000000000Z.jsonl.gz",
+			},
+		},
+		{
+			name:      "between archives",
+			startTime: time.Date(2026, 1, 1, 0, 40, 0, 0, time.UTC),
+			endTime:   time.Date(2026, 1, 1, 0, 50, 0, 0, time.UTC),
+			wantNames: []string{},
+		},
+		{
+			name:      "events received later than their timestamps",
+			startTime: time.Date(2026, 1, 1, 0, 40, 0, 0, time.UTC),
+			endTime:   time.Date(2026, 1, 1, 0, 58, 0, 0, time.UTC),
+			wantNames: []string{
+				"audit-20260101T010000.000000000Z.jsonl.gz",
+			},
+		},
+		{
+			name:      "overlapping the end of an archive",
+			startTime: time.Date(2026, 1, 1, 1, 30, 0, 0, time.UTC),
+			endTime:   time.Date(2026, 1, 1, 1, 40, 0, 0, time.UTC),
+			wantNames: []string{
+				"audit-20260101T010000.000000000Z.jsonl.gz",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			archives, err := store.List(tc.startTime, tc.endTime)
+			if err != nil {
+				t.Fatalf("List() returned an unexpected error: %v", err)
+			}
+			names := []string{}
+			for _, archive := range archives {
+				names = append(names, archive.Name)
+			}
+			if diff := cmp.Diff(tc.wantNames, names); diff != "" {
+				t.Errorf("archive names mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewArchiveStore_KeepsArchivesWrittenBefore(t *testing.T) {
+	folder := t.TempDir()
+	clock := &fakeClock{current: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	for _, auditID := range []string{"a", "b"} {
+		store, err := NewArchiveStore(folder, ArchiveStoreOptions{})
+		if err != nil {
+			t.Fatalf("NewArchiveStore() returned an unexpected error: %v", err)
+		}
+		store.now = clock.now
+		if _, err := store.AppendEventList(strings.NewReader(eventList(auditID))); err != nil {
+			t.Fatalf("AppendEventList() returned an unexpected error: %v", err)
+		}
+		clock.current = clock.current.Add(time.Minute)
+	}
+
+	store, err := NewArchiveStore(folder, ArchiveStoreOptions{})
+	if err != nil {
+		t.Fatalf("NewArchiveStore() returned an unexpected error: %v", err)
+	}
+	archives, err := store.List(time.Date(2026, 1, 1, 0, 1, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 2, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("List() returned an unexpected error: %v", err)
+	}
+	if len(archives) != 1 {
+		t.Fatalf("List() returned %d archives, want 1", len(archives))
+	}
+	if diff := cmp.Diff(`{"kind":"Event","auditID":"b","stage":"ResponseComplete"}`+"\n", readArchive(t, archives[0])); diff != "" {
+		t.Errorf("archive content mismatch (-want +got):\n%s", diff)
+	}
+}
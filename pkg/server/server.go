@@ -15,6 +15,8 @@
 package server
 
 import (
+	"bytes"
+	"crypto/subtle"
 	"embed"
 	"errors"
 	"fmt"
@@ -25,12 +27,15 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/GoogleCloudPlatform/khi/pkg/common/filter"
 	"github.com/GoogleCloudPlatform/khi/pkg/common/typedmap"
 	coreinspection "github.com/GoogleCloudPlatform/khi/pkg/core/inspection"
 	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/khifile"
 	"github.com/GoogleCloudPlatform/khi/pkg/parameters"
+	"github.com/GoogleCloudPlatform/khi/pkg/server/auditwebhook"
 	"github.com/GoogleCloudPlatform/khi/pkg/server/config"
 	"github.com/GoogleCloudPlatform/khi/pkg/server/popup"
 	"github.com/GoogleCloudPlatform/khi/pkg/server/upload"
@@ -51,6 +56,12 @@ type ServerConfig struct {
 	ResourceMonitor  ResourceMonitor
 	ServerBasePath   string
 	UploadFileStore  *upload.UploadFileStore
+	// AuditWebhookArchiveStore receives audit events sent to the audit webhook endpoint. The endpoint is not served when this is nil.
+	AuditWebhookArchiveStore *auditwebhook.ArchiveStore
+	// AuditWebhookToken is the bearer token required on requests to the audit webhook endpoint.
+	AuditWebhookToken string
+	// AuditWebhookMaxRequestSizeInBytes is the maximum size of a request body sent to the audit webhook endpoint. 0 means no limit.
+	AuditWebhookMaxRequestSizeInBytes int64
 }
 
 func redirectMiddleware(exactPath string, redirectTo string) gin.HandlerFunc {
@@ -155,6 +166,65 @@ func CreateKHIServer(engine *gin.Engine, inspectionServer *coreinspection.Inspec
 			}
 			ctx.JSON(http.StatusAccepted, &PostInspectionResponse{InspectionID: inspectionId})
 		})
+		// POST /api/v3/inspection/import
+		// Registers the uploaded .khi file as a finished inspection.
+		router.POST("/api/v3/inspection/import", func(ctx *gin.Context) {
+			file, err := ctx.FormFile("file")
+			if err != nil {
+				ctx.String(http.StatusBadRequest, err.Error())
+				return
+			}
+			if parameters.Server.MaxUploadFileSizeInBytes != nil && *parameters.Server.MaxUploadFileSizeInBytes < int(file.Size) {
+				ctx.String(http.StatusBadRequest, fmt.Sprintf("file size exceeds the limit (%d bytes)", *parameters.Server.MaxUploadFileSizeInBytes))
+				return
+			}
+			multipart, err := file.Open()
+			if err != nil {
+				ctx.String(http.StatusBadRequest, err.Error())
+				return
+			}
+			defer multipart.Close()
+			inspectionID, err := inspectionServer.ImportInspection(multipart)
+			if errors.Is(err, khifile.ErrInvalidFormat) {
+				ctx.String(http.StatusBadRequest, err.Error())
+				return
+			}
+			if err != nil {
+				ctx.String(http.StatusInternalServerError, err.Error())
+				return
+			}
+			ctx.JSON(http.StatusOK, &PostInspectionResponse{InspectionID: inspectionID})
+		})
+		if serverConfig.AuditWebhookArchiveStore != nil {
+			// POST /api/v3/audit-webhook
+			// Receives audit.k8s.io/v1 EventList from kube-apiserver configured with --audit-webhook-config-file.
+			router.POST("/api/v3/audit-webhook", func(ctx *gin.Context) {
+				if !isValidBearerToken(ctx.GetHeader("Authorization"), serverConfig.AuditWebhookToken) {
+					ctx.String(http.StatusUnauthorized, "invalid or missing bearer token")
+					return
+				}
+				body := ctx.Request.Body
+				if serverConfig.AuditWebhookMaxRequestSizeInBytes > 0 {
+					body = http.MaxBytesReader(ctx.Writer, body, serverConfig.AuditWebhookMaxRequestSizeInBytes)
+				}
+				count, err := serverConfig.AuditWebhookArchiveStore.AppendEventList(body)
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					ctx.String(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds the limit (%d bytes)", maxBytesErr.Limit))
+					return
+				}
+				if errors.Is(err, auditwebhook.ErrInvalidEventList) {
+					ctx.String(http.StatusBadRequest, err.Error())
+					return
+				}
+				if err != nil {
+					slog.Error(fmt.Sprintf("failed to store audit events received from the audit webhook\n%v", err))
+					ctx.String(http.StatusInternalServerError, err.Error())
+					return
+				}
+				ctx.String(http.StatusOK, fmt.Sprintf("received %d events", count))
+			})
+		}
 		// PATCH /api/v3/inspection/<inspection-id>
 		router.PATCH("/api/v3/inspection/:inspectionID", func(ctx *gin.Context) {
 			inspectionID := ctx.Param("inspectionID")
@@ -300,6 +370,22 @@ func CreateKHIServer(engine *gin.Engine, inspectionServer *coreinspection.Inspec
 			ctx.String(http.StatusOK, "ok")
 		})
 
+		// DELETE /api/v3/inspection/<inspection-id>
+		// Cancels the inspection if it's running and deletes it with its files.
+		router.DELETE("/api/v3/inspection/:inspectionID", func(ctx *gin.Context) {
+			inspectionID := ctx.Param("inspectionID")
+			err := inspectionServer.DeleteInspection(inspectionID)
+			if errors.Is(err, coreinspection.ErrInspectionNotFound) {
+				ctx.String(http.StatusNotFound, fmt.Sprintf("inspecton %s was not found", inspectionID))
+				return
+			}
+			if err != nil {
+				ctx.String(http.StatusInternalServerError, err.Error())
+				return
+			}
+			ctx.String(http.StatusOK, "ok")
+		})
+
 		router.GET("/api/v3/inspection/:inspectionID/metadata", func(ctx *gin.Context) {
 			inspectionID := ctx.Param("inspectionID")
 			currentTask := inspectionServer.GetInspection(inspectionID)
@@ -364,6 +450,77 @@ func CreateKHIServer(engine *gin.Engine, inspectionServer *coreinspection.Inspec
 			ctx.DataFromReader(http.StatusOK, min(maxSize, int64(fileSize)-rangeStart), "application/octet-stream", inspectionDataReader, map[string]string{})
 		})
 
+		// GET /api/v3/inspection/<inspection-id>/snapshot?time=<RFC3339 time>
+		// Returns manifests of every resource existing at the time as a multi-document YAML.
+		router.GET("/api/v3/inspection/:inspectionID/snapshot", func(ctx *gin.Context) {
+			inspectionID := ctx.Param("inspectionID")
+			currentTask := inspectionServer.GetInspection(inspectionID)
+			if currentTask == nil {
+				ctx.String(http.StatusNotFound, fmt.Sprintf("inspecton %s was not found", inspectionID))
+				return
+			}
+			at, err := time.Parse(time.RFC3339, ctx.Query("time"))
+			if err != nil {
+				ctx.String(http.StatusBadRequest, fmt.Sprintf("time must be a RFC3339 time\n%v", err))
+				return
+			}
+			result, err := currentTask.Result()
+			if err != nil {
+				ctx.String(http.StatusBadRequest, err.Error())
+				return
+			}
+			reader, err := newResultReader(result)
+			if err != nil {
+				ctx.String(http.StatusInternalServerError, err.Error())
+				return
+			}
+			entries, err := reader.Snapshot(at)
+			if err != nil {
+				ctx.String(http.StatusInternalServerError, err.Error())
+				return
+			}
+			var buf bytes.Buffer
+			if err := khifile.WriteSnapshotYAML(&buf, entries); err != nil {
+				ctx.String(http.StatusInternalServerError, err.Error())
+				return
+			}
+			ctx.Data(http.StatusOK, "application/yaml", buf.Bytes())
+		})
+
+		// GET /api/v3/inspection/<inspection-id>/diff?target=<inspection-id>
+		// Compares the inspection as the base with the target inspection.
+		router.GET("/api/v3/inspection/:inspectionID/diff", func(ctx *gin.Context) {
+			tasks := []*coreinspection.InspectionTaskRunner{}
+			for _, inspectionID := range []string{ctx.Param("inspectionID"), ctx.Query("target")} {
+				currentTask := inspectionServer.GetInspection(inspectionID)
+				if currentTask == nil {
+					ctx.String(http.StatusNotFound, fmt.Sprintf("inspecton %s was not found", inspectionID))
+					return
+				}
+				tasks = append(tasks, currentTask)
+			}
+			readers := []*khifile.Reader{}
+			for _, currentTask := range tasks {
+				result, err := currentTask.Result()
+				if err != nil {
+					ctx.String(http.StatusBadRequest, err.Error())
+					return
+				}
+				reader, err := newResultReader(result)
+				if err != nil {
+					ctx.String(http.StatusInternalServerError, err.Error())
+					return
+				}
+				readers = append(readers, reader)
+			}
+			comparison, err := khifile.Compare(readers[0], readers[1])
+			if err != nil {
+				ctx.String(http.StatusInternalServerError, err.Error())
+				return
+			}
+			ctx.JSON(http.StatusOK, comparison)
+		})
+
 		router.GET("/api/v3/popup", func(ctx *gin.Context) {
 			currentPopup := popup.Instance.GetCurrentPopup()
 			if currentPopup == nil {
@@ -467,3 +624,21 @@ func CreateKHIServer(engine *gin.Engine, inspectionServer *coreinspection.Inspec
 	}
 	return engine
 }
+
+// newResultReader returns a khifile.Reader reading the .khi file of the given inspection result.
+func newResultReader(result *coreinspection.InspectionRunResult) (*khifile.Reader, error) {
+	fileSize, err := result.ResultStore.GetInspectionResultSizeInBytes()
+	if err != nil {
+		return nil, err
+	}
+	return khifile.NewReader(khifile.NewRangeReaderAt(result.ResultStore), int64(fileSize))
+}
+
+// isValidBearerToken returns true when the Authorization header has the expected bearer token. It always returns false when the expected token is empty.
+func isValidBearerToken(authorizationHeader string, expectedToken string) bool {
+	token, found := strings.CutPrefix(authorizationHeader, "Bearer ")
+	if !found || expectedToken == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(expectedToken)) == 1
+}
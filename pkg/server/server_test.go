@@ -16,7 +16,9 @@ package server
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -37,6 +39,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/server/auditwebhook"
 	"github.com/GoogleCloudPlatform/khi/pkg/server/config"
 	"github.com/GoogleCloudPlatform/khi/pkg/server/popup"
 	"github.com/GoogleCloudPlatform/khi/pkg/server/upload"
@@ -976,3 +979,303 @@ func TestKHIDirectFileUpload(t *testing.T) {
 		})
 	}
 }
+
+func TestKHIServerResultQueries(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	inspectionServer, err := createTestInspectionServer()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	inspectionID, err := inspectionServer.CreateInspection("foo")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	testCases := []struct {
+		name        string
+		requestPath string
+		wantCode    int
+	}{
+		{
+			name:        "not existing inspection",
+			requestPath: "/api/v3/inspection/not-existing/snapshot?time=2024-01-01T00:00:00Z",
+			wantCode:    404,
+		},
+		{
+			name:        "without time",
+			requestPath: fmt.Sprintf("/api/v3/inspection/%s/snapshot", inspectionID),
+			wantCode:    400,
+		},
+		{
+			name:        "inspection without result",
+			requestPath: fmt.Sprintf("/api/v3/inspection/%s/snapshot?time=2024-01-01T00:00:00Z", inspectionID),
+			wantCode:    400,
+		},
+		{
+			name:        "diff with not existing target",
+			requestPath: fmt.Sprintf("/api/v3/inspection/%s/diff?target=not-existing", inspectionID),
+			wantCode:    404,
+		},
+		{
+			name:        "diff of inspections without result",
+			requestPath: fmt.Sprintf("/api/v3/inspection/%s/diff?target=%s", inspectionID, inspectionID),
+			wantCode:    400,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorer := httptest.NewRecorder()
+			config := ServerConfig{
+				StaticFolderPath: "dist",
+				ResourceMonitor:  &ResourceMonitorMock{UsedMemory: 1000},
+			}
+			engine := gin.New()
+			engine = CreateKHIServer(engine, inspectionServer, &config)
+			req, _ := http.NewRequest("GET", tc.requestPath, bytes.NewReader([]byte{}))
+			engine.ServeHTTP(recorer, req)
+			if recorer.Code != tc.wantCode {
+				t.Errorf("got response code %d, want %d", recorer.Code, tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestKHIServerDeleteInspection(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	inspectionServer, err := createTestInspectionServer()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	inspectionID, err := inspectionServer.CreateInspection("foo")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	config := ServerConfig{
+		StaticFolderPath: "dist",
+		ResourceMonitor:  &ResourceMonitorMock{UsedMemory: 1000},
+	}
+	engine := CreateKHIServer(gin.New(), inspectionServer, &config)
+	testCases := []struct {
+		name        string
+		requestPath string
+		wantCode    int
+	}{
+		{
+			name:        "existing inspection",
+			requestPath: fmt.Sprintf("/api/v3/inspection/%s", inspectionID),
+			wantCode:    200,
+		},
+		{
+			name:        "already deleted inspection",
+			requestPath: fmt.Sprintf("/api/v3/inspection/%s", inspectionID),
+			wantCode:    404,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorer := httptest.NewRecorder()
+			req, _ := http.NewRequest("DELETE", tc.requestPath, bytes.NewReader([]byte{}))
+			engine.ServeHTTP(recorer, req)
+			if recorer.Code != tc.wantCode {
+				t.Errorf("got response code %d, want %d", recorer.Code, tc.wantCode)
+			}
+		})
+	}
+	if inspectionServer.GetInspection(inspectionID) != nil {
+		t.Errorf("inspection %s is still registered after the deletion", inspectionID)
+	}
+}
+
+func TestKHIServerImportInspection(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	parameters.Server.MaxUploadFileSizeInBytes = nil
+	inspectionServer, err := createTestInspectionServer()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	config := ServerConfig{
+		StaticFolderPath: "dist",
+		ResourceMonitor:  &ResourceMonitorMock{UsedMemory: 1000},
+	}
+	engine := CreateKHIServer(gin.New(), inspectionServer, &config)
+	jsonBlock := `{"metadata":{"header":{"inspectionType":"foo","inspectionName":"imported"}}}`
+	validFile := append(binary.LittleEndian.AppendUint32([]byte("KHI"), uint32(len(jsonBlock))), jsonBlock...)
+	testCases := []struct {
+		name     string
+		content  []byte
+		wantCode int
+	}{
+		{
+			name:     "valid khi file",
+			content:  validFile,
+			wantCode: 200,
+		},
+		{
+			name:     "invalid khi file",
+			content:  []byte("foo"),
+			wantCode: 400,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer := multipart.NewWriter(&buf)
+			fileWriter, err := writer.CreateFormFile("file", "test.khi")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := fileWriter.Write(tc.content); err != nil {
+				t.Fatal(err)
+			}
+			writer.Close()
+
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v3/inspection/import", &buf)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			engine.ServeHTTP(recorder, req)
+			if recorder.Code != tc.wantCode {
+				t.Fatalf("got response code %d, want %d", recorder.Code, tc.wantCode)
+			}
+			if tc.wantCode != 200 {
+				return
+			}
+
+			var response PostInspectionResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to parse the response: %v", err)
+			}
+			defer inspectionServer.DeleteInspection(response.InspectionID)
+			dataRecorder := httptest.NewRecorder()
+			dataReq, _ := http.NewRequest("GET", fmt.Sprintf("/api/v3/inspection/%s/data", response.InspectionID), nil)
+			engine.ServeHTTP(dataRecorder, dataReq)
+			if dataRecorder.Code != 200 {
+				t.Fatalf("got response code %d from the data endpoint, want 200", dataRecorder.Code)
+			}
+			if diff := cmp.Diff(tc.content, dataRecorder.Body.Bytes()); diff != "" {
+				t.Errorf("served data mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestKHIServerAuditWebhook(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	inspectionServer, err := createTestInspectionServer()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	store, err := auditwebhook.NewArchiveStore(t.TempDir(), auditwebhook.ArchiveStoreOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	testCases := []struct {
+		name          string
+		config        ServerConfig
+		authorization string
+		body          string
+		wantCode      int
+	}{
+		{
+			name:          "valid event list",
+			config:        ServerConfig{AuditWebhookArchiveStore: store, AuditWebhookToken: "test-token"},
+			authorization: "Bearer test-token",
+			body:          `{"kind":"EventList","apiVersion":"audit.k8s.io/v1","items":[{"auditID":"foo","stage":"ResponseComplete"}]}`,
+			wantCode:      200,
+		},
+		{
+			name:          "event list with an unsupported version",
+			config:        ServerConfig{AuditWebhookArchiveStore: store, AuditWebhookToken: "test-token"},
+			authorization: "Bearer test-token",
+			body:          `{"kind":"EventList","apiVersion":"audit.k8s.io/v1beta1","items":[{"auditID":"bar","stage":"ResponseComplete"}]}`,
+			wantCode:      400,
+		},
+		{
+			name:          "not an event list",
+			config:        ServerConfig{AuditWebhookArchiveStore: store, AuditWebhookToken: "test-token"},
+			authorization: "Bearer test-token",
+			body:          `foo`,
+			wantCode:      400,
+		},
+		{
+			name:     "missing bearer token",
+			config:   ServerConfig{AuditWebhookArchiveStore: store, AuditWebhookToken: "test-token"},
+			body:     `{"kind":"EventList","apiVersion":"audit.k8s.io/v1","items":[{"auditID":"quux","stage":"ResponseComplete"}]}`,
+			wantCode: 401,
+		},
+		{
+			name:          "wrong bearer token",
+			config:        ServerConfig{AuditWebhookArchiveStore: store, AuditWebhookToken: "test-token"},
+			authorization: "Bearer wrong-token",
+			body:          `{"kind":"EventList","apiVersion":"audit.k8s.io/v1","items":[{"auditID":"quux","stage":"ResponseComplete"}]}`,
+			wantCode:      401,
+		},
+		{
+			name:          "no token is configured",
+			config:        ServerConfig{AuditWebhookArchiveStore: store},
+			authorization: "Bearer ",
+			body:          `{"kind":"EventList","apiVersion":"audit.k8s.io/v1","items":[{"auditID":"quux","stage":"ResponseComplete"}]}`,
+			wantCode:      401,
+		},
+		{
+			name:          "request body exceeding the limit",
+			config:        ServerConfig{AuditWebhookArchiveStore: store, AuditWebhookToken: "test-token", AuditWebhookMaxRequestSizeInBytes: 16},
+			authorization: "Bearer test-token",
+			body:          `{"kind":"EventList","apiVersion":"audit.k8s.io/v1","items":[{"auditID":"quux","stage":"ResponseComplete"}]}`,
+			wantCode:      413,
+		},
+		{
+			name:     "audit webhook is not enabled",
+			config:   ServerConfig{},
+			body:     `{"kind":"EventList","apiVersion":"audit.k8s.io/v1","items":[{"auditID":"baz","stage":"ResponseComplete"}]}`,
+			wantCode: 404,
+		},
+		{
+			name:          "viewer mode",
+			config:        ServerConfig{ViewerMode: true, AuditWebhookArchiveStore: store, AuditWebhookToken: "test-token"},
+			authorization: "Bearer test-token",
+			body:          `{"kind":"EventList","apiVersion":"audit.k8s.io/v1","items":[{"auditID":"qux","stage":"ResponseComplete"}]}`,
+			wantCode:      404,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := tc.config
+			config.StaticFolderPath = "dist"
+			config.ResourceMonitor = &ResourceMonitorMock{UsedMemory: 1000}
+			engine := CreateKHIServer(gin.New(), inspectionServer, &config)
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v3/audit-webhook", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			engine.ServeHTTP(recorder, req)
+			if recorder.Code != tc.wantCode {
+				t.Errorf("got response code %d, want %d", recorder.Code, tc.wantCode)
+			}
+		})
+	}
+
+	archives, err := store.List(time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if len(archives) != 1 {
+		t.Fatalf("got %d archives, want 1", len(archives))
+	}
+	reader, err := archives[0].Open()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	defer reader.Close()
+	decompressed, err := gzip.NewReader(reader)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	content, err := io.ReadAll(decompressed)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if diff := cmp.Diff(`{"auditID":"foo","stage":"ResponseComplete"}`+"\n", string(content)); diff != "" {
+		t.Errorf("archived events mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -19,7 +19,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"time"
+	"unicode/utf8"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/compression"
 )
 
 // UploadFileVerifier verifies uploaded files (e.g., file type checks).
@@ -44,10 +48,14 @@ func (n *NopWaitUploadFileVerifier) Verify(storeProvider UploadFileStoreProvider
 
 var _ UploadFileVerifier = &NopWaitUploadFileVerifier{}
 
+// JSONLineUploadFileVerifier verifies the uploaded file is in JSONLine format. gzip or zstd compressed files are decompressed before the verification, and each file in tar or zip archives is verified.
 type JSONLineUploadFileVerifier struct {
 	MaxLineSizeInBytes int
 }
 
+// initialLineBufferSizeInBytes is the initial size of the buffer to read a line. The buffer grows up to MaxLineSizeInBytes.
+const initialLineBufferSizeInBytes = 64 * 1024
+
 // Verify implements UploadFileVerifier.
 func (j *JSONLineUploadFileVerifier) Verify(storeProvider UploadFileStoreProvider, token UploadToken) error {
 	reader, err := storeProvider.Read(token)
@@ -56,8 +64,19 @@ func (j *JSONLineUploadFileVerifier) Verify(storeProvider UploadFileStoreProvide
 	}
 	defer reader.Close()
 
+	// Files in tar or zip archives are verified one by one. The file name is empty when the uploaded file is not an archive.
+	return compression.WalkFiles("", reader, compression.SizeOf(reader), func(name string, fileReader io.Reader) error {
+		err := j.verifyFile(fileReader)
+		if err != nil && name != "" {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return err
+	})
+}
+
+func (j *JSONLineUploadFileVerifier) verifyFile(reader io.Reader) error {
 	scanner := bufio.NewScanner(reader)
-	scanner.Buffer(make([]byte, j.MaxLineSizeInBytes), j.MaxLineSizeInBytes)
+	scanner.Buffer(make([]byte, min(initialLineBufferSizeInBytes, j.MaxLineSizeInBytes)), j.MaxLineSizeInBytes)
 	lineNumber := 0
 	for scanner.Scan() {
 		lineNumber++
@@ -88,3 +107,85 @@ func (j *JSONLineUploadFileVerifier) Verify(storeProvider UploadFileStoreProvide
 }
 
 var _ UploadFileVerifier = &JSONLineUploadFileVerifier{}
+
+// TextLineUploadFileVerifier verifies the uploaded file is a text file readable line by line, like klog or journal exports. gzip or zstd compressed files are decompressed before the verification, and each file in tar or zip archives is verified.
+type TextLineUploadFileVerifier struct {
+	MaxLineSizeInBytes int
+}
+
+// Verify implements UploadFileVerifier.
+func (t *TextLineUploadFileVerifier) Verify(storeProvider UploadFileStoreProvider, token UploadToken) error {
+	reader, err := storeProvider.Read(token)
+	if err != nil {
+		return fmt.Errorf("failed to read the uploded file")
+	}
+	defer reader.Close()
+
+	return compression.WalkFiles("", reader, compression.SizeOf(reader), func(name string, fileReader io.Reader) error {
+		err := t.verifyFile(fileReader)
+		if err != nil && name != "" {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return err
+	})
+}
+
+func (t *TextLineUploadFileVerifier) verifyFile(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, min(initialLineBufferSizeInBytes, t.MaxLineSizeInBytes)), t.MaxLineSizeInBytes)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		// Binary files uploaded by mistake are rejected here rather than producing meaningless logs later.
+		if !utf8.Valid(scanner.Bytes()) {
+			return fmt.Errorf("invalid UTF-8 text on line %d", lineNumber)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading file: %w", err)
+	}
+
+	return nil
+}
+
+var _ UploadFileVerifier = &TextLineUploadFileVerifier{}
+
+// JSONUploadFileVerifier verifies the uploaded file is a JSON array or a sequence of JSON values, like the output of `gcloud logging read --format=json` or JSONL files written by log sinks.
+// gzip or zstd compressed files are decompressed before the verification, and each file in tar or zip archives is verified.
+type JSONUploadFileVerifier struct{}
+
+// Verify implements UploadFileVerifier.
+func (j *JSONUploadFileVerifier) Verify(storeProvider UploadFileStoreProvider, token UploadToken) error {
+	reader, err := storeProvider.Read(token)
+	if err != nil {
+		return fmt.Errorf("failed to read the uploded file")
+	}
+	defer reader.Close()
+
+	return compression.WalkFiles("", reader, compression.SizeOf(reader), func(name string, fileReader io.Reader) error {
+		err := j.verifyFile(fileReader)
+		if err != nil && name != "" {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return err
+	})
+}
+
+func (j *JSONUploadFileVerifier) verifyFile(reader io.Reader) error {
+	decoder := json.NewDecoder(reader)
+	valueCount := 0
+	for {
+		var value json.RawMessage
+		err := decoder.Decode(&value)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid JSON after %d values: %w", valueCount, err)
+		}
+		valueCount++
+	}
+}
+
+var _ UploadFileVerifier = &JSONUploadFileVerifier{}
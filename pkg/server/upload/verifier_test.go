@@ -15,10 +15,46 @@
 package upload
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"strings"
 	"testing"
 )
 
+func gzipString(t *testing.T, data string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(data)); err != nil {
+		t.Fatalf("failed to write gzip: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close gzip: %v", err)
+	}
+	return buf.String()
+}
+
+func tarString(t *testing.T, files map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := tar.NewWriter(&buf)
+	for _, name := range []string{"a.log", "b.log"} {
+		content, found := files[name]
+		if !found {
+			continue
+		}
+		if err := writer.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatalf("failed to write a tar header: %v", err)
+		}
+		writer.Write([]byte(content))
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close tar: %v", err)
+	}
+	return buf.String()
+}
+
 func TestJSONLineUploadFileVerifier(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -56,6 +92,34 @@ func TestJSONLineUploadFileVerifier(t *testing.T) {
 {"name": "Hank", "age": 60}
    `, expectedErr: "",
 		},
+		{
+			name: "Gzip Compressed JSON Lines",
+			data: gzipString(t, `{"name": "Ivy", "age": 20}
+{"name": "Jack", "age": 21}`),
+			expectedErr: "",
+		},
+		{
+			name: "Gzip Compressed Invalid JSON",
+			data: gzipString(t, `{"name": "Kate", "age": 22}
+{invalid json}`),
+			expectedErr: "invalid JSON on line 2",
+		},
+		{
+			name: "Tar Archive of JSON Lines",
+			data: tarString(t, map[string]string{
+				"a.log": `{"name": "Liam", "age": 23}`,
+				"b.log": `{"name": "Mia", "age": 24}`,
+			}),
+			expectedErr: "",
+		},
+		{
+			name: "Tar Archive with Invalid JSON",
+			data: tarString(t, map[string]string{
+				"a.log": `{"name": "Noah", "age": 25}`,
+				"b.log": `{invalid json}`,
+			}),
+			expectedErr: "b.log: invalid JSON on line 1",
+		},
 	}
 
 	for _, tt := range tests {
@@ -78,3 +142,137 @@ func TestJSONLineUploadFileVerifier(t *testing.T) {
 		})
 	}
 }
+
+func TestTextLineUploadFileVerifier(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expectedErr string
+	}{
+		{
+			name:        "Valid text",
+			data:        "I0101 00:00:00.000000    1234 kubelet.go:1] foo\nbar\n",
+			expectedErr: "",
+		},
+		{
+			name:        "Empty File",
+			data:        "",
+			expectedErr: "",
+		},
+		{
+			name:        "Binary data",
+			data:        "foo\n\xff\xfe\n",
+			expectedErr: "invalid UTF-8 text on line 2",
+		},
+		{
+			name:        "Gzip Compressed Text",
+			data:        gzipString(t, "foo\nbar"),
+			expectedErr: "",
+		},
+		{
+			name: "Tar Archive with Binary data",
+			data: tarString(t, map[string]string{
+				"a.log": "foo",
+				"b.log": "\xff",
+			}),
+			expectedErr: "b.log: invalid UTF-8 text on line 1",
+		},
+		{
+			name:        "Too long line",
+			data:        strings.Repeat("a", 2*1024*1024),
+			expectedErr: "error reading file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := &TextLineUploadFileVerifier{MaxLineSizeInBytes: 1024 * 1024}
+			provider := &MockLocalUploadFileStoreProvider{Data: tt.data}
+			err := verifier.Verify(provider, &DirectUploadToken{ID: "test"})
+
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			} else {
+				if err == nil {
+					t.Errorf("Expected error, but got nil")
+				} else if !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Errorf("Expected error to contain: %q, but got: %v", tt.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestJSONUploadFileVerifier(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expectedErr string
+	}{
+		{
+			name: "JSON array",
+			data: `[
+  {"insertId": "a"},
+  {"insertId": "b"}
+]`,
+			expectedErr: "",
+		},
+		{
+			name: "JSON Lines",
+			data: `{"insertId": "a"}
+{"insertId": "b"}`,
+			expectedErr: "",
+		},
+		{
+			name:        "Empty File",
+			data:        "",
+			expectedErr: "",
+		},
+		{
+			name: "Invalid JSON",
+			data: `{"insertId": "a"}
+{invalid json}`,
+			expectedErr: "invalid JSON after 1 values",
+		},
+		{
+			name:        "Unclosed array",
+			data:        `[{"insertId": "a"}`,
+			expectedErr: "invalid JSON after 0 values",
+		},
+		{
+			name:        "Gzip Compressed JSON array",
+			data:        gzipString(t, `[{"insertId": "a"}]`),
+			expectedErr: "",
+		},
+		{
+			name: "Tar Archive with Invalid JSON",
+			data: tarString(t, map[string]string{
+				"a.log": `[{"insertId": "a"}]`,
+				"b.log": `{invalid json}`,
+			}),
+			expectedErr: "b.log: invalid JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := &JSONUploadFileVerifier{}
+			provider := &MockLocalUploadFileStoreProvider{Data: tt.data}
+			err := verifier.Verify(provider, &DirectUploadToken{ID: "test"})
+
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			} else {
+				if err == nil {
+					t.Errorf("Expected error, but got nil")
+				} else if !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Errorf("Expected error to contain: %q, but got: %v", tt.expectedErr, err)
+				}
+			}
+		})
+	}
+}
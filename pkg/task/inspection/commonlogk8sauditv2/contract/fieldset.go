@@ -41,10 +41,15 @@ type K8sAuditLogFieldSet struct {
 	StatusMessage string
 	// IsError is true if the response is an error.
 	IsError bool
+	// Severity is the severity of the log decided by the provider. The log is marked as an error when it is enum.SeverityUnknown and IsError is true.
+	Severity enum.Severity
 	// Request is the request body.
 	Request *structured.NodeReader
 	// Response is the response body.
 	Response *structured.NodeReader
+	// MetadataLevel is true if the log is recorded at the Metadata level of the audit policy.
+	// Request and response bodies are never recorded for these logs, so revisions and lifetimes of resources are inferred only from the verbs.
+	MetadataLevel bool
 }
 
 // Kind implements log.FieldSet.
@@ -66,3 +71,22 @@ func (k *K8sAuditLogFieldSet) VerbString() string {
 }
 
 var _ log.FieldSet = (*K8sAuditLogFieldSet)(nil)
+
+// VerbStringToEnum returns the revision verb for the verb recorded in Kubernetes audit logs.
+// Read-only verbs like get, list or watch are mapped to enum.RevisionVerbUnknown.
+func VerbStringToEnum(verbStr string) enum.RevisionVerb {
+	switch verbStr {
+	case "create":
+		return enum.RevisionVerbCreate
+	case "update":
+		return enum.RevisionVerbUpdate
+	case "patch":
+		return enum.RevisionVerbPatch
+	case "delete":
+		return enum.RevisionVerbDelete
+	case "deletecollection":
+		return enum.RevisionVerbDeleteCollection
+	default:
+		return enum.RevisionVerbUnknown
+	}
+}
@@ -40,6 +40,9 @@ var SuccessLogFilterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](TaskI
 // NonSuccessLogFilterTaskID is the task ID for the task to filter non-success logs.
 var NonSuccessLogFilterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](TaskIDPrefix + "non-success-log-filter")
 
+// LongRunningLogFilterTaskID is the task ID for the task to filter logs of long-running requests.
+var LongRunningLogFilterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](TaskIDPrefix + "long-running-log-filter")
+
 // LogSorterTaskID is the task ID for the task to sort logs by time.
 var LogSorterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](TaskIDPrefix + "log-sorter")
 
@@ -49,6 +52,9 @@ var LogSummaryGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskba
 // NonSuccessLogGrouperTaskID is the task ID for the task to group non-success logs.
 var NonSuccessLogGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskbase.LogGroupMap](TaskIDPrefix + "non-success-log-grouper")
 
+// LongRunningLogGrouperTaskID is the task ID for the task to group logs of long-running requests by the operation.
+var LongRunningLogGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskbase.LogGroupMap](TaskIDPrefix + "long-running-log-grouper")
+
 // ChangeTargetGrouperTaskID is the task ID for the task to group logs by the target resource.
 var ChangeTargetGrouperTaskID = taskid.NewDefaultImplementationID[ResourceLogGroupMap](TaskIDPrefix + "change-target-grouper")
 
@@ -67,6 +73,9 @@ var LogSummaryLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[stru
 // NonSuccessLogLogToTimelineMapperTaskID is the task ID for the task to generate history from non-success logs.
 var NonSuccessLogLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "non-success-timeline-mapper")
 
+// LongRunningOperationLogToTimelineMapperTaskID is the task ID for the task to map logs of long-running requests into operation history.
+var LongRunningOperationLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "long-running-operation-timeline-mapper")
+
 // ResourceRevisionLogToTimelineMapperTaskID is the task ID for the task to map logs into resource revision history.
 var ResourceRevisionLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "resource-revision-timeline-mapper")
 
@@ -85,6 +94,27 @@ var ContainerLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struc
 // ConditionLogToTimelineMapperTaskID is the task ID for the task to generate condition history.
 var ConditionLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "condition-timeline-mapper")
 
+// LeaseHolderLogToTimelineMapperTaskID is the task ID for the task to map logs into leader election history from Lease resources.
+var LeaseHolderLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "lease-holder-timeline-mapper")
+
+// HorizontalPodAutoscalerLogToTimelineMapperTaskID is the task ID for the task to map logs into scaling decision history of HorizontalPodAutoscalers.
+var HorizontalPodAutoscalerLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "horizontal-pod-autoscaler-timeline-mapper")
+
+// NodeSchedulabilityLogToTimelineMapperTaskID is the task ID for the task to map logs into node schedulability history and pod evictions.
+var NodeSchedulabilityLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "node-schedulability-timeline-mapper")
+
+// PersistentVolumeClaimLogToTimelineMapperTaskID is the task ID for the task to map logs into PersistentVolumeClaim phase history.
+var PersistentVolumeClaimLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "persistent-volume-claim-timeline-mapper")
+
+// PersistentVolumeLogToTimelineMapperTaskID is the task ID for the task to map logs into PersistentVolume phase and binding history.
+var PersistentVolumeLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "persistent-volume-timeline-mapper")
+
+// VolumeAttachmentLogToTimelineMapperTaskID is the task ID for the task to map logs into VolumeAttachment history.
+var VolumeAttachmentLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "volume-attachment-timeline-mapper")
+
+// JobRunLogToTimelineMapperTaskID is the task ID for the task to map logs into Job run history.
+var JobRunLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "job-run-timeline-mapper")
+
 // NodeNameDiscoveryTaskID is the task ID for extracting node names from audit logs.
 var NodeNameDiscoveryTaskID = taskid.NewDefaultImplementationID[[]string](TaskIDPrefix + "node-name-discovery")
 
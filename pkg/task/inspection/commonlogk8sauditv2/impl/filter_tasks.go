@@ -39,3 +39,24 @@ var NonSuccessLogFilterTask = inspectiontaskbase.NewLogFilterTask(
 		return log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{}).IsError
 	},
 )
+
+// LongRunningLogFilterTask filters out logs not from long-running requests made against a named resource.
+// Requests against collections like watches of a kind are excluded because they have no resource to place the operation under.
+var LongRunningLogFilterTask = inspectiontaskbase.NewLogFilterTask(
+	commonlogk8sauditv2_contract.LongRunningLogFilterTaskID,
+	commonlogk8sauditv2_contract.K8sAuditLogProviderRef,
+	func(ctx context.Context, l *log.Log) bool {
+		fieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+		return fieldSet.LongRunning() && isRequestToNamedResource(fieldSet)
+	},
+)
+
+// isRequestToNamedResource returns true if the request is made against a single named resource.
+// Requests against collections have no name in objectRef and readers default the missing name to `unknown`.
+func isRequestToNamedResource(fieldSet *commonlogk8sauditv2_contract.K8sAuditLogFieldSet) bool {
+	if fieldSet.K8sOperation == nil {
+		return false
+	}
+	name := fieldSet.K8sOperation.Name
+	return name != "" && name != "unknown"
+}
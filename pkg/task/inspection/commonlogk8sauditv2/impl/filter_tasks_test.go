@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	inspectiontaskbasetest "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbasetest"
+	"github.com/GoogleCloudPlatform/khi/pkg/model"
 	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
 	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
 )
@@ -42,6 +43,17 @@ func TestSuccessLogFilterTask(t *testing.T) {
 			},
 			WantIncluded: false,
 		},
+		{
+			Description: "success log of a long-running request",
+			LogFields: []log.FieldSet{
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+					IsFirst: true,
+					IsLast:  false,
+					IsError: false,
+				},
+			},
+			WantIncluded: true,
+		},
 	})
 }
 
@@ -67,3 +79,78 @@ func TestNonSuccessLogFilterTask(t *testing.T) {
 		},
 	})
 }
+
+func TestLongRunningLogFilterTask(t *testing.T) {
+	namedPodOperation := &model.KubernetesObjectOperation{
+		APIVersion: "core/v1",
+		PluralKind: "pods",
+		Namespace:  "default",
+		Name:       "test-pod",
+	}
+	inspectiontaskbasetest.AssertFilterTask(t, LongRunningLogFilterTask, commonlogk8sauditv2_contract.K8sAuditLogProviderRef, []inspectiontaskbasetest.FilterTaskTestCase{
+		{
+			Description: "log of a request completed at once",
+			LogFields: []log.FieldSet{
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+					IsFirst: true,
+					IsLast:  true,
+				},
+			},
+			WantIncluded: false,
+		},
+		{
+			Description: "first log of a long-running request",
+			LogFields: []log.FieldSet{
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+					IsFirst:      true,
+					IsLast:       false,
+					K8sOperation: namedPodOperation,
+				},
+			},
+			WantIncluded: true,
+		},
+		{
+			Description: "last log of a long-running request",
+			LogFields: []log.FieldSet{
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+					IsFirst:      false,
+					IsLast:       true,
+					IsError:      true,
+					K8sOperation: namedPodOperation,
+				},
+			},
+			WantIncluded: true,
+		},
+		{
+			Description: "long-running watch of a collection",
+			LogFields: []log.FieldSet{
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+					IsFirst: true,
+					IsLast:  false,
+					K8sOperation: &model.KubernetesObjectOperation{
+						APIVersion: "core/v1",
+						PluralKind: "pods",
+						Namespace:  "default",
+					},
+				},
+			},
+			WantIncluded: false,
+		},
+		{
+			Description: "long-running watch of a collection with the name defaulted by the reader",
+			LogFields: []log.FieldSet{
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+					IsFirst: true,
+					IsLast:  false,
+					K8sOperation: &model.KubernetesObjectOperation{
+						APIVersion: "core/v1",
+						PluralKind: "pods",
+						Namespace:  "default",
+						Name:       "unknown",
+					},
+				},
+			},
+			WantIncluded: false,
+		},
+	})
+}
@@ -41,6 +41,17 @@ var NonSuccessLogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
 	},
 )
 
+// LongRunningLogGrouperTask groups logs of long-running requests by the operation.
+// Logs at the start and the end of a request are grouped into the same group to be processed in the order.
+var LongRunningLogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
+	commonlogk8sauditv2_contract.LongRunningLogGrouperTaskID,
+	commonlogk8sauditv2_contract.LongRunningLogFilterTaskID.Ref(),
+	func(ctx context.Context, l *log.Log) string {
+		fieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+		return longRunningOperationPath(fieldSet).Path
+	},
+)
+
 // ChangeTargetGrouperTask groups logs by resource that is modified by the operation in the log.
 // This task determines the group, specifically handling the following cases:
 // 1. When multiple resources are modified by the operation, the log entry is duplicated and assigned to each group.
@@ -121,6 +132,10 @@ func (s *targetResourceScanner) scanTargetResource(l *log.Log) []*model.Kubernet
 func (s *targetResourceScanner) scanTargetResourceInternal(l *log.Log) []*model.KubernetesObjectOperation {
 	fieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
 	op := fieldSet.K8sOperation
+	// Long-running read-only requests like watches never modify resources. They are only shown as operations.
+	if fieldSet.LongRunning() && op.Verb == enum.RevisionVerbUnknown {
+		return []*model.KubernetesObjectOperation{}
+	}
 	if fieldSet.K8sOperation.Verb == enum.RevisionVerbDeleteCollection {
 		removedResourceNames := []*model.KubernetesObjectOperation{}
 		foundItemSource := false
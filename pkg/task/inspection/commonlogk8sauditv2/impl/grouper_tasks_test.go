@@ -30,6 +30,7 @@ type testScanTargetResourceInput struct {
 	op           *model.KubernetesObjectOperation
 	requestYAML  string
 	responseYAML string
+	longRunning  bool
 }
 
 func TestScanTargetResource(t *testing.T) {
@@ -297,6 +298,42 @@ kind: Binding`,
 				{"v1#pod#default#pod1#binding"},
 			},
 		},
+		{
+			desc: "long-running read-only request",
+			inputs: []testScanTargetResourceInput{
+				{
+					op: &model.KubernetesObjectOperation{
+						APIVersion: "v1",
+						PluralKind: "pods",
+						Namespace:  "default",
+						Verb:       enum.RevisionVerbUnknown,
+					},
+					longRunning: true,
+				},
+			},
+			want: [][]string{
+				{},
+			},
+		},
+		{
+			desc: "long-running request modifying a resource",
+			inputs: []testScanTargetResourceInput{
+				{
+					op: &model.KubernetesObjectOperation{
+						APIVersion:      "v1",
+						PluralKind:      "pods",
+						Namespace:       "default",
+						Name:            "pod1",
+						SubResourceName: "exec",
+						Verb:            enum.RevisionVerbCreate,
+					},
+					longRunning: true,
+				},
+			},
+			want: [][]string{
+				{"v1#pod#default#pod1#exec"},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -321,6 +358,8 @@ kind: Binding`,
 					K8sOperation: input.op,
 					Request:      request,
 					Response:     response,
+					IsFirst:      true,
+					IsLast:       !input.longRunning,
 				}))
 			}
 			var subresourceDefaultBehaviorOverrides map[string]subresourceDefaultBehavior
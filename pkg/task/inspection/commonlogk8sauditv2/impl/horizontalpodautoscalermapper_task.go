@@ -0,0 +1,305 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+)
+
+// autoscalerMetricSourceFields maps the metric source type of autoscaling/v2 to the field name holding the metric.
+var autoscalerMetricSourceFields = map[string]string{
+	"Resource":          "resource",
+	"ContainerResource": "containerResource",
+	"Pods":              "pods",
+	"Object":            "object",
+	"External":          "external",
+}
+
+type horizontalPodAutoscalerTaskState struct {
+	// lastCurrentReplicas is the last observed value of status.currentReplicas.
+	lastCurrentReplicas int
+	// lastDesiredReplicas is the last observed value of status.desiredReplicas.
+	lastDesiredReplicas int
+	// lastState is the state of the last revision. It's RevisionStateInferred before any revision is written.
+	lastState enum.RevisionState
+}
+
+type horizontalPodAutoscalerLogToTimelineMapperTaskSetting struct {
+}
+
+// Process processes the log to generate the scaling decision history of the HorizontalPodAutoscaler.
+func (h *horizontalPodAutoscalerLogToTimelineMapperTaskSetting) Process(ctx context.Context, passIndex int, event commonlogk8sauditv2_contract.ResourceChangeEvent, cs *history.ChangeSet, builder *history.Builder, state *horizontalPodAutoscalerTaskState) (*horizontalPodAutoscalerTaskState, error) {
+	if state == nil {
+		state = &horizontalPodAutoscalerTaskState{
+			lastState: enum.RevisionStateInferred,
+		}
+	}
+	commonLogFieldSet := log.MustGetFieldSet(event.Log, &log.CommonFieldSet{})
+	k8sFieldSet := log.MustGetFieldSet(event.Log, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+	autoscalerPath := resourcepath.ResourcePath{
+		Path:               event.EventTargetResource.ResourcePathString(),
+		ParentRelationship: enum.RelationshipChild,
+	}
+	replicasPath := resourcepath.HorizontalPodAutoscalerReplicas(autoscalerPath)
+
+	if event.EventType == commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion {
+		if state.lastState != enum.RevisionStateInferred {
+			cs.AddRevision(replicasPath, &history.StagingResourceRevision{
+				Verb:       k8sFieldSet.K8sOperation.Verb,
+				Body:       "",
+				Partial:    false,
+				Requestor:  k8sFieldSet.Principal,
+				ChangeTime: commonLogFieldSet.Timestamp,
+				State:      enum.RevisionStateDeleted,
+			})
+		}
+		state.lastState = enum.RevisionStateInferred
+		return state, nil
+	}
+	if event.EventTargetBodyReader == nil {
+		return state, nil
+	}
+
+	currentReplicas, err := event.EventTargetBodyReader.ReadInt("status.currentReplicas")
+	if err != nil {
+		return state, nil
+	}
+	desiredReplicas, err := event.EventTargetBodyReader.ReadInt("status.desiredReplicas")
+	if err != nil {
+		return state, nil
+	}
+	revisionState := autoscalerRevisionState(event.EventTargetBodyReader, currentReplicas, desiredReplicas)
+	if currentReplicas == state.lastCurrentReplicas && desiredReplicas == state.lastDesiredReplicas && revisionState == state.lastState {
+		return state, nil
+	}
+
+	cs.AddRevision(replicasPath, &history.StagingResourceRevision{
+		Verb:       k8sFieldSet.K8sOperation.Verb,
+		Body:       event.EventTargetBodyYAML,
+		Partial:    false,
+		Requestor:  k8sFieldSet.Principal,
+		ChangeTime: commonLogFieldSet.Timestamp,
+		State:      revisionState,
+	})
+	if scaleTarget, found := autoscalerScaleTarget(event.EventTargetBodyReader, event.EventTargetResource.Namespace); found {
+		cs.AddResourceAlias(replicasPath, resourcepath.ScaleTargetAutoscaler(scaleTarget, event.EventTargetResource.Name))
+	}
+
+	summary := fmt.Sprintf("%d → %d replicas", currentReplicas, desiredReplicas)
+	if metrics := autoscalerMetricsSummary(event.EventTargetBodyReader); len(metrics) > 0 {
+		summary = fmt.Sprintf("%s (%s)", summary, strings.Join(metrics, ", "))
+	}
+	cs.SetLogSummary(summary)
+
+	state.lastCurrentReplicas = currentReplicas
+	state.lastDesiredReplicas = desiredReplicas
+	state.lastState = revisionState
+	return state, nil
+}
+
+// autoscalerRevisionState returns the revision state from the replica counts and the conditions of the HorizontalPodAutoscaler.
+func autoscalerRevisionState(reader *structured.NodeReader, currentReplicas int, desiredReplicas int) enum.RevisionState {
+	if status, found := GetConditionStatus(reader, "AbleToScale"); found && status == "False" {
+		return enum.RevisionStateAutoscalerUnableToScale
+	}
+	if status, found := GetConditionStatus(reader, "ScalingActive"); found && status == "False" {
+		return enum.RevisionStateAutoscalerUnableToScale
+	}
+	switch {
+	case desiredReplicas > currentReplicas:
+		return enum.RevisionStateAutoscalerScalingUp
+	case desiredReplicas < currentReplicas:
+		return enum.RevisionStateAutoscalerScalingDown
+	default:
+		return enum.RevisionStateAutoscalerStable
+	}
+}
+
+// autoscalerScaleTarget returns the resource path of the workload referenced from spec.scaleTargetRef.
+func autoscalerScaleTarget(reader *structured.NodeReader, namespace string) (resourcepath.ResourcePath, bool) {
+	kind, err := reader.ReadString("spec.scaleTargetRef.kind")
+	if err != nil {
+		return resourcepath.ResourcePath{}, false
+	}
+	name, err := reader.ReadString("spec.scaleTargetRef.name")
+	if err != nil {
+		return resourcepath.ResourcePath{}, false
+	}
+	apiVersion, err := reader.ReadString("spec.scaleTargetRef.apiVersion")
+	if err != nil {
+		return resourcepath.ResourcePath{}, false
+	}
+	if !strings.Contains(apiVersion, "/") {
+		apiVersion = "core/" + apiVersion
+	}
+	return resourcepath.NameLayerGeneralItem(apiVersion, strings.ToLower(kind), namespace, name), true
+}
+
+// autoscalerMetricsSummary returns the list of `<metric name> <current>/<target>` for each metric in status.currentMetrics.
+// The CPU utilization fields of autoscaling/v1 are used when the manifest is read in autoscaling/v1.
+func autoscalerMetricsSummary(reader *structured.NodeReader) []string {
+	result := []string{}
+	if current, err := reader.ReadInt("status.currentCPUUtilizationPercentage"); err == nil {
+		metric := fmt.Sprintf("cpu %d%%", current)
+		if target, err := reader.ReadInt("spec.targetCPUUtilizationPercentage"); err == nil {
+			metric = fmt.Sprintf("%s/%d%%", metric, target)
+		}
+		return append(result, metric)
+	}
+
+	targets := map[string]string{}
+	if specMetrics, err := reader.GetReader("spec.metrics"); err == nil {
+		for _, specMetric := range specMetrics.Children() {
+			key, source, found := autoscalerMetricSource(&specMetric)
+			if !found {
+				continue
+			}
+			if target, found := autoscalerMetricValue(source, "target"); found {
+				targets[key] = target
+			}
+		}
+	}
+	currentMetrics, err := reader.GetReader("status.currentMetrics")
+	if err != nil {
+		return result
+	}
+	for _, currentMetric := range currentMetrics.Children() {
+		key, source, found := autoscalerMetricSource(&currentMetric)
+		if !found {
+			continue
+		}
+		current, found := autoscalerMetricValue(source, "current")
+		if !found {
+			continue
+		}
+		_, name, _ := strings.Cut(key, "/")
+		metric := fmt.Sprintf("%s %s", name, current)
+		if target, found := targets[key]; found {
+			metric = fmt.Sprintf("%s/%s", metric, target)
+		}
+		result = append(result, metric)
+	}
+	return result
+}
+
+// autoscalerMetricSource returns the key in `<type>/<name>` format and the reader of the metric source in the given metric spec or status.
+func autoscalerMetricSource(metric *structured.NodeReader) (string, *structured.NodeReader, bool) {
+	metricType, err := metric.ReadString("type")
+	if err != nil {
+		return "", nil, false
+	}
+	field, found := autoscalerMetricSourceFields[metricType]
+	if !found {
+		return "", nil, false
+	}
+	source, err := metric.GetReader(field)
+	if err != nil {
+		return "", nil, false
+	}
+	name, err := source.ReadString("name")
+	if err != nil {
+		name, err = source.ReadString("metric.name")
+		if err != nil {
+			return "", nil, false
+		}
+	}
+	return fmt.Sprintf("%s/%s", metricType, name), source, true
+}
+
+// autoscalerMetricValue returns the formatted value of the `target` or `current` field in the metric source.
+func autoscalerMetricValue(source *structured.NodeReader, field string) (string, bool) {
+	if utilization, err := source.ReadInt(field + ".averageUtilization"); err == nil {
+		return fmt.Sprintf("%d%%", utilization), true
+	}
+	for _, valueField := range []string{"averageValue", "value"} {
+		if value, found := readQuantity(source, field+"."+valueField); found {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// readQuantity reads a resource quantity which can be written either as a string or as a number.
+func readQuantity(reader *structured.NodeReader, fieldPath string) (string, bool) {
+	if value, err := reader.ReadString(fieldPath); err == nil {
+		return value, true
+	}
+	if value, err := reader.ReadInt(fieldPath); err == nil {
+		return strconv.Itoa(value), true
+	}
+	if value, err := reader.ReadFloat(fieldPath); err == nil {
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// Dependencies implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (h *horizontalPodAutoscalerLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{
+		// The log summary task rewrites the summary of every log. This task must run after it to keep the replica summary.
+		commonlogk8sauditv2_contract.LogSummaryLogToTimelineMapperTaskID.Ref(),
+	}
+}
+
+// PassCount implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (h *horizontalPodAutoscalerLogToTimelineMapperTaskSetting) PassCount() int {
+	return 1
+}
+
+// GroupedLogTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (h *horizontalPodAutoscalerLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[commonlogk8sauditv2_contract.ResourceManifestLogGroupMap] {
+	return commonlogk8sauditv2_contract.ResourceLifetimeTrackerTaskID.Ref()
+}
+
+// LogIngesterTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (h *horizontalPodAutoscalerLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return commonlogk8sauditv2_contract.K8sAuditLogIngesterTaskID.Ref()
+}
+
+// TaskID implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (h *horizontalPodAutoscalerLogToTimelineMapperTaskSetting) TaskID() taskid.TaskImplementationID[struct{}] {
+	return commonlogk8sauditv2_contract.HorizontalPodAutoscalerLogToTimelineMapperTaskID
+}
+
+// ResourcePairs implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (h *horizontalPodAutoscalerLogToTimelineMapperTaskSetting) ResourcePairs(ctx context.Context, groupedLogs commonlogk8sauditv2_contract.ResourceManifestLogGroupMap) ([]commonlogk8sauditv2_contract.ResourcePair, error) {
+	result := []commonlogk8sauditv2_contract.ResourcePair{}
+	for _, group := range groupedLogs {
+		// autoscaling/v2#horizontalpodautoscaler#namespace#name
+		if group.Resource.Type() != commonlogk8sauditv2_contract.Resource || !strings.HasPrefix(group.Resource.APIVersion, "autoscaling/") || group.Resource.Kind != "horizontalpodautoscaler" {
+			continue
+		}
+		result = append(result, commonlogk8sauditv2_contract.ResourcePair{
+			TargetGroup: group.Resource,
+		})
+	}
+	return result, nil
+}
+
+var _ commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting[*horizontalPodAutoscalerTaskState] = (*horizontalPodAutoscalerLogToTimelineMapperTaskSetting)(nil)
+
+// HorizontalPodAutoscalerLogToTimelineMapperTask is the task to generate scaling decision history of HorizontalPodAutoscalers.
+var HorizontalPodAutoscalerLogToTimelineMapperTask = commonlogk8sauditv2_contract.NewManifestLogToTimelineMapper[*horizontalPodAutoscalerTaskState](&horizontalPodAutoscalerLogToTimelineMapperTaskSetting{})
@@ -0,0 +1,330 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	"github.com/GoogleCloudPlatform/khi/pkg/model"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/testchangeset"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHorizontalPodAutoscalerLogToTimelineMapperTask_Process(t *testing.T) {
+	task := &horizontalPodAutoscalerLogToTimelineMapperTaskSetting{}
+	ctx := context.Background()
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	replicasPath := "autoscaling/v2#horizontalpodautoscaler#default#web#replicas"
+	scaleTargetPath := resourcepath.ScaleTargetAutoscaler(resourcepath.NameLayerGeneralItem("apps/v1", "deployment", "default", "web"), "web")
+
+	scalingUpYAML := `spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: web
+  metrics:
+  - type: Resource
+    resource:
+      name: cpu
+      target:
+        type: Utilization
+        averageUtilization: 60
+status:
+  currentReplicas: 3
+  desiredReplicas: 7
+  currentMetrics:
+  - type: Resource
+    resource:
+      name: cpu
+      current:
+        averageUtilization: 92
+        averageValue: 920m
+  conditions:
+  - type: AbleToScale
+    status: "True"
+  - type: ScalingActive
+    status: "True"
+`
+
+	testCases := []struct {
+		name         string
+		yaml         string
+		eventType    commonlogk8sauditv2_contract.ChangeEventType
+		operation    enum.RevisionVerb
+		initialState *horizontalPodAutoscalerTaskState
+		wantState    *horizontalPodAutoscalerTaskState
+		wantSummary  string
+		asserters    []testchangeset.ChangeSetAsserter
+	}{
+		{
+			name:      "scaling up",
+			yaml:      scalingUpYAML,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			wantState: &horizontalPodAutoscalerTaskState{
+				lastCurrentReplicas: 3,
+				lastDesiredReplicas: 7,
+				lastState:           enum.RevisionStateAutoscalerScalingUp,
+			},
+			wantSummary: "3 → 7 replicas (cpu 92%/60%)",
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: replicasPath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbUpdate,
+						State:      enum.RevisionStateAutoscalerScalingUp,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       scalingUpYAML,
+					},
+				},
+				&testchangeset.HasAlias{
+					Source:      replicasPath,
+					Destination: scaleTargetPath.Path,
+				},
+			},
+		},
+		{
+			name:      "unchanged replicas",
+			yaml:      scalingUpYAML,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &horizontalPodAutoscalerTaskState{
+				lastCurrentReplicas: 3,
+				lastDesiredReplicas: 7,
+				lastState:           enum.RevisionStateAutoscalerScalingUp,
+			},
+			wantState: &horizontalPodAutoscalerTaskState{
+				lastCurrentReplicas: 3,
+				lastDesiredReplicas: 7,
+				lastState:           enum.RevisionStateAutoscalerScalingUp,
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{}},
+			},
+		},
+		{
+			name: "scaling down with non resource metrics",
+			yaml: `spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: StatefulSet
+    name: db
+  metrics:
+  - type: Pods
+    pods:
+      metric:
+        name: queue_length
+      target:
+        type: AverageValue
+        averageValue: "10"
+  - type: External
+    external:
+      metric:
+        name: pubsub_backlog
+      target:
+        type: Value
+        value: 100
+status:
+  currentReplicas: 5
+  desiredReplicas: 2
+  currentMetrics:
+  - type: Pods
+    pods:
+      metric:
+        name: queue_length
+      current:
+        averageValue: "2"
+  - type: External
+    external:
+      metric:
+        name: pubsub_backlog
+      current:
+        value: 12
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			wantState: &horizontalPodAutoscalerTaskState{
+				lastCurrentReplicas: 5,
+				lastDesiredReplicas: 2,
+				lastState:           enum.RevisionStateAutoscalerScalingDown,
+			},
+			wantSummary: "5 → 2 replicas (queue_length 2/10, pubsub_backlog 12/100)",
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: replicasPath, WantCount: 1},
+				&testchangeset.HasAlias{
+					Source:      replicasPath,
+					Destination: resourcepath.ScaleTargetAutoscaler(resourcepath.NameLayerGeneralItem("apps/v1", "statefulset", "default", "db"), "web").Path,
+				},
+			},
+		},
+		{
+			name: "autoscaling/v1 manifest",
+			yaml: `spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: web
+  targetCPUUtilizationPercentage: 60
+status:
+  currentReplicas: 4
+  desiredReplicas: 4
+  currentCPUUtilizationPercentage: 55
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			wantState: &horizontalPodAutoscalerTaskState{
+				lastCurrentReplicas: 4,
+				lastDesiredReplicas: 4,
+				lastState:           enum.RevisionStateAutoscalerStable,
+			},
+			wantSummary: "4 → 4 replicas (cpu 55%/60%)",
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: replicasPath, WantCount: 1},
+			},
+		},
+		{
+			name: "unable to scale",
+			yaml: `spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: web
+status:
+  currentReplicas: 3
+  desiredReplicas: 3
+  conditions:
+  - type: AbleToScale
+    status: "True"
+  - type: ScalingActive
+    status: "False"
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &horizontalPodAutoscalerTaskState{
+				lastCurrentReplicas: 3,
+				lastDesiredReplicas: 3,
+				lastState:           enum.RevisionStateAutoscalerStable,
+			},
+			wantState: &horizontalPodAutoscalerTaskState{
+				lastCurrentReplicas: 3,
+				lastDesiredReplicas: 3,
+				lastState:           enum.RevisionStateAutoscalerUnableToScale,
+			},
+			wantSummary: "3 → 3 replicas",
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: replicasPath, WantCount: 1},
+			},
+		},
+		{
+			name: "no status yet",
+			yaml: `spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: web
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+			operation: enum.RevisionVerbCreate,
+			wantState: &horizontalPodAutoscalerTaskState{
+				lastState: enum.RevisionStateInferred,
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{}},
+			},
+		},
+		{
+			name:      "deletion",
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion,
+			operation: enum.RevisionVerbDelete,
+			initialState: &horizontalPodAutoscalerTaskState{
+				lastCurrentReplicas: 3,
+				lastDesiredReplicas: 3,
+				lastState:           enum.RevisionStateAutoscalerStable,
+			},
+			wantState: &horizontalPodAutoscalerTaskState{
+				lastCurrentReplicas: 3,
+				lastDesiredReplicas: 3,
+				lastState:           enum.RevisionStateInferred,
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: replicasPath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbDelete,
+						State:      enum.RevisionStateDeleted,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reader *structured.NodeReader
+			if tc.yaml != "" {
+				reader = mustParseYAML(t, tc.yaml)
+			}
+			l := log.NewLogWithFieldSetsForTest(
+				&log.CommonFieldSet{},
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{},
+			)
+			commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			commonFieldSet.Timestamp = timestamp
+			k8sFieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+			k8sFieldSet.K8sOperation = &model.KubernetesObjectOperation{Verb: tc.operation}
+			k8sFieldSet.Principal = "user-1"
+
+			event := commonlogk8sauditv2_contract.ResourceChangeEvent{
+				Log:                   l,
+				EventType:             tc.eventType,
+				EventTargetBodyReader: reader,
+				EventTargetResource: &commonlogk8sauditv2_contract.ResourceIdentity{
+					APIVersion: "autoscaling/v2",
+					Kind:       "horizontalpodautoscaler",
+					Namespace:  "default",
+					Name:       "web",
+				},
+				EventTargetBodyYAML: tc.yaml,
+			}
+
+			cs := history.NewChangeSet(l)
+			nextState, err := task.Process(ctx, 0, event, cs, nil, tc.initialState)
+			if err != nil {
+				t.Fatalf("Process() failed: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantState, nextState, cmp.AllowUnexported(horizontalPodAutoscalerTaskState{})); diff != "" {
+				t.Errorf("state mismatch (-want +got):\n%s", diff)
+			}
+			if cs.LogSummary != tc.wantSummary {
+				t.Errorf("summary = %q, want %q", cs.LogSummary, tc.wantSummary)
+			}
+			for _, asserter := range tc.asserters {
+				asserter.Assert(t, cs)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+)
+
+type jobRunTaskState struct {
+	// lastRunStatus is the run state and the pod counts of the last revision. It's empty before any revision is written.
+	lastRunStatus string
+	// lastState is the run state of the last revision. It's RevisionStateInferred before any revision is written.
+	lastState enum.RevisionState
+	// cronJobPath is the resource path of the CronJob owning the Job. It's empty until the owner CronJob is found.
+	cronJobPath string
+}
+
+type jobRunLogToTimelineMapperTaskSetting struct {
+}
+
+// Process processes Job logs to generate the run state history and show it under the CronJob owning the Job.
+func (j *jobRunLogToTimelineMapperTaskSetting) Process(ctx context.Context, passIndex int, event commonlogk8sauditv2_contract.ResourceChangeEvent, cs *history.ChangeSet, builder *history.Builder, state *jobRunTaskState) (*jobRunTaskState, error) {
+	if state == nil {
+		state = &jobRunTaskState{
+			lastState: enum.RevisionStateInferred,
+		}
+	}
+	commonLogFieldSet := log.MustGetFieldSet(event.Log, &log.CommonFieldSet{})
+	k8sFieldSet := log.MustGetFieldSet(event.Log, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+	jobName := event.EventTargetResource.Name
+	runPath := resourcepath.JobRun(resourcepath.ResourcePath{Path: event.EventTargetResource.ResourcePathString()})
+	if event.EventType == commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion {
+		if state.lastRunStatus != "" {
+			cs.AddRevision(runPath, &history.StagingResourceRevision{
+				Verb:       k8sFieldSet.K8sOperation.Verb,
+				Body:       "",
+				Partial:    false,
+				Requestor:  k8sFieldSet.Principal,
+				ChangeTime: commonLogFieldSet.Timestamp,
+				State:      enum.RevisionStateDeleted,
+			})
+		}
+		state.lastRunStatus = ""
+		state.lastState = enum.RevisionStateInferred
+		return state, nil
+	}
+	if event.EventTargetBodyReader == nil {
+		return state, nil
+	}
+
+	if state.cronJobPath == "" {
+		if cronJobPath, found := cronJobOwnerOfJob(event.EventTargetBodyReader, event.EventTargetResource.Namespace); found {
+			cs.AddResourceAlias(runPath, resourcepath.CronJobRun(cronJobPath, jobName))
+			state.cronJobPath = cronJobPath.Path
+		}
+	}
+	// The creation of a Job owned by a CronJob is a schedule tick of the CronJob.
+	if event.EventType == commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation && state.cronJobPath != "" {
+		cs.AddEvent(resourcepath.ResourcePath{Path: state.cronJobPath, ParentRelationship: enum.RelationshipChild})
+	}
+
+	active := event.EventTargetBodyReader.ReadIntOrDefault("status.active", 0)
+	succeeded := event.EventTargetBodyReader.ReadIntOrDefault("status.succeeded", 0)
+	failed := event.EventTargetBodyReader.ReadIntOrDefault("status.failed", 0)
+	revisionState := enum.RevisionStateJobPending
+	if active > 0 {
+		revisionState = enum.RevisionStateJobRunning
+	}
+	if status, found := GetConditionStatus(event.EventTargetBodyReader, "Suspended"); found && status == "True" {
+		revisionState = enum.RevisionStateJobSuspended
+	}
+	if status, found := GetConditionStatus(event.EventTargetBodyReader, "Complete"); found && status == "True" {
+		revisionState = enum.RevisionStateJobSucceeded
+	}
+	if status, found := GetConditionStatus(event.EventTargetBodyReader, "Failed"); found && status == "True" {
+		revisionState = enum.RevisionStateJobFailed
+	}
+	runStatus := fmt.Sprintf("%d: active=%d, succeeded=%d, failed=%d", revisionState, active, succeeded, failed)
+	if runStatus == state.lastRunStatus {
+		return state, nil
+	}
+
+	cs.AddRevision(runPath, &history.StagingResourceRevision{
+		Verb:       k8sFieldSet.K8sOperation.Verb,
+		Body:       event.EventTargetBodyYAML,
+		Partial:    false,
+		Requestor:  k8sFieldSet.Principal,
+		ChangeTime: commonLogFieldSet.Timestamp,
+		State:      revisionState,
+	})
+	if revisionState != state.lastState {
+		switch revisionState {
+		case enum.RevisionStateJobSucceeded:
+			cs.SetLogSummary(jobRunSummary("Job succeeded", event.EventTargetBodyReader, "Complete", commonLogFieldSet.Timestamp))
+		case enum.RevisionStateJobFailed:
+			cs.SetLogSummary(jobRunSummary("Job failed", event.EventTargetBodyReader, "Failed", commonLogFieldSet.Timestamp))
+			cs.SetLogSeverity(enum.SeverityWarning)
+		}
+	}
+	state.lastRunStatus = runStatus
+	state.lastState = revisionState
+	return state, nil
+}
+
+// cronJobOwnerOfJob returns the resource path of the CronJob in metadata.ownerReferences of the Job.
+func cronJobOwnerOfJob(reader *structured.NodeReader, namespace string) (resourcepath.ResourcePath, bool) {
+	ownerReferences, err := reader.GetReader("metadata.ownerReferences")
+	if err != nil {
+		return resourcepath.ResourcePath{}, false
+	}
+	for _, ownerReference := range ownerReferences.Children() {
+		kind, err := ownerReference.ReadString("kind")
+		if err != nil || strings.ToLower(kind) != "cronjob" {
+			continue
+		}
+		apiVersion, err := ownerReference.ReadString("apiVersion")
+		if err != nil {
+			continue
+		}
+		name, err := ownerReference.ReadString("name")
+		if err != nil {
+			continue
+		}
+		return resourcepath.NameLayerGeneralItem(apiVersion, "cronjob", namespace, name), true
+	}
+	return resourcepath.ResourcePath{}, false
+}
+
+// jobRunSummary returns the summary of the log finishing a Job run with the duration and the reason of the finished condition.
+// The run is regarded to be finished at status.completionTime, or at the last transition of the condition when it's not available.
+func jobRunSummary(prefix string, reader *structured.NodeReader, conditionType string, logTime time.Time) string {
+	var reason string
+	endTime := logTime
+	if conditions, err := reader.GetReader("status.conditions"); err == nil {
+		for _, condition := range conditions.Children() {
+			if t, err := condition.ReadString("type"); err != nil || t != conditionType {
+				continue
+			}
+			reason = condition.ReadStringOrDefault("reason", "")
+			endTime = condition.ReadTimestampOrDefault("lastTransitionTime", endTime)
+		}
+	}
+	endTime = reader.ReadTimestampOrDefault("status.completionTime", endTime)
+
+	summary := prefix
+	if startTime, err := reader.ReadTimestamp("status.startTime"); err == nil && !endTime.Before(startTime) {
+		summary += fmt.Sprintf(" after %s", endTime.Sub(startTime))
+	}
+	if reason != "" && conditionType != "Complete" {
+		summary += fmt.Sprintf(" (%s)", reason)
+	}
+	return summary
+}
+
+// Dependencies implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (j *jobRunLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	// Summaries and severities of the logs finishing Job runs overwrite the ones set by the log summary task.
+	return []taskid.UntypedTaskReference{
+		commonlogk8sauditv2_contract.LogSummaryLogToTimelineMapperTaskID.Ref(),
+	}
+}
+
+// PassCount implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (j *jobRunLogToTimelineMapperTaskSetting) PassCount() int {
+	return 1
+}
+
+// GroupedLogTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (j *jobRunLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[commonlogk8sauditv2_contract.ResourceManifestLogGroupMap] {
+	return commonlogk8sauditv2_contract.ResourceLifetimeTrackerTaskID.Ref()
+}
+
+// LogIngesterTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (j *jobRunLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return commonlogk8sauditv2_contract.K8sAuditLogIngesterTaskID.Ref()
+}
+
+// TaskID implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (j *jobRunLogToTimelineMapperTaskSetting) TaskID() taskid.TaskImplementationID[struct{}] {
+	return commonlogk8sauditv2_contract.JobRunLogToTimelineMapperTaskID
+}
+
+// ResourcePairs implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (j *jobRunLogToTimelineMapperTaskSetting) ResourcePairs(ctx context.Context, groupedLogs commonlogk8sauditv2_contract.ResourceManifestLogGroupMap) ([]commonlogk8sauditv2_contract.ResourcePair, error) {
+	result := []commonlogk8sauditv2_contract.ResourcePair{}
+	for _, group := range groupedLogs {
+		// batch/v1#job#namespace#name
+		if group.Resource.Type() != commonlogk8sauditv2_contract.Resource || group.Resource.APIVersion != "batch/v1" || group.Resource.Kind != "job" {
+			continue
+		}
+		result = append(result, commonlogk8sauditv2_contract.ResourcePair{
+			TargetGroup: group.Resource,
+		})
+	}
+	return result, nil
+}
+
+var _ commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting[*jobRunTaskState] = (*jobRunLogToTimelineMapperTaskSetting)(nil)
+
+// JobRunLogToTimelineMapperTask is the task to generate Job run history under Jobs and CronJobs.
+var JobRunLogToTimelineMapperTask = commonlogk8sauditv2_contract.NewManifestLogToTimelineMapper[*jobRunTaskState](&jobRunLogToTimelineMapperTaskSetting{})
@@ -0,0 +1,364 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	"github.com/GoogleCloudPlatform/khi/pkg/model"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/testchangeset"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJobRunLogToTimelineMapperTask_Process(t *testing.T) {
+	task := &jobRunLogToTimelineMapperTaskSetting{}
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	jobIdentity := &commonlogk8sauditv2_contract.ResourceIdentity{APIVersion: "batch/v1", Kind: "job", Namespace: "default", Name: "backup-28000000"}
+	cronJobPath := resourcepath.NameLayerGeneralItem("batch/v1", "cronjob", "default", "backup").Path
+	runPath := resourcepath.JobRun(resourcepath.NameLayerGeneralItem("batch/v1", "job", "default", "backup-28000000")).Path
+
+	testCases := []struct {
+		name          string
+		targetYAML    string
+		eventType     commonlogk8sauditv2_contract.ChangeEventType
+		operation     enum.RevisionVerb
+		initialState  *jobRunTaskState
+		wantState     *jobRunTaskState
+		wantSummary   string
+		wantSeverity  enum.Severity
+		wantAsserters []testchangeset.ChangeSetAsserter
+	}{
+		{
+			name: "job created by a cronjob",
+			targetYAML: `metadata:
+  ownerReferences:
+  - apiVersion: batch/v1
+    kind: CronJob
+    name: backup
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+			operation: enum.RevisionVerbCreate,
+			wantState: &jobRunTaskState{
+				lastRunStatus: "60: active=0, succeeded=0, failed=0",
+				lastState:     enum.RevisionStateJobPending,
+				cronJobPath:   cronJobPath,
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: runPath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbCreate,
+						State:      enum.RevisionStateJobPending,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "metadata:\n  ownerReferences:\n  - apiVersion: batch/v1\n    kind: CronJob\n    name: backup\n",
+					},
+				},
+				&testchangeset.HasAlias{
+					Source:      runPath,
+					Destination: resourcepath.CronJobRun(resourcepath.NameLayerGeneralItem("batch/v1", "cronjob", "default", "backup"), "backup-28000000").Path,
+				},
+				&testchangeset.HasEvent{ResourcePath: cronJobPath},
+			},
+		},
+		{
+			name: "job running",
+			targetYAML: `status:
+  active: 1
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &jobRunTaskState{
+				lastRunStatus: "60: active=0, succeeded=0, failed=0",
+				lastState:     enum.RevisionStateJobPending,
+				cronJobPath:   cronJobPath,
+			},
+			wantState: &jobRunTaskState{
+				lastRunStatus: "61: active=1, succeeded=0, failed=0",
+				lastState:     enum.RevisionStateJobRunning,
+				cronJobPath:   cronJobPath,
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{runPath}},
+				&testchangeset.HasRevision{
+					ResourcePath: runPath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbUpdate,
+						State:      enum.RevisionStateJobRunning,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "status:\n  active: 1\n",
+					},
+				},
+			},
+		},
+		{
+			name: "pod failure retried",
+			targetYAML: `status:
+  active: 1
+  failed: 1
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &jobRunTaskState{
+				lastRunStatus: "61: active=1, succeeded=0, failed=0",
+				lastState:     enum.RevisionStateJobRunning,
+			},
+			wantState: &jobRunTaskState{
+				lastRunStatus: "61: active=1, succeeded=0, failed=1",
+				lastState:     enum.RevisionStateJobRunning,
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: runPath, WantCount: 1},
+			},
+		},
+		{
+			name: "job completed",
+			targetYAML: `status:
+  succeeded: 1
+  startTime: "2024-01-01T00:00:00Z"
+  completionTime: "2024-01-01T00:01:30Z"
+  conditions:
+  - type: Complete
+    status: "True"
+    lastTransitionTime: "2024-01-01T00:01:31Z"
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &jobRunTaskState{
+				lastRunStatus: "61: active=1, succeeded=0, failed=0",
+				lastState:     enum.RevisionStateJobRunning,
+			},
+			wantState: &jobRunTaskState{
+				lastRunStatus: "62: active=0, succeeded=1, failed=0",
+				lastState:     enum.RevisionStateJobSucceeded,
+			},
+			wantSummary: "Job succeeded after 1m30s",
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: runPath, WantCount: 1},
+			},
+		},
+		{
+			name: "job failed by backoffLimit",
+			targetYAML: `status:
+  failed: 7
+  startTime: "2024-01-01T00:00:00Z"
+  conditions:
+  - type: FailureTarget
+    status: "True"
+    reason: BackoffLimitExceeded
+    lastTransitionTime: "2024-01-01T00:04:59Z"
+  - type: Failed
+    status: "True"
+    reason: BackoffLimitExceeded
+    lastTransitionTime: "2024-01-01T00:05:00Z"
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &jobRunTaskState{
+				lastRunStatus: "61: active=1, succeeded=0, failed=6",
+				lastState:     enum.RevisionStateJobRunning,
+			},
+			wantState: &jobRunTaskState{
+				lastRunStatus: "63: active=0, succeeded=0, failed=7",
+				lastState:     enum.RevisionStateJobFailed,
+			},
+			wantSummary:  "Job failed after 5m0s (BackoffLimitExceeded)",
+			wantSeverity: enum.SeverityWarning,
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: runPath, WantCount: 1},
+			},
+		},
+		{
+			name: "job failed by activeDeadlineSeconds without startTime",
+			targetYAML: `status:
+  conditions:
+  - type: Failed
+    status: "True"
+    reason: DeadlineExceeded
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &jobRunTaskState{
+				lastRunStatus: "61: active=1, succeeded=0, failed=0",
+				lastState:     enum.RevisionStateJobRunning,
+			},
+			wantState: &jobRunTaskState{
+				lastRunStatus: "63: active=0, succeeded=0, failed=0",
+				lastState:     enum.RevisionStateJobFailed,
+			},
+			wantSummary:  "Job failed (DeadlineExceeded)",
+			wantSeverity: enum.SeverityWarning,
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: runPath, WantCount: 1},
+			},
+		},
+		{
+			name: "job failed status update after the failure",
+			targetYAML: `status:
+  failed: 8
+  conditions:
+  - type: Failed
+    status: "True"
+    reason: BackoffLimitExceeded
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &jobRunTaskState{
+				lastRunStatus: "63: active=0, succeeded=0, failed=7",
+				lastState:     enum.RevisionStateJobFailed,
+			},
+			wantState: &jobRunTaskState{
+				lastRunStatus: "63: active=0, succeeded=0, failed=8",
+				lastState:     enum.RevisionStateJobFailed,
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: runPath, WantCount: 1},
+			},
+		},
+		{
+			name: "job suspended",
+			targetYAML: `spec:
+  suspend: true
+status:
+  conditions:
+  - type: Suspended
+    status: "True"
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbPatch,
+			initialState: &jobRunTaskState{
+				lastRunStatus: "61: active=1, succeeded=0, failed=0",
+				lastState:     enum.RevisionStateJobRunning,
+			},
+			wantState: &jobRunTaskState{
+				lastRunStatus: "64: active=0, succeeded=0, failed=0",
+				lastState:     enum.RevisionStateJobSuspended,
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: runPath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbPatch,
+						State:      enum.RevisionStateJobSuspended,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "spec:\n  suspend: true\nstatus:\n  conditions:\n  - type: Suspended\n    status: \"True\"\n",
+					},
+				},
+			},
+		},
+		{
+			name: "job modification without run state change",
+			targetYAML: `metadata:
+  labels:
+    app: backup
+status:
+  active: 1
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbPatch,
+			initialState: &jobRunTaskState{
+				lastRunStatus: "61: active=1, succeeded=0, failed=0",
+				lastState:     enum.RevisionStateJobRunning,
+			},
+			wantState: &jobRunTaskState{
+				lastRunStatus: "61: active=1, succeeded=0, failed=0",
+				lastState:     enum.RevisionStateJobRunning,
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{}},
+			},
+		},
+		{
+			name:      "job deletion",
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion,
+			operation: enum.RevisionVerbDelete,
+			initialState: &jobRunTaskState{
+				lastRunStatus: "62: active=0, succeeded=1, failed=0",
+				lastState:     enum.RevisionStateJobSucceeded,
+				cronJobPath:   cronJobPath,
+			},
+			wantState: &jobRunTaskState{
+				lastState:   enum.RevisionStateInferred,
+				cronJobPath: cronJobPath,
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: runPath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbDelete,
+						State:      enum.RevisionStateDeleted,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reader *structured.NodeReader
+			if tc.targetYAML != "" {
+				reader = mustParseYAML(t, tc.targetYAML)
+			}
+			l := log.NewLogWithFieldSetsForTest(
+				&log.CommonFieldSet{},
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{},
+			)
+			commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			commonFieldSet.Timestamp = timestamp
+			k8sFieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+			k8sFieldSet.K8sOperation = &model.KubernetesObjectOperation{Verb: tc.operation}
+			k8sFieldSet.Principal = "user-1"
+
+			event := commonlogk8sauditv2_contract.ResourceChangeEvent{
+				Log:                   l,
+				EventType:             tc.eventType,
+				EventTargetResource:   jobIdentity,
+				EventTargetBodyReader: reader,
+				EventTargetBodyYAML:   tc.targetYAML,
+			}
+
+			cs := history.NewChangeSet(l)
+			nextState, err := task.Process(context.Background(), 0, event, cs, nil, tc.initialState)
+			if err != nil {
+				t.Fatalf("Process() failed: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantState, nextState, cmp.AllowUnexported(jobRunTaskState{})); diff != "" {
+				t.Errorf("state mismatch (-want +got):\n%s", diff)
+			}
+			if cs.LogSummary != tc.wantSummary {
+				t.Errorf("summary = %q, want %q", cs.LogSummary, tc.wantSummary)
+			}
+			if cs.LogSeverity != tc.wantSeverity {
+				t.Errorf("severity = %v, want %v", cs.LogSeverity, tc.wantSeverity)
+			}
+			for _, asserter := range tc.wantAsserters {
+				asserter.Assert(t, cs)
+			}
+		})
+	}
+}
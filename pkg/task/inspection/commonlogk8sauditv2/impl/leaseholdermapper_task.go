@@ -0,0 +1,231 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+)
+
+type leaseHolderTaskState struct {
+	// holder is the holder identity of the current tenure. Empty when the lease is not held.
+	holder string
+	// leaseTransitions is the last observed value of spec.leaseTransitions.
+	leaseTransitions int
+	// lastRenewTime is the last observed value of spec.renewTime in the current tenure.
+	lastRenewTime time.Time
+	// holderPod is the pod resolved from the holder identity of the current tenure. Nil when it can't be resolved.
+	holderPod *podIdentity
+	// resolvedPods caches the pod resolved from each holder identity. The value is nil when it can't be resolved.
+	resolvedPods map[string]*podIdentity
+}
+
+type leaseHolderLogToTimelineMapperTaskSetting struct {
+}
+
+// Process processes the log to generate the leader election history of the lease.
+func (l *leaseHolderLogToTimelineMapperTaskSetting) Process(ctx context.Context, passIndex int, event commonlogk8sauditv2_contract.ResourceChangeEvent, cs *history.ChangeSet, builder *history.Builder, state *leaseHolderTaskState) (*leaseHolderTaskState, error) {
+	if state == nil {
+		state = &leaseHolderTaskState{
+			resolvedPods: map[string]*podIdentity{},
+		}
+	}
+	commonLogFieldSet := log.MustGetFieldSet(event.Log, &log.CommonFieldSet{})
+	k8sFieldSet := log.MustGetFieldSet(event.Log, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+	leaseNamespace := event.EventTargetResource.Namespace
+	leaseName := event.EventTargetResource.Name
+
+	if event.EventType == commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion {
+		if state.holder != "" {
+			revision := &history.StagingResourceRevision{
+				Verb:       k8sFieldSet.K8sOperation.Verb,
+				Body:       "",
+				Partial:    false,
+				Requestor:  k8sFieldSet.Principal,
+				ChangeTime: commonLogFieldSet.Timestamp,
+				State:      enum.RevisionStateDeleted,
+			}
+			cs.AddRevision(resourcepath.LeaseHolder(leaseNamespace, leaseName), revision)
+			if state.holderPod != nil {
+				cs.AddRevision(resourcepath.PodLeaseHolder(leaseNamespace, leaseName, state.holderPod.namespace, state.holderPod.name), revision)
+			}
+		}
+		state.holder = ""
+		state.holderPod = nil
+		state.lastRenewTime = time.Time{}
+		return state, nil
+	}
+	if event.EventTargetBodyReader == nil {
+		return state, nil
+	}
+
+	holder := event.EventTargetBodyReader.ReadStringOrDefault("spec.holderIdentity", "")
+	leaseTransitions := event.EventTargetBodyReader.ReadIntOrDefault("spec.leaseTransitions", 0)
+	renewTime := event.EventTargetBodyReader.ReadTimestampOrDefault("spec.renewTime", time.Time{})
+	leaseDuration := time.Duration(event.EventTargetBodyReader.ReadIntOrDefault("spec.leaseDurationSeconds", 0)) * time.Second
+
+	// A new tenure begins when the holder changes, or when the same holder acquired the lease again after it expired.
+	if holder != state.holder || leaseTransitions != state.leaseTransitions {
+		revisionState := enum.RevisionStateLeaseHeld
+		if holder == "" {
+			revisionState = enum.RevisionStateLeaseNotHeld
+		}
+		cs.AddRevision(resourcepath.LeaseHolder(leaseNamespace, leaseName), &history.StagingResourceRevision{
+			Verb:       k8sFieldSet.K8sOperation.Verb,
+			Body:       event.EventTargetBodyYAML,
+			Partial:    false,
+			Requestor:  k8sFieldSet.Principal,
+			ChangeTime: commonLogFieldSet.Timestamp,
+			State:      revisionState,
+		})
+
+		holderPod := l.resolveHolderPod(ctx, leaseNamespace, leaseName, holder, state)
+		if state.holderPod != nil && (holderPod == nil || *holderPod != *state.holderPod) {
+			cs.AddRevision(resourcepath.PodLeaseHolder(leaseNamespace, leaseName, state.holderPod.namespace, state.holderPod.name), &history.StagingResourceRevision{
+				Verb:       k8sFieldSet.K8sOperation.Verb,
+				Body:       event.EventTargetBodyYAML,
+				Partial:    false,
+				Requestor:  k8sFieldSet.Principal,
+				ChangeTime: commonLogFieldSet.Timestamp,
+				State:      enum.RevisionStateLeaseNotHeld,
+			})
+		}
+		if holderPod != nil {
+			cs.AddRevision(resourcepath.PodLeaseHolder(leaseNamespace, leaseName, holderPod.namespace, holderPod.name), &history.StagingResourceRevision{
+				Verb:       k8sFieldSet.K8sOperation.Verb,
+				Body:       event.EventTargetBodyYAML,
+				Partial:    false,
+				Requestor:  k8sFieldSet.Principal,
+				ChangeTime: commonLogFieldSet.Timestamp,
+				State:      enum.RevisionStateLeaseHeld,
+			})
+		}
+		state.holder = holder
+		state.holderPod = holderPod
+		state.leaseTransitions = leaseTransitions
+		state.lastRenewTime = renewTime
+		return state, nil
+	}
+
+	if holder == "" || renewTime.IsZero() {
+		return state, nil
+	}
+	if !state.lastRenewTime.IsZero() && leaseDuration > 0 && renewTime.Sub(state.lastRenewTime) > leaseDuration {
+		cs.SetLogSeverity(enum.SeverityWarning)
+		cs.AddEvent(resourcepath.LeaseHolder(leaseNamespace, leaseName))
+		if state.holderPod != nil {
+			cs.AddEvent(resourcepath.PodLeaseHolder(leaseNamespace, leaseName, state.holderPod.namespace, state.holderPod.name))
+		}
+	}
+	state.lastRenewTime = renewTime
+	return state, nil
+}
+
+// resolveHolderPod returns the pod matching the holder identity from the pods found in the audit logs.
+// Leader election clients in Kubernetes use `<hostname>_<uuid>` as their identity by default, and the hostname of a pod is the pod name.
+// Static pods of control plane components are named `<component>-<node name>` while the hostname is the node name, thus the name prefixed by the lease name is also tried.
+func (l *leaseHolderLogToTimelineMapperTaskSetting) resolveHolderPod(ctx context.Context, leaseNamespace string, leaseName string, holder string, state *leaseHolderTaskState) *podIdentity {
+	if holder == "" {
+		return nil
+	}
+	if pod, found := state.resolvedPods[holder]; found {
+		return pod
+	}
+	hostname, _, _ := strings.Cut(holder, "_")
+	candidates := []string{hostname, fmt.Sprintf("%s-%s", leaseName, hostname)}
+	groupedLogs := coretask.GetTaskResult(ctx, l.GroupedLogTask())
+	var resolved *podIdentity
+	for _, podName := range candidates {
+		// Prefer the pod in the same namespace as the lease.
+		if _, found := groupedLogs[resourcepath.Pod(leaseNamespace, podName).Path]; found {
+			resolved = &podIdentity{name: podName, namespace: leaseNamespace}
+			break
+		}
+		matchedNamespaces := []string{}
+		for _, group := range groupedLogs {
+			if group.Resource.Type() != commonlogk8sauditv2_contract.Resource || group.Resource.APIVersion != "core/v1" || group.Resource.Kind != "pod" {
+				continue
+			}
+			if group.Resource.Name == podName {
+				matchedNamespaces = append(matchedNamespaces, group.Resource.Namespace)
+			}
+		}
+		// Pods with the same name in multiple namespaces are ambiguous to be linked.
+		if len(matchedNamespaces) == 1 {
+			resolved = &podIdentity{name: podName, namespace: matchedNamespaces[0]}
+			break
+		}
+	}
+	state.resolvedPods[holder] = resolved
+	return resolved
+}
+
+// Dependencies implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (l *leaseHolderLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{
+		// The log summary task rewrites the severity of every log. This task must run after it to keep the warning severity on delayed renewals.
+		commonlogk8sauditv2_contract.LogSummaryLogToTimelineMapperTaskID.Ref(),
+	}
+}
+
+// PassCount implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (l *leaseHolderLogToTimelineMapperTaskSetting) PassCount() int {
+	return 1
+}
+
+// GroupedLogTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (l *leaseHolderLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[commonlogk8sauditv2_contract.ResourceManifestLogGroupMap] {
+	return commonlogk8sauditv2_contract.ResourceLifetimeTrackerTaskID.Ref()
+}
+
+// LogIngesterTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (l *leaseHolderLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return commonlogk8sauditv2_contract.K8sAuditLogIngesterTaskID.Ref()
+}
+
+// TaskID implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (l *leaseHolderLogToTimelineMapperTaskSetting) TaskID() taskid.TaskImplementationID[struct{}] {
+	return commonlogk8sauditv2_contract.LeaseHolderLogToTimelineMapperTaskID
+}
+
+// ResourcePairs implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (l *leaseHolderLogToTimelineMapperTaskSetting) ResourcePairs(ctx context.Context, groupedLogs commonlogk8sauditv2_contract.ResourceManifestLogGroupMap) ([]commonlogk8sauditv2_contract.ResourcePair, error) {
+	result := []commonlogk8sauditv2_contract.ResourcePair{}
+	for _, group := range groupedLogs {
+		// coordination.k8s.io/v1#lease#namespace#leasename
+		if group.Resource.Type() != commonlogk8sauditv2_contract.Resource || group.Resource.APIVersion != "coordination.k8s.io/v1" || group.Resource.Kind != "lease" {
+			continue
+		}
+		result = append(result, commonlogk8sauditv2_contract.ResourcePair{
+			TargetGroup: group.Resource,
+		})
+	}
+	return result, nil
+}
+
+var _ commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting[*leaseHolderTaskState] = (*leaseHolderLogToTimelineMapperTaskSetting)(nil)
+
+// LeaseHolderLogToTimelineMapperTask is the task to generate leader election history from Lease resources.
+var LeaseHolderLogToTimelineMapperTask = commonlogk8sauditv2_contract.NewManifestLogToTimelineMapper[*leaseHolderTaskState](&leaseHolderLogToTimelineMapperTaskSetting{})
@@ -0,0 +1,450 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	tasktest "github.com/GoogleCloudPlatform/khi/pkg/core/task/test"
+	"github.com/GoogleCloudPlatform/khi/pkg/model"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/testchangeset"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLeaseHolderLogToTimelineMapperTask_Process(t *testing.T) {
+	task := &leaseHolderLogToTimelineMapperTaskSetting{}
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	podGroup := func(namespace, name string) *commonlogk8sauditv2_contract.ResourceManifestLogGroup {
+		return &commonlogk8sauditv2_contract.ResourceManifestLogGroup{
+			Resource: &commonlogk8sauditv2_contract.ResourceIdentity{APIVersion: "core/v1", Kind: "pod", Namespace: namespace, Name: name},
+		}
+	}
+	groupedLogs := commonlogk8sauditv2_contract.ResourceManifestLogGroupMap{}
+	for _, group := range []*commonlogk8sauditv2_contract.ResourceManifestLogGroup{
+		podGroup("kube-system", "kube-scheduler-node-1"),
+		podGroup("kube-system", "kube-scheduler-node-2"),
+		podGroup("operators", "my-operator-abcde"),
+		podGroup("ns-1", "duplicated"),
+		podGroup("ns-2", "duplicated"),
+	} {
+		groupedLogs[group.Resource.ResourcePathString()] = group
+	}
+	ctx := tasktest.WithTaskResult(context.Background(), commonlogk8sauditv2_contract.ResourceLifetimeTrackerTaskID.Ref(), groupedLogs)
+
+	testCases := []struct {
+		name         string
+		yaml         string
+		eventType    commonlogk8sauditv2_contract.ChangeEventType
+		operation    enum.RevisionVerb
+		initialState *leaseHolderTaskState
+		wantState    *leaseHolderTaskState
+		wantSeverity enum.Severity
+		asserters    []testchangeset.ChangeSetAsserter
+	}{
+		{
+			name: "first holder resolved to a static pod",
+			yaml: `spec:
+  holderIdentity: node-1_0f2a
+  leaseDurationSeconds: 15
+  leaseTransitions: 3
+  renewTime: "2024-01-01T00:00:00.000000Z"
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			wantState: &leaseHolderTaskState{
+				holder:           "node-1_0f2a",
+				leaseTransitions: 3,
+				lastRenewTime:    timestamp,
+				holderPod:        &podIdentity{name: "kube-scheduler-node-1", namespace: "kube-system"},
+				resolvedPods: map[string]*podIdentity{
+					"node-1_0f2a": {name: "kube-scheduler-node-1", namespace: "kube-system"},
+				},
+			},
+			wantSeverity: enum.SeverityUnknown,
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{
+					resourcepath.LeaseHolder("kube-system", "kube-scheduler").Path,
+					resourcepath.PodLeaseHolder("kube-system", "kube-scheduler", "kube-system", "kube-scheduler-node-1").Path,
+				}},
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.LeaseHolder("kube-system", "kube-scheduler").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbUpdate,
+						State:      enum.RevisionStateLeaseHeld,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "spec:\n  holderIdentity: node-1_0f2a\n  leaseDurationSeconds: 15\n  leaseTransitions: 3\n  renewTime: \"2024-01-01T00:00:00.000000Z\"\n",
+					},
+				},
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.PodLeaseHolder("kube-system", "kube-scheduler", "kube-system", "kube-scheduler-node-1").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbUpdate,
+						State:      enum.RevisionStateLeaseHeld,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "spec:\n  holderIdentity: node-1_0f2a\n  leaseDurationSeconds: 15\n  leaseTransitions: 3\n  renewTime: \"2024-01-01T00:00:00.000000Z\"\n",
+					},
+				},
+			},
+		},
+		{
+			name: "renewal by the same holder within the lease duration",
+			yaml: `spec:
+  holderIdentity: node-1_0f2a
+  leaseDurationSeconds: 15
+  leaseTransitions: 3
+  renewTime: "2024-01-01T00:00:10.000000Z"
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &leaseHolderTaskState{
+				holder:           "node-1_0f2a",
+				leaseTransitions: 3,
+				lastRenewTime:    timestamp,
+				holderPod:        &podIdentity{name: "kube-scheduler-node-1", namespace: "kube-system"},
+				resolvedPods:     map[string]*podIdentity{},
+			},
+			wantState: &leaseHolderTaskState{
+				holder:           "node-1_0f2a",
+				leaseTransitions: 3,
+				lastRenewTime:    timestamp.Add(10 * time.Second),
+				holderPod:        &podIdentity{name: "kube-scheduler-node-1", namespace: "kube-system"},
+				resolvedPods:     map[string]*podIdentity{},
+			},
+			wantSeverity: enum.SeverityUnknown,
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{}},
+			},
+		},
+		{
+			name: "renewal by the same holder later than the lease duration",
+			yaml: `spec:
+  holderIdentity: node-1_0f2a
+  leaseDurationSeconds: 15
+  leaseTransitions: 3
+  renewTime: "2024-01-01T00:00:40.000000Z"
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &leaseHolderTaskState{
+				holder:           "node-1_0f2a",
+				leaseTransitions: 3,
+				lastRenewTime:    timestamp,
+				holderPod:        &podIdentity{name: "kube-scheduler-node-1", namespace: "kube-system"},
+				resolvedPods:     map[string]*podIdentity{},
+			},
+			wantState: &leaseHolderTaskState{
+				holder:           "node-1_0f2a",
+				leaseTransitions: 3,
+				lastRenewTime:    timestamp.Add(40 * time.Second),
+				holderPod:        &podIdentity{name: "kube-scheduler-node-1", namespace: "kube-system"},
+				resolvedPods:     map[string]*podIdentity{},
+			},
+			wantSeverity: enum.SeverityWarning,
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasNoRevision{ResourcePath: resourcepath.LeaseHolder("kube-system", "kube-scheduler").Path},
+				&testchangeset.HasNoRevision{ResourcePath: resourcepath.PodLeaseHolder("kube-system", "kube-scheduler", "kube-system", "kube-scheduler-node-1").Path},
+				&testchangeset.HasEvent{ResourcePath: resourcepath.LeaseHolder("kube-system", "kube-scheduler").Path},
+				&testchangeset.HasEvent{ResourcePath: resourcepath.PodLeaseHolder("kube-system", "kube-scheduler", "kube-system", "kube-scheduler-node-1").Path},
+			},
+		},
+		{
+			name: "holder changed to another pod",
+			yaml: `spec:
+  holderIdentity: node-2_9c1b
+  leaseDurationSeconds: 15
+  leaseTransitions: 4
+  renewTime: "2024-01-01T00:00:30.000000Z"
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &leaseHolderTaskState{
+				holder:           "node-1_0f2a",
+				leaseTransitions: 3,
+				lastRenewTime:    timestamp,
+				holderPod:        &podIdentity{name: "kube-scheduler-node-1", namespace: "kube-system"},
+				resolvedPods:     map[string]*podIdentity{},
+			},
+			wantState: &leaseHolderTaskState{
+				holder:           "node-2_9c1b",
+				leaseTransitions: 4,
+				lastRenewTime:    timestamp.Add(30 * time.Second),
+				holderPod:        &podIdentity{name: "kube-scheduler-node-2", namespace: "kube-system"},
+				resolvedPods: map[string]*podIdentity{
+					"node-2_9c1b": {name: "kube-scheduler-node-2", namespace: "kube-system"},
+				},
+			},
+			wantSeverity: enum.SeverityUnknown,
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: resourcepath.LeaseHolder("kube-system", "kube-scheduler").Path, WantCount: 1},
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.LeaseHolder("kube-system", "kube-scheduler").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbUpdate,
+						State:      enum.RevisionStateLeaseHeld,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "spec:\n  holderIdentity: node-2_9c1b\n  leaseDurationSeconds: 15\n  leaseTransitions: 4\n  renewTime: \"2024-01-01T00:00:30.000000Z\"\n",
+					},
+				},
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.PodLeaseHolder("kube-system", "kube-scheduler", "kube-system", "kube-scheduler-node-1").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbUpdate,
+						State:      enum.RevisionStateLeaseNotHeld,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "spec:\n  holderIdentity: node-2_9c1b\n  leaseDurationSeconds: 15\n  leaseTransitions: 4\n  renewTime: \"2024-01-01T00:00:30.000000Z\"\n",
+					},
+				},
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.PodLeaseHolder("kube-system", "kube-scheduler", "kube-system", "kube-scheduler-node-2").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbUpdate,
+						State:      enum.RevisionStateLeaseHeld,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "spec:\n  holderIdentity: node-2_9c1b\n  leaseDurationSeconds: 15\n  leaseTransitions: 4\n  renewTime: \"2024-01-01T00:00:30.000000Z\"\n",
+					},
+				},
+			},
+		},
+		{
+			name: "same holder acquired the lease again after expiry",
+			yaml: `spec:
+  holderIdentity: node-1_0f2a
+  leaseDurationSeconds: 15
+  leaseTransitions: 4
+  renewTime: "2024-01-01T00:01:00.000000Z"
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &leaseHolderTaskState{
+				holder:           "node-1_0f2a",
+				leaseTransitions: 3,
+				lastRenewTime:    timestamp,
+				holderPod:        &podIdentity{name: "kube-scheduler-node-1", namespace: "kube-system"},
+				resolvedPods: map[string]*podIdentity{
+					"node-1_0f2a": {name: "kube-scheduler-node-1", namespace: "kube-system"},
+				},
+			},
+			wantState: &leaseHolderTaskState{
+				holder:           "node-1_0f2a",
+				leaseTransitions: 4,
+				lastRenewTime:    timestamp.Add(time.Minute),
+				holderPod:        &podIdentity{name: "kube-scheduler-node-1", namespace: "kube-system"},
+				resolvedPods: map[string]*podIdentity{
+					"node-1_0f2a": {name: "kube-scheduler-node-1", namespace: "kube-system"},
+				},
+			},
+			wantSeverity: enum.SeverityUnknown,
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: resourcepath.LeaseHolder("kube-system", "kube-scheduler").Path, WantCount: 1},
+				&testchangeset.MatchRevisionCount{ResourcePath: resourcepath.PodLeaseHolder("kube-system", "kube-scheduler", "kube-system", "kube-scheduler-node-1").Path, WantCount: 1},
+			},
+		},
+		{
+			name: "holder resolved to a pod in another namespace",
+			yaml: `spec:
+  holderIdentity: my-operator-abcde_61d0
+  leaseDurationSeconds: 15
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			wantState: &leaseHolderTaskState{
+				holder:    "my-operator-abcde_61d0",
+				holderPod: &podIdentity{name: "my-operator-abcde", namespace: "operators"},
+				resolvedPods: map[string]*podIdentity{
+					"my-operator-abcde_61d0": {name: "my-operator-abcde", namespace: "operators"},
+				},
+			},
+			wantSeverity: enum.SeverityUnknown,
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{
+					resourcepath.LeaseHolder("kube-system", "kube-scheduler").Path,
+					resourcepath.PodLeaseHolder("kube-system", "kube-scheduler", "operators", "my-operator-abcde").Path,
+				}},
+			},
+		},
+		{
+			name: "holder matching pods in multiple namespaces is not linked",
+			yaml: `spec:
+  holderIdentity: duplicated
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			wantState: &leaseHolderTaskState{
+				holder: "duplicated",
+				resolvedPods: map[string]*podIdentity{
+					"duplicated": nil,
+				},
+			},
+			wantSeverity: enum.SeverityUnknown,
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{
+					resourcepath.LeaseHolder("kube-system", "kube-scheduler").Path,
+				}},
+			},
+		},
+		{
+			name: "holder released the lease",
+			yaml: `spec:
+  holderIdentity: ""
+  leaseTransitions: 3
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &leaseHolderTaskState{
+				holder:           "node-1_0f2a",
+				leaseTransitions: 3,
+				lastRenewTime:    timestamp,
+				holderPod:        &podIdentity{name: "kube-scheduler-node-1", namespace: "kube-system"},
+				resolvedPods:     map[string]*podIdentity{},
+			},
+			wantState: &leaseHolderTaskState{
+				leaseTransitions: 3,
+				resolvedPods:     map[string]*podIdentity{},
+			},
+			wantSeverity: enum.SeverityUnknown,
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.LeaseHolder("kube-system", "kube-scheduler").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbUpdate,
+						State:      enum.RevisionStateLeaseNotHeld,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "spec:\n  holderIdentity: \"\"\n  leaseTransitions: 3\n",
+					},
+				},
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.PodLeaseHolder("kube-system", "kube-scheduler", "kube-system", "kube-scheduler-node-1").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbUpdate,
+						State:      enum.RevisionStateLeaseNotHeld,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "spec:\n  holderIdentity: \"\"\n  leaseTransitions: 3\n",
+					},
+				},
+			},
+		},
+		{
+			name:      "lease deleted",
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion,
+			operation: enum.RevisionVerbDelete,
+			initialState: &leaseHolderTaskState{
+				holder:           "node-1_0f2a",
+				leaseTransitions: 3,
+				lastRenewTime:    timestamp,
+				holderPod:        &podIdentity{name: "kube-scheduler-node-1", namespace: "kube-system"},
+				resolvedPods:     map[string]*podIdentity{},
+			},
+			wantState: &leaseHolderTaskState{
+				leaseTransitions: 3,
+				resolvedPods:     map[string]*podIdentity{},
+			},
+			wantSeverity: enum.SeverityUnknown,
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.LeaseHolder("kube-system", "kube-scheduler").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbDelete,
+						State:      enum.RevisionStateDeleted,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+					},
+				},
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.PodLeaseHolder("kube-system", "kube-scheduler", "kube-system", "kube-scheduler-node-1").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbDelete,
+						State:      enum.RevisionStateDeleted,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+					},
+				},
+			},
+		},
+		{
+			name:      "no body available",
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			wantState: &leaseHolderTaskState{
+				resolvedPods: map[string]*podIdentity{},
+			},
+			wantSeverity: enum.SeverityUnknown,
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reader *structured.NodeReader
+			if tc.yaml != "" {
+				reader = mustParseYAML(t, tc.yaml)
+			}
+			l := log.NewLogWithFieldSetsForTest(
+				&log.CommonFieldSet{},
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{},
+			)
+			commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			commonFieldSet.Timestamp = timestamp
+			k8sFieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+			k8sFieldSet.K8sOperation = &model.KubernetesObjectOperation{Verb: tc.operation}
+			k8sFieldSet.Principal = "user-1"
+
+			event := commonlogk8sauditv2_contract.ResourceChangeEvent{
+				Log:                   l,
+				EventType:             tc.eventType,
+				EventTargetBodyReader: reader,
+				EventTargetResource: &commonlogk8sauditv2_contract.ResourceIdentity{
+					APIVersion: "coordination.k8s.io/v1",
+					Kind:       "lease",
+					Namespace:  "kube-system",
+					Name:       "kube-scheduler",
+				},
+				EventTargetBodyYAML: tc.yaml,
+			}
+
+			cs := history.NewChangeSet(l)
+			nextState, err := task.Process(ctx, 0, event, cs, nil, tc.initialState)
+			if err != nil {
+				t.Fatalf("Process() failed: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantState, nextState, cmp.AllowUnexported(leaseHolderTaskState{}, podIdentity{})); diff != "" {
+				t.Errorf("state mismatch (-want +got):\n%s", diff)
+			}
+			if cs.LogSeverity != tc.wantSeverity {
+				t.Errorf("severity = %v, want %v", cs.LogSeverity, tc.wantSeverity)
+			}
+			for _, asserter := range tc.asserters {
+				asserter.Assert(t, cs)
+			}
+		})
+	}
+}
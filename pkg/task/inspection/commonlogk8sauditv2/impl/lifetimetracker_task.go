@@ -85,7 +85,18 @@ func (r *lifeTimeTrackerTaskSetting) DetectLifetimeLogEvent(ctx context.Context,
 	}
 
 	if l.ResourceBodyReader == nil {
-		if isDeletiveVerb(k8sFieldSet.K8sOperation.Verb) {
+		switch {
+		case k8sFieldSet.MetadataLevel:
+			// Audit logs recorded at metadata level never have resource bodies. The lifetime is tracked only with the verbs.
+			if isDeletiveVerb(k8sFieldSet.K8sOperation.Verb) {
+				l.ResourceDeleted = true
+				prevGroupData.DeletionStarted = false
+				prevGroupData.WasCompletelyRemoved = true
+			} else if l.ResourceCreated {
+				prevGroupData.DeletionStarted = false
+				prevGroupData.WasCompletelyRemoved = false
+			}
+		case isDeletiveVerb(k8sFieldSet.K8sOperation.Verb):
 			prevGroupData.DeletionStarted = true
 			l.ResourceDeleted = true
 		}
@@ -37,6 +37,12 @@ func newTestK8sAuditLogFieldSet(verb enum.RevisionVerb, apiVersion string, plura
 	}
 }
 
+func newTestMetadataLevelK8sAuditLogFieldSet(verb enum.RevisionVerb, apiVersion string, pluralKind string) *commonlogk8sauditv2_contract.K8sAuditLogFieldSet {
+	fieldSet := newTestK8sAuditLogFieldSet(verb, apiVersion, pluralKind)
+	fieldSet.MetadataLevel = true
+	return fieldSet
+}
+
 func TestLifeTimeTrackerTask(t *testing.T) {
 	testCases := []struct {
 		desc                     string
@@ -63,6 +69,38 @@ func TestLifeTimeTrackerTask(t *testing.T) {
 			wantResourceCreated:      false,
 			wantResourceDeleted:      true,
 		},
+		{
+			desc:                     "delete at metadata level",
+			inputK8sAuditLogFieldSet: newTestMetadataLevelK8sAuditLogFieldSet(enum.RevisionVerbDelete, "core/v1", "pods"),
+			prevState:                &lifeTimeTrackerGroupState{WasCompletelyRemoved: false, DeletionStarted: false},
+			wantState:                &lifeTimeTrackerGroupState{WasCompletelyRemoved: true, DeletionStarted: false},
+			wantResourceCreated:      false,
+			wantResourceDeleted:      true,
+		},
+		{
+			desc:                     "delete at metadata level after deletion",
+			inputK8sAuditLogFieldSet: newTestMetadataLevelK8sAuditLogFieldSet(enum.RevisionVerbDelete, "core/v1", "pods"),
+			prevState:                &lifeTimeTrackerGroupState{WasCompletelyRemoved: true, DeletionStarted: false},
+			wantState:                &lifeTimeTrackerGroupState{WasCompletelyRemoved: true, DeletionStarted: false},
+			wantResourceCreated:      false,
+			wantResourceDeleted:      false,
+		},
+		{
+			desc:                     "re-create at metadata level after deletion",
+			inputK8sAuditLogFieldSet: newTestMetadataLevelK8sAuditLogFieldSet(enum.RevisionVerbCreate, "core/v1", "pods"),
+			prevState:                &lifeTimeTrackerGroupState{WasCompletelyRemoved: true, DeletionStarted: false},
+			wantState:                &lifeTimeTrackerGroupState{WasCompletelyRemoved: false, DeletionStarted: false},
+			wantResourceCreated:      true,
+			wantResourceDeleted:      false,
+		},
+		{
+			desc:                     "patch at metadata level during deletion",
+			inputK8sAuditLogFieldSet: newTestMetadataLevelK8sAuditLogFieldSet(enum.RevisionVerbPatch, "core/v1", "pods"),
+			prevState:                &lifeTimeTrackerGroupState{WasCompletelyRemoved: false, DeletionStarted: true},
+			wantState:                &lifeTimeTrackerGroupState{WasCompletelyRemoved: false, DeletionStarted: true},
+			wantResourceCreated:      false,
+			wantResourceDeleted:      false,
+		},
 		{
 			desc:                     "delete with body (graceful period > 0)",
 			inputK8sAuditLogFieldSet: newTestK8sAuditLogFieldSet(enum.RevisionVerbDelete, "core/v1", "pods"),
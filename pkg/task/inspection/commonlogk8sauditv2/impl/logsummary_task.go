@@ -69,7 +69,9 @@ func (s *logSummaryLogToTimelineMapperSetting) LogIngesterTask() taskid.TaskRefe
 func (s *logSummaryLogToTimelineMapperSetting) ProcessLogByGroup(ctx context.Context, l *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
 	commonFieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
 
-	if commonFieldSet.IsError {
+	if commonFieldSet.Severity != enum.SeverityUnknown {
+		cs.SetLogSeverity(commonFieldSet.Severity)
+	} else if commonFieldSet.IsError {
 		cs.SetLogSeverity(enum.SeverityError)
 	}
 
@@ -79,11 +81,20 @@ func (s *logSummaryLogToTimelineMapperSetting) ProcessLogByGroup(ctx context.Con
 }
 
 // logSummary generates the summary string from given log field set.
+// Logs of long-running requests are suffixed with the phase of the request.
 func (s *logSummaryLogToTimelineMapperSetting) logSummary(fieldSet *commonlogk8sauditv2_contract.K8sAuditLogFieldSet) string {
+	suffix := ""
+	if fieldSet.LongRunning() {
+		if fieldSet.IsFirst {
+			suffix = " (started)"
+		} else {
+			suffix = " (finished)"
+		}
+	}
 	if fieldSet.IsError {
-		return fmt.Sprintf("【%s(%d)】%s %s", fieldSet.StatusMessage, fieldSet.StatusCode, fieldSet.VerbString(), fieldSet.RequestURI)
+		return fmt.Sprintf("【%s(%d)】%s %s%s", fieldSet.StatusMessage, fieldSet.StatusCode, fieldSet.VerbString(), fieldSet.RequestURI, suffix)
 	} else {
-		return fmt.Sprintf("%s %s", fieldSet.VerbString(), fieldSet.RequestURI)
+		return fmt.Sprintf("%s %s%s", fieldSet.VerbString(), fieldSet.RequestURI, suffix)
 	}
 }
 
@@ -54,6 +54,32 @@ func TestLogSummaryLogToTimelineMapperSetting_getLogSummary(t *testing.T) {
 			},
 			want: "Delete /test",
 		},
+		{
+			desc: "start of a long-running request",
+			input: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				IsFirst:    true,
+				IsLast:     false,
+				StatusCode: 101,
+				K8sOperation: &model.KubernetesObjectOperation{
+					Verb: enum.RevisionVerbCreate,
+				},
+				RequestURI: "/api/v1/namespaces/default/pods/test/exec",
+			},
+			want: "Create /api/v1/namespaces/default/pods/test/exec (started)",
+		},
+		{
+			desc: "end of a long-running request",
+			input: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				IsFirst:    false,
+				IsLast:     true,
+				StatusCode: 101,
+				K8sOperation: &model.KubernetesObjectOperation{
+					Verb: enum.RevisionVerbCreate,
+				},
+				RequestURI: "/api/v1/namespaces/default/pods/test/exec",
+			},
+			want: "Create /api/v1/namespaces/default/pods/test/exec (finished)",
+		},
 	}
 
 	for _, tc := range testCases {
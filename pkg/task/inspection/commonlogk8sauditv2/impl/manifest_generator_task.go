@@ -15,6 +15,7 @@
 package commonlogk8sauditv2_impl
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log/slog"
@@ -38,10 +39,56 @@ import (
 	googlecloudk8scommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudk8scommon/contract"
 	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
 	"golang.org/x/sync/errgroup"
+	"gopkg.in/yaml.v3"
 )
 
 var bodyPlaceholderForMetadataLevelAuditLog = "# Resource data is unavailable. Audit logs for this resource is recorded at metadata level."
 
+// metadataLevelAuditLogBody is the partial body shown for the revision from an audit log without request and response bodies.
+type metadataLevelAuditLogBody struct {
+	Verb       string                          `yaml:"verb"`
+	User       string                          `yaml:"user,omitempty"`
+	StatusCode int                             `yaml:"statusCode,omitempty"`
+	ObjectRef  metadataLevelAuditLogBodyObjRef `yaml:"objectRef"`
+}
+
+type metadataLevelAuditLogBodyObjRef struct {
+	APIVersion  string `yaml:"apiVersion"`
+	Resource    string `yaml:"resource"`
+	Namespace   string `yaml:"namespace,omitempty"`
+	Name        string `yaml:"name,omitempty"`
+	Subresource string `yaml:"subresource,omitempty"`
+}
+
+// metadataLevelAuditLogBodyYAML returns the YAML shown as the resource body for the audit log recorded at metadata level.
+// It contains the fields of the request read from the audit log instead of the resource because the resource data is unavailable.
+func metadataLevelAuditLogBodyYAML(fieldSet *commonlogk8sauditv2_contract.K8sAuditLogFieldSet) string {
+	op := fieldSet.K8sOperation
+	namespace := op.Namespace
+	if namespace == "cluster-scope" {
+		namespace = ""
+	}
+	var body bytes.Buffer
+	encoder := yaml.NewEncoder(&body)
+	encoder.SetIndent(2)
+	err := encoder.Encode(&metadataLevelAuditLogBody{
+		Verb:       strings.ToLower(fieldSet.VerbString()),
+		User:       fieldSet.Principal,
+		StatusCode: fieldSet.StatusCode,
+		ObjectRef: metadataLevelAuditLogBodyObjRef{
+			APIVersion:  op.APIVersion,
+			Resource:    op.PluralKind,
+			Namespace:   namespace,
+			Name:        op.Name,
+			Subresource: op.SubResourceName,
+		},
+	})
+	if err != nil {
+		return bodyPlaceholderForMetadataLevelAuditLog
+	}
+	return fmt.Sprintf("%s\n# The following fields are read from the audit log.\n%s", bodyPlaceholderForMetadataLevelAuditLog, body.String())
+}
+
 // ManifestGeneratorTask is the task to generate manifest from k8s audit logs.
 var ManifestGeneratorTask = inspectiontaskbase.NewProgressReportableInspectionTask(commonlogk8sauditv2_contract.ManifestGeneratorTaskID, []taskid.UntypedTaskReference{
 	commonlogk8sauditv2_contract.ChangeTargetGrouperTaskID.Ref(),
@@ -144,9 +191,13 @@ func (g *groupManifestGenerator) Process(ctx context.Context, l *log.Log) (*comm
 	}
 
 	if currentBodyReader == nil {
+		bodyYAML := bodyPlaceholderForMetadataLevelAuditLog
+		if fieldSet.MetadataLevel {
+			bodyYAML = metadataLevelAuditLogBodyYAML(fieldSet)
+		}
 		return &commonlogk8sauditv2_contract.ResourceManifestLog{
 			Log:                l,
-			ResourceBodyYAML:   bodyPlaceholderForMetadataLevelAuditLog,
+			ResourceBodyYAML:   bodyYAML,
 			ResourceBodyReader: nil,
 		}, nil
 	}
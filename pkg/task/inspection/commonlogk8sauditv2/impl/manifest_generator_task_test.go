@@ -27,9 +27,12 @@ import (
 )
 
 type testGroupManifestGeneratorInput struct {
-	op           *model.KubernetesObjectOperation
-	requestYAML  string
-	responseYAML string
+	op            *model.KubernetesObjectOperation
+	requestYAML   string
+	responseYAML  string
+	principal     string
+	statusCode    int
+	metadataLevel bool
 }
 
 func TestGroupManifestGenerator(t *testing.T) {
@@ -325,6 +328,56 @@ metadata:
 				"# Resource data is unavailable. Audit logs for this resource is recorded at metadata level.",
 			},
 		},
+		{
+			desc: "requests recorded at metadata level",
+			inputs: []*testGroupManifestGeneratorInput{
+				{
+					op: &model.KubernetesObjectOperation{
+						APIVersion: "apps/v1",
+						PluralKind: "deployments",
+						Namespace:  "default",
+						Name:       "test-deployment",
+						Verb:       enum.RevisionVerbUpdate,
+					},
+					principal:     "user@example.com",
+					statusCode:    200,
+					metadataLevel: true,
+				},
+				{
+					op: &model.KubernetesObjectOperation{
+						APIVersion:      "core/v1",
+						PluralKind:      "nodes",
+						Namespace:       "cluster-scope",
+						Name:            "test-node",
+						SubResourceName: "proxy",
+						Verb:            enum.RevisionVerbDeleteCollection,
+					},
+					metadataLevel: true,
+				},
+			},
+			wantBodies: []string{
+				`# Resource data is unavailable. Audit logs for this resource is recorded at metadata level.
+# The following fields are read from the audit log.
+verb: update
+user: user@example.com
+statusCode: 200
+objectRef:
+  apiVersion: apps/v1
+  resource: deployments
+  namespace: default
+  name: test-deployment
+`,
+				`# Resource data is unavailable. Audit logs for this resource is recorded at metadata level.
+# The following fields are read from the audit log.
+verb: deletecollection
+objectRef:
+  apiVersion: core/v1
+  resource: nodes
+  name: test-node
+  subresource: proxy
+`,
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -346,9 +399,12 @@ metadata:
 					response = structured.NewNodeReader(node)
 				}
 				logs = append(logs, log.NewLogWithFieldSetsForTest(&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
-					K8sOperation: input.op,
-					Request:      request,
-					Response:     response,
+					K8sOperation:  input.op,
+					Request:       request,
+					Response:      response,
+					Principal:     input.principal,
+					StatusCode:    input.statusCode,
+					MetadataLevel: input.metadataLevel,
 				}))
 			}
 
@@ -0,0 +1,253 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+)
+
+// nodeSchedulabilityStateNames is the short name of each node schedulability state used in the summary of eviction logs.
+var nodeSchedulabilityStateNames = map[enum.RevisionState]string{
+	enum.RevisionStateNodeSchedulable:      "schedulable",
+	enum.RevisionStateNodeCordoned:         "cordoned",
+	enum.RevisionStateNodeNoExecuteTainted: "NoExecute-tainted",
+}
+
+type nodeSchedulabilityTaskState struct {
+	// lastSchedulability is the string representation of spec.unschedulable and spec.taints at the last revision.
+	lastSchedulability string
+}
+
+type nodeSchedulabilityLogToTimelineMapperTaskSetting struct {
+}
+
+// Process processes node logs to generate the node schedulability history, and pods/eviction logs to annotate evictions on the pod and node timelines.
+func (n *nodeSchedulabilityLogToTimelineMapperTaskSetting) Process(ctx context.Context, passIndex int, event commonlogk8sauditv2_contract.ResourceChangeEvent, cs *history.ChangeSet, builder *history.Builder, state *nodeSchedulabilityTaskState) (*nodeSchedulabilityTaskState, error) {
+	if state == nil {
+		state = &nodeSchedulabilityTaskState{}
+	}
+	switch {
+	case event.EventTargetResource.Kind == "node":
+		return n.processNode(ctx, event, cs, state)
+	case event.EventType == commonlogk8sauditv2_contract.ChangeEventTypeSourceCreation || event.EventType == commonlogk8sauditv2_contract.ChangeEventTypeSourceModification:
+		return n.processEviction(ctx, event, cs, state)
+	default:
+		return state, nil
+	}
+}
+
+// processNode generates revisions on the schedulability timeline when spec.unschedulable or spec.taints of the node is changed.
+func (n *nodeSchedulabilityLogToTimelineMapperTaskSetting) processNode(ctx context.Context, event commonlogk8sauditv2_contract.ResourceChangeEvent, cs *history.ChangeSet, state *nodeSchedulabilityTaskState) (*nodeSchedulabilityTaskState, error) {
+	commonLogFieldSet := log.MustGetFieldSet(event.Log, &log.CommonFieldSet{})
+	k8sFieldSet := log.MustGetFieldSet(event.Log, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+	schedulabilityPath := resourcepath.NodeSchedulability(event.EventTargetResource.Name)
+	if event.EventType == commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion {
+		if state.lastSchedulability != "" {
+			cs.AddRevision(schedulabilityPath, &history.StagingResourceRevision{
+				Verb:       k8sFieldSet.K8sOperation.Verb,
+				Body:       "",
+				Partial:    false,
+				Requestor:  k8sFieldSet.Principal,
+				ChangeTime: commonLogFieldSet.Timestamp,
+				State:      enum.RevisionStateDeleted,
+			})
+		}
+		state.lastSchedulability = ""
+		return state, nil
+	}
+	if event.EventTargetBodyReader == nil {
+		return state, nil
+	}
+	revisionState, schedulability := nodeSchedulability(event.EventTargetBodyReader)
+	if schedulability == state.lastSchedulability {
+		return state, nil
+	}
+	cs.AddRevision(schedulabilityPath, &history.StagingResourceRevision{
+		Verb:       k8sFieldSet.K8sOperation.Verb,
+		Body:       schedulability,
+		Partial:    false,
+		Requestor:  k8sFieldSet.Principal,
+		ChangeTime: commonLogFieldSet.Timestamp,
+		State:      revisionState,
+	})
+	state.lastSchedulability = schedulability
+	return state, nil
+}
+
+// processEviction adds the eviction log on the pod and the node timelines with the summary containing the node state and the principal evicted the pod.
+func (n *nodeSchedulabilityLogToTimelineMapperTaskSetting) processEviction(ctx context.Context, event commonlogk8sauditv2_contract.ResourceChangeEvent, cs *history.ChangeSet, state *nodeSchedulabilityTaskState) (*nodeSchedulabilityTaskState, error) {
+	k8sFieldSet := log.MustGetFieldSet(event.Log, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+	if k8sFieldSet.K8sOperation.Verb != enum.RevisionVerbCreate {
+		return state, nil
+	}
+	commonLogFieldSet := log.MustGetFieldSet(event.Log, &log.CommonFieldSet{})
+	cs.AddEvent(resourcepath.ResourcePath{
+		Path:               event.EventTargetResource.ResourcePathString(),
+		ParentRelationship: enum.RelationshipChild,
+	})
+	nodeName, found := GetNodeNameOfPod(event.EventTargetBodyReader)
+	if !found || nodeName == "" {
+		cs.SetLogSummary(fmt.Sprintf("Evicted by %s", k8sFieldSet.Principal))
+		return state, nil
+	}
+	cs.AddEvent(resourcepath.NodeSchedulability(nodeName))
+	groupedLogs := coretask.GetTaskResult(ctx, n.GroupedLogTask())
+	nodeState, found := nodeSchedulabilityAt(groupedLogs, nodeName, commonLogFieldSet.Timestamp)
+	if !found {
+		cs.SetLogSummary(fmt.Sprintf("Evicted from node %s by %s", nodeName, k8sFieldSet.Principal))
+		return state, nil
+	}
+	cs.SetLogSummary(fmt.Sprintf("Evicted from %s node %s by %s", nodeSchedulabilityStateNames[nodeState], nodeName, k8sFieldSet.Principal))
+	return state, nil
+}
+
+// nodeUnschedulableTaintKey is the key of the taint added to cordoned nodes.
+const nodeUnschedulableTaintKey = "node.kubernetes.io/unschedulable"
+
+// nodeSchedulability returns the schedulability state of the node and the YAML representation of the fields deciding the state.
+// Only spec.unschedulable and the node.kubernetes.io/unschedulable taint mark the node cordoned. Other NoSchedule taints like the ones of dedicated node pools or control plane nodes are only shown in the body.
+func nodeSchedulability(reader *structured.NodeReader) (enum.RevisionState, string) {
+	unschedulable := reader.ReadBoolOrDefault("spec.unschedulable", false)
+	cordoned := unschedulable
+	taints := []string{}
+	effects := map[string]struct{}{}
+	if taintsReader, err := reader.GetReader("spec.taints"); err == nil {
+		for _, taint := range taintsReader.Children() {
+			key, err := taint.ReadString("key")
+			if err != nil {
+				continue
+			}
+			effect := taint.ReadStringOrDefault("effect", "")
+			if key == nodeUnschedulableTaintKey && effect == "NoSchedule" {
+				cordoned = true
+			}
+			if value := taint.ReadStringOrDefault("value", ""); value != "" {
+				key = fmt.Sprintf("%s=%s", key, value)
+			}
+			taints = append(taints, fmt.Sprintf("%s:%s", key, effect))
+			effects[effect] = struct{}{}
+		}
+	}
+	slices.Sort(taints)
+
+	revisionState := enum.RevisionStateNodeSchedulable
+	if _, found := effects["NoExecute"]; found {
+		revisionState = enum.RevisionStateNodeNoExecuteTainted
+	} else if cordoned {
+		revisionState = enum.RevisionStateNodeCordoned
+	}
+
+	schedulability := fmt.Sprintf("unschedulable: %t\n", unschedulable)
+	if len(taints) > 0 {
+		schedulability += "taints:\n"
+		for _, taint := range taints {
+			schedulability += fmt.Sprintf("- %s\n", taint)
+		}
+	}
+	return revisionState, schedulability
+}
+
+// nodeSchedulabilityAt returns the schedulability state of the node from the last node manifest observed before the given time.
+func nodeSchedulabilityAt(groupedLogs commonlogk8sauditv2_contract.ResourceManifestLogGroupMap, nodeName string, t time.Time) (enum.RevisionState, bool) {
+	group, found := groupedLogs[resourcepath.Node(nodeName).Path]
+	if !found {
+		return enum.RevisionStateNodeSchedulable, false
+	}
+	var lastReader *structured.NodeReader
+	for _, manifestLog := range group.Logs {
+		commonLogFieldSet := log.MustGetFieldSet(manifestLog.Log, &log.CommonFieldSet{})
+		if commonLogFieldSet.Timestamp.After(t) {
+			break
+		}
+		if manifestLog.ResourceBodyReader != nil {
+			lastReader = manifestLog.ResourceBodyReader
+		}
+	}
+	if lastReader == nil {
+		return enum.RevisionStateNodeSchedulable, false
+	}
+	revisionState, _ := nodeSchedulability(lastReader)
+	return revisionState, true
+}
+
+// Dependencies implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (n *nodeSchedulabilityLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{
+		// The log summary task rewrites the summary of every log. This task must run after it to keep the eviction summary.
+		commonlogk8sauditv2_contract.LogSummaryLogToTimelineMapperTaskID.Ref(),
+	}
+}
+
+// PassCount implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (n *nodeSchedulabilityLogToTimelineMapperTaskSetting) PassCount() int {
+	return 1
+}
+
+// GroupedLogTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (n *nodeSchedulabilityLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[commonlogk8sauditv2_contract.ResourceManifestLogGroupMap] {
+	return commonlogk8sauditv2_contract.ResourceLifetimeTrackerTaskID.Ref()
+}
+
+// LogIngesterTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (n *nodeSchedulabilityLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return commonlogk8sauditv2_contract.K8sAuditLogIngesterTaskID.Ref()
+}
+
+// TaskID implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (n *nodeSchedulabilityLogToTimelineMapperTaskSetting) TaskID() taskid.TaskImplementationID[struct{}] {
+	return commonlogk8sauditv2_contract.NodeSchedulabilityLogToTimelineMapperTaskID
+}
+
+// ResourcePairs implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+// It returns nodes as the target without source, and pods as the target with their eviction subresource as the source.
+func (n *nodeSchedulabilityLogToTimelineMapperTaskSetting) ResourcePairs(ctx context.Context, groupedLogs commonlogk8sauditv2_contract.ResourceManifestLogGroupMap) ([]commonlogk8sauditv2_contract.ResourcePair, error) {
+	result := []commonlogk8sauditv2_contract.ResourcePair{}
+	for _, group := range groupedLogs {
+		if group.Resource.APIVersion != "core/v1" {
+			continue
+		}
+		switch {
+		// core/v1#node#cluster-scope#nodename
+		case group.Resource.Type() == commonlogk8sauditv2_contract.Resource && group.Resource.Kind == "node":
+			result = append(result, commonlogk8sauditv2_contract.ResourcePair{
+				TargetGroup: group.Resource,
+			})
+		// core/v1#pod#namespace#podname#eviction
+		case group.Resource.Type() == commonlogk8sauditv2_contract.Subresource && group.Resource.Kind == "pod" && group.Resource.SubresourceName == "eviction":
+			result = append(result, commonlogk8sauditv2_contract.ResourcePair{
+				TargetGroup: group.Resource.ParentIdentity(),
+				SourceGroup: group.Resource,
+			})
+		}
+	}
+	return result, nil
+}
+
+var _ commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting[*nodeSchedulabilityTaskState] = (*nodeSchedulabilityLogToTimelineMapperTaskSetting)(nil)
+
+// NodeSchedulabilityLogToTimelineMapperTask is the task to generate node schedulability history and annotate pod evictions.
+var NodeSchedulabilityLogToTimelineMapperTask = commonlogk8sauditv2_contract.NewManifestLogToTimelineMapper[*nodeSchedulabilityTaskState](&nodeSchedulabilityLogToTimelineMapperTaskSetting{})
@@ -0,0 +1,406 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	tasktest "github.com/GoogleCloudPlatform/khi/pkg/core/task/test"
+	"github.com/GoogleCloudPlatform/khi/pkg/model"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/testchangeset"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestNodeSchedulabilityLogToTimelineMapperTask_Process(t *testing.T) {
+	task := &nodeSchedulabilityLogToTimelineMapperTaskSetting{}
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	nodeIdentity := &commonlogk8sauditv2_contract.ResourceIdentity{APIVersion: "core/v1", Kind: "node", Namespace: "cluster-scope", Name: "node-1"}
+	podIdentity := &commonlogk8sauditv2_contract.ResourceIdentity{APIVersion: "core/v1", Kind: "pod", Namespace: "default", Name: "web-0"}
+	evictionIdentity := podIdentity.SubresourceIdentity("eviction")
+
+	nodeLog := func(t *testing.T, timestamp time.Time, yaml string) *commonlogk8sauditv2_contract.ResourceManifestLog {
+		return &commonlogk8sauditv2_contract.ResourceManifestLog{
+			Log:                log.NewLogWithFieldSetsForTest(&log.CommonFieldSet{Timestamp: timestamp}),
+			ResourceBodyYAML:   yaml,
+			ResourceBodyReader: mustParseYAML(t, yaml),
+		}
+	}
+	groupedLogs := commonlogk8sauditv2_contract.ResourceManifestLogGroupMap{
+		nodeIdentity.ResourcePathString(): {
+			Resource: nodeIdentity,
+			Logs: []*commonlogk8sauditv2_contract.ResourceManifestLog{
+				nodeLog(t, timestamp.Add(-time.Minute), "spec: {}\n"),
+				nodeLog(t, timestamp.Add(-time.Second), "spec:\n  unschedulable: true\n"),
+				nodeLog(t, timestamp.Add(time.Minute), "spec:\n  taints:\n  - key: node.kubernetes.io/unreachable\n    effect: NoExecute\n"),
+			},
+		},
+		resourcepath.Node("control-plane-1").Path: {
+			Resource: &commonlogk8sauditv2_contract.ResourceIdentity{APIVersion: "core/v1", Kind: "node", Namespace: "cluster-scope", Name: "control-plane-1"},
+			Logs: []*commonlogk8sauditv2_contract.ResourceManifestLog{
+				nodeLog(t, timestamp.Add(-time.Minute), "spec:\n  taints:\n  - key: node-role.kubernetes.io/control-plane\n    effect: NoSchedule\n"),
+			},
+		},
+	}
+	ctx := tasktest.WithTaskResult(context.Background(), commonlogk8sauditv2_contract.ResourceLifetimeTrackerTaskID.Ref(), groupedLogs)
+
+	testCases := []struct {
+		name          string
+		targetYAML    string
+		eventType     commonlogk8sauditv2_contract.ChangeEventType
+		operation     enum.RevisionVerb
+		target        *commonlogk8sauditv2_contract.ResourceIdentity
+		source        *commonlogk8sauditv2_contract.ResourceIdentity
+		initialState  *nodeSchedulabilityTaskState
+		wantState     *nodeSchedulabilityTaskState
+		wantSummary   string
+		wantAsserters []testchangeset.ChangeSetAsserter
+	}{
+		{
+			name: "schedulable node",
+			targetYAML: `spec:
+  podCIDR: 10.0.0.0/24
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+			operation: enum.RevisionVerbCreate,
+			target:    nodeIdentity,
+			wantState: &nodeSchedulabilityTaskState{
+				lastSchedulability: "unschedulable: false\n",
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.NodeSchedulability("node-1").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbCreate,
+						State:      enum.RevisionStateNodeSchedulable,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "unschedulable: false\n",
+					},
+				},
+			},
+		},
+		{
+			name: "cordoned node",
+			targetYAML: `spec:
+  unschedulable: true
+  taints:
+  - key: node.kubernetes.io/unschedulable
+    effect: NoSchedule
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbPatch,
+			target:    nodeIdentity,
+			initialState: &nodeSchedulabilityTaskState{
+				lastSchedulability: "unschedulable: false\n",
+			},
+			wantState: &nodeSchedulabilityTaskState{
+				lastSchedulability: "unschedulable: true\ntaints:\n- node.kubernetes.io/unschedulable:NoSchedule\n",
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.NodeSchedulability("node-1").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbPatch,
+						State:      enum.RevisionStateNodeCordoned,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "unschedulable: true\ntaints:\n- node.kubernetes.io/unschedulable:NoSchedule\n",
+					},
+				},
+			},
+		},
+		{
+			name: "node with a NoSchedule taint of a dedicated node pool",
+			targetYAML: `spec:
+  taints:
+  - key: dedicated
+    value: gpu
+    effect: NoSchedule
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+			operation: enum.RevisionVerbCreate,
+			target:    nodeIdentity,
+			wantState: &nodeSchedulabilityTaskState{
+				lastSchedulability: "unschedulable: false\ntaints:\n- dedicated=gpu:NoSchedule\n",
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.NodeSchedulability("node-1").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbCreate,
+						State:      enum.RevisionStateNodeSchedulable,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "unschedulable: false\ntaints:\n- dedicated=gpu:NoSchedule\n",
+					},
+				},
+			},
+		},
+		{
+			name: "cordoned node only with the unschedulable taint",
+			targetYAML: `spec:
+  taints:
+  - key: node.kubernetes.io/unschedulable
+    effect: NoSchedule
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbPatch,
+			target:    nodeIdentity,
+			initialState: &nodeSchedulabilityTaskState{
+				lastSchedulability: "unschedulable: false\n",
+			},
+			wantState: &nodeSchedulabilityTaskState{
+				lastSchedulability: "unschedulable: false\ntaints:\n- node.kubernetes.io/unschedulable:NoSchedule\n",
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.NodeSchedulability("node-1").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbPatch,
+						State:      enum.RevisionStateNodeCordoned,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "unschedulable: false\ntaints:\n- node.kubernetes.io/unschedulable:NoSchedule\n",
+					},
+				},
+			},
+		},
+		{
+			name: "NoExecute tainted node",
+			targetYAML: `spec:
+  taints:
+  - key: node.kubernetes.io/unreachable
+    effect: NoSchedule
+  - key: dedicated
+    value: gpu
+    effect: NoExecute
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbPatch,
+			target:    nodeIdentity,
+			initialState: &nodeSchedulabilityTaskState{
+				lastSchedulability: "unschedulable: false\n",
+			},
+			wantState: &nodeSchedulabilityTaskState{
+				lastSchedulability: "unschedulable: false\ntaints:\n- dedicated=gpu:NoExecute\n- node.kubernetes.io/unreachable:NoSchedule\n",
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.NodeSchedulability("node-1").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbPatch,
+						State:      enum.RevisionStateNodeNoExecuteTainted,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "unschedulable: false\ntaints:\n- dedicated=gpu:NoExecute\n- node.kubernetes.io/unreachable:NoSchedule\n",
+					},
+				},
+			},
+		},
+		{
+			name: "node status update without schedulability change",
+			targetYAML: `spec: {}
+status:
+  phase: Running
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbPatch,
+			target:    nodeIdentity,
+			initialState: &nodeSchedulabilityTaskState{
+				lastSchedulability: "unschedulable: false\n",
+			},
+			wantState: &nodeSchedulabilityTaskState{
+				lastSchedulability: "unschedulable: false\n",
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{}},
+			},
+		},
+		{
+			name:      "node deletion",
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion,
+			operation: enum.RevisionVerbDelete,
+			target:    nodeIdentity,
+			initialState: &nodeSchedulabilityTaskState{
+				lastSchedulability: "unschedulable: false\n",
+			},
+			wantState: &nodeSchedulabilityTaskState{},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: resourcepath.NodeSchedulability("node-1").Path,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbDelete,
+						State:      enum.RevisionStateDeleted,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+					},
+				},
+			},
+		},
+		{
+			name: "eviction from a cordoned node",
+			targetYAML: `spec:
+  nodeName: node-1
+`,
+			eventType:   commonlogk8sauditv2_contract.ChangeEventTypeSourceCreation,
+			operation:   enum.RevisionVerbCreate,
+			target:      podIdentity,
+			source:      evictionIdentity,
+			wantState:   &nodeSchedulabilityTaskState{},
+			wantSummary: "Evicted from cordoned node node-1 by user-1",
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: resourcepath.Pod("default", "web-0").Path, WantCount: 0},
+				&testchangeset.HasEvent{ResourcePath: resourcepath.Pod("default", "web-0").Path},
+				&testchangeset.HasEvent{ResourcePath: resourcepath.NodeSchedulability("node-1").Path},
+			},
+		},
+		{
+			name: "eviction from a control plane node with a NoSchedule taint",
+			targetYAML: `spec:
+  nodeName: control-plane-1
+`,
+			eventType:   commonlogk8sauditv2_contract.ChangeEventTypeSourceCreation,
+			operation:   enum.RevisionVerbCreate,
+			target:      podIdentity,
+			source:      evictionIdentity,
+			wantState:   &nodeSchedulabilityTaskState{},
+			wantSummary: "Evicted from schedulable node control-plane-1 by user-1",
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasEvent{ResourcePath: resourcepath.Pod("default", "web-0").Path},
+				&testchangeset.HasEvent{ResourcePath: resourcepath.NodeSchedulability("control-plane-1").Path},
+			},
+		},
+		{
+			name: "eviction from a node without logs",
+			targetYAML: `spec:
+  nodeName: node-2
+`,
+			eventType:   commonlogk8sauditv2_contract.ChangeEventTypeSourceModification,
+			operation:   enum.RevisionVerbCreate,
+			target:      podIdentity,
+			source:      evictionIdentity,
+			wantState:   &nodeSchedulabilityTaskState{},
+			wantSummary: "Evicted from node node-2 by user-1",
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasEvent{ResourcePath: resourcepath.Pod("default", "web-0").Path},
+				&testchangeset.HasEvent{ResourcePath: resourcepath.NodeSchedulability("node-2").Path},
+			},
+		},
+		{
+			name:        "eviction of a pod without known body",
+			eventType:   commonlogk8sauditv2_contract.ChangeEventTypeSourceCreation,
+			operation:   enum.RevisionVerbCreate,
+			target:      podIdentity,
+			source:      evictionIdentity,
+			wantState:   &nodeSchedulabilityTaskState{},
+			wantSummary: "Evicted by user-1",
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{resourcepath.Pod("default", "web-0").Path}},
+			},
+		},
+		{
+			name: "pod modification in the eviction pair",
+			targetYAML: `spec:
+  nodeName: node-1
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+			operation: enum.RevisionVerbCreate,
+			target:    podIdentity,
+			source:    evictionIdentity,
+			wantState: &nodeSchedulabilityTaskState{},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reader *structured.NodeReader
+			if tc.targetYAML != "" {
+				reader = mustParseYAML(t, tc.targetYAML)
+			}
+			l := log.NewLogWithFieldSetsForTest(
+				&log.CommonFieldSet{},
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{},
+			)
+			commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			commonFieldSet.Timestamp = timestamp
+			k8sFieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+			k8sFieldSet.K8sOperation = &model.KubernetesObjectOperation{Verb: tc.operation}
+			k8sFieldSet.Principal = "user-1"
+
+			event := commonlogk8sauditv2_contract.ResourceChangeEvent{
+				Log:                   l,
+				EventType:             tc.eventType,
+				EventSourceResource:   tc.source,
+				EventTargetResource:   tc.target,
+				EventTargetBodyReader: reader,
+				EventTargetBodyYAML:   tc.targetYAML,
+			}
+
+			cs := history.NewChangeSet(l)
+			nextState, err := task.Process(ctx, 0, event, cs, nil, tc.initialState)
+			if err != nil {
+				t.Fatalf("Process() failed: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantState, nextState, cmp.AllowUnexported(nodeSchedulabilityTaskState{})); diff != "" {
+				t.Errorf("state mismatch (-want +got):\n%s", diff)
+			}
+			if cs.LogSummary != tc.wantSummary {
+				t.Errorf("summary = %q, want %q", cs.LogSummary, tc.wantSummary)
+			}
+			for _, asserter := range tc.wantAsserters {
+				asserter.Assert(t, cs)
+			}
+		})
+	}
+}
+
+func TestNodeSchedulabilityLogToTimelineMapperTask_ResourcePairs(t *testing.T) {
+	nodeIdentity := &commonlogk8sauditv2_contract.ResourceIdentity{APIVersion: "core/v1", Kind: "node", Namespace: "cluster-scope", Name: "node-1"}
+	podIdentity := &commonlogk8sauditv2_contract.ResourceIdentity{APIVersion: "core/v1", Kind: "pod", Namespace: "default", Name: "web-0"}
+	evictionIdentity := podIdentity.SubresourceIdentity("eviction")
+	bindingIdentity := podIdentity.SubresourceIdentity("binding")
+	groupedLogs := commonlogk8sauditv2_contract.ResourceManifestLogGroupMap{}
+	for _, identity := range []*commonlogk8sauditv2_contract.ResourceIdentity{nodeIdentity, podIdentity, evictionIdentity, bindingIdentity} {
+		groupedLogs[identity.ResourcePathString()] = &commonlogk8sauditv2_contract.ResourceManifestLogGroup{Resource: identity}
+	}
+
+	got, err := (&nodeSchedulabilityLogToTimelineMapperTaskSetting{}).ResourcePairs(context.Background(), groupedLogs)
+	if err != nil {
+		t.Fatalf("ResourcePairs() failed: %v", err)
+	}
+	want := []commonlogk8sauditv2_contract.ResourcePair{
+		{TargetGroup: nodeIdentity},
+		{TargetGroup: podIdentity, SourceGroup: evictionIdentity},
+	}
+	sortPairs := cmpopts.SortSlices(func(a, b commonlogk8sauditv2_contract.ResourcePair) bool {
+		return a.TargetGroup.ResourcePathString() < b.TargetGroup.ResourcePathString()
+	})
+	if diff := cmp.Diff(want, got, sortPairs); diff != "" {
+		t.Errorf("ResourcePairs() mismatch (-want +got):\n%s", diff)
+	}
+}
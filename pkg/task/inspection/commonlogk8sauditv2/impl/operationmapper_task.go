@@ -0,0 +1,104 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+)
+
+// LongRunningOperationLogToTimelineMapperTask is the task to show long-running requests like exec, port-forward or log streaming as operations with their start and end.
+var LongRunningOperationLogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](
+	commonlogk8sauditv2_contract.LongRunningOperationLogToTimelineMapperTaskID,
+	&longRunningOperationLogToTimelineMapperTaskSetting{},
+)
+
+type longRunningOperationLogToTimelineMapperTaskSetting struct{}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (l *longRunningOperationLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (l *longRunningOperationLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return commonlogk8sauditv2_contract.LongRunningLogGrouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (l *longRunningOperationLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return commonlogk8sauditv2_contract.K8sAuditLogIngesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (l *longRunningOperationLogToTimelineMapperTaskSetting) ProcessLogByGroup(ctx context.Context, lg *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	return struct{}{}, l.addOperationRevision(lg, cs)
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*longRunningOperationLogToTimelineMapperTaskSetting)(nil)
+
+// addOperationRevision adds the revision for the start or the end of the long-running request.
+func (l *longRunningOperationLogToTimelineMapperTaskSetting) addOperationRevision(lg *log.Log, cs *history.ChangeSet) error {
+	commonFieldSet := log.MustGetFieldSet(lg, &log.CommonFieldSet{})
+	fieldSet := log.MustGetFieldSet(lg, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+
+	verb := enum.RevisionVerbOperationStart
+	state := enum.RevisionStateOperationStarted
+	if fieldSet.IsLast {
+		verb = enum.RevisionVerbOperationFinish
+		state = enum.RevisionStateOperationFinished
+	}
+	body := ""
+	if fieldSet.Request != nil {
+		requestBody, err := fieldSet.Request.Serialize("", &structured.YAMLNodeSerializer{})
+		if err != nil {
+			return fmt.Errorf("failed to serialize the request body: %w", err)
+		}
+		body = string(requestBody)
+	}
+	cs.AddRevision(longRunningOperationPath(fieldSet), &history.StagingResourceRevision{
+		Body:       body,
+		Verb:       verb,
+		State:      state,
+		Requestor:  fieldSet.Principal,
+		ChangeTime: commonFieldSet.Timestamp,
+		Partial:    false,
+	})
+	return nil
+}
+
+// longRunningOperationPath returns the path of the operation timeline for the long-running request.
+// The operation is placed under the resource the request is made against and named with the subresource like exec. Requests without subresources like watches are named as `request`.
+func longRunningOperationPath(fieldSet *commonlogk8sauditv2_contract.K8sAuditLogFieldSet) resourcepath.ResourcePath {
+	owner := fieldSet.K8sOperation.Clone()
+	method := owner.SubResourceName
+	if method == "" {
+		method = "request"
+	}
+	owner.SubResourceName = ""
+	return resourcepath.Operation(resourcepath.ResourcePath{
+		Path:               owner.ResourcePath(),
+		ParentRelationship: enum.RelationshipChild,
+	}, method, fieldSet.OperationID)
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/model"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/testchangeset"
+)
+
+func TestLongRunningOperationLogToTimelineMapperTaskSetting_AddOperationRevision(t *testing.T) {
+	testTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	execOperation := &model.KubernetesObjectOperation{
+		APIVersion:      "core/v1",
+		PluralKind:      "pods",
+		Namespace:       "default",
+		Name:            "test-pod",
+		SubResourceName: "exec",
+		Verb:            enum.RevisionVerbCreate,
+	}
+	testCases := []struct {
+		desc     string
+		input    *commonlogk8sauditv2_contract.K8sAuditLogFieldSet
+		wantPath string
+		want     history.StagingResourceRevision
+	}{
+		{
+			desc: "start of an exec session",
+			input: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				OperationID:  "exec-id",
+				IsFirst:      true,
+				IsLast:       false,
+				Principal:    "user@example.com",
+				K8sOperation: execOperation,
+			},
+			wantPath: "core/v1#pod#default#test-pod#exec-exec-id",
+			want: history.StagingResourceRevision{
+				Verb:       enum.RevisionVerbOperationStart,
+				State:      enum.RevisionStateOperationStarted,
+				Requestor:  "user@example.com",
+				ChangeTime: testTime,
+			},
+		},
+		{
+			desc: "end of an exec session",
+			input: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				OperationID:  "exec-id",
+				IsFirst:      false,
+				IsLast:       true,
+				Principal:    "user@example.com",
+				K8sOperation: execOperation,
+			},
+			wantPath: "core/v1#pod#default#test-pod#exec-exec-id",
+			want: history.StagingResourceRevision{
+				Verb:       enum.RevisionVerbOperationFinish,
+				State:      enum.RevisionStateOperationFinished,
+				Requestor:  "user@example.com",
+				ChangeTime: testTime,
+			},
+		},
+		{
+			desc: "watch without subresource",
+			input: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				OperationID: "watch-id",
+				IsFirst:     true,
+				IsLast:      false,
+				Principal:   "system:kube-scheduler",
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion: "core/v1",
+					PluralKind: "pods",
+					Namespace:  "default",
+					Name:       "test-pod",
+					Verb:       enum.RevisionVerbUnknown,
+				},
+			},
+			wantPath: "core/v1#pod#default#test-pod#request-watch-id",
+			want: history.StagingResourceRevision{
+				Verb:       enum.RevisionVerbOperationStart,
+				State:      enum.RevisionStateOperationStarted,
+				Requestor:  "system:kube-scheduler",
+				ChangeTime: testTime,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l := log.NewLogWithFieldSetsForTest(&log.CommonFieldSet{Timestamp: testTime}, tc.input)
+			cs := history.NewChangeSet(l)
+
+			setting := &longRunningOperationLogToTimelineMapperTaskSetting{}
+			err := setting.addOperationRevision(l, cs)
+			if err != nil {
+				t.Fatalf("addOperationRevision() returned an unexpected error: %v", err)
+			}
+
+			asserter := testchangeset.HasRevision{
+				ResourcePath: tc.wantPath,
+				WantRevision: tc.want,
+			}
+			asserter.Assert(t, cs)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+)
+
+var claimPhaseToState = map[string]enum.RevisionState{
+	"Pending": enum.RevisionStateVolumePending,
+	"Bound":   enum.RevisionStateVolumeBound,
+	"Lost":    enum.RevisionStateVolumeLost,
+}
+
+type persistentVolumeClaimTaskState struct {
+	// lastPhase is the last phase of the PersistentVolumeClaim.
+	lastPhase string
+	// linkedClaims is the set of claim names already linked under the Pod.
+	linkedClaims map[string]struct{}
+}
+
+type persistentVolumeClaimLogToTimelineMapperTaskSetting struct {
+}
+
+// Process processes PersistentVolumeClaim logs to generate the phase history, and Pod logs to show the phase of the claims under the Pod using them.
+func (p *persistentVolumeClaimLogToTimelineMapperTaskSetting) Process(ctx context.Context, passIndex int, event commonlogk8sauditv2_contract.ResourceChangeEvent, cs *history.ChangeSet, builder *history.Builder, state *persistentVolumeClaimTaskState) (*persistentVolumeClaimTaskState, error) {
+	if state == nil {
+		state = &persistentVolumeClaimTaskState{
+			linkedClaims: map[string]struct{}{},
+		}
+	}
+	if event.EventTargetResource.Kind == "pod" {
+		return p.processPod(ctx, event, cs, state)
+	}
+	return p.processClaim(ctx, event, cs, state)
+}
+
+// processClaim generates revisions on the phase timeline of the PersistentVolumeClaim when status.phase is changed.
+func (p *persistentVolumeClaimLogToTimelineMapperTaskSetting) processClaim(ctx context.Context, event commonlogk8sauditv2_contract.ResourceChangeEvent, cs *history.ChangeSet, state *persistentVolumeClaimTaskState) (*persistentVolumeClaimTaskState, error) {
+	commonLogFieldSet := log.MustGetFieldSet(event.Log, &log.CommonFieldSet{})
+	k8sFieldSet := log.MustGetFieldSet(event.Log, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+	phasePath := resourcepath.VolumePhase(resourcepath.PersistentVolumeClaim(event.EventTargetResource.Namespace, event.EventTargetResource.Name))
+	if event.EventType == commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion {
+		if state.lastPhase != "" {
+			cs.AddRevision(phasePath, &history.StagingResourceRevision{
+				Verb:       k8sFieldSet.K8sOperation.Verb,
+				Body:       "",
+				Partial:    false,
+				Requestor:  k8sFieldSet.Principal,
+				ChangeTime: commonLogFieldSet.Timestamp,
+				State:      enum.RevisionStateDeleted,
+			})
+		}
+		state.lastPhase = ""
+		return state, nil
+	}
+	if event.EventTargetBodyReader == nil {
+		return state, nil
+	}
+	phase, err := event.EventTargetBodyReader.ReadString("status.phase")
+	if err != nil {
+		return state, nil
+	}
+	revisionState, found := claimPhaseToState[phase]
+	if !found || phase == state.lastPhase {
+		return state, nil
+	}
+	cs.AddRevision(phasePath, &history.StagingResourceRevision{
+		Verb:       k8sFieldSet.K8sOperation.Verb,
+		Body:       event.EventTargetBodyYAML,
+		Partial:    false,
+		Requestor:  k8sFieldSet.Principal,
+		ChangeTime: commonLogFieldSet.Timestamp,
+		State:      revisionState,
+	})
+	state.lastPhase = phase
+	return state, nil
+}
+
+// processPod links the phase timelines of the PersistentVolumeClaims referenced from spec.volumes under the Pod.
+func (p *persistentVolumeClaimLogToTimelineMapperTaskSetting) processPod(ctx context.Context, event commonlogk8sauditv2_contract.ResourceChangeEvent, cs *history.ChangeSet, state *persistentVolumeClaimTaskState) (*persistentVolumeClaimTaskState, error) {
+	if event.EventTargetBodyReader == nil {
+		return state, nil
+	}
+	volumes, err := event.EventTargetBodyReader.GetReader("spec.volumes")
+	if err != nil {
+		return state, nil
+	}
+	groupedLogs := coretask.GetTaskResult(ctx, p.GroupedLogTask())
+	for _, volume := range volumes.Children() {
+		claimName, err := volume.ReadString("persistentVolumeClaim.claimName")
+		if err != nil {
+			continue
+		}
+		if _, found := state.linkedClaims[claimName]; found {
+			continue
+		}
+		claimPath := resourcepath.PersistentVolumeClaim(event.EventTargetResource.Namespace, claimName)
+		// Claims without any logs have no phase timeline to show under the Pod.
+		if _, found := groupedLogs[claimPath.Path]; !found {
+			continue
+		}
+		phasePath := resourcepath.VolumePhase(claimPath)
+		cs.AddResourceAlias(phasePath, resourcepath.PodVolumeClaim(event.EventTargetResource.Namespace, event.EventTargetResource.Name, claimName))
+		state.linkedClaims[claimName] = struct{}{}
+	}
+	return state, nil
+}
+
+// Dependencies implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (p *persistentVolumeClaimLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// PassCount implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (p *persistentVolumeClaimLogToTimelineMapperTaskSetting) PassCount() int {
+	return 1
+}
+
+// GroupedLogTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (p *persistentVolumeClaimLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[commonlogk8sauditv2_contract.ResourceManifestLogGroupMap] {
+	return commonlogk8sauditv2_contract.ResourceLifetimeTrackerTaskID.Ref()
+}
+
+// LogIngesterTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (p *persistentVolumeClaimLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return commonlogk8sauditv2_contract.K8sAuditLogIngesterTaskID.Ref()
+}
+
+// TaskID implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (p *persistentVolumeClaimLogToTimelineMapperTaskSetting) TaskID() taskid.TaskImplementationID[struct{}] {
+	return commonlogk8sauditv2_contract.PersistentVolumeClaimLogToTimelineMapperTaskID
+}
+
+// ResourcePairs implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+// It returns PersistentVolumeClaims and Pods which may use them.
+func (p *persistentVolumeClaimLogToTimelineMapperTaskSetting) ResourcePairs(ctx context.Context, groupedLogs commonlogk8sauditv2_contract.ResourceManifestLogGroupMap) ([]commonlogk8sauditv2_contract.ResourcePair, error) {
+	result := []commonlogk8sauditv2_contract.ResourcePair{}
+	for _, group := range groupedLogs {
+		// core/v1#persistentvolumeclaim#namespace#name or core/v1#pod#namespace#name
+		if group.Resource.Type() != commonlogk8sauditv2_contract.Resource || group.Resource.APIVersion != "core/v1" || (group.Resource.Kind != "persistentvolumeclaim" && group.Resource.Kind != "pod") {
+			continue
+		}
+		result = append(result, commonlogk8sauditv2_contract.ResourcePair{
+			TargetGroup: group.Resource,
+		})
+	}
+	return result, nil
+}
+
+var _ commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting[*persistentVolumeClaimTaskState] = (*persistentVolumeClaimLogToTimelineMapperTaskSetting)(nil)
+
+// PersistentVolumeClaimLogToTimelineMapperTask is the task to generate PersistentVolumeClaim phase history.
+var PersistentVolumeClaimLogToTimelineMapperTask = commonlogk8sauditv2_contract.NewManifestLogToTimelineMapper[*persistentVolumeClaimTaskState](&persistentVolumeClaimLogToTimelineMapperTaskSetting{})
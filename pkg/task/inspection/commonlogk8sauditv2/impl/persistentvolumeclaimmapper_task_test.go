@@ -0,0 +1,278 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	tasktest "github.com/GoogleCloudPlatform/khi/pkg/core/task/test"
+	"github.com/GoogleCloudPlatform/khi/pkg/model"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/testchangeset"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestPersistentVolumeClaimLogToTimelineMapperTask_Process(t *testing.T) {
+	task := &persistentVolumeClaimLogToTimelineMapperTaskSetting{}
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	claimIdentity := &commonlogk8sauditv2_contract.ResourceIdentity{APIVersion: "core/v1", Kind: "persistentvolumeclaim", Namespace: "default", Name: "data-web-0"}
+	podIdentity := &commonlogk8sauditv2_contract.ResourceIdentity{APIVersion: "core/v1", Kind: "pod", Namespace: "default", Name: "web-0"}
+	groupedLogs := commonlogk8sauditv2_contract.ResourceManifestLogGroupMap{
+		claimIdentity.ResourcePathString(): {Resource: claimIdentity},
+	}
+	ctx := tasktest.WithTaskResult(context.Background(), commonlogk8sauditv2_contract.ResourceLifetimeTrackerTaskID.Ref(), groupedLogs)
+	claimPhasePath := resourcepath.VolumePhase(resourcepath.PersistentVolumeClaim("default", "data-web-0")).Path
+
+	testCases := []struct {
+		name          string
+		targetYAML    string
+		eventType     commonlogk8sauditv2_contract.ChangeEventType
+		operation     enum.RevisionVerb
+		target        *commonlogk8sauditv2_contract.ResourceIdentity
+		initialState  *persistentVolumeClaimTaskState
+		wantState     *persistentVolumeClaimTaskState
+		wantAliases   []string
+		wantAsserters []testchangeset.ChangeSetAsserter
+	}{
+		{
+			name: "pending claim",
+			targetYAML: `status:
+  phase: Pending
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+			operation: enum.RevisionVerbCreate,
+			target:    claimIdentity,
+			wantState: &persistentVolumeClaimTaskState{
+				lastPhase:    "Pending",
+				linkedClaims: map[string]struct{}{},
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: claimPhasePath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbCreate,
+						State:      enum.RevisionStateVolumePending,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "status:\n  phase: Pending\n",
+					},
+				},
+			},
+		},
+		{
+			name: "claim bound",
+			targetYAML: `spec:
+  volumeName: pv-1
+status:
+  phase: Bound
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			target:    claimIdentity,
+			initialState: &persistentVolumeClaimTaskState{
+				lastPhase:    "Pending",
+				linkedClaims: map[string]struct{}{},
+			},
+			wantState: &persistentVolumeClaimTaskState{
+				lastPhase:    "Bound",
+				linkedClaims: map[string]struct{}{},
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: claimPhasePath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbUpdate,
+						State:      enum.RevisionStateVolumeBound,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "spec:\n  volumeName: pv-1\nstatus:\n  phase: Bound\n",
+					},
+				},
+			},
+		},
+		{
+			name: "claim modification without phase change",
+			targetYAML: `metadata:
+  labels:
+    app: web
+status:
+  phase: Bound
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbPatch,
+			target:    claimIdentity,
+			initialState: &persistentVolumeClaimTaskState{
+				lastPhase:    "Bound",
+				linkedClaims: map[string]struct{}{},
+			},
+			wantState: &persistentVolumeClaimTaskState{
+				lastPhase:    "Bound",
+				linkedClaims: map[string]struct{}{},
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{}},
+			},
+		},
+		{
+			name:      "claim deletion",
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion,
+			operation: enum.RevisionVerbDelete,
+			target:    claimIdentity,
+			initialState: &persistentVolumeClaimTaskState{
+				lastPhase:    "Bound",
+				linkedClaims: map[string]struct{}{},
+			},
+			wantState: &persistentVolumeClaimTaskState{
+				linkedClaims: map[string]struct{}{},
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: claimPhasePath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbDelete,
+						State:      enum.RevisionStateDeleted,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+					},
+				},
+			},
+		},
+		{
+			name: "pod using claims",
+			targetYAML: `spec:
+  volumes:
+  - name: data
+    persistentVolumeClaim:
+      claimName: data-web-0
+  - name: cache
+    persistentVolumeClaim:
+      claimName: cache-without-logs
+  - name: config
+    configMap:
+      name: web-config
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+			operation: enum.RevisionVerbCreate,
+			target:    podIdentity,
+			wantState: &persistentVolumeClaimTaskState{
+				linkedClaims: map[string]struct{}{"data-web-0": {}},
+			},
+			wantAliases: []string{resourcepath.PodVolumeClaim("default", "web-0", "data-web-0").Path},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{}},
+			},
+		},
+		{
+			name: "pod using already linked claim",
+			targetYAML: `spec:
+  volumes:
+  - name: data
+    persistentVolumeClaim:
+      claimName: data-web-0
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbPatch,
+			target:    podIdentity,
+			initialState: &persistentVolumeClaimTaskState{
+				linkedClaims: map[string]struct{}{"data-web-0": {}},
+			},
+			wantState: &persistentVolumeClaimTaskState{
+				linkedClaims: map[string]struct{}{"data-web-0": {}},
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reader *structured.NodeReader
+			if tc.targetYAML != "" {
+				reader = mustParseYAML(t, tc.targetYAML)
+			}
+			l := log.NewLogWithFieldSetsForTest(
+				&log.CommonFieldSet{},
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{},
+			)
+			commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			commonFieldSet.Timestamp = timestamp
+			k8sFieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+			k8sFieldSet.K8sOperation = &model.KubernetesObjectOperation{Verb: tc.operation}
+			k8sFieldSet.Principal = "user-1"
+
+			event := commonlogk8sauditv2_contract.ResourceChangeEvent{
+				Log:                   l,
+				EventType:             tc.eventType,
+				EventTargetResource:   tc.target,
+				EventTargetBodyReader: reader,
+				EventTargetBodyYAML:   tc.targetYAML,
+			}
+
+			cs := history.NewChangeSet(l)
+			nextState, err := task.Process(ctx, 0, event, cs, nil, tc.initialState)
+			if err != nil {
+				t.Fatalf("Process() failed: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantState, nextState, cmp.AllowUnexported(persistentVolumeClaimTaskState{})); diff != "" {
+				t.Errorf("state mismatch (-want +got):\n%s", diff)
+			}
+			// Aliases from the phase timeline of claims without logs must not be added.
+			gotAliases := cs.GetAliases(resourcepath.VolumePhase(resourcepath.PersistentVolumeClaim("default", "cache-without-logs")))
+			gotAliases = append(gotAliases, cs.GetAliases(resourcepath.ResourcePath{Path: claimPhasePath})...)
+			if diff := cmp.Diff(tc.wantAliases, gotAliases, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("aliases mismatch (-want +got):\n%s", diff)
+			}
+			for _, asserter := range tc.wantAsserters {
+				asserter.Assert(t, cs)
+			}
+		})
+	}
+}
+
+func TestPersistentVolumeClaimLogToTimelineMapperTask_ResourcePairs(t *testing.T) {
+	claimIdentity := &commonlogk8sauditv2_contract.ResourceIdentity{APIVersion: "core/v1", Kind: "persistentvolumeclaim", Namespace: "default", Name: "data-web-0"}
+	podIdentity := &commonlogk8sauditv2_contract.ResourceIdentity{APIVersion: "core/v1", Kind: "pod", Namespace: "default", Name: "web-0"}
+	volumeIdentity := &commonlogk8sauditv2_contract.ResourceIdentity{APIVersion: "core/v1", Kind: "persistentvolume", Namespace: "cluster-scope", Name: "pv-1"}
+	bindingIdentity := podIdentity.SubresourceIdentity("binding")
+	groupedLogs := commonlogk8sauditv2_contract.ResourceManifestLogGroupMap{}
+	for _, identity := range []*commonlogk8sauditv2_contract.ResourceIdentity{claimIdentity, podIdentity, volumeIdentity, bindingIdentity} {
+		groupedLogs[identity.ResourcePathString()] = &commonlogk8sauditv2_contract.ResourceManifestLogGroup{Resource: identity}
+	}
+
+	got, err := (&persistentVolumeClaimLogToTimelineMapperTaskSetting{}).ResourcePairs(context.Background(), groupedLogs)
+	if err != nil {
+		t.Fatalf("ResourcePairs() failed: %v", err)
+	}
+	want := []commonlogk8sauditv2_contract.ResourcePair{
+		{TargetGroup: claimIdentity},
+		{TargetGroup: podIdentity},
+	}
+	sortPairs := cmpopts.SortSlices(func(a, b commonlogk8sauditv2_contract.ResourcePair) bool {
+		return a.TargetGroup.ResourcePathString() < b.TargetGroup.ResourcePathString()
+	})
+	if diff := cmp.Diff(want, got, sortPairs); diff != "" {
+		t.Errorf("ResourcePairs() mismatch (-want +got):\n%s", diff)
+	}
+}
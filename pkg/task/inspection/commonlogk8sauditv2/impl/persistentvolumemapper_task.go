@@ -0,0 +1,149 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+)
+
+var volumePhaseToState = map[string]enum.RevisionState{
+	"Pending":   enum.RevisionStateVolumePending,
+	"Available": enum.RevisionStateVolumeAvailable,
+	"Bound":     enum.RevisionStateVolumeBound,
+	"Released":  enum.RevisionStateVolumeReleased,
+	"Failed":    enum.RevisionStateVolumeFailed,
+}
+
+type persistentVolumeTaskState struct {
+	// lastPhase is the last phase of the PersistentVolume.
+	lastPhase string
+	// linkedClaims is the set of claims in `<namespace>/<name>` format already linked with the PersistentVolume.
+	linkedClaims map[string]struct{}
+}
+
+type persistentVolumeLogToTimelineMapperTaskSetting struct {
+}
+
+// Process processes PersistentVolume logs to generate the phase history and show it under the claim bound with spec.claimRef.
+func (p *persistentVolumeLogToTimelineMapperTaskSetting) Process(ctx context.Context, passIndex int, event commonlogk8sauditv2_contract.ResourceChangeEvent, cs *history.ChangeSet, builder *history.Builder, state *persistentVolumeTaskState) (*persistentVolumeTaskState, error) {
+	if state == nil {
+		state = &persistentVolumeTaskState{
+			linkedClaims: map[string]struct{}{},
+		}
+	}
+	commonLogFieldSet := log.MustGetFieldSet(event.Log, &log.CommonFieldSet{})
+	k8sFieldSet := log.MustGetFieldSet(event.Log, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+	volumeName := event.EventTargetResource.Name
+	phasePath := resourcepath.VolumePhase(resourcepath.PersistentVolume(volumeName))
+	if event.EventType == commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion {
+		if state.lastPhase != "" {
+			cs.AddRevision(phasePath, &history.StagingResourceRevision{
+				Verb:       k8sFieldSet.K8sOperation.Verb,
+				Body:       "",
+				Partial:    false,
+				Requestor:  k8sFieldSet.Principal,
+				ChangeTime: commonLogFieldSet.Timestamp,
+				State:      enum.RevisionStateDeleted,
+			})
+		}
+		state.lastPhase = ""
+		return state, nil
+	}
+	if event.EventTargetBodyReader == nil {
+		return state, nil
+	}
+
+	claimNamespace, namespaceErr := event.EventTargetBodyReader.ReadString("spec.claimRef.namespace")
+	claimName, nameErr := event.EventTargetBodyReader.ReadString("spec.claimRef.name")
+	if namespaceErr == nil && nameErr == nil {
+		claimKey := fmt.Sprintf("%s/%s", claimNamespace, claimName)
+		if _, found := state.linkedClaims[claimKey]; !found {
+			cs.AddResourceAlias(phasePath, resourcepath.VolumeClaimBoundVolume(claimNamespace, claimName, volumeName))
+			state.linkedClaims[claimKey] = struct{}{}
+		}
+	}
+
+	phase, err := event.EventTargetBodyReader.ReadString("status.phase")
+	if err != nil {
+		return state, nil
+	}
+	revisionState, found := volumePhaseToState[phase]
+	if !found || phase == state.lastPhase {
+		return state, nil
+	}
+	cs.AddRevision(phasePath, &history.StagingResourceRevision{
+		Verb:       k8sFieldSet.K8sOperation.Verb,
+		Body:       event.EventTargetBodyYAML,
+		Partial:    false,
+		Requestor:  k8sFieldSet.Principal,
+		ChangeTime: commonLogFieldSet.Timestamp,
+		State:      revisionState,
+	})
+	state.lastPhase = phase
+	return state, nil
+}
+
+// Dependencies implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (p *persistentVolumeLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// PassCount implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (p *persistentVolumeLogToTimelineMapperTaskSetting) PassCount() int {
+	return 1
+}
+
+// GroupedLogTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (p *persistentVolumeLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[commonlogk8sauditv2_contract.ResourceManifestLogGroupMap] {
+	return commonlogk8sauditv2_contract.ResourceLifetimeTrackerTaskID.Ref()
+}
+
+// LogIngesterTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (p *persistentVolumeLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return commonlogk8sauditv2_contract.K8sAuditLogIngesterTaskID.Ref()
+}
+
+// TaskID implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (p *persistentVolumeLogToTimelineMapperTaskSetting) TaskID() taskid.TaskImplementationID[struct{}] {
+	return commonlogk8sauditv2_contract.PersistentVolumeLogToTimelineMapperTaskID
+}
+
+// ResourcePairs implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (p *persistentVolumeLogToTimelineMapperTaskSetting) ResourcePairs(ctx context.Context, groupedLogs commonlogk8sauditv2_contract.ResourceManifestLogGroupMap) ([]commonlogk8sauditv2_contract.ResourcePair, error) {
+	result := []commonlogk8sauditv2_contract.ResourcePair{}
+	for _, group := range groupedLogs {
+		// core/v1#persistentvolume#cluster-scope#name
+		if group.Resource.Type() != commonlogk8sauditv2_contract.Resource || group.Resource.APIVersion != "core/v1" || group.Resource.Kind != "persistentvolume" {
+			continue
+		}
+		result = append(result, commonlogk8sauditv2_contract.ResourcePair{
+			TargetGroup: group.Resource,
+		})
+	}
+	return result, nil
+}
+
+var _ commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting[*persistentVolumeTaskState] = (*persistentVolumeLogToTimelineMapperTaskSetting)(nil)
+
+// PersistentVolumeLogToTimelineMapperTask is the task to generate PersistentVolume phase and binding history.
+var PersistentVolumeLogToTimelineMapperTask = commonlogk8sauditv2_contract.NewManifestLogToTimelineMapper[*persistentVolumeTaskState](&persistentVolumeLogToTimelineMapperTaskSetting{})
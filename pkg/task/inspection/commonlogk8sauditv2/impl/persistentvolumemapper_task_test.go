@@ -0,0 +1,222 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	"github.com/GoogleCloudPlatform/khi/pkg/model"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/testchangeset"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPersistentVolumeLogToTimelineMapperTask_Process(t *testing.T) {
+	task := &persistentVolumeLogToTimelineMapperTaskSetting{}
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	volumeIdentity := &commonlogk8sauditv2_contract.ResourceIdentity{APIVersion: "core/v1", Kind: "persistentvolume", Namespace: "cluster-scope", Name: "pv-1"}
+	volumePhasePath := resourcepath.VolumePhase(resourcepath.PersistentVolume("pv-1")).Path
+
+	testCases := []struct {
+		name          string
+		targetYAML    string
+		eventType     commonlogk8sauditv2_contract.ChangeEventType
+		operation     enum.RevisionVerb
+		initialState  *persistentVolumeTaskState
+		wantState     *persistentVolumeTaskState
+		wantAsserters []testchangeset.ChangeSetAsserter
+	}{
+		{
+			name: "available volume",
+			targetYAML: `status:
+  phase: Available
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+			operation: enum.RevisionVerbCreate,
+			wantState: &persistentVolumeTaskState{
+				lastPhase:    "Available",
+				linkedClaims: map[string]struct{}{},
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: volumePhasePath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbCreate,
+						State:      enum.RevisionStateVolumeAvailable,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "status:\n  phase: Available\n",
+					},
+				},
+			},
+		},
+		{
+			name: "volume bound to a claim",
+			targetYAML: `spec:
+  claimRef:
+    namespace: default
+    name: data-web-0
+status:
+  phase: Bound
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &persistentVolumeTaskState{
+				lastPhase:    "Available",
+				linkedClaims: map[string]struct{}{},
+			},
+			wantState: &persistentVolumeTaskState{
+				lastPhase:    "Bound",
+				linkedClaims: map[string]struct{}{"default/data-web-0": {}},
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: volumePhasePath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbUpdate,
+						State:      enum.RevisionStateVolumeBound,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "spec:\n  claimRef:\n    namespace: default\n    name: data-web-0\nstatus:\n  phase: Bound\n",
+					},
+				},
+				&testchangeset.HasAlias{
+					Source:      volumePhasePath,
+					Destination: resourcepath.VolumeClaimBoundVolume("default", "data-web-0", "pv-1").Path,
+				},
+			},
+		},
+		{
+			name: "volume released by the claim",
+			targetYAML: `spec:
+  claimRef:
+    namespace: default
+    name: data-web-0
+status:
+  phase: Released
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbUpdate,
+			initialState: &persistentVolumeTaskState{
+				lastPhase:    "Bound",
+				linkedClaims: map[string]struct{}{"default/data-web-0": {}},
+			},
+			wantState: &persistentVolumeTaskState{
+				lastPhase:    "Released",
+				linkedClaims: map[string]struct{}{"default/data-web-0": {}},
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: volumePhasePath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbUpdate,
+						State:      enum.RevisionStateVolumeReleased,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "spec:\n  claimRef:\n    namespace: default\n    name: data-web-0\nstatus:\n  phase: Released\n",
+					},
+				},
+			},
+		},
+		{
+			name: "volume modification without phase change",
+			targetYAML: `status:
+  phase: Available
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbPatch,
+			initialState: &persistentVolumeTaskState{
+				lastPhase:    "Available",
+				linkedClaims: map[string]struct{}{},
+			},
+			wantState: &persistentVolumeTaskState{
+				lastPhase:    "Available",
+				linkedClaims: map[string]struct{}{},
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{}},
+			},
+		},
+		{
+			name:      "volume deletion",
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion,
+			operation: enum.RevisionVerbDelete,
+			initialState: &persistentVolumeTaskState{
+				lastPhase:    "Released",
+				linkedClaims: map[string]struct{}{"default/data-web-0": {}},
+			},
+			wantState: &persistentVolumeTaskState{
+				linkedClaims: map[string]struct{}{"default/data-web-0": {}},
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: volumePhasePath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbDelete,
+						State:      enum.RevisionStateDeleted,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reader *structured.NodeReader
+			if tc.targetYAML != "" {
+				reader = mustParseYAML(t, tc.targetYAML)
+			}
+			l := log.NewLogWithFieldSetsForTest(
+				&log.CommonFieldSet{},
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{},
+			)
+			commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			commonFieldSet.Timestamp = timestamp
+			k8sFieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+			k8sFieldSet.K8sOperation = &model.KubernetesObjectOperation{Verb: tc.operation}
+			k8sFieldSet.Principal = "user-1"
+
+			event := commonlogk8sauditv2_contract.ResourceChangeEvent{
+				Log:                   l,
+				EventType:             tc.eventType,
+				EventTargetResource:   volumeIdentity,
+				EventTargetBodyReader: reader,
+				EventTargetBodyYAML:   tc.targetYAML,
+			}
+
+			cs := history.NewChangeSet(l)
+			nextState, err := task.Process(context.Background(), 0, event, cs, nil, tc.initialState)
+			if err != nil {
+				t.Fatalf("Process() failed: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantState, nextState, cmp.AllowUnexported(persistentVolumeTaskState{})); diff != "" {
+				t.Errorf("state mismatch (-want +got):\n%s", diff)
+			}
+			for _, asserter := range tc.wantAsserters {
+				asserter.Assert(t, cs)
+			}
+		})
+	}
+}
@@ -38,8 +38,18 @@ func Register(registry coreinspection.InspectionTaskRegistry) error {
 		ResourceOwnerReferenceTimelineMapperTask,
 		PodPhaseLogToTimelineMapperTask,
 		EndpointResourceLogToTimelineMapperTask,
+		LeaseHolderLogToTimelineMapperTask,
+		HorizontalPodAutoscalerLogToTimelineMapperTask,
+		NodeSchedulabilityLogToTimelineMapperTask,
+		PersistentVolumeClaimLogToTimelineMapperTask,
+		PersistentVolumeLogToTimelineMapperTask,
+		VolumeAttachmentLogToTimelineMapperTask,
+		JobRunLogToTimelineMapperTask,
 		ContainerLogToTimelineMapperTask,
 		NamespaceRequestLogToTimelineMapperTask,
+		LongRunningLogFilterTask,
+		LongRunningLogGrouperTask,
+		LongRunningOperationLogToTimelineMapperTask,
 
 		NodeNameInventoryTask,
 		NodeNameDiscoveryTask,
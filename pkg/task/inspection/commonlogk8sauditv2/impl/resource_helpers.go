@@ -149,3 +149,27 @@ func GetCreationTimestamp(reader *structured.NodeReader) (time.Time, bool) {
 	}
 	return t, true
 }
+
+// GetConditionStatus returns the status of the condition with the given type in status.conditions.
+// It returns the value and true if the condition exists and its status is a string.
+// Otherwise, it returns empty string and false.
+func GetConditionStatus(reader *structured.NodeReader, conditionType string) (string, bool) {
+	if reader == nil {
+		return "", false
+	}
+	conditions, err := reader.GetReader("status.conditions")
+	if err != nil {
+		return "", false
+	}
+	for _, condition := range conditions.Children() {
+		if t, err := condition.ReadString("type"); err != nil || t != conditionType {
+			continue
+		}
+		status, err := condition.ReadString("status")
+		if err != nil {
+			return "", false
+		}
+		return status, true
+	}
+	return "", false
+}
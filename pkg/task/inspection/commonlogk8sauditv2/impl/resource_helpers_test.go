@@ -369,3 +369,63 @@ func mustParseYAML(t *testing.T, yamlStr string) *structured.NodeReader {
 	}
 	return structured.NewNodeReader(node)
 }
+
+func TestGetConditionStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		yaml          string
+		conditionType string
+		want          string
+		wantFound     bool
+	}{
+		{
+			name: "exists",
+			yaml: `
+status:
+  conditions:
+  - type: AbleToScale
+    status: "True"
+  - type: ScalingActive
+    status: "False"
+`,
+			conditionType: "ScalingActive",
+			want:          "False",
+			wantFound:     true,
+		},
+		{
+			name: "condition type not found",
+			yaml: `
+status:
+  conditions:
+  - type: AbleToScale
+    status: "True"
+`,
+			conditionType: "ScalingActive",
+			want:          "",
+			wantFound:     false,
+		},
+		{
+			name: "no conditions",
+			yaml: `
+status:
+  phase: Running
+`,
+			conditionType: "Ready",
+			want:          "",
+			wantFound:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := mustParseYAML(t, tt.yaml)
+			got, found := GetConditionStatus(reader, tt.conditionType)
+			if got != tt.want {
+				t.Errorf("GetConditionStatus() got = %v, want %v", got, tt.want)
+			}
+			if found != tt.wantFound {
+				t.Errorf("GetConditionStatus() found = %v, want %v", found, tt.wantFound)
+			}
+		})
+	}
+}
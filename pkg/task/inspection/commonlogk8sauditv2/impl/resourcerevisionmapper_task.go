@@ -163,7 +163,26 @@ func (r *ResourceRevisionLogToTimelineMapperTaskSetting) handleTargetChange(ctx
 	}
 
 	state := enum.RevisionStateExisting
-	if event.EventTargetBodyReader == nil {
+	partial := false
+	if event.EventTargetBodyReader == nil && k8sFieldSet.MetadataLevel {
+		// The audit log is recorded at metadata level. The state is inferred from the verb in the same way as the lifetime tracker.
+		partial = true
+		switch {
+		case isDeletiveVerb(k8sFieldSet.K8sOperation.Verb):
+			prevGroupData.DeletionStarted = false
+			prevGroupData.WasCompletelyRemoved = true
+			state = enum.RevisionStateDeleted
+		case event.EventType == commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation:
+			prevGroupData.DeletionStarted = false
+			prevGroupData.WasCompletelyRemoved = false
+			state = enum.RevisionStateInferred
+		case prevGroupData.DeletionStarted:
+			state = enum.RevisionStateDeleting
+		case prevGroupData.PrevUID == "":
+			// No resource body was found for the resource. Its existence is inferred only from the request.
+			state = enum.RevisionStateInferred
+		}
+	} else if event.EventTargetBodyReader == nil {
 		if isDeletiveVerb(k8sFieldSet.K8sOperation.Verb) {
 			prevGroupData.DeletionStarted = true
 			state = enum.RevisionStateDeleted
@@ -269,6 +288,7 @@ func (r *ResourceRevisionLogToTimelineMapperTaskSetting) handleTargetChange(ctx
 		Requestor:  k8sFieldSet.Principal,
 		ChangeTime: commonFieldSet.Timestamp,
 		Body:       event.EventTargetBodyYAML,
+		Partial:    partial,
 		State:      state,
 	})
 	return prevGroupData, nil
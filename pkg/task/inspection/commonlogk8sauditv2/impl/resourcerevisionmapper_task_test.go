@@ -36,6 +36,7 @@ func TestResourceRevisionLogToTimelineMapperTask_Process(t *testing.T) {
 		inputState             *resourceRevisionLogToTimelineMapperState
 		verb                   enum.RevisionVerb
 		targetResourceBodyYAML string
+		metadataLevel          bool
 		eventType              commonlogk8sauditv2_contract.ChangeEventType
 		wantState              *resourceRevisionLogToTimelineMapperState
 		subResourceName        string
@@ -93,6 +94,108 @@ func TestResourceRevisionLogToTimelineMapperTask_Process(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Delete event at metadata level",
+			inputState: &resourceRevisionLogToTimelineMapperState{
+				PrevUID: "test-uid",
+			},
+			verb:                   enum.RevisionVerbDelete,
+			targetResourceBodyYAML: "",
+			metadataLevel:          true,
+			eventType:              commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion,
+			wantState: &resourceRevisionLogToTimelineMapperState{
+				WasCompletelyRemoved: true,
+				DeletionStarted:      false,
+				PrevUID:              "test-uid",
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: "core/v1#pod#default#test",
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbDelete,
+						State:      enum.RevisionStateDeleted,
+						Requestor:  "",
+						ChangeTime: testTime,
+						Body:       "",
+						Partial:    true,
+					},
+				},
+			},
+		},
+		{
+			name:                   "Create event at metadata level",
+			inputState:             nil,
+			verb:                   enum.RevisionVerbCreate,
+			targetResourceBodyYAML: "",
+			metadataLevel:          true,
+			eventType:              commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+			wantState:              &resourceRevisionLogToTimelineMapperState{},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: "core/v1#pod#default#test",
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbCreate,
+						State:      enum.RevisionStateInferred,
+						Requestor:  "",
+						ChangeTime: testTime,
+						Body:       "",
+						Partial:    true,
+					},
+				},
+			},
+		},
+		{
+			name: "Patch event at metadata level after a revision with body",
+			inputState: &resourceRevisionLogToTimelineMapperState{
+				PrevUID: "test-uid",
+			},
+			verb:                   enum.RevisionVerbPatch,
+			targetResourceBodyYAML: "",
+			metadataLevel:          true,
+			eventType:              commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			wantState: &resourceRevisionLogToTimelineMapperState{
+				PrevUID: "test-uid",
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: "core/v1#pod#default#test",
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbPatch,
+						State:      enum.RevisionStateExisting,
+						Requestor:  "",
+						ChangeTime: testTime,
+						Body:       "",
+						Partial:    true,
+					},
+				},
+			},
+		},
+		{
+			name: "Patch event at metadata level during deletion",
+			inputState: &resourceRevisionLogToTimelineMapperState{
+				DeletionStarted: true,
+			},
+			verb:                   enum.RevisionVerbPatch,
+			targetResourceBodyYAML: "",
+			metadataLevel:          true,
+			eventType:              commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			wantState: &resourceRevisionLogToTimelineMapperState{
+				DeletionStarted: true,
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: "core/v1#pod#default#test",
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbPatch,
+						State:      enum.RevisionStateDeleting,
+						Requestor:  "",
+						ChangeTime: testTime,
+						Body:       "",
+						Partial:    true,
+					},
+				},
+			},
+		},
 		{
 			name: "Delete event with graceful period > 0",
 			inputState: &resourceRevisionLogToTimelineMapperState{
@@ -500,6 +603,7 @@ status:
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			fs := newTestK8sAuditLogFieldSet(tc.verb, "core/v1", "pods")
+			fs.MetadataLevel = tc.metadataLevel
 			commonFs := &log.CommonFieldSet{
 				Timestamp: testTime,
 			}
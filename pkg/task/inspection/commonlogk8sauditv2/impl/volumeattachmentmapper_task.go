@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+)
+
+type volumeAttachmentTaskState struct {
+	// lastState is the state of the last revision. It's RevisionStateInferred before any revision is written.
+	lastState enum.RevisionState
+	// lastError is the last error message of attach or detach operation.
+	lastError string
+	// nodeName is the value of spec.nodeName.
+	nodeName string
+	// volumeName is the name of the PersistentVolume attached, or the name of VolumeAttachment for inline volumes.
+	volumeName string
+}
+
+type volumeAttachmentLogToTimelineMapperTaskSetting struct {
+}
+
+// Process processes VolumeAttachment logs to generate the attachment history under the Node and the PersistentVolume.
+func (v *volumeAttachmentLogToTimelineMapperTaskSetting) Process(ctx context.Context, passIndex int, event commonlogk8sauditv2_contract.ResourceChangeEvent, cs *history.ChangeSet, builder *history.Builder, state *volumeAttachmentTaskState) (*volumeAttachmentTaskState, error) {
+	if state == nil {
+		state = &volumeAttachmentTaskState{
+			lastState: enum.RevisionStateInferred,
+		}
+	}
+	commonLogFieldSet := log.MustGetFieldSet(event.Log, &log.CommonFieldSet{})
+	k8sFieldSet := log.MustGetFieldSet(event.Log, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+	if event.EventType == commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion {
+		if state.lastState != enum.RevisionStateInferred {
+			cs.AddRevision(resourcepath.NodeVolumeAttachment(state.nodeName, state.volumeName), &history.StagingResourceRevision{
+				Verb:       k8sFieldSet.K8sOperation.Verb,
+				Body:       "",
+				Partial:    false,
+				Requestor:  k8sFieldSet.Principal,
+				ChangeTime: commonLogFieldSet.Timestamp,
+				State:      enum.RevisionStateDeleted,
+			})
+		}
+		state.lastState = enum.RevisionStateInferred
+		return state, nil
+	}
+	if event.EventTargetBodyReader == nil {
+		return state, nil
+	}
+	nodeName, err := event.EventTargetBodyReader.ReadString("spec.nodeName")
+	if err != nil {
+		return state, nil
+	}
+	volumeName := event.EventTargetBodyReader.ReadStringOrDefault("spec.source.persistentVolumeName", "")
+	isPersistentVolume := volumeName != ""
+	if !isPersistentVolume {
+		volumeName = event.EventTargetResource.Name
+	}
+
+	revisionState := enum.RevisionStateVolumeDetached
+	if event.EventTargetBodyReader.ReadBoolOrDefault("status.attached", false) {
+		revisionState = enum.RevisionStateVolumeAttached
+	}
+	attachError := event.EventTargetBodyReader.ReadStringOrDefault("status.attachError.message", "")
+	if attachError == "" {
+		attachError = event.EventTargetBodyReader.ReadStringOrDefault("status.detachError.message", "")
+	}
+	if attachError != "" {
+		revisionState = enum.RevisionStateVolumeAttachError
+	}
+	if revisionState == state.lastState && attachError == state.lastError && nodeName == state.nodeName && volumeName == state.volumeName {
+		return state, nil
+	}
+
+	attachmentPath := resourcepath.NodeVolumeAttachment(nodeName, volumeName)
+	cs.AddRevision(attachmentPath, &history.StagingResourceRevision{
+		Verb:       k8sFieldSet.K8sOperation.Verb,
+		Body:       event.EventTargetBodyYAML,
+		Partial:    false,
+		Requestor:  k8sFieldSet.Principal,
+		ChangeTime: commonLogFieldSet.Timestamp,
+		State:      revisionState,
+	})
+	if isPersistentVolume {
+		cs.AddResourceAlias(attachmentPath, resourcepath.PersistentVolumeAttachment(volumeName, nodeName))
+	}
+	state.lastState = revisionState
+	state.lastError = attachError
+	state.nodeName = nodeName
+	state.volumeName = volumeName
+	return state, nil
+}
+
+// Dependencies implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (v *volumeAttachmentLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// PassCount implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (v *volumeAttachmentLogToTimelineMapperTaskSetting) PassCount() int {
+	return 1
+}
+
+// GroupedLogTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (v *volumeAttachmentLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[commonlogk8sauditv2_contract.ResourceManifestLogGroupMap] {
+	return commonlogk8sauditv2_contract.ResourceLifetimeTrackerTaskID.Ref()
+}
+
+// LogIngesterTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (v *volumeAttachmentLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return commonlogk8sauditv2_contract.K8sAuditLogIngesterTaskID.Ref()
+}
+
+// TaskID implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (v *volumeAttachmentLogToTimelineMapperTaskSetting) TaskID() taskid.TaskImplementationID[struct{}] {
+	return commonlogk8sauditv2_contract.VolumeAttachmentLogToTimelineMapperTaskID
+}
+
+// ResourcePairs implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (v *volumeAttachmentLogToTimelineMapperTaskSetting) ResourcePairs(ctx context.Context, groupedLogs commonlogk8sauditv2_contract.ResourceManifestLogGroupMap) ([]commonlogk8sauditv2_contract.ResourcePair, error) {
+	result := []commonlogk8sauditv2_contract.ResourcePair{}
+	for _, group := range groupedLogs {
+		// storage.k8s.io/v1#volumeattachment#cluster-scope#name
+		if group.Resource.Type() != commonlogk8sauditv2_contract.Resource || group.Resource.APIVersion != "storage.k8s.io/v1" || group.Resource.Kind != "volumeattachment" {
+			continue
+		}
+		result = append(result, commonlogk8sauditv2_contract.ResourcePair{
+			TargetGroup: group.Resource,
+		})
+	}
+	return result, nil
+}
+
+var _ commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting[*volumeAttachmentTaskState] = (*volumeAttachmentLogToTimelineMapperTaskSetting)(nil)
+
+// VolumeAttachmentLogToTimelineMapperTask is the task to generate VolumeAttachment history under nodes and volumes.
+var VolumeAttachmentLogToTimelineMapperTask = commonlogk8sauditv2_contract.NewManifestLogToTimelineMapper[*volumeAttachmentTaskState](&volumeAttachmentLogToTimelineMapperTaskSetting{})
@@ -0,0 +1,268 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	"github.com/GoogleCloudPlatform/khi/pkg/model"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/testchangeset"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVolumeAttachmentLogToTimelineMapperTask_Process(t *testing.T) {
+	task := &volumeAttachmentLogToTimelineMapperTaskSetting{}
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	attachmentIdentity := &commonlogk8sauditv2_contract.ResourceIdentity{APIVersion: "storage.k8s.io/v1", Kind: "volumeattachment", Namespace: "cluster-scope", Name: "csi-1234"}
+	attachmentPath := resourcepath.NodeVolumeAttachment("node-1", "pv-1").Path
+
+	testCases := []struct {
+		name          string
+		targetYAML    string
+		eventType     commonlogk8sauditv2_contract.ChangeEventType
+		operation     enum.RevisionVerb
+		initialState  *volumeAttachmentTaskState
+		wantState     *volumeAttachmentTaskState
+		wantAsserters []testchangeset.ChangeSetAsserter
+	}{
+		{
+			name: "attachment requested",
+			targetYAML: `spec:
+  nodeName: node-1
+  source:
+    persistentVolumeName: pv-1
+status:
+  attached: false
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+			operation: enum.RevisionVerbCreate,
+			wantState: &volumeAttachmentTaskState{
+				lastState:  enum.RevisionStateVolumeDetached,
+				nodeName:   "node-1",
+				volumeName: "pv-1",
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: attachmentPath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbCreate,
+						State:      enum.RevisionStateVolumeDetached,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "spec:\n  nodeName: node-1\n  source:\n    persistentVolumeName: pv-1\nstatus:\n  attached: false\n",
+					},
+				},
+				&testchangeset.HasAlias{
+					Source:      attachmentPath,
+					Destination: resourcepath.PersistentVolumeAttachment("pv-1", "node-1").Path,
+				},
+			},
+		},
+		{
+			name: "volume attached",
+			targetYAML: `spec:
+  nodeName: node-1
+  source:
+    persistentVolumeName: pv-1
+status:
+  attached: true
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbPatch,
+			initialState: &volumeAttachmentTaskState{
+				lastState:  enum.RevisionStateVolumeDetached,
+				nodeName:   "node-1",
+				volumeName: "pv-1",
+			},
+			wantState: &volumeAttachmentTaskState{
+				lastState:  enum.RevisionStateVolumeAttached,
+				nodeName:   "node-1",
+				volumeName: "pv-1",
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: attachmentPath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbPatch,
+						State:      enum.RevisionStateVolumeAttached,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "spec:\n  nodeName: node-1\n  source:\n    persistentVolumeName: pv-1\nstatus:\n  attached: true\n",
+					},
+				},
+			},
+		},
+		{
+			name: "attach error",
+			targetYAML: `spec:
+  nodeName: node-1
+  source:
+    persistentVolumeName: pv-1
+status:
+  attached: false
+  attachError:
+    message: 'rpc error: disk is already attached to another node'
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbPatch,
+			initialState: &volumeAttachmentTaskState{
+				lastState:  enum.RevisionStateVolumeDetached,
+				nodeName:   "node-1",
+				volumeName: "pv-1",
+			},
+			wantState: &volumeAttachmentTaskState{
+				lastState:  enum.RevisionStateVolumeAttachError,
+				lastError:  "rpc error: disk is already attached to another node",
+				nodeName:   "node-1",
+				volumeName: "pv-1",
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: attachmentPath, WantCount: 1},
+				&testchangeset.HasRevision{
+					ResourcePath: attachmentPath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbPatch,
+						State:      enum.RevisionStateVolumeAttachError,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+						Body:       "spec:\n  nodeName: node-1\n  source:\n    persistentVolumeName: pv-1\nstatus:\n  attached: false\n  attachError:\n    message: 'rpc error: disk is already attached to another node'\n",
+					},
+				},
+			},
+		},
+		{
+			name: "same attach error again",
+			targetYAML: `spec:
+  nodeName: node-1
+  source:
+    persistentVolumeName: pv-1
+status:
+  attached: false
+  attachError:
+    message: rpc error
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+			operation: enum.RevisionVerbPatch,
+			initialState: &volumeAttachmentTaskState{
+				lastState:  enum.RevisionStateVolumeAttachError,
+				lastError:  "rpc error",
+				nodeName:   "node-1",
+				volumeName: "pv-1",
+			},
+			wantState: &volumeAttachmentTaskState{
+				lastState:  enum.RevisionStateVolumeAttachError,
+				lastError:  "rpc error",
+				nodeName:   "node-1",
+				volumeName: "pv-1",
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{}},
+			},
+		},
+		{
+			name: "inline volume attachment",
+			targetYAML: `spec:
+  nodeName: node-1
+  source:
+    inlineVolumeSpec: {}
+status:
+  attached: true
+`,
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+			operation: enum.RevisionVerbCreate,
+			wantState: &volumeAttachmentTaskState{
+				lastState:  enum.RevisionStateVolumeAttached,
+				nodeName:   "node-1",
+				volumeName: "csi-1234",
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{resourcepath.NodeVolumeAttachment("node-1", "csi-1234").Path}},
+			},
+		},
+		{
+			name:      "attachment deletion",
+			eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetDeletion,
+			operation: enum.RevisionVerbDelete,
+			initialState: &volumeAttachmentTaskState{
+				lastState:  enum.RevisionStateVolumeAttached,
+				nodeName:   "node-1",
+				volumeName: "pv-1",
+			},
+			wantState: &volumeAttachmentTaskState{
+				lastState:  enum.RevisionStateInferred,
+				nodeName:   "node-1",
+				volumeName: "pv-1",
+			},
+			wantAsserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: attachmentPath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbDelete,
+						State:      enum.RevisionStateDeleted,
+						ChangeTime: timestamp,
+						Requestor:  "user-1",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reader *structured.NodeReader
+			if tc.targetYAML != "" {
+				reader = mustParseYAML(t, tc.targetYAML)
+			}
+			l := log.NewLogWithFieldSetsForTest(
+				&log.CommonFieldSet{},
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{},
+			)
+			commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			commonFieldSet.Timestamp = timestamp
+			k8sFieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+			k8sFieldSet.K8sOperation = &model.KubernetesObjectOperation{Verb: tc.operation}
+			k8sFieldSet.Principal = "user-1"
+
+			event := commonlogk8sauditv2_contract.ResourceChangeEvent{
+				Log:                   l,
+				EventType:             tc.eventType,
+				EventTargetResource:   attachmentIdentity,
+				EventTargetBodyReader: reader,
+				EventTargetBodyYAML:   tc.targetYAML,
+			}
+
+			cs := history.NewChangeSet(l)
+			nextState, err := task.Process(context.Background(), 0, event, cs, nil, tc.initialState)
+			if err != nil {
+				t.Fatalf("Process() failed: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantState, nextState, cmp.AllowUnexported(volumeAttachmentTaskState{})); diff != "" {
+				t.Errorf("state mismatch (-want +got):\n%s", diff)
+			}
+			for _, asserter := range tc.wantAsserters {
+				asserter.Assert(t, cs)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchclusterk8s_contract
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AuditLogFieldMapping holds the paths of the fields in documents holding the fields of kube-apiserver audit events.
+// Paths are separated with dots to read nested objects. Use `\.` for a dot in a field name. An empty path means the field is not indexed.
+type AuditLogFieldMapping struct {
+	// Timestamp is the path of the date field used to filter and sort documents.
+	Timestamp       string
+	AuditID         string
+	Stage           string
+	Level           string
+	Verb            string
+	Username        string
+	APIGroup        string
+	APIVersion      string
+	Resource        string
+	Namespace       string
+	Name            string
+	Subresource     string
+	ResponseCode    string
+	ResponseMessage string
+	RequestURI      string
+	RequestObject   string
+	ResponseObject  string
+	// Cluster is the path of the field holding the cluster name. Documents are not filtered by the cluster name when it's empty.
+	Cluster string
+}
+
+// auditLogFieldMappingKeys are the keys used to override the paths in ParseAuditLogFieldMapping.
+var auditLogFieldMappingKeys = []struct {
+	key   string
+	field func(mapping *AuditLogFieldMapping) *string
+}{
+	{"timestamp", func(m *AuditLogFieldMapping) *string { return &m.Timestamp }},
+	{"auditID", func(m *AuditLogFieldMapping) *string { return &m.AuditID }},
+	{"stage", func(m *AuditLogFieldMapping) *string { return &m.Stage }},
+	{"level", func(m *AuditLogFieldMapping) *string { return &m.Level }},
+	{"verb", func(m *AuditLogFieldMapping) *string { return &m.Verb }},
+	{"username", func(m *AuditLogFieldMapping) *string { return &m.Username }},
+	{"apiGroup", func(m *AuditLogFieldMapping) *string { return &m.APIGroup }},
+	{"apiVersion", func(m *AuditLogFieldMapping) *string { return &m.APIVersion }},
+	{"resource", func(m *AuditLogFieldMapping) *string { return &m.Resource }},
+	{"namespace", func(m *AuditLogFieldMapping) *string { return &m.Namespace }},
+	{"name", func(m *AuditLogFieldMapping) *string { return &m.Name }},
+	{"subresource", func(m *AuditLogFieldMapping) *string { return &m.Subresource }},
+	{"responseCode", func(m *AuditLogFieldMapping) *string { return &m.ResponseCode }},
+	{"responseMessage", func(m *AuditLogFieldMapping) *string { return &m.ResponseMessage }},
+	{"requestURI", func(m *AuditLogFieldMapping) *string { return &m.RequestURI }},
+	{"requestObject", func(m *AuditLogFieldMapping) *string { return &m.RequestObject }},
+	{"responseObject", func(m *AuditLogFieldMapping) *string { return &m.ResponseObject }},
+	{"cluster", func(m *AuditLogFieldMapping) *string { return &m.Cluster }},
+}
+
+// DefaultAuditLogFieldMapping returns the mapping for audit events indexed as is, like the events forwarded by Fluent Bit with the JSON parser.
+func DefaultAuditLogFieldMapping() *AuditLogFieldMapping {
+	return &AuditLogFieldMapping{
+		Timestamp:       "@timestamp",
+		AuditID:         "auditID",
+		Stage:           "stage",
+		Level:           "level",
+		Verb:            "verb",
+		Username:        "user.username",
+		APIGroup:        "objectRef.apiGroup",
+		APIVersion:      "objectRef.apiVersion",
+		Resource:        "objectRef.resource",
+		Namespace:       "objectRef.namespace",
+		Name:            "objectRef.name",
+		Subresource:     "objectRef.subresource",
+		ResponseCode:    "responseStatus.code",
+		ResponseMessage: "responseStatus.message",
+		RequestURI:      "requestURI",
+		RequestObject:   "requestObject",
+		ResponseObject:  "responseObject",
+		Cluster:         "",
+	}
+}
+
+// AuditLogFieldMappingKeys returns the keys accepted in ParseAuditLogFieldMapping.
+func AuditLogFieldMappingKeys() []string {
+	keys := make([]string, len(auditLogFieldMappingKeys))
+	for i, key := range auditLogFieldMappingKeys {
+		keys[i] = key.key
+	}
+	return keys
+}
+
+// ParseAuditLogFieldMapping returns the default mapping overridden with `key=path` pairs separated by commas or new lines like `cluster=kubernetes.cluster, username=user.name`.
+func ParseAuditLogFieldMapping(source string) (*AuditLogFieldMapping, error) {
+	mapping := DefaultAuditLogFieldMapping()
+	for _, pair := range strings.FieldsFunc(source, func(r rune) bool { return r == ',' || r == '\n' }) {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, path, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("%q is not in the form of `key=path`", pair)
+		}
+		key = strings.TrimSpace(key)
+		field := findAuditLogFieldMappingField(mapping, key)
+		if field == nil {
+			return nil, fmt.Errorf("unknown key %q. The key must be one of %s", key, strings.Join(AuditLogFieldMappingKeys(), ", "))
+		}
+		*field = strings.TrimSpace(path)
+	}
+	if mapping.Timestamp == "" {
+		return nil, fmt.Errorf("the path of timestamp must not be empty")
+	}
+	return mapping, nil
+}
+
+func findAuditLogFieldMappingField(mapping *AuditLogFieldMapping, key string) *string {
+	for _, candidate := range auditLogFieldMappingKeys {
+		if strings.EqualFold(candidate.key, key) {
+			return candidate.field(mapping)
+		}
+	}
+	return nil
+}
+
+// QueryField returns the field name used in queries for the path. Elasticsearch handles dots in field names same as nested objects.
+func QueryField(path string) string {
+	return strings.ReplaceAll(path, `\.`, ".")
+}
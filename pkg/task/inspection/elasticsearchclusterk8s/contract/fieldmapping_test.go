@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchclusterk8s_contract
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseAuditLogFieldMapping(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		input   string
+		want    func(mapping *AuditLogFieldMapping)
+		wantErr bool
+	}{
+		{
+			desc:  "empty",
+			input: "",
+			want:  func(mapping *AuditLogFieldMapping) {},
+		},
+		{
+			desc:  "overrides separated by commas and new lines",
+			input: "cluster = kubernetes.cluster_name,\nusername=user.name , timestamp=stageTimestamp",
+			want: func(mapping *AuditLogFieldMapping) {
+				mapping.Cluster = "kubernetes.cluster_name"
+				mapping.Username = "user.name"
+				mapping.Timestamp = "stageTimestamp"
+			},
+		},
+		{
+			desc:  "case insensitive keys and an empty path",
+			input: "RequestObject=",
+			want: func(mapping *AuditLogFieldMapping) {
+				mapping.RequestObject = ""
+			},
+		},
+		{
+			desc:    "unknown key",
+			input:   "foo=bar",
+			wantErr: true,
+		},
+		{
+			desc:    "missing path",
+			input:   "cluster",
+			wantErr: true,
+		},
+		{
+			desc:    "empty timestamp",
+			input:   "timestamp=",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ParseAuditLogFieldMapping(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ParseAuditLogFieldMapping(%q) returned no error", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAuditLogFieldMapping(%q) returned an unexpected error: %v", tc.input, err)
+			}
+			want := DefaultAuditLogFieldMapping()
+			tc.want(want)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("ParseAuditLogFieldMapping(%q) mismatch (-want +got):\n%s", tc.input, diff)
+			}
+		})
+	}
+}
+
+func TestQueryField(t *testing.T) {
+	if got, want := QueryField(`kubernetes.labels.app\.kubernetes\.io/name`), "kubernetes.labels.app.kubernetes.io/name"; got != want {
+		t.Errorf("QueryField() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchclusterk8s_contract
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	"github.com/GoogleCloudPlatform/khi/pkg/model"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+)
+
+// ElasticsearchK8sAuditLogFieldSetReader implements log.FieldSetReader for K8sAuditLogFieldSet of documents read with the field mapping.
+type ElasticsearchK8sAuditLogFieldSetReader struct {
+	Mapping *AuditLogFieldMapping
+}
+
+// FieldSetKind implements log.FieldSetReader.
+func (e *ElasticsearchK8sAuditLogFieldSetReader) FieldSetKind() string {
+	return (&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (e *ElasticsearchK8sAuditLogFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	var result commonlogk8sauditv2_contract.K8sAuditLogFieldSet
+	result.OperationID = readStringOrDefault(reader, e.Mapping.AuditID, "")
+	// Only the logs at the ResponseComplete stage are queried.
+	result.IsFirst = true
+	result.IsLast = true
+	apiGroup := readStringOrDefault(reader, e.Mapping.APIGroup, "core")
+	apiVersion := readStringOrDefault(reader, e.Mapping.APIVersion, "unknown")
+	kind := readStringOrDefault(reader, e.Mapping.Resource, "unknown")
+	namespace := readStringOrDefault(reader, e.Mapping.Namespace, "cluster-scope")
+	name := readStringOrDefault(reader, e.Mapping.Name, "unknown")
+	subresource := readStringOrDefault(reader, e.Mapping.Subresource, "")
+	verb := readStringOrDefault(reader, e.Mapping.Verb, "")
+
+	if name == "unknown" && verb == "create" && e.Mapping.ResponseObject != "" {
+		// the name may be generated from the server side.
+		name = reader.ReadStringOrDefault(e.Mapping.ResponseObject+".metadata.name", "unknown")
+	}
+
+	result.K8sOperation = &model.KubernetesObjectOperation{
+		APIVersion:      fmt.Sprintf("%s/%s", apiGroup, apiVersion),
+		PluralKind:      kind,
+		Namespace:       namespace,
+		Name:            name,
+		SubResourceName: subresource,
+		Verb:            commonlogk8sauditv2_contract.VerbStringToEnum(verb),
+	}
+
+	result.RequestURI = readStringOrDefault(reader, e.Mapping.RequestURI, "")
+	result.Principal = readStringOrDefault(reader, e.Mapping.Username, "unknown")
+	if e.Mapping.ResponseCode != "" {
+		// Documents are regarded as successful when the status code is not available.
+		if statusCode, err := reader.ReadInt(e.Mapping.ResponseCode); err == nil {
+			result.StatusCode = statusCode
+			result.IsError = statusCode < 200 || statusCode >= 300
+		}
+	}
+	result.StatusMessage = readStringOrDefault(reader, e.Mapping.ResponseMessage, "")
+	if e.Mapping.RequestObject != "" {
+		result.Request, _ = reader.GetReader(e.Mapping.RequestObject)
+	}
+	if e.Mapping.ResponseObject != "" {
+		result.Response, _ = reader.GetReader(e.Mapping.ResponseObject)
+	}
+	result.MetadataLevel = readStringOrDefault(reader, e.Mapping.Level, "") == "Metadata"
+	return &result, nil
+}
+
+var _ log.FieldSetReader = (*ElasticsearchK8sAuditLogFieldSetReader)(nil)
+
+// ElasticsearchK8sAuditLogCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet of documents read with the field mapping.
+type ElasticsearchK8sAuditLogCommonFieldSetReader struct {
+	Mapping *AuditLogFieldMapping
+}
+
+// FieldSetKind implements log.FieldSetReader.
+func (e *ElasticsearchK8sAuditLogCommonFieldSetReader) FieldSetKind() string {
+	return (&log.CommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (e *ElasticsearchK8sAuditLogCommonFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	var err error
+	result := &log.CommonFieldSet{}
+	result.DisplayID = readStringOrDefault(reader, e.Mapping.AuditID, "unknown")
+	result.Timestamp, err = reader.ReadTimestamp(e.Mapping.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the timestamp from %q: %w", e.Mapping.Timestamp, err)
+	}
+	result.Severity = enum.SeverityUnknown
+	return result, nil
+}
+
+var _ log.FieldSetReader = (*ElasticsearchK8sAuditLogCommonFieldSetReader)(nil)
+
+// readStringOrDefault reads the string at the path. It returns the default value when the path is empty because the field is not indexed.
+func readStringOrDefault(reader *structured.NodeReader, path string, defaultValue string) string {
+	if path == "" {
+		return defaultValue
+	}
+	return reader.ReadStringOrDefault(path, defaultValue)
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchclusterk8s_contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/model"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestElasticsearchK8sAuditLogFieldSetReader(t *testing.T) {
+	customMapping := DefaultAuditLogFieldMapping()
+	customMapping.AuditID = "audit.id"
+	customMapping.Verb = "audit.verb"
+	customMapping.Username = "audit.user"
+	customMapping.Resource = "audit.resource"
+	customMapping.Namespace = "audit.namespace"
+	customMapping.Name = "audit.name"
+	customMapping.APIGroup = ""
+	customMapping.APIVersion = "audit.version"
+	customMapping.ResponseCode = "audit.code"
+	customMapping.ResponseObject = ""
+
+	mappingWithoutResponseCode := DefaultAuditLogFieldMapping()
+	mappingWithoutResponseCode.ResponseCode = ""
+
+	testCases := []struct {
+		desc    string
+		mapping *AuditLogFieldMapping
+		input   string
+		want    *commonlogk8sauditv2_contract.K8sAuditLogFieldSet
+	}{
+		{
+			desc:    "default mapping",
+			mapping: DefaultAuditLogFieldMapping(),
+			input: `
+"@timestamp": "2026-01-01T00:00:00Z"
+auditID: "test-audit-id"
+verb: "create"
+user:
+  username: "test-user"
+responseStatus:
+  code: 201
+objectRef:
+  apiGroup: "apps"
+  apiVersion: "v1"
+  resource: "deployments"
+  namespace: "default"
+responseObject:
+  metadata:
+    name: "generated-name"
+requestURI: "/apis/apps/v1/namespaces/default/deployments"
+`,
+			want: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				OperationID: "test-audit-id",
+				IsFirst:     true,
+				IsLast:      true,
+				Principal:   "test-user",
+				StatusCode:  201,
+				RequestURI:  "/apis/apps/v1/namespaces/default/deployments",
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion: "apps/v1",
+					PluralKind: "deployments",
+					Namespace:  "default",
+					Name:       "generated-name",
+					Verb:       enum.RevisionVerbCreate,
+				},
+			},
+		},
+		{
+			desc:    "custom mapping",
+			mapping: customMapping,
+			input: `
+"@timestamp": "2026-01-01T00:00:00Z"
+audit:
+  id: "test-audit-id"
+  verb: "delete"
+  user: "test-user"
+  resource: "pods"
+  namespace: "kube-system"
+  name: "test-pod"
+  version: "v1"
+  code: 409
+objectRef:
+  apiGroup: "ignored"
+`,
+			want: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				OperationID: "test-audit-id",
+				IsFirst:     true,
+				IsLast:      true,
+				Principal:   "test-user",
+				StatusCode:  409,
+				IsError:     true,
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion: "core/v1",
+					PluralKind: "pods",
+					Namespace:  "kube-system",
+					Name:       "test-pod",
+					Verb:       enum.RevisionVerbDelete,
+				},
+			},
+		},
+		{
+			desc:    "document without the status code",
+			mapping: DefaultAuditLogFieldMapping(),
+			input: `
+"@timestamp": "2026-01-01T00:00:00Z"
+auditID: "test-audit-id"
+verb: "delete"
+user:
+  username: "test-user"
+objectRef:
+  apiVersion: "v1"
+  resource: "pods"
+  namespace: "default"
+  name: "test-pod"
+`,
+			want: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				OperationID: "test-audit-id",
+				IsFirst:     true,
+				IsLast:      true,
+				Principal:   "test-user",
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion: "core/v1",
+					PluralKind: "pods",
+					Namespace:  "default",
+					Name:       "test-pod",
+					Verb:       enum.RevisionVerbDelete,
+				},
+			},
+		},
+		{
+			desc:    "mapping without the status code",
+			mapping: mappingWithoutResponseCode,
+			input: `
+"@timestamp": "2026-01-01T00:00:00Z"
+auditID: "test-audit-id"
+verb: "patch"
+user:
+  username: "test-user"
+responseStatus:
+  code: 500
+objectRef:
+  apiVersion: "v1"
+  resource: "pods"
+  namespace: "default"
+  name: "test-pod"
+`,
+			want: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				OperationID: "test-audit-id",
+				IsFirst:     true,
+				IsLast:      true,
+				Principal:   "test-user",
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion: "core/v1",
+					PluralKind: "pods",
+					Namespace:  "default",
+					Name:       "test-pod",
+					Verb:       enum.RevisionVerbPatch,
+				},
+			},
+		},
+		{
+			desc:    "metadata level",
+			mapping: DefaultAuditLogFieldMapping(),
+			input: `
+"@timestamp": "2026-01-01T00:00:00Z"
+auditID: "test-audit-id"
+level: "Metadata"
+verb: "delete"
+user:
+  username: "test-user"
+responseStatus:
+  code: 200
+objectRef:
+  apiVersion: "v1"
+  resource: "secrets"
+  namespace: "default"
+  name: "test-secret"
+`,
+			want: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				OperationID:   "test-audit-id",
+				IsFirst:       true,
+				IsLast:        true,
+				Principal:     "test-user",
+				StatusCode:    200,
+				MetadataLevel: true,
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion: "core/v1",
+					PluralKind: "secrets",
+					Namespace:  "default",
+					Name:       "test-secret",
+					Verb:       enum.RevisionVerbDelete,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := log.NewLogFromYAMLString(tc.input)
+			if err != nil {
+				t.Fatalf("failed to parse YAML test input to log: %v", err)
+			}
+			err = l.SetFieldSetReader(&ElasticsearchK8sAuditLogFieldSetReader{Mapping: tc.mapping})
+			if err != nil {
+				t.Errorf("failed to run ElasticsearchK8sAuditLogFieldSetReader.Read(): %v", err)
+			}
+			got := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+			opts := []cmp.Option{
+				cmpopts.IgnoreFields(commonlogk8sauditv2_contract.K8sAuditLogFieldSet{}, "Request", "Response"),
+			}
+			if diff := cmp.Diff(tc.want, got, opts...); diff != "" {
+				t.Errorf("K8sAuditLogFieldSet mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestElasticsearchK8sAuditLogCommonFieldSetReader(t *testing.T) {
+	mapping := DefaultAuditLogFieldMapping()
+	mapping.Timestamp = "stageTimestamp"
+	l, err := log.NewLogFromYAMLString(`
+auditID: "test-audit-id"
+stageTimestamp: "2026-01-01T10:00:00.123456Z"
+`)
+	if err != nil {
+		t.Fatalf("failed to parse YAML test input to log: %v", err)
+	}
+	err = l.SetFieldSetReader(&ElasticsearchK8sAuditLogCommonFieldSetReader{Mapping: mapping})
+	if err != nil {
+		t.Fatalf("failed to run ElasticsearchK8sAuditLogCommonFieldSetReader.Read(): %v", err)
+	}
+	want := &log.CommonFieldSet{
+		DisplayID: "test-audit-id",
+		Timestamp: time.Date(2026, 1, 1, 10, 0, 0, 123456000, time.UTC),
+		Severity:  enum.SeverityUnknown,
+	}
+	if diff := cmp.Diff(want, log.MustGetFieldSet(l, &log.CommonFieldSet{})); diff != "" {
+		t.Errorf("CommonFieldSet mismatch (-want +got):\n%s", diff)
+	}
+
+	missing, err := log.NewLogFromYAMLString(`auditID: "test-audit-id"`)
+	if err != nil {
+		t.Fatalf("failed to parse YAML test input to log: %v", err)
+	}
+	if err := missing.SetFieldSetReader(&ElasticsearchK8sAuditLogCommonFieldSetReader{Mapping: mapping}); err == nil {
+		t.Errorf("ElasticsearchK8sAuditLogCommonFieldSetReader.Read() returned no error for a log without the timestamp")
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchclusterk8s_contract
+
+import (
+	"math"
+
+	coreinspection "github.com/GoogleCloudPlatform/khi/pkg/core/inspection"
+)
+
+const InspectionTypeID = "elasticsearch-kubernetes"
+
+var ElasticsearchKubernetesInspectionType = coreinspection.InspectionType{
+	Id:          InspectionTypeID,
+	Name:        "Kubernetes Logs on Elasticsearch/OpenSearch",
+	Description: "Visualize logs of self-managed Kubernetes clusters indexed in Elasticsearch or OpenSearch",
+	Icon:        "assets/icons/k8s.png",
+	Priority:    math.MaxInt - 1002,
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchclusterk8s_contract
+
+import (
+	"github.com/GoogleCloudPlatform/khi/pkg/api/elasticsearch"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudk8scommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+)
+
+// ElasticsearchTaskPrefix is the prefix of IDs used in tasks reading logs from Elasticsearch or OpenSearch.
+const ElasticsearchTaskPrefix = "khi.google.com/elasticsearch/"
+
+var InputElasticsearchURLTaskID = taskid.NewDefaultImplementationID[string](ElasticsearchTaskPrefix + "form/url")
+var InputAuditLogIndexTaskID = taskid.NewDefaultImplementationID[string](ElasticsearchTaskPrefix + "form/audit-log-index")
+var InputAuditLogFieldMappingTaskID = taskid.NewDefaultImplementationID[*AuditLogFieldMapping](ElasticsearchTaskPrefix + "form/audit-log-field-mapping")
+
+// ElasticsearchClientTaskID is the task ID for the client of the Elasticsearch given in the form.
+var ElasticsearchClientTaskID = taskid.NewDefaultImplementationID[*elasticsearch.Client](ElasticsearchTaskPrefix + "client")
+
+// ClusterNamePrefixTaskID returns the empty prefix because cluster names are given as the field value as is.
+var ClusterNamePrefixTaskID = taskid.NewImplementationID(googlecloudk8scommon_contract.ClusterNamePrefixTaskRef, "elasticsearch")
+
+// AutocompleteClusterIdentityTaskID returns no suggestion of cluster names instead of suggesting them from Cloud Monitoring.
+var AutocompleteClusterIdentityTaskID = taskid.NewImplementationID(googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID.Ref(), "elasticsearch")
+
+// AutocompleteNamespacesTaskID returns no suggestion of namespaces instead of suggesting them from Cloud Monitoring.
+var AutocompleteNamespacesTaskID = taskid.NewImplementationID(googlecloudk8scommon_contract.AutocompleteNamespacesTaskID.Ref(), "elasticsearch")
+
+var AuditLogQueryTaskID = taskid.NewDefaultImplementationID[[]*log.Log](ElasticsearchTaskPrefix + "query/audit")
+var ElasticsearchK8sAuditLogProviderTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogProviderRef, "elasticsearch")
+var ElasticsearchK8sAuditLogParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogParserTailRef, "elasticsearch")
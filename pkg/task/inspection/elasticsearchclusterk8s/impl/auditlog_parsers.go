@@ -0,0 +1,210 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchclusterk8s_impl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/api/elasticsearch"
+	"github.com/GoogleCloudPlatform/khi/pkg/common/khictx"
+	"github.com/GoogleCloudPlatform/khi/pkg/common/typedmap"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/gcpqueryutil"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/progressutil"
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	elasticsearchclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/elasticsearchclusterk8s/contract"
+	googlecloudcommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// searchPageSize is the count of documents read in a search request. The default index.max_result_window is 10000.
+const searchPageSize = 1000
+
+// AuditLogQueryTask queries kube-apiserver audit logs at the ResponseComplete stage from Elasticsearch or OpenSearch.
+var AuditLogQueryTask = inspectiontaskbase.NewProgressReportableInspectionTask(elasticsearchclusterk8s_contract.AuditLogQueryTaskID, []taskid.UntypedTaskReference{
+	elasticsearchclusterk8s_contract.ElasticsearchClientTaskID.Ref(),
+	elasticsearchclusterk8s_contract.InputAuditLogIndexTaskID.Ref(),
+	elasticsearchclusterk8s_contract.InputAuditLogFieldMappingTaskID.Ref(),
+	googlecloudk8scommon_contract.InputClusterNameTaskID.Ref(),
+	googlecloudk8scommon_contract.InputKindFilterTaskID.Ref(),
+	googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref(),
+	googlecloudcommon_contract.InputStartTimeTaskID.Ref(),
+	googlecloudcommon_contract.InputEndTimeTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, progress *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+	client := coretask.GetTaskResult(ctx, elasticsearchclusterk8s_contract.ElasticsearchClientTaskID.Ref())
+	index := coretask.GetTaskResult(ctx, elasticsearchclusterk8s_contract.InputAuditLogIndexTaskID.Ref())
+	mapping := coretask.GetTaskResult(ctx, elasticsearchclusterk8s_contract.InputAuditLogFieldMappingTaskID.Ref())
+	clusterName := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputClusterNameTaskID.Ref())
+	kindFilter := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputKindFilterTaskID.Ref())
+	namespaceFilter := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref())
+	startTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputStartTimeTaskID.Ref())
+	endTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputEndTimeTaskID.Ref())
+
+	query := GenerateK8sAuditQuery(mapping, clusterName, kindFilter, namespaceFilter, startTime, endTime)
+	metadata := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	queryInfo, found := typedmap.Get(metadata, inspectionmetadata.QueryMetadataKey)
+	if !found {
+		return nil, fmt.Errorf("query metadata was not found")
+	}
+	queryInfo.SetQuery(elasticsearchclusterk8s_contract.AuditLogQueryTaskID.String(), "K8s audit logs", formatSearchQuery(index, query))
+
+	// Don't run the query except the run mode
+	if taskMode != inspectioncore_contract.TaskModeRun {
+		return []*log.Log{}, nil
+	}
+
+	logs := []*log.Log{}
+	lastTimestamp := atomic.Int64{}
+	lastTimestamp.Store(startTime.UnixNano())
+	logCount := atomic.Int64{}
+	progressUpdator := progressutil.NewProgressUpdator(progress, 500*time.Millisecond, func(tp *inspectionmetadata.TaskProgressMetadata) {
+		current := time.Unix(0, lastTimestamp.Load())
+		tp.Update(float32(current.Sub(startTime))/float32(endTime.Sub(startTime)), fmt.Sprintf("%d logs fetched", logCount.Load()))
+	})
+	progressUpdator.Start(ctx)
+	defer progressUpdator.Done()
+
+	commonFieldSetReader := &elasticsearchclusterk8s_contract.ElasticsearchK8sAuditLogCommonFieldSetReader{Mapping: mapping}
+	err := client.SearchAll(ctx, index, query, elasticsearchclusterk8s_contract.QueryField(mapping.Timestamp), searchPageSize, func(hit *elasticsearch.Hit) error {
+		l, err := newAuditLogFromHit(hit, mapping, clusterName, commonFieldSetReader)
+		if err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("ignored a document not convertible to an audit log (index: %s, id: %s): %v", hit.Index, hit.ID, err))
+			return nil
+		}
+		if l == nil {
+			return nil
+		}
+		logs = append(logs, l)
+		logCount.Add(1)
+		lastTimestamp.Store(log.MustGetFieldSet(l, &log.CommonFieldSet{}).Timestamp.UnixNano())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search audit logs in Elasticsearch: %w", err)
+	}
+
+	// Documents are sorted by the indexed timestamp with the millisecond precision. Sort them again with the timestamp read from documents.
+	slices.SortStableFunc(logs, func(a, b *log.Log) int {
+		logACommonField := log.MustGetFieldSet(a, &log.CommonFieldSet{})
+		logBCommonField := log.MustGetFieldSet(b, &log.CommonFieldSet{})
+		return logACommonField.Timestamp.Compare(logBCommonField.Timestamp)
+	})
+	return logs, nil
+}, inspectioncore_contract.InspectionTypeLabel(elasticsearchclusterk8s_contract.InspectionTypeID),
+	inspectioncore_contract.NewQueryTaskLabelOpt(enum.LogTypeAudit, formatSearchQuery("kube-audit-*", GenerateK8sAuditQuery(
+		elasticsearchclusterk8s_contract.DefaultAuditLogFieldMapping(), "test-cluster",
+		&gcpqueryutil.SetFilterParseResult{
+			Additives: []string{"deployments", "replicasets", "pods", "nodes"},
+		},
+		&gcpqueryutil.SetFilterParseResult{
+			Additives: []string{"#cluster-scoped", "#namespaced"},
+		},
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC),
+	))),
+)
+
+// newAuditLogFromHit reads the source of the document as a log. It returns nil for documents not matching the query exactly,
+// because match_phrase queries on text fields match values containing the phrase like `prod-1` for `prod`.
+func newAuditLogFromHit(hit *elasticsearch.Hit, mapping *elasticsearchclusterk8s_contract.AuditLogFieldMapping, clusterName string, commonFieldSetReader log.FieldSetReader) (*log.Log, error) {
+	l, err := log.NewLogFromYAMLString(string(hit.Source))
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Cluster != "" && l.ReadStringOrDefault(mapping.Cluster, "") != clusterName {
+		return nil, nil
+	}
+	if mapping.Stage != "" && l.ReadStringOrDefault(mapping.Stage, "") != "ResponseComplete" {
+		return nil, nil
+	}
+	if mapping.Verb != "" && !slices.Contains(auditLogVerbs, l.ReadStringOrDefault(mapping.Verb, "")) {
+		return nil, nil
+	}
+	if err := l.SetFieldSetReader(commonFieldSetReader); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// formatSearchQuery returns the readable representation of the search request shown in the metadata.
+func formatSearchQuery(index string, query map[string]any) string {
+	body, err := json.MarshalIndent(map[string]any{"query": query}, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("failed to format the query: %v", err)
+	}
+	return fmt.Sprintf("POST /%s/_search\n%s", index, body)
+}
+
+// ElasticsearchK8sAuditLogFieldExtractorTask reads the fields of audit logs with the field mapping given in the form.
+var ElasticsearchK8sAuditLogFieldExtractorTask = inspectiontaskbase.NewInspectionTask(elasticsearchclusterk8s_contract.ElasticsearchK8sAuditLogProviderTaskID, []taskid.UntypedTaskReference{
+	elasticsearchclusterk8s_contract.AuditLogQueryTaskID.Ref(),
+	elasticsearchclusterk8s_contract.InputAuditLogFieldMappingTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]*log.Log, error) {
+	if taskMode != inspectioncore_contract.TaskModeRun {
+		return []*log.Log{}, nil
+	}
+	logs := coretask.GetTaskResult(ctx, elasticsearchclusterk8s_contract.AuditLogQueryTaskID.Ref())
+	mapping := coretask.GetTaskResult(ctx, elasticsearchclusterk8s_contract.InputAuditLogFieldMappingTaskID.Ref())
+	reader := &elasticsearchclusterk8s_contract.ElasticsearchK8sAuditLogFieldSetReader{Mapping: mapping}
+	for _, l := range logs {
+		if err := l.SetFieldSetReader(reader); err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("failed to run fieldSetReader(%s) for log id=%s\nError: %v", reader.FieldSetKind(), l.ID, err.Error()))
+		}
+	}
+	return logs, nil
+}, inspectioncore_contract.InspectionTypeLabel(elasticsearchclusterk8s_contract.InspectionTypeID))
+
+var ElasticsearchK8sAuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	elasticsearchclusterk8s_contract.ElasticsearchK8sAuditLogParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		commonlogk8sauditv2_contract.LogSummaryLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.NonSuccessLogLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.NamespaceRequestLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.ResourceRevisionLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.ConditionLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.ResourceOwnerReferenceTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.PodPhaseLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.EndpointResourceLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.LeaseHolderLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.HorizontalPodAutoscalerLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.NodeSchedulabilityLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.PersistentVolumeClaimLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.PersistentVolumeLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.VolumeAttachmentLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.JobRunLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.ContainerLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.LongRunningOperationLogToTimelineMapperTaskID.Ref(),
+
+		commonlogk8sauditv2_contract.NodeNameDiscoveryTaskID.Ref(),
+		commonlogk8sauditv2_contract.ResourceUIDDiscoveryTaskID.Ref(),
+		commonlogk8sauditv2_contract.ContainerIDDiscoveryTaskID.Ref(),
+		commonlogk8sauditv2_contract.IPLeaseHistoryDiscoveryTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Audit Log(v3)", `Gather kubernetes audit logs from Elasticsearch or OpenSearch and visualize resource modifications.`, enum.LogTypeAudit, 1001, true, elasticsearchclusterk8s_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
@@ -0,0 +1,88 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchclusterk8s_impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/api/elasticsearch"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	elasticsearchclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/elasticsearchclusterk8s/contract"
+)
+
+func TestNewAuditLogFromHit(t *testing.T) {
+	mapping := elasticsearchclusterk8s_contract.DefaultAuditLogFieldMapping()
+	mapping.Cluster = "cluster"
+	testCases := []struct {
+		name          string
+		source        string
+		wantIgnored   bool
+		wantErr       bool
+		wantTimestamp time.Time
+	}{
+		{
+			name:          "matching document",
+			source:        `{"@timestamp":"2026-01-01T00:00:00.123Z","cluster":"prod","stage":"ResponseComplete","verb":"create","auditID":"a"}`,
+			wantTimestamp: time.Date(2026, 1, 1, 0, 0, 0, 123000000, time.UTC),
+		},
+		{
+			name:        "cluster name containing the given name",
+			source:      `{"@timestamp":"2026-01-01T00:00:00Z","cluster":"prod-1","stage":"ResponseComplete","verb":"create"}`,
+			wantIgnored: true,
+		},
+		{
+			name:        "stage other than ResponseComplete",
+			source:      `{"@timestamp":"2026-01-01T00:00:00Z","cluster":"prod","stage":"ResponseStarted","verb":"create"}`,
+			wantIgnored: true,
+		},
+		{
+			name:        "read only verb",
+			source:      `{"@timestamp":"2026-01-01T00:00:00Z","cluster":"prod","stage":"ResponseComplete","verb":"get"}`,
+			wantIgnored: true,
+		},
+		{
+			name:    "missing timestamp",
+			source:  `{"cluster":"prod","stage":"ResponseComplete","verb":"create"}`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := newAuditLogFromHit(&elasticsearch.Hit{Source: []byte(tc.source)}, mapping, "prod", &elasticsearchclusterk8s_contract.ElasticsearchK8sAuditLogCommonFieldSetReader{Mapping: mapping})
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("newAuditLogFromHit() returned no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newAuditLogFromHit() returned an unexpected error: %v", err)
+			}
+			if tc.wantIgnored {
+				if l != nil {
+					t.Errorf("newAuditLogFromHit() returned a log, want nil")
+				}
+				return
+			}
+			if l == nil {
+				t.Fatalf("newAuditLogFromHit() returned nil, want a log")
+			}
+			if got := log.MustGetFieldSet(l, &log.CommonFieldSet{}).Timestamp; !got.Equal(tc.wantTimestamp) {
+				t.Errorf("timestamp = %v, want %v", got, tc.wantTimestamp)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchclusterk8s_impl
+
+import (
+	"context"
+
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	elasticsearchclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/elasticsearchclusterk8s/contract"
+	googlecloudk8scommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// ClusterNamePrefixTask returns the empty prefix because the cluster name is used as the value of the cluster field as is.
+var ClusterNamePrefixTask = coretask.NewTask(elasticsearchclusterk8s_contract.ClusterNamePrefixTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context) (string, error) {
+	return "", nil
+}, inspectioncore_contract.InspectionTypeLabel(elasticsearchclusterk8s_contract.InspectionTypeID))
+
+// AutocompleteClusterIdentityTask returns no suggestion of cluster names. Cluster names can't be listed without knowing the mapping of indices, and the default autocomplete depends on Google Cloud projects.
+var AutocompleteClusterIdentityTask = coretask.NewTask(elasticsearchclusterk8s_contract.AutocompleteClusterIdentityTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity], error) {
+	return &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+		Values: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{},
+		Hint:   "Cluster names are not suggested for Elasticsearch. The cluster name is used to filter documents when the path of `cluster` is given in the field mapping, and it's used only as the name of the inspection otherwise.",
+	}, nil
+}, inspectioncore_contract.InspectionTypeLabel(elasticsearchclusterk8s_contract.InspectionTypeID),
+	coretask.WithSelectionPriority(1000), // Setting higher priority compared to the default autocomplete using Cloud Monitoring to override it.
+)
+
+// AutocompleteNamespacesTask returns no suggestion of namespaces instead of the default autocomplete depending on Google Cloud projects.
+var AutocompleteNamespacesTask = coretask.NewTask(elasticsearchclusterk8s_contract.AutocompleteNamespacesTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[string], error) {
+	return &inspectioncore_contract.AutocompleteResult[string]{
+		Values: []string{},
+	}, nil
+}, inspectioncore_contract.InspectionTypeLabel(elasticsearchclusterk8s_contract.InspectionTypeID),
+	coretask.WithSelectionPriority(1000),
+)
@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchclusterk8s_impl
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/api/elasticsearch"
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/parameters"
+	elasticsearchclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/elasticsearchclusterk8s/contract"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// ElasticsearchClientTask returns the client for the Elasticsearch given in the form with the credentials given in the flags.
+var ElasticsearchClientTask = inspectiontaskbase.NewInspectionTask(elasticsearchclusterk8s_contract.ElasticsearchClientTaskID, []taskid.UntypedTaskReference{
+	elasticsearchclusterk8s_contract.InputElasticsearchURLTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (*elasticsearch.Client, error) {
+	url := coretask.GetTaskResult(ctx, elasticsearchclusterk8s_contract.InputElasticsearchURLTaskID.Ref())
+	return elasticsearch.NewClient(url, credentialsFromParameters())
+})
+
+// credentialsFromParameters returns the credentials given in the flags. Credentials are not received in the form not to store them in the browser.
+func credentialsFromParameters() elasticsearch.Credentials {
+	var credentials elasticsearch.Credentials
+	if parameters.Auth.ElasticsearchUsername != nil {
+		credentials.Username = *parameters.Auth.ElasticsearchUsername
+	}
+	if parameters.Auth.ElasticsearchPassword != nil {
+		credentials.Password = *parameters.Auth.ElasticsearchPassword
+	}
+	if parameters.Auth.ElasticsearchAPIKey != nil {
+		credentials.APIKey = *parameters.Auth.ElasticsearchAPIKey
+	}
+	return credentials
+}
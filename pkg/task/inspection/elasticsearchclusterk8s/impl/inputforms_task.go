@@ -0,0 +1,79 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchclusterk8s_impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/api/elasticsearch"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	elasticsearchclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/elasticsearchclusterk8s/contract"
+	googlecloudcommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+// InputElasticsearchURLTask is a form task receiving the base URL of the Elasticsearch or OpenSearch to query.
+var InputElasticsearchURLTask = formtask.NewTextFormTaskBuilder(elasticsearchclusterk8s_contract.InputElasticsearchURLTaskID, googlecloudcommon_contract.PriorityForResourceIdentifierGroup+6000, "Elasticsearch URL").
+	WithDescription("The base URL of the Elasticsearch or OpenSearch like `https://opensearch.logging.svc:9200`. The URL must be reachable from KHI. Credentials are given with the `--elasticsearch-username` and `--elasticsearch-password` or `--elasticsearch-api-key` flags of KHI.").
+	WithValidatingTiming(inspectionmetadata.Blur).
+	WithDefaultValueConstant("http://localhost:9200", true).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if _, err := elasticsearch.NewClient(strings.TrimSpace(value), elasticsearch.Credentials{}); err != nil {
+			return err.Error(), nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
+
+// InputAuditLogIndexTask is a form task receiving the index pattern of the indices containing kube-apiserver audit logs.
+var InputAuditLogIndexTask = formtask.NewTextFormTaskBuilder(elasticsearchclusterk8s_contract.InputAuditLogIndexTaskID, googlecloudcommon_contract.PriorityForLogSourceGroup+2000, "Audit log index").
+	WithDescription("The index pattern of the indices or data streams containing kube-apiserver audit logs like `kube-audit-*`. Multiple patterns can be separated with commas.").
+	WithDefaultValueConstant("kube-audit-*", true).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return "Index pattern must not be empty", nil
+		}
+		if strings.ContainsAny(value, " /\\\"?<>|#") {
+			return "Index pattern must not contain spaces or any of `/\\\"?<>|#`", nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
+
+// InputAuditLogFieldMappingTask is a form task receiving the overrides of the field mapping from documents to audit events.
+var InputAuditLogFieldMappingTask = formtask.NewTextFormTaskBuilder(elasticsearchclusterk8s_contract.InputAuditLogFieldMappingTaskID, googlecloudcommon_contract.PriorityForLogSourceGroup+1000, "Audit log field mapping").
+	WithDescription(fmt.Sprintf("The paths of fields in documents to read audit events, given as `key=path` pairs separated by commas like `cluster=kubernetes.cluster, timestamp=stageTimestamp`. "+
+		"Fields not specified here are read from the paths of the audit event indexed as is (e.g. `verb`, `objectRef.resource`), and the timestamp is read from `@timestamp`. "+
+		"Documents are filtered by the cluster name only when the path of `cluster` is given. Available keys: %s", strings.Join(elasticsearchclusterk8s_contract.AuditLogFieldMappingKeys(), ", "))).
+	WithDefaultValueConstant("", true).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if _, err := elasticsearchclusterk8s_contract.ParseAuditLogFieldMapping(value); err != nil {
+			return err.Error(), nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (*elasticsearchclusterk8s_contract.AuditLogFieldMapping, error) {
+		return elasticsearchclusterk8s_contract.ParseAuditLogFieldMapping(value)
+	}).
+	Build()
@@ -0,0 +1,147 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchclusterk8s_impl
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/gcpqueryutil"
+	elasticsearchclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/elasticsearchclusterk8s/contract"
+)
+
+// auditLogVerbs are the verbs of audit logs queried. Read only requests don't change resources.
+var auditLogVerbs = []string{"create", "update", "patch", "delete", "deletecollection"}
+
+// GenerateK8sAuditQuery constructs a query DSL for fetching Kubernetes audit logs at the ResponseComplete stage in [startTime, endTime) based on the field mapping, cluster name, kind filters and namespace filters.
+// The filters are the same with the query for Cloud Logging. Filters with validation errors are ignored.
+func GenerateK8sAuditQuery(mapping *elasticsearchclusterk8s_contract.AuditLogFieldMapping, clusterName string, auditKindFilter *gcpqueryutil.SetFilterParseResult, namespaceFilter *gcpqueryutil.SetFilterParseResult, startTime time.Time, endTime time.Time) map[string]any {
+	filters := []any{
+		map[string]any{
+			"range": map[string]any{
+				elasticsearchclusterk8s_contract.QueryField(mapping.Timestamp): map[string]any{
+					"gte":    startTime.UnixMilli(),
+					"lt":     endTime.UnixMilli(),
+					"format": "epoch_millis",
+				},
+			},
+		},
+	}
+	mustNots := []any{}
+	if mapping.Cluster != "" {
+		filters = append(filters, matchPhrase(mapping.Cluster, clusterName))
+	}
+	if mapping.Stage != "" {
+		filters = append(filters, matchPhrase(mapping.Stage, "ResponseComplete"))
+	}
+	if mapping.Verb != "" {
+		filters = append(filters, matchAnyPhrase(mapping.Verb, auditLogVerbs))
+	}
+	if mapping.Resource != "" {
+		filter, mustNot := generateAuditKindFilter(mapping.Resource, auditKindFilter)
+		filters = append(filters, filter...)
+		mustNots = append(mustNots, mustNot...)
+	}
+	if mapping.Namespace != "" {
+		filter, mustNot := generateK8sAuditNamespaceFilter(mapping.Namespace, namespaceFilter)
+		filters = append(filters, filter...)
+		mustNots = append(mustNots, mustNot...)
+	}
+	query := map[string]any{"filter": filters}
+	if len(mustNots) > 0 {
+		query["must_not"] = mustNots
+	}
+	return map[string]any{"bool": query}
+}
+
+// generateAuditKindFilter returns the clauses of filter and must_not for Kubernetes resource kinds based on the parsed filter result.
+func generateAuditKindFilter(resourcePath string, filter *gcpqueryutil.SetFilterParseResult) (filters []any, mustNots []any) {
+	if filter.ValidationError != "" {
+		return nil, nil
+	}
+	if filter.SubtractMode {
+		if len(filter.Subtractives) == 0 {
+			return nil, nil
+		}
+		return nil, []any{matchAnyPhrase(resourcePath, filter.Subtractives)}
+	}
+	if len(filter.Additives) == 0 {
+		// None of the resources will be selected. Ignoring kind filter.
+		return nil, nil
+	}
+	return []any{matchAnyPhrase(resourcePath, filter.Additives)}, nil
+}
+
+// generateK8sAuditNamespaceFilter returns the clauses of filter and must_not for Kubernetes namespaces based on the parsed filter result.
+// Cluster scoped resources are the documents without the namespace field.
+func generateK8sAuditNamespaceFilter(namespacePath string, filter *gcpqueryutil.SetFilterParseResult) (filters []any, mustNots []any) {
+	if filter.ValidationError != "" || filter.SubtractMode {
+		return nil, nil
+	}
+	hasClusterScope := slices.Contains(filter.Additives, "#cluster-scoped")
+	hasNamespacedScope := slices.Contains(filter.Additives, "#namespaced")
+	if hasClusterScope && hasNamespacedScope {
+		return nil, nil
+	}
+	namespaceExists := map[string]any{"exists": map[string]any{"field": elasticsearchclusterk8s_contract.QueryField(namespacePath)}}
+	if !hasClusterScope && hasNamespacedScope {
+		return []any{namespaceExists}, nil
+	}
+	namespaces := []string{}
+	for _, additive := range filter.Additives {
+		if strings.HasPrefix(additive, "#") {
+			continue
+		}
+		namespaces = append(namespaces, additive)
+	}
+	if hasClusterScope {
+		if len(namespaces) == 0 {
+			return nil, []any{namespaceExists}
+		}
+		return []any{map[string]any{
+			"bool": map[string]any{
+				"should": []any{
+					matchAnyPhrase(namespacePath, namespaces),
+					map[string]any{"bool": map[string]any{"must_not": []any{namespaceExists}}},
+				},
+				"minimum_should_match": 1,
+			},
+		}}, nil
+	}
+	if len(namespaces) == 0 {
+		// None of the resources will be selected. Ignoring namespace filter.
+		return nil, nil
+	}
+	return []any{matchAnyPhrase(namespacePath, namespaces)}, nil
+}
+
+// matchPhrase returns the clause matching documents with the value in the field.
+// match_phrase is used instead of term because the field can be a text field when the index has no explicit mapping.
+func matchPhrase(path string, value string) map[string]any {
+	return map[string]any{"match_phrase": map[string]any{elasticsearchclusterk8s_contract.QueryField(path): value}}
+}
+
+// matchAnyPhrase returns the clause matching documents with one of the values in the field.
+func matchAnyPhrase(path string, values []string) map[string]any {
+	if len(values) == 1 {
+		return matchPhrase(path, values[0])
+	}
+	clauses := make([]any, len(values))
+	for i, value := range values {
+		clauses[i] = matchPhrase(path, value)
+	}
+	return map[string]any{"bool": map[string]any{"should": clauses, "minimum_should_match": 1}}
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchclusterk8s_impl
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/api/elasticsearch"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/gcpqueryutil"
+	elasticsearchclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/elasticsearchclusterk8s/contract"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/elasticsearchstub"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateK8sAuditQuery(t *testing.T) {
+	baseTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	auditDocument := func(auditID, cluster, stage, verb, resource, namespace string, timestamp time.Time) elasticsearchstub.Document {
+		objectRef := map[string]any{"resource": resource}
+		if namespace != "" {
+			objectRef["namespace"] = namespace
+		}
+		return elasticsearchstub.Document{
+			Index: "kube-audit-2026.01.01",
+			ID:    auditID,
+			Source: map[string]any{
+				"@timestamp": timestamp.Format(time.RFC3339Nano),
+				"kubernetes": map[string]any{"cluster": cluster},
+				"auditID":    auditID,
+				"stage":      stage,
+				"verb":       verb,
+				"objectRef":  objectRef,
+			},
+		}
+	}
+	server := elasticsearchstub.NewServer(
+		auditDocument("create-pod", "foo", "ResponseComplete", "create", "pods", "default", baseTime),
+		auditDocument("create-pod-started", "foo", "ResponseStarted", "create", "pods", "default", baseTime),
+		auditDocument("get-pod", "foo", "ResponseComplete", "get", "pods", "default", baseTime),
+		auditDocument("patch-pod-kube-system", "foo", "ResponseComplete", "patch", "pods", "kube-system", baseTime.Add(time.Millisecond)),
+		auditDocument("update-node", "foo", "ResponseComplete", "update", "nodes", "", baseTime.Add(2*time.Millisecond)),
+		auditDocument("delete-deployment", "foo", "ResponseComplete", "delete", "deployments", "default", baseTime.Add(3*time.Millisecond)),
+		auditDocument("other-cluster", "bar", "ResponseComplete", "create", "pods", "default", baseTime),
+		auditDocument("out-of-range", "foo", "ResponseComplete", "create", "pods", "default", baseTime.Add(time.Second)),
+	)
+	defer server.Close()
+	client, err := elasticsearch.NewClient(server.URL, elasticsearch.Credentials{})
+	if err != nil {
+		t.Fatalf("NewClient() returned an unexpected error: %v", err)
+	}
+	mapping := elasticsearchclusterk8s_contract.DefaultAuditLogFieldMapping()
+	mapping.Cluster = "kubernetes.cluster"
+
+	testCases := []struct {
+		name            string
+		kindFilter      *gcpqueryutil.SetFilterParseResult
+		namespaceFilter *gcpqueryutil.SetFilterParseResult
+		wantAuditIDs    []string
+	}{
+		{
+			name:            "all kinds and namespaces",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{SubtractMode: true},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped", "#namespaced"}},
+			wantAuditIDs:    []string{"create-pod", "patch-pod-kube-system", "update-node", "delete-deployment"},
+		},
+		{
+			name:            "specific kinds",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{Additives: []string{"pods", "nodes"}},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped", "#namespaced"}},
+			wantAuditIDs:    []string{"create-pod", "patch-pod-kube-system", "update-node"},
+		},
+		{
+			name:            "excluded kinds",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{SubtractMode: true, Subtractives: []string{"pods"}},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped", "#namespaced"}},
+			wantAuditIDs:    []string{"update-node", "delete-deployment"},
+		},
+		{
+			name:            "namespaced resources only",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{SubtractMode: true},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#namespaced"}},
+			wantAuditIDs:    []string{"create-pod", "patch-pod-kube-system", "delete-deployment"},
+		},
+		{
+			name:            "cluster scoped resources only",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{SubtractMode: true},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped"}},
+			wantAuditIDs:    []string{"update-node"},
+		},
+		{
+			name:            "cluster scoped resources and a namespace",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{SubtractMode: true},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped", "kube-system"}},
+			wantAuditIDs:    []string{"patch-pod-kube-system", "update-node"},
+		},
+		{
+			name:            "specific namespaces",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{SubtractMode: true},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"default", "kube-system"}},
+			wantAuditIDs:    []string{"create-pod", "patch-pod-kube-system", "delete-deployment"},
+		},
+		{
+			name:            "filters with validation errors are ignored",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{ValidationError: "invalid"},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{ValidationError: "invalid"},
+			wantAuditIDs:    []string{"create-pod", "patch-pod-kube-system", "update-node", "delete-deployment"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query := GenerateK8sAuditQuery(mapping, "foo", tc.kindFilter, tc.namespaceFilter, baseTime, baseTime.Add(time.Second))
+			gotAuditIDs := []string{}
+			err := client.SearchAll(context.Background(), "kube-audit-*", query, mapping.Timestamp, 100, func(hit *elasticsearch.Hit) error {
+				gotAuditIDs = append(gotAuditIDs, hit.ID)
+				return nil
+			})
+			queryJSON, _ := json.Marshal(query)
+			if err != nil {
+				t.Fatalf("SearchAll() returned an unexpected error: %v\nquery:\n%s", err, queryJSON)
+			}
+			if diff := cmp.Diff(tc.wantAuditIDs, gotAuditIDs); diff != "" {
+				t.Errorf("audit IDs mismatch (-want +got):\n%s\nquery:\n%s", diff, queryJSON)
+			}
+		})
+	}
+}
+
+func TestGenerateK8sAuditQuery_WithoutOptionalFields(t *testing.T) {
+	mapping := elasticsearchclusterk8s_contract.DefaultAuditLogFieldMapping()
+	mapping.Stage = ""
+	mapping.Verb = ""
+	mapping.Resource = ""
+	mapping.Namespace = ""
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	got := GenerateK8sAuditQuery(mapping, "foo",
+		&gcpqueryutil.SetFilterParseResult{Additives: []string{"pods"}},
+		&gcpqueryutil.SetFilterParseResult{Additives: []string{"default"}},
+		start, end,
+	)
+	want := map[string]any{
+		"bool": map[string]any{
+			"filter": []any{
+				map[string]any{
+					"range": map[string]any{
+						"@timestamp": map[string]any{
+							"gte":    start.UnixMilli(),
+							"lt":     end.UnixMilli(),
+							"format": "epoch_millis",
+						},
+					},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateK8sAuditQuery() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchclusterk8s_impl
+
+import (
+	coreinspection "github.com/GoogleCloudPlatform/khi/pkg/core/inspection"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	elasticsearchclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/elasticsearchclusterk8s/contract"
+)
+
+func Register(registry coreinspection.InspectionTaskRegistry) error {
+	err := registry.AddInspectionType(elasticsearchclusterk8s_contract.ElasticsearchKubernetesInspectionType)
+	if err != nil {
+		return err
+	}
+
+	return coretask.RegisterTasks(registry,
+		InputElasticsearchURLTask,
+		InputAuditLogIndexTask,
+		InputAuditLogFieldMappingTask,
+		ElasticsearchClientTask,
+		ClusterNamePrefixTask,
+		AutocompleteClusterIdentityTask,
+		AutocompleteNamespacesTask,
+		AuditLogQueryTask,
+		ElasticsearchK8sAuditLogFieldExtractorTask,
+		ElasticsearchK8sAuditLogParserTailTask,
+	)
+}
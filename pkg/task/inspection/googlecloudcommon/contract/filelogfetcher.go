@@ -0,0 +1,180 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/GoogleCloudPlatform/khi/pkg/api/googlecloud"
+	"github.com/GoogleCloudPlatform/khi/pkg/common/compression"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/gcpqueryutil"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// exportedLogEntry is a log entry read from an exported file. The entry is kept in its JSON form until it matches a filter.
+type exportedLogEntry struct {
+	timestamp time.Time
+	raw       json.RawMessage
+}
+
+// fileLogFetcher is the implementation of LogFetcher returning log entries exported from Cloud Logging instead of calling the API.
+type fileLogFetcher struct {
+	// entries are sorted by timestamp.
+	entries []exportedLogEntry
+}
+
+// NewFileLogFetcher returns a LogFetcher reading log entries in the JSON representation of LogEntry from the given reader.
+// The source can be a JSON array of log entries like the output of `gcloud logging read --format=json`, or a sequence of log entries like JSONL files written by log sinks.
+// gzip or zstd compressed files and files in tar or zip archives are read as well.
+func NewFileLogFetcher(ctx context.Context, source io.Reader) (LogFetcher, error) {
+	fetcher := &fileLogFetcher{}
+	seen := map[string]struct{}{}
+	err := compression.WalkFiles("", source, compression.SizeOf(source), func(name string, reader io.Reader) error {
+		decoder := json.NewDecoder(reader)
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var value json.RawMessage
+			err := decoder.Decode(&value)
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read log entries in %q: %w", name, err)
+			}
+			values := []json.RawMessage{value}
+			if trimmed := bytes.TrimSpace(value); len(trimmed) > 0 && trimmed[0] == '[' {
+				values = nil
+				if err := json.Unmarshal(value, &values); err != nil {
+					return fmt.Errorf("failed to read log entries in %q: %w", name, err)
+				}
+			}
+			for _, raw := range values {
+				entry, key, err := readExportedLogEntry(raw)
+				if err != nil {
+					return fmt.Errorf("failed to read a log entry in %q: %w", name, err)
+				}
+				// The same log entry can be included in multiple files when the exported time ranges are overlapping.
+				if _, found := seen[key]; found {
+					continue
+				}
+				seen[key] = struct{}{}
+				fetcher.entries = append(fetcher.entries, entry)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(fetcher.entries, func(i, j int) bool {
+		return fetcher.entries[i].timestamp.Before(fetcher.entries[j].timestamp)
+	})
+	return fetcher, nil
+}
+
+// readExportedLogEntry reads the timestamp of the given log entry and returns the entry with the key to identify duplicated entries.
+func readExportedLogEntry(raw json.RawMessage) (exportedLogEntry, string, error) {
+	var header struct {
+		InsertID         string `json:"insertId"`
+		LogName          string `json:"logName"`
+		Timestamp        string `json:"timestamp"`
+		ReceiveTimestamp string `json:"receiveTimestamp"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return exportedLogEntry{}, "", err
+	}
+	timestampStr := header.Timestamp
+	if timestampStr == "" {
+		timestampStr = header.ReceiveTimestamp
+	}
+	if timestampStr == "" {
+		return exportedLogEntry{}, "", errors.New("log entry has neither timestamp nor receiveTimestamp")
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, timestampStr)
+	if err != nil {
+		return exportedLogEntry{}, "", fmt.Errorf("failed to parse the timestamp of a log entry: %w", err)
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, raw); err != nil {
+		return exportedLogEntry{}, "", err
+	}
+	key := compacted.String()
+	if header.InsertID != "" {
+		key = fmt.Sprintf("%s/%s/%s", header.LogName, header.InsertID, timestampStr)
+	}
+	return exportedLogEntry{
+		timestamp: timestamp,
+		raw:       compacted.Bytes(),
+	}, key, nil
+}
+
+// FetchLogs implements LogFetcher.
+// Entries matching the filter are sent in the ascending order of timestamp. The resource containers are ignored because exported files have no information to choose them.
+func (f *fileLogFetcher) FetchLogs(dest chan<- *loggingpb.LogEntry, ctx context.Context, filter string, container googlecloud.ResourceContainer, resourceContainers []string) error {
+	defer close(dest)
+	loggingFilter, err := gcpqueryutil.ParseLoggingFilter(filter)
+	if err != nil {
+		return fmt.Errorf("failed to parse the filter to read exported logs: %w", err)
+	}
+
+	begin, end := loggingFilter.TimestampRange()
+	startIndex := 0
+	if !begin.IsZero() {
+		startIndex = sort.Search(len(f.entries), func(i int) bool {
+			return !f.entries[i].timestamp.Before(begin)
+		})
+	}
+	for _, entry := range f.entries[startIndex:] {
+		if !end.IsZero() && entry.timestamp.After(end) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var jsonEntry map[string]any
+		if err := json.Unmarshal(entry.raw, &jsonEntry); err != nil {
+			return err
+		}
+		if !loggingFilter.Match(jsonEntry) {
+			continue
+		}
+		logEntry := &loggingpb.LogEntry{}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(entry.raw, logEntry); err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("ignored an exported log entry not convertible to LogEntry: %v", err))
+			continue
+		}
+		select {
+		case dest <- logEntry:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+var _ LogFetcher = (*fileLogFetcher)(nil)
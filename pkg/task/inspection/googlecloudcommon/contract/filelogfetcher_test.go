@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/GoogleCloudPlatform/khi/pkg/api/googlecloud"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/gcpqueryutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+const testExportedLogsJSON = `[
+  {
+    "insertId": "c",
+    "logName": "projects/test-project/logs/cloudaudit.googleapis.com%2Factivity",
+    "timestamp": "2026-01-01T12:00:00Z",
+    "resource": {"type": "k8s_cluster", "labels": {"cluster_name": "test-cluster"}},
+    "protoPayload": {
+      "@type": "type.googleapis.com/google.cloud.audit.AuditLog",
+      "methodName": "io.k8s.core.v1.pods.delete"
+    }
+  },
+  {
+    "insertId": "a",
+    "logName": "projects/test-project/logs/cloudaudit.googleapis.com%2Factivity",
+    "timestamp": "2026-01-01T10:00:00Z",
+    "resource": {"type": "k8s_cluster", "labels": {"cluster_name": "test-cluster"}},
+    "protoPayload": {
+      "@type": "type.googleapis.com/google.cloud.audit.AuditLog",
+      "methodName": "io.k8s.core.v1.pods.create"
+    }
+  }
+]`
+
+const testExportedLogsJSONL = `{"insertId": "b", "logName": "projects/test-project/logs/events", "receiveTimestamp": "2026-01-01T11:00:00Z", "resource": {"type": "k8s_cluster", "labels": {"cluster_name": "other-cluster"}}, "jsonPayload": {"reason": "Scheduled"}, "unknownField": 1}
+{"insertId": "a", "logName": "projects/test-project/logs/cloudaudit.googleapis.com%2Factivity", "timestamp": "2026-01-01T10:00:00Z", "resource": {"type": "k8s_cluster"}}
+{"insertId": "d", "logName": "projects/test-project/logs/events", "timestamp": "2026-01-01T13:00:00Z", "resource": {"type": "k8s_cluster", "labels": {"cluster_name": "test-cluster"}}, "jsonPayload": {"reason": "Killing"}}
+`
+
+func TestFileLogFetcher_FetchLogs(t *testing.T) {
+	testCases := []struct {
+		name          string
+		source        string
+		filter        string
+		wantInsertIDs []string
+	}{
+		{
+			name:          "JSON array sorted by timestamp",
+			source:        testExportedLogsJSON,
+			filter:        "",
+			wantInsertIDs: []string{"a", "c"},
+		},
+		{
+			name:          "JSON array and JSON lines with duplicated entries",
+			source:        testExportedLogsJSON + "\n" + testExportedLogsJSONL,
+			filter:        "",
+			wantInsertIDs: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:   "filter with time range",
+			source: testExportedLogsJSON + "\n" + testExportedLogsJSONL,
+			filter: `resource.labels.cluster_name="test-cluster"
+` + gcpqueryutil.TimeRangeQuerySection(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC), false),
+			wantInsertIDs: []string{"a", "c"},
+		},
+		{
+			name:          "filter with log ID",
+			source:        testExportedLogsJSONL,
+			filter:        `LOG_ID("events")`,
+			wantInsertIDs: []string{"b", "d"},
+		},
+		{
+			name:          "filter with method name",
+			source:        testExportedLogsJSON,
+			filter:        `protoPayload.methodName=~"\.delete$"`,
+			wantInsertIDs: []string{"c"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fetcher, err := NewFileLogFetcher(t.Context(), strings.NewReader(tc.source))
+			if err != nil {
+				t.Fatalf("NewFileLogFetcher() returned an unexpected error: %v", err)
+			}
+			dest := make(chan *loggingpb.LogEntry)
+			errChan := make(chan error, 1)
+			go func() {
+				errChan <- fetcher.FetchLogs(dest, t.Context(), tc.filter, googlecloud.Project("test-project"), []string{"projects/test-project"})
+			}()
+			gotInsertIDs := []string{}
+			for entry := range dest {
+				gotInsertIDs = append(gotInsertIDs, entry.GetInsertId())
+			}
+			if err := <-errChan; err != nil {
+				t.Fatalf("FetchLogs() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantInsertIDs, gotInsertIDs); diff != "" {
+				t.Errorf("FetchLogs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewFileLogFetcherWithInvalidSource(t *testing.T) {
+	testCases := []struct {
+		name   string
+		source string
+	}{
+		{name: "invalid JSON", source: `{"insertId": `},
+		{name: "no timestamp", source: `{"insertId": "a"}`},
+		{name: "invalid timestamp", source: `{"insertId": "a", "timestamp": "yesterday"}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewFileLogFetcher(t.Context(), strings.NewReader(tc.source))
+			if err == nil {
+				t.Errorf("NewFileLogFetcher() returned no error, want an error")
+			}
+		})
+	}
+}
@@ -23,4 +23,6 @@ const (
 	PriorityForResourceIdentifierGroup = FormBasePriority + 40000
 	// PriorityForK8sResourceFilterGroup is the priority for the k8s resource filter group.
 	PriorityForK8sResourceFilterGroup = FormBasePriority + 30000
+	// PriorityForLogSourceGroup is the priority for the log source group.
+	PriorityForLogSourceGroup = FormBasePriority + 20000
 )
@@ -16,10 +16,12 @@ package googlecloudcommon_contract
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"cloud.google.com/go/logging/apiv2/loggingpb"
 	"github.com/GoogleCloudPlatform/khi/pkg/api/googlecloud"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/gcpqueryutil"
 	"github.com/googleapis/gax-go/v2"
 	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
@@ -32,6 +34,26 @@ type LogFetcher interface {
 	FetchLogs(dest chan<- *loggingpb.LogEntry, ctx context.Context, filter string, container googlecloud.ResourceContainer, resourceContainers []string) error
 }
 
+// TimeRangeLogFetcher is an interface for fetching logs in the given time range from log backends other than Cloud Logging.
+// The progress reportable log fetchers pass the time range of each partition explicitly instead of appending it to the filter as a Cloud Logging query.
+// The implementation must close the destination channel after the query is done.
+type TimeRangeLogFetcher interface {
+	FetchLogsInTimeRange(dest chan<- *loggingpb.LogEntry, ctx context.Context, beginTime, endTime time.Time, filterWithoutTimeRange string, container googlecloud.ResourceContainer, resourceContainers []string) error
+}
+
+// cloudLoggingTimeRangeLogFetcher is a TimeRangeLogFetcher appending the time range to the filter of the LogFetcher as a Cloud Logging query.
+type cloudLoggingTimeRangeLogFetcher struct {
+	fetcher LogFetcher
+}
+
+// FetchLogsInTimeRange implements TimeRangeLogFetcher.
+func (c *cloudLoggingTimeRangeLogFetcher) FetchLogsInTimeRange(dest chan<- *loggingpb.LogEntry, ctx context.Context, beginTime, endTime time.Time, filterWithoutTimeRange string, container googlecloud.ResourceContainer, resourceContainers []string) error {
+	filter := fmt.Sprintf("%s\n%s", filterWithoutTimeRange, gcpqueryutil.TimeRangeQuerySection(beginTime, endTime, false))
+	return c.fetcher.FetchLogs(dest, ctx, filter, container, resourceContainers)
+}
+
+var _ TimeRangeLogFetcher = (*cloudLoggingTimeRangeLogFetcher)(nil)
+
 // logFetcherImpl is the implementation of LogFetcher actually accessing to the Cloud Logging API.
 type logFetcherImpl struct {
 	factory            *googlecloud.ClientFactory
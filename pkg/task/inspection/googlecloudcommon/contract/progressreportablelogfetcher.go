@@ -16,14 +16,12 @@ package googlecloudcommon_contract
 
 import (
 	"context"
-	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"cloud.google.com/go/logging/apiv2/loggingpb"
 	"github.com/GoogleCloudPlatform/khi/pkg/api/googlecloud"
-	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/gcpqueryutil"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -44,12 +42,17 @@ type ProgressReportableLogFetcher interface {
 // StandardProgressReportableLogFetcher is a decorator for a LogFetcher that adds the ability
 // to report the progress of log fetching.
 type StandardProgressReportableLogFetcher struct {
-	fetcher        LogFetcher
+	fetcher        TimeRangeLogFetcher
 	reportInterval time.Duration
 }
 
 // NewProgressReportableLogFetcher creates a new instance of ProgressReportableLogFetcher.
 func NewStandardProgressReportableLogFetcher(fetcher LogFetcher, interval time.Duration) *StandardProgressReportableLogFetcher {
+	return NewStandardProgressReportableTimeRangeLogFetcher(&cloudLoggingTimeRangeLogFetcher{fetcher: fetcher}, interval)
+}
+
+// NewStandardProgressReportableTimeRangeLogFetcher creates a new instance of ProgressReportableLogFetcher passing the time range explicitly to the given TimeRangeLogFetcher.
+func NewStandardProgressReportableTimeRangeLogFetcher(fetcher TimeRangeLogFetcher, interval time.Duration) *StandardProgressReportableLogFetcher {
 	return &StandardProgressReportableLogFetcher{
 		fetcher:        fetcher,
 		reportInterval: interval,
@@ -67,8 +70,6 @@ func (s *StandardProgressReportableLogFetcher) FetchLogsWithProgress(dest chan<-
 	stubChan := make(chan *loggingpb.LogEntry)
 	subroutineCtx, cancelSubroutine := context.WithCancel(ctx)
 
-	filter := fmt.Sprintf("%s\n%s", filterWithoutTimeRange, gcpqueryutil.TimeRangeQuerySection(beginTime, endTime, false))
-
 	wg := sync.WaitGroup{}
 	wg.Add(2)
 	logCount := atomic.Int32{}
@@ -130,7 +131,7 @@ func (s *StandardProgressReportableLogFetcher) FetchLogsWithProgress(dest chan<-
 		}
 	}()
 
-	err := s.fetcher.FetchLogs(stubChan, ctx, filter, container, resourceContainers)
+	err := s.fetcher.FetchLogsInTimeRange(stubChan, ctx, beginTime, endTime, filterWithoutTimeRange, container, resourceContainers)
 	if err != nil {
 		cancelSubroutine()
 		wg.Wait()
@@ -158,8 +159,13 @@ type TimePartitioningProgressReportableLogFetcher struct {
 }
 
 func NewTimePartitioningProgressReportableLogFetcher(fetcher LogFetcher, interval time.Duration, partitionCount int, maxParallelism int) *TimePartitioningProgressReportableLogFetcher {
+	return NewTimePartitioningProgressReportableTimeRangeLogFetcher(&cloudLoggingTimeRangeLogFetcher{fetcher: fetcher}, interval, partitionCount, maxParallelism)
+}
+
+// NewTimePartitioningProgressReportableTimeRangeLogFetcher creates a new instance of TimePartitioningProgressReportableLogFetcher passing the time range of each partition explicitly to the given TimeRangeLogFetcher.
+func NewTimePartitioningProgressReportableTimeRangeLogFetcher(fetcher TimeRangeLogFetcher, interval time.Duration, partitionCount int, maxParallelism int) *TimePartitioningProgressReportableLogFetcher {
 	return &TimePartitioningProgressReportableLogFetcher{
-		client:         NewStandardProgressReportableLogFetcher(fetcher, interval),
+		client:         NewStandardProgressReportableTimeRangeLogFetcher(fetcher, interval),
 		partitionCount: partitionCount,
 		maxParallelism: maxParallelism,
 		reportInterval: interval,
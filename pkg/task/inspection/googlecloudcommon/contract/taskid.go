@@ -19,6 +19,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/khi/pkg/api/googlecloud"
 	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/server/upload"
 	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
 )
 
@@ -48,6 +49,9 @@ var InputStartTimeTaskID = taskid.NewDefaultImplementationID[time.Time](GoogleCl
 // InputLocationsTaskID is the task ID for the locations of the target resource.
 var InputLocationsTaskID = taskid.NewDefaultImplementationID[string](GoogleCloudCommonTaskIDPrefix + "input-location")
 
+// InputExportedLogFileTaskID is the task ID for the optional file of log entries exported from Cloud Logging. Logs are read from the file instead of the Cloud Logging API when the file is uploaded.
+var InputExportedLogFileTaskID = taskid.NewDefaultImplementationID[upload.UploadResult](GoogleCloudCommonTaskIDPrefix + "input-exported-log-file")
+
 // APIClientFactoryTaskID is the task ID to generate the ClientFactory. This factory is instantiated with the options generated from the task with APIClientFactoryOptionsTaskID.
 var APIClientFactoryTaskID = taskid.NewDefaultImplementationID[*googlecloud.ClientFactory](GoogleCloudCommonTaskIDPrefix + "api-client-factory")
 
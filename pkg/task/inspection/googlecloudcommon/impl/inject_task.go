@@ -16,11 +16,16 @@ package googlecloudcommon_impl
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/GoogleCloudPlatform/khi/pkg/api/googlecloud"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
 	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
 	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/server/upload"
 	googlecloudcommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudcommon/contract"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
 )
 
 // LocationFetcherTask is the task to inject the reference to LocationFetcher.
@@ -40,11 +45,25 @@ var LocationFetcherTask = coretask.NewTask(googlecloudcommon_contract.LocationFe
 })
 
 // LoggingFetcherTask is a task to inject the reference to LogFetcher.
-var LoggingFetcherTask = coretask.NewTask(googlecloudcommon_contract.LoggingFetcherTaskID, []taskid.UntypedTaskReference{
+// The LogFetcher reads the uploaded file instead of calling Cloud Logging API when logs exported from Cloud Logging are uploaded.
+var LoggingFetcherTask = inspectiontaskbase.NewProgressReportableInspectionTask(googlecloudcommon_contract.LoggingFetcherTaskID, []taskid.UntypedTaskReference{
 	googlecloudcommon_contract.APIClientFactoryTaskID.Ref(),
 	googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref(),
-}, func(ctx context.Context) (googlecloudcommon_contract.LogFetcher, error) {
+	googlecloudcommon_contract.InputExportedLogFileTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) (googlecloudcommon_contract.LogFetcher, error) {
 	clientFactory := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
 	callOptionInjector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
-	return googlecloudcommon_contract.NewLogFetcher(clientFactory, callOptionInjector, 1000), nil
+	exportedLogFile := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputExportedLogFileTaskID.Ref())
+	if taskMode == inspectioncore_contract.TaskModeDryRun || exportedLogFile.Status != upload.UploadStatusCompleted {
+		return googlecloudcommon_contract.NewLogFetcher(clientFactory, callOptionInjector, 1000), nil
+	}
+
+	tp.MarkIndeterminate()
+	tp.Message = "Reading the exported log file"
+	reader, err := exportedLogFile.GetReader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the exported log file: %w", err)
+	}
+	defer reader.Close()
+	return googlecloudcommon_contract.NewFileLogFetcher(ctx, reader)
 })
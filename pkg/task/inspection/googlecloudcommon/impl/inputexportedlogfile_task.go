@@ -0,0 +1,29 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/formtask"
+	"github.com/GoogleCloudPlatform/khi/pkg/server/upload"
+	googlecloudcommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+// InputExportedLogFileTask defines an optional form task to upload log entries exported from Cloud Logging.
+var InputExportedLogFileTask = formtask.NewFileFormTaskBuilder(googlecloudcommon_contract.InputExportedLogFileTaskID, googlecloudcommon_contract.PriorityForLogSourceGroup+1000, "Exported log file (optional)", &upload.JSONUploadFileVerifier{}).
+	WithDescription(`Upload log entries exported from Cloud Logging to inspect them without accessing the Cloud Logging API. ` +
+		`The file can be the output of ` + "`gcloud logging read --format=json`" + ` or JSONL files written by a log sink to Cloud Storage. gzip or zstd compressed files and tar or zip archives of them are also accepted. ` +
+		`The time range and the other parameters are applied to the uploaded logs in the same way as the queries to Cloud Logging. Leave this empty to query Cloud Logging.`).
+	WithOptional().
+	Build()
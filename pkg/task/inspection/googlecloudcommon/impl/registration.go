@@ -34,5 +34,6 @@ func Register(registry coreinspection.InspectionTaskRegistry) error {
 		APICallOptionsInjectorTask,
 		LocationFetcherTask,
 		LoggingFetcherTask,
+		InputExportedLogFileTask,
 	)
 }
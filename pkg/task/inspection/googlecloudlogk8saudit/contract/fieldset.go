@@ -47,9 +47,19 @@ func (g *GCPK8sAuditLogFieldSetReader) Read(reader *structured.NodeReader) (log.
 	result.IsError = result.StatusCode != 0
 	result.Request, _ = reader.GetReader("protoPayload.request")
 	result.Response, _ = reader.GetReader("protoPayload.response")
+	result.MetadataLevel = isMetadataLevelRequest(&result)
 	return &result, nil
 }
 
+// isMetadataLevelRequest returns true if the log looks recorded at the Metadata level of the audit policy.
+// Cloud Audit Logs don't record the audit level, so mutating requests recorded without both of the request and response bodies are regarded as Metadata level.
+func isMetadataLevelRequest(fieldSet *commonlogk8sauditv2_contract.K8sAuditLogFieldSet) bool {
+	if fieldSet.Request != nil || fieldSet.Response != nil {
+		return false
+	}
+	return fieldSet.K8sOperation.Verb != enum.RevisionVerbUnknown
+}
+
 var _ log.FieldSetReader = (*GCPK8sAuditLogFieldSetReader)(nil)
 
 // parseKubernetesOperation parses the resourceName and methodName from a GCP audit log
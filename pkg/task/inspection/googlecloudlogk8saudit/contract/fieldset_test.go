@@ -19,9 +19,70 @@ import (
 
 	"github.com/GoogleCloudPlatform/khi/pkg/model"
 	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
 	"github.com/google/go-cmp/cmp"
 )
 
+func TestGCPK8sAuditLogFieldSetReader_MetadataLevel(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		input string
+		want  bool
+	}{
+		{
+			desc: "mutating request with request and response bodies",
+			input: `protoPayload:
+  resourceName: core/v1/namespaces/default/pods/nginx
+  methodName: io.k8s.core.v1.pods.create
+  request:
+    kind: Pod
+  response:
+    kind: Pod`,
+			want: false,
+		},
+		{
+			desc: "mutating request with only the response body",
+			input: `protoPayload:
+  resourceName: core/v1/namespaces/default/pods/nginx
+  methodName: io.k8s.core.v1.pods.delete
+  response:
+    kind: Pod`,
+			want: false,
+		},
+		{
+			desc: "mutating request without bodies",
+			input: `protoPayload:
+  resourceName: core/v1/namespaces/default/secrets/foo
+  methodName: io.k8s.core.v1.secrets.update`,
+			want: true,
+		},
+		{
+			desc: "read-only request without bodies",
+			input: `protoPayload:
+  resourceName: core/v1/namespaces/default/secrets/foo
+  methodName: io.k8s.core.v1.secrets.get`,
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := log.NewLogFromYAMLString(tc.input)
+			if err != nil {
+				t.Fatalf("failed to parse YAML test input to log: %v", err)
+			}
+			if err := l.SetFieldSetReader(&GCPK8sAuditLogFieldSetReader{}); err != nil {
+				t.Fatalf("failed to run GCPK8sAuditLogFieldSetReader.Read(): %v", err)
+			}
+			got := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+			if got.MetadataLevel != tc.want {
+				t.Errorf("MetadataLevel = %v, want %v", got.MetadataLevel, tc.want)
+			}
+		})
+	}
+}
+
 func TestParseKubernetesOperation(t *testing.T) {
 	testCases := []struct {
 		desc         string
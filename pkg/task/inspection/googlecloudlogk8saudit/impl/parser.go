@@ -49,7 +49,15 @@ var GCPK8sAuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
 		commonlogk8sauditv2_contract.ResourceOwnerReferenceTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.PodPhaseLogToTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.EndpointResourceLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.LeaseHolderLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.HorizontalPodAutoscalerLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.NodeSchedulabilityLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.PersistentVolumeClaimLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.PersistentVolumeLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.VolumeAttachmentLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.JobRunLogToTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.ContainerLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.LongRunningOperationLogToTimelineMapperTaskID.Ref(),
 
 		commonlogk8sauditv2_contract.NodeNameDiscoveryTaskID.Ref(),
 		commonlogk8sauditv2_contract.ResourceUIDDiscoveryTaskID.Ref(),
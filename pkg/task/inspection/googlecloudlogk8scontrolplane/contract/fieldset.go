@@ -138,8 +138,12 @@ func (k *K8sSchedulerComponentFieldSetReader) FieldSetKind() string {
 
 // Read implements log.FieldSetReader.
 func (k *K8sSchedulerComponentFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	return NewK8sSchedulerComponentFieldSet(reader.ReadStringOrDefault("jsonPayload.message", "")), nil
+}
+
+// NewK8sSchedulerComponentFieldSet returns the K8sSchedulerComponentFieldSet read from the klog message of a scheduler log.
+func NewK8sSchedulerComponentFieldSet(message string) *K8sSchedulerComponentFieldSet {
 	var result K8sSchedulerComponentFieldSet
-	message := reader.ReadStringOrDefault("jsonPayload.message", "")
 	podFQDN, err := logutil.ExtractKLogField(message, "pod")
 	if err == nil {
 		podNameFragments := strings.Split(podFQDN, "/")
@@ -148,7 +152,7 @@ func (k *K8sSchedulerComponentFieldSetReader) Read(reader *structured.NodeReader
 			result.PodName = podNameFragments[1]
 		}
 	}
-	return &result, nil
+	return &result
 }
 
 var _ log.FieldSetReader = (*K8sSchedulerComponentFieldSetReader)(nil)
@@ -185,6 +189,44 @@ type K8sControllerManagerComponentFieldSetReader struct {
 	KLogParser                             *logutil.KLogTextParser
 }
 
+// NewK8sControllerManagerComponentFieldSetReader returns the K8sControllerManagerComponentFieldSetReader with the well known controllers and the klog fields of resources.
+func NewK8sControllerManagerComponentFieldSetReader() *K8sControllerManagerComponentFieldSetReader {
+	return &K8sControllerManagerComponentFieldSetReader{
+		WellKnownSourceLocationToControllerMap: map[string]string{
+			"namespace_controller.go":      "namespace-controller",
+			"resource_quota_controller.go": "resourcequota-controller",
+			"requestheader_controller.go":  "requestheader-controller",
+			"pv_protection_controller.go":  "persistentvolume-protection-controller",
+		},
+		WellKnownKindToKLogFieldPairs: []*KindToKLogFieldPairData{
+			kindToKLogFieldPair("apps/v1", "deployment", "deployment", true),
+			kindToKLogFieldPair("apps/v1", "replicaset", "replicaSet", true),
+			kindToKLogFieldPair("apps/v1", "statefulset", "statefulSet", true),
+			kindToKLogFieldPair("apps/v1", "daemonset", "daemonSet", true),
+			kindToKLogFieldPair("batch/v1", "cronjob", "cronjob", true),
+			kindToKLogFieldPair("batch/v1", "job", "job", true),
+			kindToKLogFieldPair("policy/v1", "poddisruptionbudget", "podDisruptionBudget", true),
+			kindToKLogFieldPair("certificates.k8s.io/v1", "certificatesigningrequest", "csr", false),
+			kindToKLogFieldPair("core/v1", "persistentvolumeclaim", "PVC", true),
+			kindToKLogFieldPair("core/v1", "persistentvolume", "volumeName", false),
+			kindToKLogFieldPair("core/v1", "service", "service", true),
+			kindToKLogFieldPair("core/v1", "node", "node", false),
+			kindToKLogFieldPair("core/v1", "pod", "pod", true),
+			kindToKLogFieldPair("core/v1", "namespace", "namespace", false),
+		},
+		KLogParser: logutil.NewKLogTextParser(false),
+	}
+}
+
+func kindToKLogFieldPair(apiVersion string, kind string, klogField string, isNamespaced bool) *KindToKLogFieldPairData {
+	return &KindToKLogFieldPairData{
+		APIVersion:   apiVersion,
+		KindName:     kind,
+		KLogField:    klogField,
+		IsNamespaced: isNamespaced,
+	}
+}
+
 // FieldSetKind implements log.FieldSetReader.
 func (k *K8sControllerManagerComponentFieldSetReader) FieldSetKind() string {
 	return (&K8sControllerManagerComponentFieldSet{}).Kind()
@@ -192,18 +234,21 @@ func (k *K8sControllerManagerComponentFieldSetReader) FieldSetKind() string {
 
 // c Read implements log.FieldSetReader.
 func (k *K8sControllerManagerComponentFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
-	var result K8sControllerManagerComponentFieldSet
 	message := reader.ReadStringOrDefault("jsonPayload.message", "")
 	sourceFile := reader.ReadStringOrDefault("sourceLocation.file", "")
+	return k.ReadMessage(message, sourceFile), nil
+}
 
+// ReadMessage returns the K8sControllerManagerComponentFieldSet read from the klog message and the source file name of a controller manager log.
+func (k *K8sControllerManagerComponentFieldSetReader) ReadMessage(message string, sourceFile string) *K8sControllerManagerComponentFieldSet {
+	var result K8sControllerManagerComponentFieldSet
 	structured := k.KLogParser.TryParse(message)
 	controller, _ := k.readController(structured, sourceFile)
 	result.Controller = controller
 	if structured != nil {
 		result.AssociatedResources = k.readResourceAssociations(structured)
 	}
-
-	return &result, nil
+	return &result
 }
 
 func (k *K8sControllerManagerComponentFieldSetReader) readController(structured *logutil.ParseStructuredLogResult, sourceFile string) (string, error) {
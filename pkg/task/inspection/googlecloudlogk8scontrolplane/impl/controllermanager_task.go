@@ -18,7 +18,6 @@ import (
 	"context"
 
 	"github.com/GoogleCloudPlatform/khi/pkg/common/patternfinder"
-	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/logutil"
 	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
 	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
 	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
@@ -30,15 +29,6 @@ import (
 	googlecloudlogk8scontrolplane_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8scontrolplane/contract"
 )
 
-func kindToKLogFieldPair(apiVersion string, kind string, klogField string, isNamespaced bool) *googlecloudlogk8scontrolplane_contract.KindToKLogFieldPairData {
-	return &googlecloudlogk8scontrolplane_contract.KindToKLogFieldPairData{
-		APIVersion:   apiVersion,
-		KindName:     kind,
-		KLogField:    klogField,
-		IsNamespaced: isNamespaced,
-	}
-}
-
 var ControllerManagerFilterTask = inspectiontaskbase.NewLogFilterTask(
 	googlecloudlogk8scontrolplane_contract.ControllerManagerLogFilterTaskID,
 	googlecloudlogk8scontrolplane_contract.CommonFieldSetReaderTaskID.Ref(),
@@ -55,31 +45,7 @@ var ControllerManagerLogFieldSetReaderTask = inspectiontaskbase.NewFieldSetReadT
 	googlecloudlogk8scontrolplane_contract.ControllerManagerLogFilterTaskID.Ref(),
 	[]log.FieldSetReader{
 		&googlecloudlogk8scontrolplane_contract.K8sControlplaneCommonMessageFieldSetReader{},
-		&googlecloudlogk8scontrolplane_contract.K8sControllerManagerComponentFieldSetReader{
-			WellKnownSourceLocationToControllerMap: map[string]string{
-				"namespace_controller.go":      "namespace-controller",
-				"resource_quota_controller.go": "resourcequota-controller",
-				"requestheader_controller.go":  "requestheader-controller",
-				"pv_protection_controller.go":  "persistentvolume-protection-controller",
-			},
-			WellKnownKindToKLogFieldPairs: []*googlecloudlogk8scontrolplane_contract.KindToKLogFieldPairData{
-				kindToKLogFieldPair("apps/v1", "deployment", "deployment", true),
-				kindToKLogFieldPair("apps/v1", "replicaset", "replicaSet", true),
-				kindToKLogFieldPair("apps/v1", "statefulset", "statefulSet", true),
-				kindToKLogFieldPair("apps/v1", "daemonset", "daemonSet", true),
-				kindToKLogFieldPair("batch/v1", "cronjob", "cronjob", true),
-				kindToKLogFieldPair("batch/v1", "job", "job", true),
-				kindToKLogFieldPair("policy/v1", "poddisruptionbudget", "podDisruptionBudget", true),
-				kindToKLogFieldPair("certificates.k8s.io/v1", "certificatesigningrequest", "csr", false),
-				kindToKLogFieldPair("core/v1", "persistentvolumeclaim", "PVC", true),
-				kindToKLogFieldPair("core/v1", "persistentvolume", "volumeName", false),
-				kindToKLogFieldPair("core/v1", "service", "service", true),
-				kindToKLogFieldPair("core/v1", "node", "node", false),
-				kindToKLogFieldPair("core/v1", "pod", "pod", true),
-				kindToKLogFieldPair("core/v1", "namespace", "namespace", false),
-			},
-			KLogParser: logutil.NewKLogTextParser(false),
-		},
+		googlecloudlogk8scontrolplane_contract.NewK8sControllerManagerComponentFieldSetReader(),
 	},
 )
 
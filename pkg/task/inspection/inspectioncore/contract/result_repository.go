@@ -42,6 +42,11 @@ func NewFileSystemInspectionResultRepository(filePath string) *FileSystemStore {
 	}
 }
 
+// FilePath returns the path of the file storing the inspection result.
+func (r *FileSystemStore) FilePath() string {
+	return r.filePath
+}
+
 func (r *FileSystemStore) GetWriter() (io.WriteCloser, error) {
 	file, err := os.Create(r.filePath)
 	if err != nil {
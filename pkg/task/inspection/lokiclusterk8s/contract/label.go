@@ -0,0 +1,28 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiclusterk8s_contract
+
+// Labels of streams used to filter logs and to suggest form values.
+// Streams are expected to have these labels. Use relabeling rules of the log agent to set them.
+const (
+	// ClusterLabel is the label holding the name of the cluster.
+	ClusterLabel = "cluster"
+	// NamespaceLabel is the label holding the namespace of Pod logs. This is only used for suggesting namespaces.
+	NamespaceLabel = "namespace"
+	// NodeNameLabel is the label holding the name of the node emitting node logs.
+	NodeNameLabel = "node_name"
+	// UnitLabel is the label holding the systemd unit of node logs read from journald.
+	UnitLabel = "unit"
+)
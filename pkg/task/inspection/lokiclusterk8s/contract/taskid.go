@@ -0,0 +1,55 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiclusterk8s_contract
+
+import (
+	"github.com/GoogleCloudPlatform/khi/pkg/api/loki"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudk8scommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	googlecloudlogk8snode_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+)
+
+// LokiTaskPrefix is the prefix of IDs used in tasks reading logs from Loki.
+const LokiTaskPrefix = "khi.google.com/loki/"
+
+var InputLokiURLTaskID = taskid.NewDefaultImplementationID[string](LokiTaskPrefix + "form/url")
+var InputLokiTenantIDTaskID = taskid.NewDefaultImplementationID[string](LokiTaskPrefix + "form/tenant-id")
+var InputAuditLogStreamSelectorTaskID = taskid.NewDefaultImplementationID[string](LokiTaskPrefix + "form/audit-log-stream-selector")
+var InputNodeLogStreamSelectorTaskID = taskid.NewDefaultImplementationID[string](LokiTaskPrefix + "form/node-log-stream-selector")
+
+// LokiClientTaskID is the task ID for the client of the Loki given in the form.
+var LokiClientTaskID = taskid.NewDefaultImplementationID[*loki.Client](LokiTaskPrefix + "client")
+
+// ClusterNamePrefixTaskID returns the empty prefix because cluster names are given as the label value as is.
+var ClusterNamePrefixTaskID = taskid.NewImplementationID(googlecloudk8scommon_contract.ClusterNamePrefixTaskRef, "loki")
+
+// AutocompleteClusterIdentityTaskID suggests cluster names from the values of the cluster label in Loki instead of Cloud Monitoring.
+var AutocompleteClusterIdentityTaskID = taskid.NewImplementationID(googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID.Ref(), "loki")
+
+// AutocompleteNamespacesTaskID suggests namespaces from the values of the namespace label in Loki instead of Cloud Monitoring.
+var AutocompleteNamespacesTaskID = taskid.NewImplementationID(googlecloudk8scommon_contract.AutocompleteNamespacesTaskID.Ref(), "loki")
+
+// AutocompleteNodeNamesTaskID suggests node names from the values of the node name label in Loki instead of Cloud Monitoring.
+var AutocompleteNodeNamesTaskID = taskid.NewImplementationID(googlecloudk8scommon_contract.AutocompleteNodeNamesTaskID.Ref(), "loki")
+
+var AuditLogQueryTaskID = taskid.NewDefaultImplementationID[[]*log.Log](LokiTaskPrefix + "query/audit")
+var LokiK8sAuditLogProviderTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogProviderRef, "loki")
+var LokiK8sAuditLogParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogParserTailRef, "loki")
+
+// NodeLogQueryTaskID is the task ID to query node logs from Loki. This task provides logs to the node log parsers instead of the task querying Cloud Logging.
+var NodeLogQueryTaskID = taskid.NewImplementationID(googlecloudlogk8snode_contract.ListLogEntriesTaskID.Ref(), "loki")
+var LokiK8sNodeLogParserTailTaskID = taskid.NewDefaultImplementationID[struct{}](LokiTaskPrefix + "node-log-parser-tail")
@@ -0,0 +1,122 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiclusterk8s_impl
+
+import (
+	"context"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/gcpqueryutil"
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudk8scommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	lokiclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/lokiclusterk8s/contract"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// AuditLogQueryTask queries kube-apiserver audit logs at the ResponseComplete stage from Loki.
+var AuditLogQueryTask = newLokiQueryTask(&lokiQueryTaskSetting{
+	TaskID:    lokiclusterk8s_contract.AuditLogQueryTaskID,
+	QueryName: "K8s audit logs",
+	LogType:   enum.LogTypeAudit,
+	ExampleQuery: GenerateK8sAuditQuery(`{job="kube-apiserver-audit"}`, "test-cluster",
+		&gcpqueryutil.SetFilterParseResult{
+			Additives: []string{"deployments", "replicasets", "pods", "nodes"},
+		},
+		&gcpqueryutil.SetFilterParseResult{
+			Additives: []string{"#cluster-scoped", "#namespaced"},
+		},
+	),
+	Dependencies: []taskid.UntypedTaskReference{
+		lokiclusterk8s_contract.InputAuditLogStreamSelectorTaskID.Ref(),
+		googlecloudk8scommon_contract.InputClusterNameTaskID.Ref(),
+		googlecloudk8scommon_contract.InputKindFilterTaskID.Ref(),
+		googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref(),
+		ossclusterk8s_contract.AuditLogSeverityPolicyTaskID.Ref(),
+	},
+	Query: func(ctx context.Context) (string, error) {
+		selector := coretask.GetTaskResult(ctx, lokiclusterk8s_contract.InputAuditLogStreamSelectorTaskID.Ref())
+		clusterName := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputClusterNameTaskID.Ref())
+		kindFilter := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputKindFilterTaskID.Ref())
+		namespaceFilter := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref())
+		return GenerateK8sAuditQuery(selector, clusterName, kindFilter, namespaceFilter), nil
+	},
+	ConvertLog: newAuditLogFromLokiEntry,
+}, inspectioncore_contract.InspectionTypeLabel(lokiclusterk8s_contract.InspectionTypeID))
+
+// newAuditLogFromLokiEntry parses the line of the entry as an audit event. Events not at the ResponseComplete stage are ignored.
+func newAuditLogFromLokiEntry(ctx context.Context, entry *loggingpb.LogEntry) (*log.Log, error) {
+	l, err := log.NewLogFromYAMLString(entry.GetTextPayload())
+	if err != nil {
+		return nil, err
+	}
+	if l.ReadStringOrDefault("stage", "") != "ResponseComplete" {
+		return nil, nil
+	}
+	severityPolicy := coretask.GetTaskResult(ctx, ossclusterk8s_contract.AuditLogSeverityPolicyTaskID.Ref())
+	if err := l.SetFieldSetReader(&ossclusterk8s_contract.OSSK8sAuditLogCommonFieldSetReader{SeverityPolicy: severityPolicy}); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// LokiK8sAuditLogFieldExtractorTask reads the fields of audit logs from Loki. The audit logs are in the same format as the audit log files of OSS Kubernetes.
+var LokiK8sAuditLogFieldExtractorTask = inspectiontaskbase.NewFieldSetReadTaskWithReaderFactory(
+	lokiclusterk8s_contract.LokiK8sAuditLogProviderTaskID,
+	lokiclusterk8s_contract.AuditLogQueryTaskID.Ref(),
+	[]taskid.UntypedTaskReference{ossclusterk8s_contract.AuditLogSeverityPolicyTaskID.Ref()},
+	func(ctx context.Context, logs []*log.Log) []log.FieldSetReader {
+		severityPolicy := coretask.GetTaskResult(ctx, ossclusterk8s_contract.AuditLogSeverityPolicyTaskID.Ref())
+		return []log.FieldSetReader{&ossclusterk8s_contract.OSSK8sAuditLogFieldSetReader{SeverityPolicy: severityPolicy}}
+	},
+	inspectioncore_contract.InspectionTypeLabel(lokiclusterk8s_contract.InspectionTypeID),
+)
+
+var LokiK8sAuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	lokiclusterk8s_contract.LokiK8sAuditLogParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		commonlogk8sauditv2_contract.LogSummaryLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.NonSuccessLogLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.NamespaceRequestLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.ResourceRevisionLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.ConditionLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.ResourceOwnerReferenceTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.PodPhaseLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.EndpointResourceLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.LeaseHolderLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.HorizontalPodAutoscalerLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.NodeSchedulabilityLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.PersistentVolumeClaimLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.PersistentVolumeLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.VolumeAttachmentLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.JobRunLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.ContainerLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.LongRunningOperationLogToTimelineMapperTaskID.Ref(),
+
+		commonlogk8sauditv2_contract.NodeNameDiscoveryTaskID.Ref(),
+		commonlogk8sauditv2_contract.ResourceUIDDiscoveryTaskID.Ref(),
+		commonlogk8sauditv2_contract.ContainerIDDiscoveryTaskID.Ref(),
+		commonlogk8sauditv2_contract.IPLeaseHistoryDiscoveryTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Audit Log(v3)", `Gather kubernetes audit logs from Loki and visualize resource modifications.`, enum.LogTypeAudit, 1001, true, lokiclusterk8s_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
@@ -0,0 +1,141 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiclusterk8s_impl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	googlecloudcommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	lokiclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/lokiclusterk8s/contract"
+)
+
+// ClusterNamePrefixTask returns the empty prefix because the cluster name is used as the value of the cluster label as is.
+var ClusterNamePrefixTask = coretask.NewTask(lokiclusterk8s_contract.ClusterNamePrefixTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context) (string, error) {
+	return "", nil
+}, inspectioncore_contract.InspectionTypeLabel(lokiclusterk8s_contract.InspectionTypeID))
+
+// AutocompleteClusterIdentityTask suggests cluster names from the values of the cluster label.
+var AutocompleteClusterIdentityTask = inspectiontaskbase.NewCachedTask(lokiclusterk8s_contract.AutocompleteClusterIdentityTaskID, []taskid.UntypedTaskReference{
+	lokiclusterk8s_contract.InputLokiURLTaskID.Ref(),
+	lokiclusterk8s_contract.InputLokiTenantIDTaskID.Ref(),
+	lokiclusterk8s_contract.LokiClientTaskID.Ref(),
+	googlecloudcommon_contract.InputStartTimeTaskID.Ref(),
+	googlecloudcommon_contract.InputEndTimeTaskID.Ref(),
+}, func(ctx context.Context, prevValue inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]]) (inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]], error) {
+	url := coretask.GetTaskResult(ctx, lokiclusterk8s_contract.InputLokiURLTaskID.Ref())
+	tenantID := coretask.GetTaskResult(ctx, lokiclusterk8s_contract.InputLokiTenantIDTaskID.Ref())
+	client := coretask.GetTaskResult(ctx, lokiclusterk8s_contract.LokiClientTaskID.Ref())
+	startTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputStartTimeTaskID.Ref())
+	endTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputEndTimeTaskID.Ref())
+
+	currentDigest := fmt.Sprintf("%s-%s-%d-%d", url, tenantID, startTime.Unix(), endTime.Unix())
+	if currentDigest == prevValue.DependencyDigest {
+		return prevValue, nil
+	}
+
+	errorString := ""
+	hintString := ""
+	clusterNames, err := client.LabelValues(ctx, lokiclusterk8s_contract.ClusterLabel, "", startTime, endTime)
+	if err != nil {
+		errorString = err.Error()
+	}
+	if errorString == "" && len(clusterNames) == 0 {
+		hintString = fmt.Sprintf("No values of the `%s` label found between %s and %s. Please verify the time range, or make sure the streams have the `%s` label.", lokiclusterk8s_contract.ClusterLabel, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), lokiclusterk8s_contract.ClusterLabel)
+	}
+
+	identities := make([]googlecloudk8scommon_contract.GoogleCloudClusterIdentity, len(clusterNames))
+	for i, clusterName := range clusterNames {
+		identities[i] = googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+			ClusterName: clusterName,
+		}
+	}
+	return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]]{
+		DependencyDigest: currentDigest,
+		Value: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+			Values: identities,
+			Error:  errorString,
+			Hint:   hintString,
+		},
+	}, nil
+}, inspectioncore_contract.InspectionTypeLabel(lokiclusterk8s_contract.InspectionTypeID),
+	coretask.WithSelectionPriority(1000), // Setting higher priority compared to the default autocomplete using Cloud Monitoring to override it.
+)
+
+// AutocompleteNamespacesTask suggests namespaces from the values of the namespace label of streams in the cluster.
+var AutocompleteNamespacesTask = newLabelValuesAutocompleteTask(lokiclusterk8s_contract.AutocompleteNamespacesTaskID, lokiclusterk8s_contract.NamespaceLabel, "namespace names")
+
+// AutocompleteNodeNamesTask suggests node names from the values of the node name label of streams in the cluster.
+var AutocompleteNodeNamesTask = newLabelValuesAutocompleteTask(lokiclusterk8s_contract.AutocompleteNodeNamesTaskID, lokiclusterk8s_contract.NodeNameLabel, "node names")
+
+// newLabelValuesAutocompleteTask returns a task suggesting the values of the label on streams in the cluster given in the form.
+func newLabelValuesAutocompleteTask(taskID taskid.TaskImplementationID[*inspectioncore_contract.AutocompleteResult[string]], label string, readableName string) coretask.Task[*inspectioncore_contract.AutocompleteResult[string]] {
+	return inspectiontaskbase.NewCachedTask(taskID, []taskid.UntypedTaskReference{
+		lokiclusterk8s_contract.InputLokiURLTaskID.Ref(),
+		lokiclusterk8s_contract.InputLokiTenantIDTaskID.Ref(),
+		lokiclusterk8s_contract.LokiClientTaskID.Ref(),
+		googlecloudk8scommon_contract.InputClusterNameTaskID.Ref(),
+		googlecloudcommon_contract.InputStartTimeTaskID.Ref(),
+		googlecloudcommon_contract.InputEndTimeTaskID.Ref(),
+	}, func(ctx context.Context, prevValue inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]]) (inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]], error) {
+		url := coretask.GetTaskResult(ctx, lokiclusterk8s_contract.InputLokiURLTaskID.Ref())
+		tenantID := coretask.GetTaskResult(ctx, lokiclusterk8s_contract.InputLokiTenantIDTaskID.Ref())
+		client := coretask.GetTaskResult(ctx, lokiclusterk8s_contract.LokiClientTaskID.Ref())
+		clusterName := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputClusterNameTaskID.Ref())
+		startTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputStartTimeTaskID.Ref())
+		endTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputEndTimeTaskID.Ref())
+
+		currentDigest := fmt.Sprintf("%s-%s-%s-%d-%d", url, tenantID, clusterName, startTime.Unix(), endTime.Unix())
+		if currentDigest == prevValue.DependencyDigest {
+			return prevValue, nil
+		}
+		if clusterName == "" {
+			return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]]{
+				Value: &inspectioncore_contract.AutocompleteResult[string]{
+					Values: []string{},
+					Error:  "",
+					Hint:   fmt.Sprintf("The %s are suggested after the cluster name is provided.", readableName),
+				},
+				DependencyDigest: currentDigest,
+			}, nil
+		}
+
+		errorString := ""
+		hintString := ""
+		values, err := client.LabelValues(ctx, label, withLabelMatchers("{}", labelEqualMatcher(lokiclusterk8s_contract.ClusterLabel, clusterName)), startTime, endTime)
+		if err != nil {
+			errorString = err.Error()
+		}
+		if errorString == "" && len(values) == 0 {
+			hintString = fmt.Sprintf("No values of the `%s` label found between %s and %s. Please verify the time range, or proceed by manually entering the %s.", label, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), readableName)
+		}
+		return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]]{
+			DependencyDigest: currentDigest,
+			Value: &inspectioncore_contract.AutocompleteResult[string]{
+				Values: values,
+				Error:  errorString,
+				Hint:   hintString,
+			},
+		}, nil
+	}, inspectioncore_contract.InspectionTypeLabel(lokiclusterk8s_contract.InspectionTypeID),
+		coretask.WithSelectionPriority(1000),
+	)
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiclusterk8s_impl
+
+import (
+	"context"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/api/loki"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	googlecloudcommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudcommon/contract"
+	lokiclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/lokiclusterk8s/contract"
+)
+
+// InputLokiURLTask is a form task receiving the base URL of the Loki to query.
+var InputLokiURLTask = formtask.NewTextFormTaskBuilder(lokiclusterk8s_contract.InputLokiURLTaskID, googlecloudcommon_contract.PriorityForResourceIdentifierGroup+6000, "Loki URL").
+	WithDescription("The base URL of the Loki HTTP API like `http://loki-gateway.monitoring.svc:3100`. The URL must be reachable from KHI.").
+	WithValidatingTiming(inspectionmetadata.Blur).
+	WithDefaultValueConstant("http://localhost:3100", true).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if _, err := loki.NewClient(strings.TrimSpace(value), ""); err != nil {
+			return err.Error(), nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
+
+// InputLokiTenantIDTask is a form task receiving the tenant ID sent to a multi-tenant Loki.
+var InputLokiTenantIDTask = formtask.NewTextFormTaskBuilder(lokiclusterk8s_contract.InputLokiTenantIDTaskID, googlecloudcommon_contract.PriorityForResourceIdentifierGroup+5000, "Tenant ID").
+	WithDescription("The tenant ID sent as the `X-Scope-OrgID` header. Leave it empty when the Loki is not running in the multi-tenant mode.").
+	WithDefaultValueConstant("", true).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
+
+// InputAuditLogStreamSelectorTask is a form task receiving the LogQL stream selector of kube-apiserver audit logs.
+var InputAuditLogStreamSelectorTask = formtask.NewTextFormTaskBuilder(lokiclusterk8s_contract.InputAuditLogStreamSelectorTaskID, googlecloudcommon_contract.PriorityForLogSourceGroup+2000, "Audit log stream selector").
+	WithDescription("The LogQL stream selector of streams containing kube-apiserver audit logs in the JSON format. The matcher on the `"+lokiclusterk8s_contract.ClusterLabel+"` label is added to this selector.").
+	WithDefaultValueConstant(`{job="kube-apiserver-audit"}`, true).
+	WithValidator(validateStreamSelector).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
+
+// InputNodeLogStreamSelectorTask is a form task receiving the LogQL stream selector of node component logs.
+var InputNodeLogStreamSelectorTask = formtask.NewTextFormTaskBuilder(lokiclusterk8s_contract.InputNodeLogStreamSelectorTaskID, googlecloudcommon_contract.PriorityForLogSourceGroup+1000, "Node log stream selector").
+	WithDescription("The LogQL stream selector of streams containing kubelet, containerd and other node component logs read from journald. The matchers on the `"+lokiclusterk8s_contract.ClusterLabel+"` and `"+lokiclusterk8s_contract.NodeNameLabel+"` labels are added to this selector. The component is read from the `"+lokiclusterk8s_contract.UnitLabel+"` label unless the line is a journal entry in JSON.").
+	WithDefaultValueConstant(`{job="systemd-journal"}`, true).
+	WithValidator(validateStreamSelector).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
+
+// validateStreamSelector returns a validation error message when the value is not a stream selector. Only the outline is checked because the query is validated by Loki.
+func validateStreamSelector(ctx context.Context, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "{") || !strings.HasSuffix(value, "}") {
+		return "Stream selector must be enclosed with `{` and `}` like `{job=\"foo\"}`", nil
+	}
+	return "", nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiclusterk8s_impl
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/GoogleCloudPlatform/khi/pkg/api/googlecloud"
+	"github.com/GoogleCloudPlatform/khi/pkg/api/loki"
+	googlecloudcommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudcommon/contract"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// lokiLogFetcher is the implementation of TimeRangeLogFetcher reading logs from Loki instead of Cloud Logging.
+// This lets the Loki query tasks share the time partitioning and the progress reporting of the log fetchers for Cloud Logging.
+// The filter must be a LogQL log query.
+type lokiLogFetcher struct {
+	client   *loki.Client
+	pageSize int
+}
+
+func newLokiLogFetcher(client *loki.Client, pageSize int) googlecloudcommon_contract.TimeRangeLogFetcher {
+	return &lokiLogFetcher{
+		client:   client,
+		pageSize: pageSize,
+	}
+}
+
+// FetchLogsInTimeRange implements TimeRangeLogFetcher.
+// Logs at the end time are not included. The line of a log is given as the text payload and the labels of the stream are given as the labels of LogEntry. The resource containers are ignored.
+func (l *lokiLogFetcher) FetchLogsInTimeRange(dest chan<- *loggingpb.LogEntry, ctx context.Context, beginTime, endTime time.Time, filterWithoutTimeRange string, container googlecloud.ResourceContainer, resourceContainers []string) error {
+	defer close(dest)
+	return l.client.QueryRangeAll(ctx, filterWithoutTimeRange, beginTime, endTime, l.pageSize, func(entry *loki.Entry) error {
+		logEntry := &loggingpb.LogEntry{
+			InsertId:  entry.ID(),
+			Timestamp: timestamppb.New(entry.Timestamp),
+			Labels:    entry.Labels,
+			Payload: &loggingpb.LogEntry_TextPayload{
+				TextPayload: entry.Line,
+			},
+		}
+		select {
+		case dest <- logEntry:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+var _ googlecloudcommon_contract.TimeRangeLogFetcher = (*lokiLogFetcher)(nil)
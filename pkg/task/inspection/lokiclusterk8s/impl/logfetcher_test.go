@@ -0,0 +1,86 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiclusterk8s_impl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/GoogleCloudPlatform/khi/pkg/api/loki"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/lokistub"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLokiLogFetcher_FetchLogsInTimeRange(t *testing.T) {
+	baseTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var entries []lokistub.Entry
+	for i := 0; i < 5; i++ {
+		entries = append(entries, lokistub.Entry{Timestamp: baseTime.Add(time.Duration(i) * time.Second), Line: fmt.Sprintf("line-%d", i)})
+	}
+	server := lokistub.NewServer(lokistub.Stream{Labels: map[string]string{"job": "foo"}, Entries: entries})
+	defer server.Close()
+	client, err := loki.NewClient(server.URL, "")
+	if err != nil {
+		t.Fatalf("NewClient() returned an unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name      string
+		beginTime time.Time
+		endTime   time.Time
+		wantLines []string
+	}{
+		{
+			name:      "end time is excluded",
+			beginTime: baseTime.Add(time.Second),
+			endTime:   baseTime.Add(3 * time.Second),
+			wantLines: []string{"line-1", "line-2"},
+		},
+		{
+			name:      "sub-second time range",
+			beginTime: baseTime.Add(time.Second),
+			endTime:   baseTime.Add(time.Second + time.Millisecond),
+			wantLines: []string{"line-1"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dest := make(chan *loggingpb.LogEntry)
+			gotLines := []string{}
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for entry := range dest {
+					if entry.Labels["job"] != "foo" {
+						t.Errorf("labels of the entry = %v, want the labels of the stream", entry.Labels)
+					}
+					gotLines = append(gotLines, entry.GetTextPayload())
+				}
+			}()
+			// Use the small page size to read logs over pages.
+			err := newLokiLogFetcher(client, 2).FetchLogsInTimeRange(dest, context.Background(), tc.beginTime, tc.endTime, `{job="foo"}`, nil, nil)
+			<-done
+			if err != nil {
+				t.Fatalf("FetchLogsInTimeRange() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantLines, gotLines); diff != "" {
+				t.Errorf("FetchLogsInTimeRange() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiclusterk8s_impl
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/api/loki"
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	lokiclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/lokiclusterk8s/contract"
+)
+
+// LokiClientTask returns the client for the Loki given in the form.
+var LokiClientTask = inspectiontaskbase.NewInspectionTask(lokiclusterk8s_contract.LokiClientTaskID, []taskid.UntypedTaskReference{
+	lokiclusterk8s_contract.InputLokiURLTaskID.Ref(),
+	lokiclusterk8s_contract.InputLokiTenantIDTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (*loki.Client, error) {
+	url := coretask.GetTaskResult(ctx, lokiclusterk8s_contract.InputLokiURLTaskID.Ref())
+	tenantID := coretask.GetTaskResult(ctx, lokiclusterk8s_contract.InputLokiTenantIDTaskID.Ref())
+	return loki.NewClient(url, tenantID)
+})
@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiclusterk8s_impl
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	googlecloudk8scommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	googlecloudlogk8snode_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	lokiclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/lokiclusterk8s/contract"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// NodeLogQueryTask queries node component logs from Loki. This task is used by the node log parsers in googlecloudlogk8snode instead of the task querying Cloud Logging.
+var NodeLogQueryTask = newLokiQueryTask(&lokiQueryTaskSetting{
+	TaskID:       lokiclusterk8s_contract.NodeLogQueryTaskID,
+	QueryName:    "Kubernetes node logs",
+	LogType:      enum.LogTypeNode,
+	ExampleQuery: GenerateNodeLogQuery(`{job="systemd-journal"}`, "test-cluster", []string{"node-1", "node-2"}),
+	Dependencies: []taskid.UntypedTaskReference{
+		lokiclusterk8s_contract.InputNodeLogStreamSelectorTaskID.Ref(),
+		googlecloudk8scommon_contract.InputClusterNameTaskID.Ref(),
+		googlecloudk8scommon_contract.InputNodeNameFilterTaskID.Ref(),
+	},
+	Query: func(ctx context.Context) (string, error) {
+		selector := coretask.GetTaskResult(ctx, lokiclusterk8s_contract.InputNodeLogStreamSelectorTaskID.Ref())
+		clusterName := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputClusterNameTaskID.Ref())
+		nodeNameSubstrings := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputNodeNameFilterTaskID.Ref())
+		return GenerateNodeLogQuery(selector, clusterName, nodeNameSubstrings), nil
+	},
+	ConvertLog: newNodeLogFromLokiEntry,
+}, inspectioncore_contract.InspectionTypeLabel(lokiclusterk8s_contract.InspectionTypeID),
+	coretask.WithSelectionPriority(1000),
+)
+
+// newNodeLogFromLokiEntry returns the node log from the entry. The line can be a journal entry in JSON or the plain message.
+// The node name and the component name are read from the labels of the stream when the line doesn't have them.
+func newNodeLogFromLokiEntry(ctx context.Context, entry *loggingpb.LogEntry) (*log.Log, error) {
+	line := entry.GetTextPayload()
+	var payload map[string]any
+	if !strings.HasPrefix(strings.TrimSpace(line), "{") || json.Unmarshal([]byte(line), &payload) != nil || payload["MESSAGE"] == nil {
+		payload = map[string]any{
+			"MESSAGE": line,
+		}
+	}
+	if identifier, _ := payload["SYSLOG_IDENTIFIER"].(string); identifier == "" {
+		unit, _ := payload["_SYSTEMD_UNIT"].(string)
+		if unit == "" {
+			unit = entry.Labels[lokiclusterk8s_contract.UnitLabel]
+		}
+		payload["SYSLOG_IDENTIFIER"] = strings.TrimSuffix(unit, ".service")
+	}
+	nodeName := entry.Labels[lokiclusterk8s_contract.NodeNameLabel]
+	if nodeName == "" {
+		nodeName, _ = payload["_HOSTNAME"].(string)
+	}
+	return ossclusterk8s_contract.NewNodeLog(entry.InsertId, entry.Timestamp.AsTime(), nodeName, payload)
+}
+
+// LokiK8sNodeLogParserTailTask requires the node log parsers shared with the Cloud Logging based inspections. Logs are given from NodeLogQueryTask.
+var LokiK8sNodeLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	lokiclusterk8s_contract.LokiK8sNodeLogParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		googlecloudlogk8snode_contract.ContainerdLogLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8snode_contract.KubeletLogLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8snode_contract.OtherLogLogToTimelineMapperTaskID.Ref(),
+
+		googlecloudlogk8snode_contract.ContainerIDDiscoveryTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Node Logs", `Gather kubelet, containerd and other node component logs from Loki.`, enum.LogTypeNode, 1100, false, lokiclusterk8s_contract.InspectionTypeID),
+)
@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiclusterk8s_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestNewNodeLogFromLokiEntry(t *testing.T) {
+	timestamp := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name           string
+		line           string
+		labels         map[string]string
+		wantMessage    string
+		wantIdentifier string
+		wantNodeName   string
+	}{
+		{
+			name:           "plain message with labels",
+			line:           `I0101 00:00:00.000000    1234 kubelet.go:100] "SyncLoop ADD" source="api"`,
+			labels:         map[string]string{"unit": "kubelet.service", "node_name": "node-1"},
+			wantMessage:    `I0101 00:00:00.000000    1234 kubelet.go:100] "SyncLoop ADD" source="api"`,
+			wantIdentifier: "kubelet",
+			wantNodeName:   "node-1",
+		},
+		{
+			name:           "journal entry in JSON",
+			line:           `{"MESSAGE":"starting containerd","_SYSTEMD_UNIT":"containerd.service","_HOSTNAME":"node-2"}`,
+			labels:         map[string]string{},
+			wantMessage:    "starting containerd",
+			wantIdentifier: "containerd",
+			wantNodeName:   "node-2",
+		},
+		{
+			name:           "JSON message not from journald",
+			line:           `{"level":"info","msg":"foo"}`,
+			labels:         map[string]string{"unit": "foo.service", "node_name": "node-3"},
+			wantMessage:    `{"level":"info","msg":"foo"}`,
+			wantIdentifier: "foo",
+			wantNodeName:   "node-3",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := newNodeLogFromLokiEntry(context.Background(), &loggingpb.LogEntry{
+				InsertId:  "id",
+				Timestamp: timestamppb.New(timestamp),
+				Labels:    tc.labels,
+				Payload:   &loggingpb.LogEntry_TextPayload{TextPayload: tc.line},
+			})
+			if err != nil {
+				t.Fatalf("newNodeLogFromLokiEntry() returned an unexpected error: %v", err)
+			}
+			got := []string{
+				l.ReadStringOrDefault("jsonPayload.MESSAGE", ""),
+				l.ReadStringOrDefault("jsonPayload.SYSLOG_IDENTIFIER", ""),
+				l.ReadStringOrDefault("resource.labels.node_name", ""),
+			}
+			want := []string{tc.wantMessage, tc.wantIdentifier, tc.wantNodeName}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("newNodeLogFromLokiEntry() mismatch (-want +got):\n%s", diff)
+			}
+			commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			if !commonFieldSet.Timestamp.Equal(timestamp) {
+				t.Errorf("timestamp = %v, want %v", commonFieldSet.Timestamp, timestamp)
+			}
+		})
+	}
+}
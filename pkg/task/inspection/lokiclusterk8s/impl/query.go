@@ -0,0 +1,142 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiclusterk8s_impl
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/gcpqueryutil"
+	lokiclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/lokiclusterk8s/contract"
+)
+
+// auditStageLabel, auditVerbLabel, auditResourceLabel and auditNamespaceLabel are the labels extracted from audit logs with the json parser in LogQL.
+// These are prefixed not to conflict with the labels of the stream.
+const (
+	auditStageLabel     = "khi_stage"
+	auditVerbLabel      = "khi_verb"
+	auditResourceLabel  = "khi_resource"
+	auditNamespaceLabel = "khi_namespace"
+)
+
+// GenerateK8sAuditQuery constructs a LogQL query for fetching Kubernetes audit logs at the ResponseComplete stage based on the stream selector, cluster name, kind filters and namespace filters.
+// The filters are the same with the query for Cloud Logging. Filters not representable in LogQL are left as comments.
+func GenerateK8sAuditQuery(selector string, clusterName string, auditKindFilter *gcpqueryutil.SetFilterParseResult, namespaceFilter *gcpqueryutil.SetFilterParseResult) string {
+	return fmt.Sprintf(`%s
+|= "ResponseComplete"
+| json %s="stage", %s="verb", %s="objectRef.resource", %s="objectRef.namespace"
+| %s="ResponseComplete"
+| %s=~"create|update|patch|delete|deletecollection"
+%s
+%s
+`, withLabelMatchers(selector, labelEqualMatcher(lokiclusterk8s_contract.ClusterLabel, clusterName)),
+		auditStageLabel, auditVerbLabel, auditResourceLabel, auditNamespaceLabel,
+		auditStageLabel, auditVerbLabel,
+		generateAuditKindFilter(auditKindFilter), generateK8sAuditNamespaceFilter(namespaceFilter))
+}
+
+// generateAuditKindFilter creates a LogQL label filter for Kubernetes resource kinds based on the parsed filter result.
+func generateAuditKindFilter(filter *gcpqueryutil.SetFilterParseResult) string {
+	if filter.ValidationError != "" {
+		return fmt.Sprintf(`# Failed to generate kind filter due to the validation error "%s"`, filter.ValidationError)
+	}
+	if filter.SubtractMode {
+		if len(filter.Subtractives) == 0 {
+			return "# No kind filter"
+		}
+		return fmt.Sprintf(`| %s!~%s`, auditResourceLabel, quotedAlternation(filter.Subtractives))
+	}
+	if len(filter.Additives) == 0 {
+		return `# Invalid: none of the resources will be selected. Ignoring kind filter.`
+	}
+	return fmt.Sprintf(`| %s=~%s`, auditResourceLabel, quotedAlternation(filter.Additives))
+}
+
+// generateK8sAuditNamespaceFilter creates a LogQL label filter for Kubernetes namespaces based on the parsed filter result.
+func generateK8sAuditNamespaceFilter(filter *gcpqueryutil.SetFilterParseResult) string {
+	if filter.ValidationError != "" {
+		return fmt.Sprintf(`# Failed to generate namespace filter due to the validation error "%s"`, filter.ValidationError)
+	}
+	if filter.SubtractMode {
+		return "# Unsupported operation"
+	}
+	hasClusterScope := slices.Contains(filter.Additives, "#cluster-scoped")
+	hasNamespacedScope := slices.Contains(filter.Additives, "#namespaced")
+	if hasClusterScope && hasNamespacedScope {
+		return "# No namespace filter"
+	}
+	if !hasClusterScope && hasNamespacedScope {
+		return fmt.Sprintf(`| %s!=""`, auditNamespaceLabel)
+	}
+	namespaces := []string{}
+	for _, additive := range filter.Additives {
+		if strings.HasPrefix(additive, "#") {
+			continue
+		}
+		namespaces = append(namespaces, additive)
+	}
+	if hasClusterScope {
+		if len(namespaces) == 0 {
+			return fmt.Sprintf(`| %s=""`, auditNamespaceLabel)
+		}
+		// The empty alternative matches cluster scoped resources.
+		return fmt.Sprintf(`| %s=~%s`, auditNamespaceLabel, quotedAlternation(append([]string{""}, namespaces...)))
+	}
+	if len(namespaces) == 0 {
+		return `# Invalid: none of the resources will be selected. Ignoring namespace filter.`
+	}
+	return fmt.Sprintf(`| %s=~%s`, auditNamespaceLabel, quotedAlternation(namespaces))
+}
+
+// GenerateNodeLogQuery constructs a LogQL query for fetching node component logs based on the stream selector, cluster name and node name substrings.
+func GenerateNodeLogQuery(selector string, clusterName string, nodeNameSubstrings []string) string {
+	matchers := []string{labelEqualMatcher(lokiclusterk8s_contract.ClusterLabel, clusterName)}
+	if len(nodeNameSubstrings) > 0 {
+		quoted := make([]string, len(nodeNameSubstrings))
+		for i, substring := range nodeNameSubstrings {
+			quoted[i] = regexp.QuoteMeta(substring)
+		}
+		matchers = append(matchers, fmt.Sprintf(`%s=~%s`, lokiclusterk8s_contract.NodeNameLabel, strconv.Quote(fmt.Sprintf(".*(%s).*", strings.Join(quoted, "|")))))
+	}
+	return withLabelMatchers(selector, matchers...)
+}
+
+// withLabelMatchers returns the stream selector with the additional label matchers.
+func withLabelMatchers(selector string, matchers ...string) string {
+	selector = strings.TrimSpace(selector)
+	inner := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(selector, "{"), "}"))
+	if inner == "" {
+		return fmt.Sprintf("{%s}", strings.Join(matchers, ", "))
+	}
+	return fmt.Sprintf("{%s, %s}", inner, strings.Join(matchers, ", "))
+}
+
+// labelEqualMatcher returns the label matcher like `cluster="foo"`.
+func labelEqualMatcher(label string, value string) string {
+	return fmt.Sprintf("%s=%s", label, strconv.Quote(value))
+}
+
+// quotedAlternation returns the quoted regular expression matching one of the given values.
+// LogQL regular expressions are fully anchored.
+func quotedAlternation(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = regexp.QuoteMeta(value)
+	}
+	return strconv.Quote(strings.Join(quoted, "|"))
+}
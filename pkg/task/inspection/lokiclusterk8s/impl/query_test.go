@@ -0,0 +1,173 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiclusterk8s_impl
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/api/loki"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/gcpqueryutil"
+	"github.com/GoogleCloudPlatform/khi/pkg/testutil/lokistub"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateK8sAuditQuery(t *testing.T) {
+	baseTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	auditLine := func(auditID, stage, verb, resource, namespace string) lokistub.Entry {
+		return lokistub.Entry{
+			Timestamp: baseTime,
+			Line:      `{"auditID":"` + auditID + `","stage":"` + stage + `","verb":"` + verb + `","objectRef":{"resource":"` + resource + `","namespace":"` + namespace + `"}}`,
+		}
+	}
+	server := lokistub.NewServer(
+		lokistub.Stream{
+			Labels: map[string]string{"job": "audit", "cluster": "foo"},
+			Entries: []lokistub.Entry{
+				auditLine("create-pod", "ResponseComplete", "create", "pods", "default"),
+				auditLine("create-pod-started", "ResponseStarted", "create", "pods", "default"),
+				auditLine("get-pod", "ResponseComplete", "get", "pods", "default"),
+				auditLine("patch-pod-kube-system", "ResponseComplete", "patch", "pods", "kube-system"),
+				auditLine("update-node", "ResponseComplete", "update", "nodes", ""),
+				auditLine("delete-deployment", "ResponseComplete", "delete", "deployments", "default"),
+			},
+		},
+		lokistub.Stream{
+			Labels: map[string]string{"job": "audit", "cluster": "bar"},
+			Entries: []lokistub.Entry{
+				auditLine("other-cluster", "ResponseComplete", "create", "pods", "default"),
+			},
+		},
+	)
+	defer server.Close()
+	client, err := loki.NewClient(server.URL, "")
+	if err != nil {
+		t.Fatalf("NewClient() returned an unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name            string
+		kindFilter      *gcpqueryutil.SetFilterParseResult
+		namespaceFilter *gcpqueryutil.SetFilterParseResult
+		wantAuditIDs    []string
+	}{
+		{
+			name:            "all kinds and namespaces",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{SubtractMode: true},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped", "#namespaced"}},
+			wantAuditIDs:    []string{"create-pod", "patch-pod-kube-system", "update-node", "delete-deployment"},
+		},
+		{
+			name:            "specific kinds",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{Additives: []string{"pods", "nodes"}},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped", "#namespaced"}},
+			wantAuditIDs:    []string{"create-pod", "patch-pod-kube-system", "update-node"},
+		},
+		{
+			name:            "excluded kinds",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{SubtractMode: true, Subtractives: []string{"pods"}},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped", "#namespaced"}},
+			wantAuditIDs:    []string{"update-node", "delete-deployment"},
+		},
+		{
+			name:            "namespaced resources only",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{SubtractMode: true},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#namespaced"}},
+			wantAuditIDs:    []string{"create-pod", "patch-pod-kube-system", "delete-deployment"},
+		},
+		{
+			name:            "cluster scoped resources only",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{SubtractMode: true},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped"}},
+			wantAuditIDs:    []string{"update-node"},
+		},
+		{
+			name:            "cluster scoped resources and a namespace",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{SubtractMode: true},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped", "kube-system"}},
+			wantAuditIDs:    []string{"patch-pod-kube-system", "update-node"},
+		},
+		{
+			name:            "specific namespaces",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{SubtractMode: true},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"default", "kube-system"}},
+			wantAuditIDs:    []string{"create-pod", "patch-pod-kube-system", "delete-deployment"},
+		},
+		{
+			name:            "filters with validation errors are ignored",
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{ValidationError: "invalid"},
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{ValidationError: "invalid"},
+			wantAuditIDs:    []string{"create-pod", "patch-pod-kube-system", "update-node", "delete-deployment"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query := GenerateK8sAuditQuery(`{job="audit"}`, "foo", tc.kindFilter, tc.namespaceFilter)
+			entries, err := client.QueryRange(context.Background(), query, baseTime, baseTime.Add(time.Second), 100)
+			if err != nil {
+				t.Fatalf("QueryRange() returned an unexpected error: %v\nquery:\n%s", err, query)
+			}
+			gotAuditIDs := []string{}
+			for _, entry := range entries {
+				var auditLog struct {
+					AuditID string `json:"auditID"`
+				}
+				if err := json.Unmarshal([]byte(entry.Line), &auditLog); err != nil {
+					t.Fatalf("failed to parse the returned line %q: %v", entry.Line, err)
+				}
+				gotAuditIDs = append(gotAuditIDs, auditLog.AuditID)
+			}
+			if diff := cmp.Diff(tc.wantAuditIDs, gotAuditIDs); diff != "" {
+				t.Errorf("audit IDs mismatch (-want +got):\n%s\nquery:\n%s", diff, query)
+			}
+		})
+	}
+}
+
+func TestGenerateNodeLogQuery(t *testing.T) {
+	testCases := []struct {
+		name               string
+		selector           string
+		nodeNameSubstrings []string
+		want               string
+	}{
+		{
+			name:     "without node name filter",
+			selector: `{job="systemd-journal"}`,
+			want:     `{job="systemd-journal", cluster="foo"}`,
+		},
+		{
+			name:               "with node name filter",
+			selector:           ` {job="systemd-journal", env=~"prod|staging"} `,
+			nodeNameSubstrings: []string{"node-1", "pool-a"},
+			want:               `{job="systemd-journal", env=~"prod|staging", cluster="foo", node_name=~".*(node-1|pool-a).*"}`,
+		},
+		{
+			name:     "empty selector",
+			selector: `{}`,
+			want:     `{cluster="foo"}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := GenerateNodeLogQuery(tc.selector, "foo", tc.nodeNameSubstrings)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateNodeLogQuery() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiclusterk8s_impl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/GoogleCloudPlatform/khi/pkg/common/khictx"
+	"github.com/GoogleCloudPlatform/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	googlecloudcommon_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudcommon/contract"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	lokiclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/lokiclusterk8s/contract"
+)
+
+const (
+	// queryPageSize is the count of entries read in a query_range call. The default max_entries_limit_per_query of Loki is 5000.
+	queryPageSize = 1000
+	// queryTimePartitionCount is the count of the time ranges queried in parallel.
+	queryTimePartitionCount = 10
+)
+
+// lokiQueryTaskSetting defines the settings of a task querying logs from Loki.
+type lokiQueryTaskSetting struct {
+	// TaskID is the ID of the task returning the logs.
+	TaskID taskid.TaskImplementationID[[]*log.Log]
+	// QueryName is the readable name of the query shown in the metadata.
+	QueryName string
+	// LogType is the default log type of the returned logs.
+	LogType enum.LogType
+	// ExampleQuery is an example of the generated query shown in the documents.
+	ExampleQuery string
+	// Dependencies are the tasks used in Query.
+	Dependencies []taskid.UntypedTaskReference
+	// Query returns the LogQL log query.
+	Query func(ctx context.Context) (string, error)
+	// ConvertLog converts an entry read from Loki to a log. It returns nil to ignore the entry. It can read the results of Dependencies from the context.
+	ConvertLog func(ctx context.Context, entry *loggingpb.LogEntry) (*log.Log, error)
+}
+
+// newLokiQueryTask returns a task querying logs from Loki in the time range given in the form.
+// The returned logs are sorted by their timestamps.
+func newLokiQueryTask(setting *lokiQueryTaskSetting, labelOpts ...coretask.LabelOpt) coretask.Task[[]*log.Log] {
+	dependencies := append(slices.Clone(setting.Dependencies), googlecloudcommon_contract.InputStartTimeTaskID.Ref(), googlecloudcommon_contract.InputEndTimeTaskID.Ref(), lokiclusterk8s_contract.LokiClientTaskID.Ref())
+	labelOpts = append(labelOpts, inspectioncore_contract.NewQueryTaskLabelOpt(setting.LogType, setting.ExampleQuery))
+	return inspectiontaskbase.NewProgressReportableInspectionTask(setting.TaskID, dependencies, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, progress *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		startTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputStartTimeTaskID.Ref())
+		endTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputEndTimeTaskID.Ref())
+		client := coretask.GetTaskResult(ctx, lokiclusterk8s_contract.LokiClientTaskID.Ref())
+
+		query, err := setting.Query(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate the LogQL query: %w", err)
+		}
+		metadata := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+		queryInfo, found := typedmap.Get(metadata, inspectionmetadata.QueryMetadataKey)
+		if !found {
+			return nil, fmt.Errorf("query metadata was not found")
+		}
+		queryInfo.SetQuery(setting.TaskID.String(), setting.QueryName, query)
+
+		// Don't run the query except the run mode
+		if taskMode != inspectioncore_contract.TaskModeRun {
+			return []*log.Log{}, nil
+		}
+
+		fetcher := googlecloudcommon_contract.NewTimePartitioningProgressReportableTimeRangeLogFetcher(newLokiLogFetcher(client, queryPageSize), 500*time.Millisecond, queryTimePartitionCount, runtime.GOMAXPROCS(0))
+		logChan := make(chan *loggingpb.LogEntry)
+		progressChan := make(chan googlecloudcommon_contract.LogFetchProgress)
+		logs := []*log.Log{}
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for fetchProgress := range progressChan {
+				progress.Update(fetchProgress.Progress, fmt.Sprintf("%d logs fetched", fetchProgress.LogCount))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for entry := range logChan {
+				l, err := setting.ConvertLog(ctx, entry)
+				if err != nil {
+					slog.WarnContext(ctx, fmt.Sprintf("ignored a log from Loki not convertible to a log (timestamp: %v): %v", entry.Timestamp.AsTime(), err))
+					continue
+				}
+				if l != nil {
+					logs = append(logs, l)
+				}
+			}
+		}()
+		err = fetcher.FetchLogsWithProgress(logChan, progressChan, ctx, startTime, endTime, query, nil, nil)
+		wg.Wait()
+		if err != nil {
+			return nil, fmt.Errorf("failed to query logs from Loki: %w", err)
+		}
+
+		// Logs from the time partitions are received in parallel. Use the stable sort to keep the order returned from Loki for logs with the same timestamp.
+		slices.SortStableFunc(logs, func(a, b *log.Log) int {
+			logACommonField := log.MustGetFieldSet(a, &log.CommonFieldSet{})
+			logBCommonField := log.MustGetFieldSet(b, &log.CommonFieldSet{})
+			return logACommonField.Timestamp.Compare(logBCommonField.Timestamp)
+		})
+		return logs, nil
+	}, labelOpts...)
+}
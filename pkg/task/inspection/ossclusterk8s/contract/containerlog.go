@@ -0,0 +1,104 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/logutil"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+)
+
+// ContainerLogIdentity is the container emitting a container log. It's read from the path of the log file like `/var/log/pods/<namespace>_<pod>_<uid>/<container>/0.log`.
+type ContainerLogIdentity struct {
+	Namespace     string
+	PodName       string
+	PodUID        string
+	ContainerName string
+}
+
+// containerLogMessageParser detects the severity of container logs written in klog or logfmt.
+var containerLogMessageParser = logutil.NewMultiTextLogParser(
+	logutil.NewKLogTextParser(true),
+	logutil.NewLogfmtTextParser(),
+)
+
+// NewContainerLog returns a log in the shape of container logs on Cloud Logging to share the container log parser with the Cloud Logging based inspections.
+// stream is the output stream of the log, `stdout` or `stderr`.
+func NewContainerLog(id string, timestamp time.Time, container ContainerLogIdentity, stream string, message string) (*log.Log, error) {
+	node, err := structured.FromGoValue(map[string]any{
+		"insertId":    id,
+		"timestamp":   timestamp.Format(time.RFC3339Nano),
+		"logName":     stream,
+		"textPayload": message,
+		"resource": map[string]any{
+			"labels": map[string]any{
+				"namespace_name": container.Namespace,
+				"pod_name":       container.PodName,
+				"pod_uid":        container.PodUID,
+				"container_name": container.ContainerName,
+			},
+		},
+	}, &structured.AlphabeticalGoMapKeyOrderProvider{})
+	if err != nil {
+		return nil, err
+	}
+	l := log.NewLog(structured.NewNodeReader(node))
+	l.LogType = enum.LogTypeContainer
+	if err := l.SetFieldSetReader(&OSSK8sContainerLogCommonFieldSetReader{}); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// OSSK8sContainerLogCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet{} of container logs read from uploaded files.
+// The severity is read from the message when it's written in klog or logfmt.
+type OSSK8sContainerLogCommonFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (o *OSSK8sContainerLogCommonFieldSetReader) FieldSetKind() string {
+	return (&log.CommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (o *OSSK8sContainerLogCommonFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	var err error
+	result := &log.CommonFieldSet{}
+	result.DisplayID = reader.ReadStringOrDefault("insertId", "unknown")
+	result.Timestamp, err = reader.ReadTimestamp("timestamp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timestamp from given log: %w", err)
+	}
+	result.Severity = containerLogSeverity(reader.ReadStringOrDefault("textPayload", ""))
+	return result, nil
+}
+
+var _ log.FieldSetReader = (*OSSK8sContainerLogCommonFieldSetReader)(nil)
+
+// containerLogSeverity returns the severity of the container log message. It returns enum.SeverityUnknown when the message is neither klog nor logfmt with a severity field.
+func containerLogSeverity(message string) enum.Severity {
+	parsed := containerLogMessageParser.TryParse(message)
+	if parsed == nil {
+		return enum.SeverityUnknown
+	}
+	severity, err := parsed.Severity()
+	if err != nil {
+		return enum.SeverityUnknown
+	}
+	return severity
+}
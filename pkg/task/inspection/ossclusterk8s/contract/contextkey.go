@@ -0,0 +1,28 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"github.com/GoogleCloudPlatform/khi/pkg/common/typedmap"
+	"github.com/GoogleCloudPlatform/khi/pkg/server/auditwebhook"
+)
+
+// AuditWebhookArchiveStoreContextKey is the key to retrieve the store of the audit webhook archives from task context.
+// The value is injected on the task server during the initialization only when the audit webhook is enabled.
+var AuditWebhookArchiveStoreContextKey = typedmap.NewTypedKey[*auditwebhook.ArchiveStore]("audit-webhook-archive-store")
+
+// AuditLogSeverityPolicyContextKey is the key to retrieve the audit log severity policy loaded from the file given in the parameter.
+// The value is injected on the task server during the initialization only when the policy file is given.
+var AuditLogSeverityPolicyContextKey = typedmap.NewTypedKey[*AuditLogSeverityPolicy]("audit-log-severity-policy")
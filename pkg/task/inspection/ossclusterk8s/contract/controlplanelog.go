@@ -0,0 +1,78 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	googlecloudlogk8scontrolplane_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8scontrolplane/contract"
+)
+
+// ControlPlaneLogSource is the location in the source code written in the klog header of a control plane component log.
+type ControlPlaneLogSource struct {
+	File string
+	Line int
+}
+
+// controllerManagerComponentFieldSetReader reads the controller and the associated resources from controller manager logs in the same way as the Cloud Logging based inspections.
+var controllerManagerComponentFieldSetReader = googlecloudlogk8scontrolplane_contract.NewK8sControllerManagerComponentFieldSetReader()
+
+// NewControlPlaneLog returns a control plane component log with the field sets used in the control plane log parsers shared with the Cloud Logging based inspections.
+// component is the name of the component used in the control plane log parsers like `scheduler` or `controller-manager`. message is the klog message without its header.
+func NewControlPlaneLog(id string, timestamp time.Time, clusterName string, component string, severity enum.Severity, source ControlPlaneLogSource, message string) (*log.Log, error) {
+	fields := map[string]any{
+		"id":        id,
+		"timestamp": timestamp.Format(time.RFC3339Nano),
+		"severity":  enum.Severities[severity].Label,
+		"cluster":   clusterName,
+		"component": component,
+		"message":   message,
+	}
+	if source.File != "" {
+		fields["source"] = map[string]any{
+			"file": source.File,
+			"line": source.Line,
+		}
+	}
+	node, err := structured.FromGoValue(fields, &structured.AlphabeticalGoMapKeyOrderProvider{})
+	if err != nil {
+		return nil, err
+	}
+	l := log.NewLog(structured.NewNodeReader(node))
+	l.LogType = enum.LogTypeControlPlaneComponent
+	l.SetFieldSet(&log.CommonFieldSet{
+		DisplayID: id,
+		Timestamp: timestamp,
+		Severity:  severity,
+	})
+	componentFieldSet := &googlecloudlogk8scontrolplane_contract.K8sControlplaneComponentFieldSet{
+		ClusterName:   clusterName,
+		ComponentName: component,
+	}
+	l.SetFieldSet(componentFieldSet)
+	l.SetFieldSet(&googlecloudlogk8scontrolplane_contract.K8sControlplaneCommonMessageFieldSet{
+		Message: message,
+	})
+	switch componentFieldSet.ComponentParserType() {
+	case googlecloudlogk8scontrolplane_contract.ComponentParserTypeScheduler:
+		l.SetFieldSet(googlecloudlogk8scontrolplane_contract.NewK8sSchedulerComponentFieldSet(message))
+	case googlecloudlogk8scontrolplane_contract.ComponentParserTypeControllerManager:
+		l.SetFieldSet(controllerManagerComponentFieldSetReader.ReadMessage(message, source.File))
+	}
+	return l, nil
+}
@@ -24,7 +24,39 @@ import (
 	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
 )
 
-type OSSK8sAuditLogFieldSetReader struct{}
+// Stages of kube-apiserver audit logs.
+const (
+	AuditStageRequestReceived  = "RequestReceived"
+	AuditStageResponseStarted  = "ResponseStarted"
+	AuditStageResponseComplete = "ResponseComplete"
+	AuditStagePanic            = "Panic"
+)
+
+// requestOnlyStatusMessage is the status message of requests logged only at the RequestReceived stage.
+const requestOnlyStatusMessage = "No response was logged for the request"
+
+// panicStatusMessage is the status message of requests logged at the Panic stage without any status message.
+const panicStatusMessage = "kube-apiserver panicked while handling the request"
+
+// OSSK8sAuditLogFieldSetReader reads K8sAuditLogFieldSet from kube-apiserver audit logs.
+type OSSK8sAuditLogFieldSetReader struct {
+	// LongRunningAuditIDs is the set of auditIDs of requests logged at the ResponseStarted stage.
+	// The logs at the ResponseComplete or Panic stage of these requests are the last logs of the long-running operations.
+	LongRunningAuditIDs map[string]struct{}
+	// SeverityPolicy is the policy to decide the severity of logs. DefaultAuditLogSeverityPolicy is used when it is nil.
+	SeverityPolicy *AuditLogSeverityPolicy
+}
+
+// NewOSSK8sAuditLogFieldSetReader returns OSSK8sAuditLogFieldSetReader pairing the stages of requests in the given logs.
+func NewOSSK8sAuditLogFieldSetReader(logs []*log.Log, severityPolicy *AuditLogSeverityPolicy) *OSSK8sAuditLogFieldSetReader {
+	longRunningAuditIDs := map[string]struct{}{}
+	for _, l := range logs {
+		if l.ReadStringOrDefault("stage", "") == AuditStageResponseStarted {
+			longRunningAuditIDs[l.ReadStringOrDefault("auditID", "")] = struct{}{}
+		}
+	}
+	return &OSSK8sAuditLogFieldSetReader{LongRunningAuditIDs: longRunningAuditIDs, SeverityPolicy: severityPolicy}
+}
 
 // FieldSetKind implements log.FieldSetReader.
 func (o *OSSK8sAuditLogFieldSetReader) FieldSetKind() string {
@@ -35,9 +67,10 @@ func (o *OSSK8sAuditLogFieldSetReader) FieldSetKind() string {
 func (o *OSSK8sAuditLogFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
 	var result commonlogk8sauditv2_contract.K8sAuditLogFieldSet
 	result.OperationID = reader.ReadStringOrDefault("auditID", "")
-	// Currently this won't support the long running operation. TODO: support long runnning operation
-	result.IsFirst = true
-	result.IsLast = true
+	_, longRunning := o.LongRunningAuditIDs[result.OperationID]
+	stage := reader.ReadStringOrDefault("stage", AuditStageResponseComplete)
+	result.IsFirst = stage == AuditStageRequestReceived || stage == AuditStageResponseStarted || !longRunning
+	result.IsLast = stage != AuditStageResponseStarted
 	apiGroup := reader.ReadStringOrDefault("objectRef.apiGroup", "core")
 	apiVersion := reader.ReadStringOrDefault("objectRef.apiVersion", "unknown")
 	kind := reader.ReadStringOrDefault("objectRef.resource", "unknown")
@@ -57,23 +90,39 @@ func (o *OSSK8sAuditLogFieldSetReader) Read(reader *structured.NodeReader) (log.
 		Namespace:       namespace,
 		Name:            name,
 		SubResourceName: subresource,
-		Verb:            verbStringToEnum(verb),
+		Verb:            commonlogk8sauditv2_contract.VerbStringToEnum(verb),
 	}
 
 	result.RequestURI = reader.ReadStringOrDefault("requestURI", "")
 	result.Principal = reader.ReadStringOrDefault("user.username", "unknown")
 	result.StatusCode = reader.ReadIntOrDefault("responseStatus.code", 0)
 	result.StatusMessage = reader.ReadStringOrDefault("responseStatus.message", "")
-	result.IsError = result.StatusCode < 200 || result.StatusCode >= 300
+	// Upgraded connections like exec or port-forward respond with 101 Switching Protocols.
+	result.IsError = (result.StatusCode < 200 && result.StatusCode != 101) || result.StatusCode >= 300
+	result.Severity = auditLogSeverity(reader, o.SeverityPolicy)
+	switch stage {
+	case AuditStageRequestReceived:
+		result.IsError = true
+		result.StatusMessage = requestOnlyStatusMessage
+	case AuditStagePanic:
+		result.IsError = true
+		if result.StatusMessage == "" {
+			result.StatusMessage = panicStatusMessage
+		}
+	}
 	result.Request, _ = reader.GetReader("requestObject")
 	result.Response, _ = reader.GetReader("responseObject")
+	result.MetadataLevel = reader.ReadStringOrDefault("level", "") == "Metadata"
 	return &result, nil
 }
 
 var _ log.FieldSetReader = (*OSSK8sAuditLogFieldSetReader)(nil)
 
 // OSSK8sAuditLogCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet{}.
-type OSSK8sAuditLogCommonFieldSetReader struct{}
+type OSSK8sAuditLogCommonFieldSetReader struct {
+	// SeverityPolicy is the policy to decide the severity of logs. DefaultAuditLogSeverityPolicy is used when it is nil.
+	SeverityPolicy *AuditLogSeverityPolicy
+}
 
 // FieldSetKind implements log.FieldSetReader.
 func (o *OSSK8sAuditLogCommonFieldSetReader) FieldSetKind() string {
@@ -89,26 +138,62 @@ func (o *OSSK8sAuditLogCommonFieldSetReader) Read(reader *structured.NodeReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read timestmap from given log")
 	}
-	result.Severity = enum.SeverityUnknown // TODO: handle OSS k8s audit log severity properly
+	result.Severity = auditLogSeverity(reader, o.SeverityPolicy)
 	return result, nil
 }
 
 var _ log.FieldSetReader = (*OSSK8sAuditLogCommonFieldSetReader)(nil)
 
-func verbStringToEnum(verbStr string) enum.RevisionVerb {
-	switch verbStr {
-	case "create":
-		return enum.RevisionVerbCreate
-	case "update":
-		return enum.RevisionVerbUpdate
-	case "patch":
-		return enum.RevisionVerbPatch
-	case "delete":
-		return enum.RevisionVerbDelete
-	case "deletecollection":
-		return enum.RevisionVerbDeleteCollection
+// auditLogSeverity returns the severity of the audit log decided by the policy.
+// Logs at the Panic stage and logs only at the RequestReceived stage are always errors because the kube-apiserver failed to respond.
+func auditLogSeverity(reader *structured.NodeReader, policy *AuditLogSeverityPolicy) enum.Severity {
+	switch reader.ReadStringOrDefault("stage", "") {
+	case AuditStagePanic, AuditStageRequestReceived:
+		return enum.SeverityError
+	}
+	if policy == nil {
+		policy = DefaultAuditLogSeverityPolicy
+	}
+	return policy.Severity(reader)
+}
+
+// OSSK8sNodeLogCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet{} of node logs read from uploaded files.
+// Node logs are normalized to the shape of node logs on Cloud Logging to share the node log parsers. `jsonPayload` holds the fields of a journal entry.
+type OSSK8sNodeLogCommonFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (o *OSSK8sNodeLogCommonFieldSetReader) FieldSetKind() string {
+	return (&log.CommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (o *OSSK8sNodeLogCommonFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	var err error
+	result := &log.CommonFieldSet{}
+	result.DisplayID = reader.ReadStringOrDefault("insertId", "unknown")
+	result.Timestamp, err = reader.ReadTimestamp("timestamp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timestmap from given log")
+	}
+	result.Severity = syslogPriorityToSeverity(reader.ReadStringOrDefault("jsonPayload.PRIORITY", ""))
+	return result, nil
+}
+
+var _ log.FieldSetReader = (*OSSK8sNodeLogCommonFieldSetReader)(nil)
+
+// syslogPriorityToSeverity converts the PRIORITY field of journal entries to the severity.
+// klog files don't have the field and the node log parsers read the severity from the klog header instead.
+func syslogPriorityToSeverity(priority string) enum.Severity {
+	switch priority {
+	case "0", "1", "2":
+		return enum.SeverityFatal
+	case "3":
+		return enum.SeverityError
+	case "4":
+		return enum.SeverityWarning
+	case "5", "6", "7":
+		return enum.SeverityInfo
 	default:
-		// Add verbs for get/list/watch
-		return enum.RevisionVerbUnknown
+		return enum.SeverityUnknown
 	}
 }
@@ -58,6 +58,7 @@ requestURI: "/api/v1/namespaces/default/pods/test-pod"
 				StatusCode:    200,
 				StatusMessage: "OK",
 				IsError:       false,
+				Severity:      enum.SeverityInfo,
 				RequestURI:    "/api/v1/namespaces/default/pods/test-pod",
 				K8sOperation: &model.KubernetesObjectOperation{
 					APIVersion:      "core/v1",
@@ -93,6 +94,7 @@ responseStatus:
 				StatusCode:    201,
 				StatusMessage: "",
 				IsError:       false,
+				Severity:      enum.SeverityInfo,
 				RequestURI:    "",
 				K8sOperation: &model.KubernetesObjectOperation{
 					APIVersion:      "apps/v1",
@@ -124,6 +126,7 @@ objectRef:
 				StatusCode:    404,
 				StatusMessage: "Not Found",
 				IsError:       true,
+				Severity:      enum.SeverityWarning,
 				RequestURI:    "",
 				K8sOperation: &model.KubernetesObjectOperation{
 					APIVersion:      "core/unknown",
@@ -135,6 +138,169 @@ objectRef:
 				},
 			},
 		},
+		{
+			desc: "long-running request at the ResponseStarted stage",
+			input: `
+auditID: "long-running-audit-id"
+stage: "ResponseStarted"
+verb: "create"
+responseStatus:
+  code: 101
+objectRef:
+  apiVersion: "v1"
+  resource: "pods"
+  namespace: "default"
+  name: "test-pod"
+  subresource: "exec"
+`,
+			want: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				OperationID: "long-running-audit-id",
+				IsFirst:     true,
+				IsLast:      false,
+				Principal:   "unknown",
+				StatusCode:  101,
+				IsError:     false,
+				Severity:    enum.SeverityInfo,
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion:      "core/v1",
+					PluralKind:      "pods",
+					Namespace:       "default",
+					Name:            "test-pod",
+					SubResourceName: "exec",
+					Verb:            enum.RevisionVerbCreate,
+				},
+			},
+		},
+		{
+			desc: "long-running request at the ResponseComplete stage",
+			input: `
+auditID: "long-running-audit-id"
+stage: "ResponseComplete"
+verb: "create"
+responseStatus:
+  code: 101
+objectRef:
+  apiVersion: "v1"
+  resource: "pods"
+  namespace: "default"
+  name: "test-pod"
+  subresource: "exec"
+`,
+			want: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				OperationID: "long-running-audit-id",
+				IsFirst:     false,
+				IsLast:      true,
+				Principal:   "unknown",
+				StatusCode:  101,
+				IsError:     false,
+				Severity:    enum.SeverityInfo,
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion:      "core/v1",
+					PluralKind:      "pods",
+					Namespace:       "default",
+					Name:            "test-pod",
+					SubResourceName: "exec",
+					Verb:            enum.RevisionVerbCreate,
+				},
+			},
+		},
+		{
+			desc: "request logged only at the RequestReceived stage",
+			input: `
+auditID: "request-only-audit-id"
+stage: "RequestReceived"
+verb: "create"
+objectRef:
+  apiVersion: "v1"
+  resource: "pods"
+  namespace: "default"
+  name: "test-pod"
+  subresource: "exec"
+`,
+			want: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				OperationID:   "request-only-audit-id",
+				IsFirst:       true,
+				IsLast:        true,
+				Principal:     "unknown",
+				StatusMessage: "No response was logged for the request",
+				IsError:       true,
+				Severity:      enum.SeverityError,
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion:      "core/v1",
+					PluralKind:      "pods",
+					Namespace:       "default",
+					Name:            "test-pod",
+					SubResourceName: "exec",
+					Verb:            enum.RevisionVerbCreate,
+				},
+			},
+		},
+		{
+			desc: "panic",
+			input: `
+auditID: "panic-audit-id"
+stage: "Panic"
+verb: "create"
+responseStatus:
+  code: 500
+objectRef:
+  apiVersion: "v1"
+  resource: "pods"
+  namespace: "default"
+  name: "test-pod"
+  subresource: "exec"
+`,
+			want: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				OperationID:   "panic-audit-id",
+				IsFirst:       true,
+				IsLast:        true,
+				Principal:     "unknown",
+				StatusCode:    500,
+				StatusMessage: "kube-apiserver panicked while handling the request",
+				IsError:       true,
+				Severity:      enum.SeverityError,
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion:      "core/v1",
+					PluralKind:      "pods",
+					Namespace:       "default",
+					Name:            "test-pod",
+					SubResourceName: "exec",
+					Verb:            enum.RevisionVerbCreate,
+				},
+			},
+		},
+		{
+			desc: "metadata level",
+			input: `
+auditID: "metadata-audit-id"
+level: "Metadata"
+verb: "delete"
+responseStatus:
+  code: 200
+objectRef:
+  apiVersion: "v1"
+  resource: "secrets"
+  namespace: "default"
+  name: "test-secret"
+`,
+			want: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				OperationID:   "metadata-audit-id",
+				IsFirst:       true,
+				IsLast:        true,
+				Principal:     "unknown",
+				StatusCode:    200,
+				IsError:       false,
+				Severity:      enum.SeverityInfo,
+				MetadataLevel: true,
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion: "core/v1",
+					PluralKind: "secrets",
+					Namespace:  "default",
+					Name:       "test-secret",
+					Verb:       enum.RevisionVerbDelete,
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -143,7 +309,7 @@ objectRef:
 			if err != nil {
 				t.Fatalf("failed to parse YAML test input to log: %v", err)
 			}
-			err = l.SetFieldSetReader(&OSSK8sAuditLogFieldSetReader{})
+			err = l.SetFieldSetReader(&OSSK8sAuditLogFieldSetReader{LongRunningAuditIDs: map[string]struct{}{"long-running-audit-id": {}}})
 			if err != nil {
 				t.Errorf("failed to run OSSK8sAuditLogFieldSetReader.Read(): %v", err)
 			}
@@ -161,6 +327,26 @@ objectRef:
 	}
 }
 
+func TestNewOSSK8sAuditLogFieldSetReader(t *testing.T) {
+	logs := []*log.Log{}
+	for _, input := range []string{
+		`{"auditID": "short", "stage": "ResponseComplete"}`,
+		`{"auditID": "watch", "stage": "ResponseStarted"}`,
+		`{"auditID": "watch", "stage": "ResponseComplete"}`,
+		`{"auditID": "exec", "stage": "ResponseStarted"}`,
+	} {
+		l, err := log.NewLogFromYAMLString(input)
+		if err != nil {
+			t.Fatalf("failed to parse YAML test input to log: %v", err)
+		}
+		logs = append(logs, l)
+	}
+	want := map[string]struct{}{"watch": {}, "exec": {}}
+	if diff := cmp.Diff(want, NewOSSK8sAuditLogFieldSetReader(logs, nil).LongRunningAuditIDs); diff != "" {
+		t.Errorf("LongRunningAuditIDs mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestOSSK8sAuditLogCommonFieldSetReader(t *testing.T) {
 	testCases := []struct {
 		desc  string
@@ -179,6 +365,34 @@ stageTimestamp: "2023-10-26T10:00:00Z"
 				Severity:  enum.SeverityUnknown,
 			},
 		},
+		{
+			desc: "severity from the status code",
+			input: `
+auditID: "test-audit-id"
+stage: "ResponseComplete"
+stageTimestamp: "2023-10-26T10:00:00Z"
+responseStatus:
+  code: 500
+`,
+			want: &log.CommonFieldSet{
+				DisplayID: "test-audit-id",
+				Timestamp: time.Date(2023, 10, 26, 10, 0, 0, 0, time.UTC),
+				Severity:  enum.SeverityError,
+			},
+		},
+		{
+			desc: "request without response",
+			input: `
+auditID: "test-audit-id"
+stage: "RequestReceived"
+stageTimestamp: "2023-10-26T10:00:00Z"
+`,
+			want: &log.CommonFieldSet{
+				DisplayID: "test-audit-id",
+				Timestamp: time.Date(2023, 10, 26, 10, 0, 0, 0, time.UTC),
+				Severity:  enum.SeverityError,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -198,3 +412,57 @@ stageTimestamp: "2023-10-26T10:00:00Z"
 		})
 	}
 }
+
+func TestOSSK8sNodeLogCommonFieldSetReader(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		input string
+		want  *log.CommonFieldSet
+	}{
+		{
+			desc: "journal entry",
+			input: `
+insertId: "node-1/journal.log:1"
+timestamp: "2023-10-26T10:00:00Z"
+jsonPayload:
+  PRIORITY: "3"
+`,
+			want: &log.CommonFieldSet{
+				DisplayID: "node-1/journal.log:1",
+				Timestamp: time.Date(2023, 10, 26, 10, 0, 0, 0, time.UTC),
+				Severity:  enum.SeverityError,
+			},
+		},
+		{
+			desc: "klog line without priority",
+			input: `
+insertId: "node-1/kubelet.log:1"
+timestamp: "2023-10-26T10:00:00Z"
+jsonPayload:
+  MESSAGE: "I1026 10:00:00.000000 1 foo.go:1] bar"
+`,
+			want: &log.CommonFieldSet{
+				DisplayID: "node-1/kubelet.log:1",
+				Timestamp: time.Date(2023, 10, 26, 10, 0, 0, 0, time.UTC),
+				Severity:  enum.SeverityUnknown,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := log.NewLogFromYAMLString(tc.input)
+			if err != nil {
+				t.Fatalf("failed to parse YAML test input to log: %v", err)
+			}
+			err = l.SetFieldSetReader(&OSSK8sNodeLogCommonFieldSetReader{})
+			if err != nil {
+				t.Errorf("failed to run OSSK8sNodeLogCommonFieldSetReader.Read(): %v", err)
+			}
+			got := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("CommonFieldSet mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
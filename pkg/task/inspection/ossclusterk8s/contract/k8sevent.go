@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+)
+
+// NewK8sEventLog returns a log in the shape of Kubernetes Event logs on Cloud Logging to share the event log parser with the Cloud Logging based inspections.
+// event is a core/v1 Event. Events in events.k8s.io/v1 must be converted to the core/v1 fields before calling this.
+func NewK8sEventLog(id string, timestamp time.Time, event map[string]any) (*log.Log, error) {
+	node, err := structured.FromGoValue(map[string]any{
+		"insertId":    id,
+		"timestamp":   timestamp.Format(time.RFC3339Nano),
+		"jsonPayload": event,
+	}, &structured.AlphabeticalGoMapKeyOrderProvider{})
+	if err != nil {
+		return nil, err
+	}
+	l := log.NewLog(structured.NewNodeReader(node))
+	l.LogType = enum.LogTypeEvent
+	if err := l.SetFieldSetReader(&OSSK8sEventLogCommonFieldSetReader{}); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// OSSK8sEventLogCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet{} of Kubernetes Events read from uploaded files.
+type OSSK8sEventLogCommonFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (o *OSSK8sEventLogCommonFieldSetReader) FieldSetKind() string {
+	return (&log.CommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (o *OSSK8sEventLogCommonFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	var err error
+	result := &log.CommonFieldSet{}
+	result.DisplayID = reader.ReadStringOrDefault("insertId", "unknown")
+	result.Timestamp, err = reader.ReadTimestamp("timestamp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timestamp from given log: %w", err)
+	}
+	switch reader.ReadStringOrDefault("jsonPayload.type", "") {
+	case "Warning":
+		result.Severity = enum.SeverityWarning
+	case "Normal":
+		result.Severity = enum.SeverityInfo
+	default:
+		result.Severity = enum.SeverityUnknown
+	}
+	return result, nil
+}
+
+var _ log.FieldSetReader = (*OSSK8sEventLogCommonFieldSetReader)(nil)
@@ -0,0 +1,47 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+)
+
+// NewNodeLog returns a log in the shape of node logs on Cloud Logging to share the node log parsers with the Cloud Logging based inspections.
+// payload is the journal entry fields like MESSAGE and SYSLOG_IDENTIFIER.
+func NewNodeLog(id string, timestamp time.Time, nodeName string, payload map[string]any) (*log.Log, error) {
+	node, err := structured.FromGoValue(map[string]any{
+		"insertId":    id,
+		"timestamp":   timestamp.Format(time.RFC3339Nano),
+		"jsonPayload": payload,
+		"resource": map[string]any{
+			"labels": map[string]any{
+				"node_name": nodeName,
+			},
+		},
+	}, &structured.AlphabeticalGoMapKeyOrderProvider{})
+	if err != nil {
+		return nil, err
+	}
+	l := log.NewLog(structured.NewNodeReader(node))
+	l.LogType = enum.LogTypeNode
+	if err := l.SetFieldSetReader(&OSSK8sNodeLogCommonFieldSetReader{}); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/structured"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"gopkg.in/yaml.v3"
+)
+
+// AuditLogSeverityPolicy decides the severity of kube-apiserver audit logs from their response status codes and annotations.
+type AuditLogSeverityPolicy struct {
+	// StatusCodes are the rules matched with `responseStatus.code`. The first matched rule decides the severity.
+	StatusCodes []*StatusCodeSeverityRule `yaml:"statusCodes"`
+	// Annotations are the rules matched with `annotations`. The most severe one among the matched rules is used when it is more severe than the one from the status code.
+	Annotations []*AnnotationSeverityRule `yaml:"annotations"`
+}
+
+// StatusCodeSeverityRule gives the severity to logs with the status code between Min and Max inclusive.
+type StatusCodeSeverityRule struct {
+	Min      int              `yaml:"min"`
+	Max      int              `yaml:"max"`
+	Severity AuditLogSeverity `yaml:"severity"`
+}
+
+// AnnotationSeverityRule gives the severity to logs with the annotation matching Key and Value.
+// Key and Value are patterns of path.Match. An empty Value matches any value.
+type AnnotationSeverityRule struct {
+	Key      string           `yaml:"key"`
+	Value    string           `yaml:"value"`
+	Severity AuditLogSeverity `yaml:"severity"`
+}
+
+// AuditLogSeverity is enum.Severity written as its lower case label like `warning` in policy files.
+type AuditLogSeverity enum.Severity
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (s *AuditLogSeverity) UnmarshalYAML(node *yaml.Node) error {
+	for severity, metadata := range enum.Severities {
+		if strings.EqualFold(metadata.Label, node.Value) {
+			*s = AuditLogSeverity(severity)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown severity %q at line %d", node.Value, node.Line)
+}
+
+// DefaultAuditLogSeverityPolicy is the policy used when no policy file is given.
+// Conflicts are usual on optimistic concurrency control of controllers and they are not treated as warnings.
+var DefaultAuditLogSeverityPolicy = &AuditLogSeverityPolicy{
+	StatusCodes: []*StatusCodeSeverityRule{
+		{Min: 409, Max: 409, Severity: AuditLogSeverity(enum.SeverityInfo)},
+		{Min: 500, Max: 599, Severity: AuditLogSeverity(enum.SeverityError)},
+		{Min: 400, Max: 499, Severity: AuditLogSeverity(enum.SeverityWarning)},
+		{Min: 100, Max: 399, Severity: AuditLogSeverity(enum.SeverityInfo)},
+	},
+	Annotations: []*AnnotationSeverityRule{
+		{Key: "authorization.k8s.io/decision", Value: "forbid", Severity: AuditLogSeverity(enum.SeverityWarning)},
+		{Key: "pod-security.kubernetes.io/*-violations", Severity: AuditLogSeverity(enum.SeverityWarning)},
+	},
+}
+
+// LoadAuditLogSeverityPolicy reads the AuditLogSeverityPolicy from the YAML file.
+func LoadAuditLogSeverityPolicy(filePath string) (*AuditLogSeverityPolicy, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the audit log severity policy file %s: %w", filePath, err)
+	}
+	return ParseAuditLogSeverityPolicy(content)
+}
+
+// ParseAuditLogSeverityPolicy parses the AuditLogSeverityPolicy written in YAML.
+func ParseAuditLogSeverityPolicy(content []byte) (*AuditLogSeverityPolicy, error) {
+	policy := &AuditLogSeverityPolicy{}
+	if err := yaml.Unmarshal(content, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse the audit log severity policy: %w", err)
+	}
+	for _, rule := range policy.Annotations {
+		if _, err := path.Match(rule.Key, ""); err != nil {
+			return nil, fmt.Errorf("invalid annotation key pattern %q: %w", rule.Key, err)
+		}
+		if _, err := path.Match(rule.Value, ""); err != nil {
+			return nil, fmt.Errorf("invalid annotation value pattern %q: %w", rule.Value, err)
+		}
+	}
+	return policy, nil
+}
+
+// Severity returns the severity of the audit log. It returns enum.SeverityUnknown when no rule is matched.
+func (p *AuditLogSeverityPolicy) Severity(reader *structured.NodeReader) enum.Severity {
+	result := enum.SeverityUnknown
+	code := reader.ReadIntOrDefault("responseStatus.code", 0)
+	for _, rule := range p.StatusCodes {
+		if rule.Min <= code && code <= rule.Max {
+			result = enum.Severity(rule.Severity)
+			break
+		}
+	}
+	annotations, err := reader.GetReader("annotations")
+	if err != nil {
+		return result
+	}
+	for key, value := range annotations.Children() {
+		annotationValue := value.ReadStringOrDefault("", "")
+		for _, rule := range p.Annotations {
+			if enum.Severity(rule.Severity) <= result || !rule.matches(key.Key, annotationValue) {
+				continue
+			}
+			result = enum.Severity(rule.Severity)
+		}
+	}
+	return result
+}
+
+func (r *AnnotationSeverityRule) matches(key string, value string) bool {
+	if matched, _ := path.Match(r.Key, key); !matched {
+		return false
+	}
+	if r.Value == "" {
+		return true
+	}
+	matched, _ := path.Match(r.Value, value)
+	return matched
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAuditLogSeverityPolicy_Severity(t *testing.T) {
+	customPolicy, err := ParseAuditLogSeverityPolicy([]byte(`
+statusCodes:
+- min: 400
+  max: 599
+  severity: error
+annotations:
+- key: example.com/*
+  value: "danger-*"
+  severity: fatal
+`))
+	if err != nil {
+		t.Fatalf("ParseAuditLogSeverityPolicy() returned an unexpected error: %v", err)
+	}
+	testCases := []struct {
+		desc   string
+		policy *AuditLogSeverityPolicy
+		input  string
+		want   enum.Severity
+	}{
+		{
+			desc:   "success",
+			policy: DefaultAuditLogSeverityPolicy,
+			input:  `responseStatus: {code: 200}`,
+			want:   enum.SeverityInfo,
+		},
+		{
+			desc:   "client error",
+			policy: DefaultAuditLogSeverityPolicy,
+			input:  `responseStatus: {code: 404}`,
+			want:   enum.SeverityWarning,
+		},
+		{
+			desc:   "conflict",
+			policy: DefaultAuditLogSeverityPolicy,
+			input:  `responseStatus: {code: 409}`,
+			want:   enum.SeverityInfo,
+		},
+		{
+			desc:   "server error",
+			policy: DefaultAuditLogSeverityPolicy,
+			input:  `responseStatus: {code: 503}`,
+			want:   enum.SeverityError,
+		},
+		{
+			desc:   "no response status",
+			policy: DefaultAuditLogSeverityPolicy,
+			input:  `verb: get`,
+			want:   enum.SeverityUnknown,
+		},
+		{
+			desc:   "forbidden by the authorizer",
+			policy: DefaultAuditLogSeverityPolicy,
+			input: `
+responseStatus: {code: 403}
+annotations:
+  authorization.k8s.io/decision: forbid
+`,
+			want: enum.SeverityWarning,
+		},
+		{
+			desc:   "pod security violation on an accepted request",
+			policy: DefaultAuditLogSeverityPolicy,
+			input: `
+responseStatus: {code: 201}
+annotations:
+  pod-security.kubernetes.io/enforce-policy: "baseline:latest"
+  pod-security.kubernetes.io/audit-violations: "would violate PodSecurity \"restricted:latest\""
+`,
+			want: enum.SeverityWarning,
+		},
+		{
+			desc:   "annotation less severe than the status code",
+			policy: DefaultAuditLogSeverityPolicy,
+			input: `
+responseStatus: {code: 500}
+annotations:
+  pod-security.kubernetes.io/audit-violations: "would violate PodSecurity \"restricted:latest\""
+`,
+			want: enum.SeverityError,
+		},
+		{
+			desc:   "custom policy with matched annotation value",
+			policy: customPolicy,
+			input: `
+responseStatus: {code: 200}
+annotations:
+  example.com/level: danger-high
+`,
+			want: enum.SeverityFatal,
+		},
+		{
+			desc:   "custom policy with unmatched annotation value",
+			policy: customPolicy,
+			input: `
+responseStatus: {code: 409}
+annotations:
+  example.com/level: safe
+`,
+			want: enum.SeverityError,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := log.NewLogFromYAMLString(tc.input)
+			if err != nil {
+				t.Fatalf("failed to parse YAML test input to log: %v", err)
+			}
+			got := tc.policy.Severity(l.NodeReader)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Severity() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseAuditLogSeverityPolicy(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		input   string
+		want    *AuditLogSeverityPolicy
+		wantErr bool
+	}{
+		{
+			desc: "valid policy",
+			input: `
+statusCodes:
+- min: 500
+  max: 599
+  severity: ERROR
+annotations:
+- key: authorization.k8s.io/decision
+  value: forbid
+  severity: warning
+`,
+			want: &AuditLogSeverityPolicy{
+				StatusCodes: []*StatusCodeSeverityRule{
+					{Min: 500, Max: 599, Severity: AuditLogSeverity(enum.SeverityError)},
+				},
+				Annotations: []*AnnotationSeverityRule{
+					{Key: "authorization.k8s.io/decision", Value: "forbid", Severity: AuditLogSeverity(enum.SeverityWarning)},
+				},
+			},
+		},
+		{
+			desc: "unknown severity",
+			input: `
+statusCodes:
+- min: 500
+  max: 599
+  severity: critical
+`,
+			wantErr: true,
+		},
+		{
+			desc: "invalid annotation key pattern",
+			input: `
+annotations:
+- key: "example.com/["
+  severity: warning
+`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ParseAuditLogSeverityPolicy([]byte(tc.input))
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ParseAuditLogSeverityPolicy() returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAuditLogSeverityPolicy() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseAuditLogSeverityPolicy() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
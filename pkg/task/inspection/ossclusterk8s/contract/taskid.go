@@ -15,16 +15,44 @@
 package ossclusterk8s_contract
 
 import (
+	"time"
+
 	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
 	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
 	"github.com/GoogleCloudPlatform/khi/pkg/server/upload"
 	commonlogk8sauditv2_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudlogk8scontainer_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+	googlecloudlogk8scontrolplane_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8scontrolplane/contract"
+	googlecloudlogk8sevent_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8sevent/contract"
+	googlecloudlogk8snode_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
 )
 
 // OSSTaskPrefix is the prefixes of IDs used in OSS related tasks.
 const OSSTaskPrefix = "khi.google.com/oss/"
 
+const (
+	// AuditLogSourceUploadedFiles reads audit logs from the files uploaded to the audit log files form.
+	AuditLogSourceUploadedFiles = "Uploaded files"
+	// AuditLogSourceAuditWebhook reads audit logs in the time range from the archives received by the audit webhook endpoint of KHI.
+	AuditLogSourceAuditWebhook = "Audit webhook archives"
+)
+
+// InputAuditLogSourceFormTaskID is the task ID of the form to choose the source of audit logs from AuditLogSourceUploadedFiles and AuditLogSourceAuditWebhook.
+var InputAuditLogSourceFormTaskID = taskid.NewDefaultImplementationID[string](OSSTaskPrefix + "form/kube-apiserver-audit-log-source")
+
+// AuditWebhookTimeRange is the time range of audit logs read from the audit webhook archives.
+type AuditWebhookTimeRange struct {
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// InputAuditWebhookTimeRangeFormTaskID is the task ID of the form to specify the time range of audit logs read from the audit webhook archives.
+// The result is nil when audit logs are not read from the audit webhook archives.
+var InputAuditWebhookTimeRangeFormTaskID = taskid.NewDefaultImplementationID[*AuditWebhookTimeRange](OSSTaskPrefix + "form/audit-webhook-time-range")
 var InputAuditLogFilesFormTaskID = taskid.NewDefaultImplementationID[upload.UploadResult](OSSTaskPrefix + "form/kube-apiserver-audit-log-files")
+
+// AuditLogSeverityPolicyTaskID is the task ID returning the policy deciding the severity of kube-apiserver audit logs.
+var AuditLogSeverityPolicyTaskID = taskid.NewDefaultImplementationID[*AuditLogSeverityPolicy](OSSTaskPrefix + "audit-log-severity-policy")
 var AuditLogFileReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](OSSTaskPrefix + "audit-log-reader")
 var NonEventAuditLogFilterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](OSSTaskPrefix + "audit-log-filter-non-event-audit")
 var EventAuditLogFilterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](OSSTaskPrefix + "audit-log-filter-event-audit")
@@ -32,3 +60,37 @@ var OSSK8sEventLogParserTaskID = taskid.NewDefaultImplementationID[struct{}](OSS
 
 var OSSK8sAuditLogProviderTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogProviderRef, "oss")
 var OSSK8sAuditLogParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogParserTailRef, "oss")
+
+var InputNodeLogFilesFormTaskID = taskid.NewDefaultImplementationID[upload.UploadResult](OSSTaskPrefix + "form/node-log-files")
+
+// NodeLogFileReaderTaskID is the task ID to read node logs from the uploaded files. This task provides logs to the node log parsers instead of the task querying Cloud Logging.
+var NodeLogFileReaderTaskID = taskid.NewImplementationID(googlecloudlogk8snode_contract.ListLogEntriesTaskID.Ref(), "oss")
+var OSSK8sNodeLogParserTailTaskID = taskid.NewDefaultImplementationID[struct{}](OSSTaskPrefix + "node-log-parser-tail")
+
+var InputContainerLogFilesFormTaskID = taskid.NewDefaultImplementationID[upload.UploadResult](OSSTaskPrefix + "form/container-log-files")
+
+// ContainerLogFileReaderTaskID is the task ID to read container logs from the uploaded files. This task provides logs to the container log parser instead of the task querying Cloud Logging.
+var ContainerLogFileReaderTaskID = taskid.NewImplementationID(googlecloudlogk8scontainer_contract.ListLogEntriesTaskID.Ref(), "oss")
+var OSSK8sContainerLogParserTailTaskID = taskid.NewDefaultImplementationID[struct{}](OSSTaskPrefix + "container-log-parser-tail")
+
+var InputK8sEventFilesFormTaskID = taskid.NewDefaultImplementationID[upload.UploadResult](OSSTaskPrefix + "form/k8s-event-files")
+
+// K8sEventFileReaderTaskID is the task ID to read Kubernetes Events from the uploaded dumps. This task provides logs to the event log parser instead of the task querying Cloud Logging.
+var K8sEventFileReaderTaskID = taskid.NewImplementationID(googlecloudlogk8sevent_contract.ListLogEntriesTaskID.Ref(), "oss")
+var OSSK8sEventFileParserTailTaskID = taskid.NewDefaultImplementationID[struct{}](OSSTaskPrefix + "event-file-parser-tail")
+
+var InputControlPlaneLogFilesFormTaskID = taskid.NewDefaultImplementationID[upload.UploadResult](OSSTaskPrefix + "form/control-plane-log-files")
+
+// InputClusterNameFormTaskID is the task ID of the form to specify the cluster name used in the resource paths of control plane components.
+var InputClusterNameFormTaskID = taskid.NewDefaultImplementationID[string](OSSTaskPrefix + "form/cluster-name")
+
+// ControlPlaneLogFileReaderTaskID is the task ID to read control plane component logs from the uploaded files. This task provides logs to the control plane log parsers instead of the task querying Cloud Logging.
+var ControlPlaneLogFileReaderTaskID = taskid.NewImplementationID(googlecloudlogk8scontrolplane_contract.ListLogEntriesTaskID.Ref(), "oss")
+var OSSK8sControlPlaneLogParserTailTaskID = taskid.NewDefaultImplementationID[struct{}](OSSTaskPrefix + "control-plane-log-parser-tail")
+
+// The field sets of control plane component logs read from the uploaded files are set by ControlPlaneLogFileReaderTask.
+// These tasks pass the logs through instead of the tasks reading the field sets from Cloud Logging LogEntry.
+var ControlPlaneLogCommonFieldSetReaderTaskID = taskid.NewImplementationID(googlecloudlogk8scontrolplane_contract.CommonFieldSetReaderTaskID.Ref(), "oss")
+var ControlPlaneSchedulerLogFieldSetReaderTaskID = taskid.NewImplementationID(googlecloudlogk8scontrolplane_contract.SchedulerLogFieldSetReaderTaskID.Ref(), "oss")
+var ControlPlaneControllerManagerLogFieldSetReaderTaskID = taskid.NewImplementationID(googlecloudlogk8scontrolplane_contract.ControllerManagerLogFieldSetReaderTaskID.Ref(), "oss")
+var ControlPlaneOtherLogFieldSetReaderTaskID = taskid.NewImplementationID(googlecloudlogk8scontrolplane_contract.OtherLogFieldSetReaderTaskID.Ref(), "oss")
@@ -17,14 +17,10 @@ package ossclusterk8s_impl
 import (
 	"context"
 	"fmt"
-	"io"
-	"slices"
-	"strings"
 
 	"github.com/GoogleCloudPlatform/khi/pkg/common/khictx"
 	"github.com/GoogleCloudPlatform/khi/pkg/common/typedmap"
 	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
-	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/progressutil"
 	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
 	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
 	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
@@ -37,60 +33,47 @@ var AuditLogFileReaderTask = inspectiontaskbase.NewProgressReportableInspectionT
 	ossclusterk8s_contract.AuditLogFileReaderTaskID,
 	[]taskid.UntypedTaskReference{
 		ossclusterk8s_contract.InputAuditLogFilesFormTaskID.Ref(),
+		ossclusterk8s_contract.InputAuditWebhookTimeRangeFormTaskID.Ref(),
+		ossclusterk8s_contract.AuditLogSeverityPolicyTaskID.Ref(),
 	},
 	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
 		if taskMode == inspectioncore_contract.TaskModeDryRun {
 			return []*log.Log{}, nil
 		}
-		result := coretask.GetTaskResult(ctx, ossclusterk8s_contract.InputAuditLogFilesFormTaskID.Ref())
+		metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+		header := typedmap.GetOrDefault(metadataSet, inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
 
-		reader, err := result.GetReader()
+		var logs []*log.Log
+		var stats []*logFileStats
+		var err error
+		severityPolicy := coretask.GetTaskResult(ctx, ossclusterk8s_contract.AuditLogSeverityPolicyTaskID.Ref())
+		timeRange := coretask.GetTaskResult(ctx, ossclusterk8s_contract.InputAuditWebhookTimeRangeFormTaskID.Ref())
+		if timeRange != nil {
+			store, storeErr := khictx.GetValue(ctx, ossclusterk8s_contract.AuditWebhookArchiveStoreContextKey)
+			if storeErr != nil || store == nil {
+				return nil, fmt.Errorf("the audit webhook is not enabled")
+			}
+			header.StartTimeUnixSeconds = timeRange.StartTime.Unix()
+			header.EndTimeUnixSeconds = timeRange.EndTime.Unix()
+			logs, stats, err = readAuditWebhookArchives(ctx, store, timeRange.StartTime, timeRange.EndTime, severityPolicy, tp)
+		} else {
+			result := coretask.GetTaskResult(ctx, ossclusterk8s_contract.InputAuditLogFilesFormTaskID.Ref())
+			reader, readerErr := result.GetReader()
+			if readerErr != nil {
+				return nil, readerErr
+			}
+			defer reader.Close()
+			logs, stats, err = readAuditLogFiles(ctx, reader, severityPolicy, tp)
+		}
 		if err != nil {
 			return nil, err
 		}
-		defer reader.Close()
-
-		logData, err := io.ReadAll(reader)
-		if err != nil {
+		if err := reportLogFileStats(ctx, "audit log file", stats, tp); err != nil {
 			return nil, err
 		}
 
-		logLines := strings.Split(string(logData), "\n")
-		var logs []*log.Log
-
-		progressutil.ReportProgressFromArraySync(tp, logLines, func(i int, line string) error {
-			if strings.TrimSpace(line) == "" {
-				return nil
-			}
-
-			l, err := log.NewLogFromYAMLString(line)
-			if err != nil {
-				return fmt.Errorf("failed to read a log: %w", err)
-			}
-
-			err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSK8sAuditLogCommonFieldSetReader{})
-			if err != nil {
-				return err
-			}
-
-			// TODO: we may need to consider processing logs not with ResponseComplete stage. All logs not on the ResponseComplete stage will be ignored for now.
-			if l.ReadStringOrDefault("stage", "") != "ResponseComplete" {
-				return nil
-			}
-
-			logs = append(logs, l)
-			return nil
-		})
-
-		slices.SortFunc(logs, func(a, b *log.Log) int {
-			logACommonField := log.MustGetFieldSet(a, &log.CommonFieldSet{})
-			logBCommonField := log.MustGetFieldSet(b, &log.CommonFieldSet{})
-			return int(logACommonField.Timestamp.UnixNano() - logBCommonField.Timestamp.UnixNano())
-		})
-		metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
-		header := typedmap.GetOrDefault(metadataSet, inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
-
-		if len(logs) > 0 {
+		// The time range of the inspection is the time range of the logs when it is not specified.
+		if timeRange == nil && len(logs) > 0 {
 			startLogCommonField := log.MustGetFieldSet(logs[0], &log.CommonFieldSet{})
 			lastLogCommonField := log.MustGetFieldSet(logs[len(logs)-1], &log.CommonFieldSet{})
 
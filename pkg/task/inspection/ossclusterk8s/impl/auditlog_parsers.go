@@ -27,10 +27,15 @@ import (
 	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
 )
 
-var OSSK8sAuditLogFieldExtractorTask = inspectiontaskbase.NewFieldSetReadTask(
+var OSSK8sAuditLogFieldExtractorTask = inspectiontaskbase.NewFieldSetReadTaskWithReaderFactory(
 	ossclusterk8s_contract.OSSK8sAuditLogProviderTaskID,
 	ossclusterk8s_contract.NonEventAuditLogFilterTaskID.Ref(),
-	[]log.FieldSetReader{(&ossclusterk8s_contract.OSSK8sAuditLogFieldSetReader{})},
+	[]taskid.UntypedTaskReference{ossclusterk8s_contract.AuditLogSeverityPolicyTaskID.Ref()},
+	func(ctx context.Context, logs []*log.Log) []log.FieldSetReader {
+		// The reader needs to know which requests are long-running ones to pair their stages.
+		severityPolicy := coretask.GetTaskResult(ctx, ossclusterk8s_contract.AuditLogSeverityPolicyTaskID.Ref())
+		return []log.FieldSetReader{ossclusterk8s_contract.NewOSSK8sAuditLogFieldSetReader(logs, severityPolicy)}
+	},
 	inspectioncore_contract.InspectionTypeLabel(ossclusterk8s_contract.InspectionTypeID),
 )
 
@@ -45,7 +50,15 @@ var OSSK8sAuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
 		commonlogk8sauditv2_contract.ResourceOwnerReferenceTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.PodPhaseLogToTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.EndpointResourceLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.LeaseHolderLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.HorizontalPodAutoscalerLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.NodeSchedulabilityLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.PersistentVolumeClaimLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.PersistentVolumeLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.VolumeAttachmentLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.JobRunLogToTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.ContainerLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.LongRunningOperationLogToTimelineMapperTaskID.Ref(),
 
 		commonlogk8sauditv2_contract.NodeNameDiscoveryTaskID.Ref(),
 		commonlogk8sauditv2_contract.ResourceUIDDiscoveryTaskID.Ref(),
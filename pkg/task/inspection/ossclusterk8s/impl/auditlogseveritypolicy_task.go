@@ -0,0 +1,39 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/khictx"
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// AuditLogSeverityPolicyTask returns the policy deciding the severity of kube-apiserver audit logs.
+// It returns the policy injected on the task server from the file given in the parameter, or DefaultAuditLogSeverityPolicy when no file is given.
+var AuditLogSeverityPolicyTask = inspectiontaskbase.NewInspectionTask(
+	ossclusterk8s_contract.AuditLogSeverityPolicyTaskID,
+	[]taskid.UntypedTaskReference{},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (*ossclusterk8s_contract.AuditLogSeverityPolicy, error) {
+		policy, err := khictx.GetValue(ctx, ossclusterk8s_contract.AuditLogSeverityPolicyContextKey)
+		if err != nil || policy == nil {
+			return ossclusterk8s_contract.DefaultAuditLogSeverityPolicy, nil
+		}
+		return policy, nil
+	},
+)
@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/khictx"
+	inspectiontest "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/test"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+func TestAuditLogSeverityPolicyTask(t *testing.T) {
+	customPolicy := &ossclusterk8s_contract.AuditLogSeverityPolicy{
+		StatusCodes: []*ossclusterk8s_contract.StatusCodeSeverityRule{
+			{Min: 100, Max: 599, Severity: ossclusterk8s_contract.AuditLogSeverity(enum.SeverityInfo)},
+		},
+	}
+	testCases := []struct {
+		desc   string
+		policy *ossclusterk8s_contract.AuditLogSeverityPolicy
+		want   *ossclusterk8s_contract.AuditLogSeverityPolicy
+	}{
+		{
+			desc: "without the injected policy",
+			want: ossclusterk8s_contract.DefaultAuditLogSeverityPolicy,
+		},
+		{
+			desc:   "with the injected policy",
+			policy: customPolicy,
+			want:   customPolicy,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			if tc.policy != nil {
+				ctx = khictx.WithValue(ctx, ossclusterk8s_contract.AuditLogSeverityPolicyContextKey, tc.policy)
+			}
+			got, _, err := inspectiontest.RunInspectionTask(ctx, AuditLogSeverityPolicyTask, inspectioncore_contract.TaskModeRun, map[string]any{})
+			if err != nil {
+				t.Fatalf("RunInspectionTask returned an unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("AuditLogSeverityPolicyTask returned %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
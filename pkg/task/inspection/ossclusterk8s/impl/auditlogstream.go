@@ -0,0 +1,204 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/compression"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/progressutil"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	"github.com/GoogleCloudPlatform/khi/pkg/server/auditwebhook"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// stageFieldMarker is searched in lines before parsing them to skip lines not from audit logs.
+var stageFieldMarker = []byte(`"stage"`)
+
+// auditLogSource is a file to read audit logs from.
+type auditLogSource struct {
+	// name is the name of the file used in statistics.
+	name   string
+	reader io.Reader
+	// size is the size of the file in bytes used to report the progress. 0 means the size is unknown.
+	size int64
+}
+
+// readAuditLogFiles reads kube-apiserver audit logs from the uploaded file. The file can be a JSONLine file or a tar or zip archive containing multiple JSONLine files like rotated audit logs from multiple kube-apiservers.
+// The returned logs are sorted by their timestamps. A log is read only once even when multiple files contain the log with the same auditID and stage.
+// Stages of a request are paired by auditID. A log at the RequestReceived stage is returned only when no other stage is found for the request, e.g. the kube-apiserver crashed before responding.
+// The severities of the logs are decided by severityPolicy.
+func readAuditLogFiles(ctx context.Context, source io.Reader, severityPolicy *ossclusterk8s_contract.AuditLogSeverityPolicy, progress *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, []*logFileStats, error) {
+	return readAuditLogSources(ctx, []*auditLogSource{{name: uploadedLogFileName, reader: source, size: compression.SizeOf(source)}}, severityPolicy, progress)
+}
+
+// readAuditLogSources reads kube-apiserver audit logs from the sources in the same way as readAuditLogFiles. Logs are deduplicated and sorted across the sources.
+func readAuditLogSources(ctx context.Context, sources []*auditLogSource, severityPolicy *ossclusterk8s_contract.AuditLogSeverityPolicy, progress *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, []*logFileStats, error) {
+	var totalBytes, readBytesInPreviousSources int64
+	for _, source := range sources {
+		totalBytes += source.size
+	}
+	counter := &countingReader{}
+	readBytes := func() int64 {
+		return readBytesInPreviousSources + counter.count.Load()
+	}
+	reportProgress := func(current *logFileStats) {
+		progressutil.ReportProgressFromBytes(progress, readBytes(), totalBytes)
+		if current != nil {
+			progress.Message = fmt.Sprintf("%s (%s)", progress.Message, current.String())
+		}
+	}
+	reportProgress(nil)
+
+	var logs []*log.Log
+	stats := []*logFileStats{}
+	seen := map[string]struct{}{}
+	// requestReceivedLogs holds the logs at the RequestReceived stage until all the logs are read to know if the request has the other stages.
+	var requestReceivedLogs []*log.Log
+	respondedAuditIDs := map[string]struct{}{}
+	var lastReportedBytes int64
+	for _, source := range sources {
+		counter = &countingReader{reader: source.reader}
+		err := compression.WalkFiles(source.name, counter, source.size, func(name string, reader io.Reader) error {
+			fileStats := &logFileStats{Name: name}
+			stats = append(stats, fileStats)
+			err := readAuditLogStream(ctx, reader, severityPolicy, func() {
+				fileStats.Lines++
+				if current := readBytes(); current-lastReportedBytes >= logProgressIntervalInBytes {
+					lastReportedBytes = current
+					reportProgress(fileStats)
+				}
+			}, func(l *log.Log) error {
+				auditID := l.ReadStringOrDefault("auditID", "")
+				if auditID != "" {
+					key := auditID + "/" + l.ReadStringOrDefault("stage", "")
+					if _, found := seen[key]; found {
+						fileStats.Duplicated++
+						return nil
+					}
+					seen[key] = struct{}{}
+				}
+				fileStats.Logs++
+				if auditID != "" && l.ReadStringOrDefault("stage", "") == ossclusterk8s_contract.AuditStageRequestReceived {
+					requestReceivedLogs = append(requestReceivedLogs, l)
+					return nil
+				}
+				if auditID != "" {
+					respondedAuditIDs[auditID] = struct{}{}
+				}
+				logs = append(logs, l)
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			reportProgress(fileStats)
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		readBytesInPreviousSources += counter.count.Load()
+	}
+	for _, l := range requestReceivedLogs {
+		if _, found := respondedAuditIDs[l.ReadStringOrDefault("auditID", "")]; !found {
+			logs = append(logs, l)
+		}
+	}
+
+	// Use the stable sort to keep the order in the file for logs with the same timestamp.
+	slices.SortStableFunc(logs, func(a, b *log.Log) int {
+		logACommonField := log.MustGetFieldSet(a, &log.CommonFieldSet{})
+		logBCommonField := log.MustGetFieldSet(b, &log.CommonFieldSet{})
+		return logACommonField.Timestamp.Compare(logBCommonField.Timestamp)
+	})
+	return logs, stats, nil
+}
+
+// readAuditWebhookArchives reads kube-apiserver audit logs with timestamps in [startTime, endTime] from the archives received by the audit webhook endpoint.
+func readAuditWebhookArchives(ctx context.Context, store *auditwebhook.ArchiveStore, startTime time.Time, endTime time.Time, severityPolicy *ossclusterk8s_contract.AuditLogSeverityPolicy, progress *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, []*logFileStats, error) {
+	if store == nil {
+		return nil, nil, fmt.Errorf("the audit webhook is not enabled. Run KHI with --audit-webhook to receive audit logs")
+	}
+	archives, err := store.List(startTime, endTime)
+	if err != nil {
+		return nil, nil, err
+	}
+	sources := make([]*auditLogSource, 0, len(archives))
+	for _, archive := range archives {
+		reader, err := archive.Open()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open the audit webhook archive %s: %w", archive.Name, err)
+		}
+		defer reader.Close()
+		sources = append(sources, &auditLogSource{name: archive.Name, reader: reader, size: archive.SizeInBytes})
+	}
+	logs, stats, err := readAuditLogSources(ctx, sources, severityPolicy, progress)
+	if err != nil {
+		return nil, nil, err
+	}
+	return slices.DeleteFunc(logs, func(l *log.Log) bool {
+		commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+		return commonFieldSet.Timestamp.Before(startTime) || commonFieldSet.Timestamp.After(endTime)
+	}), stats, nil
+}
+
+// readAuditLogStream reads kube-apiserver audit logs in JSONLine format from the decompressed source line by line.
+// handle is called for each log at any audit stage in the order of the source. Lines without the stage field are skipped.
+// onLine is called for each line before parsing it.
+func readAuditLogStream(ctx context.Context, source io.Reader, severityPolicy *ossclusterk8s_contract.AuditLogSeverityPolicy, onLine func(), handle func(l *log.Log) error) error {
+	scanner := bufio.NewScanner(source)
+	scanner.Buffer(make([]byte, initialLogLineBufferSizeInBytes), maxLogLineSizeInBytes)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if lineNumber%logContextCheckIntervalInLines == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		onLine()
+		line := scanner.Bytes()
+		// Parsing a log is far more expensive than searching bytes. Skip lines never be audit logs before parsing them.
+		if !bytes.Contains(line, stageFieldMarker) {
+			continue
+		}
+		l, err := log.NewLogFromYAMLString(string(line))
+		if err != nil {
+			return fmt.Errorf("failed to read a log at line %d: %w", lineNumber, err)
+		}
+		if l.ReadStringOrDefault("stage", "") == "" {
+			continue
+		}
+		err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSK8sAuditLogCommonFieldSetReader{SeverityPolicy: severityPolicy})
+		if err != nil {
+			return fmt.Errorf("failed to read the common fields of the log at line %d: %w", lineNumber, err)
+		}
+		if err := handle(l); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read the audit log file at line %d: %w", lineNumber+1, err)
+	}
+	return nil
+}
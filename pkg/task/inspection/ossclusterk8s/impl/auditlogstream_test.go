@@ -0,0 +1,232 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	"github.com/GoogleCloudPlatform/khi/pkg/server/auditwebhook"
+	"github.com/google/go-cmp/cmp"
+	"github.com/klauspost/compress/zstd"
+)
+
+const testAuditLogFile = `{"kind":"Event","auditID":"a1","stage":"RequestReceived","verb":"create","stageTimestamp":"2024-01-01T00:00:00Z"}
+{"kind":"Event","auditID":"a1","stage":"ResponseComplete","verb":"create","stageTimestamp":"2024-01-01T00:00:01Z"}
+
+{"kind":"Event","auditID":"a2","stage":"ResponseStarted","verb":"watch","stageTimestamp":"2024-01-01T00:00:02Z","annotations":{"note":"ResponseComplete"}}
+{"kind":"Event","auditID":"a3","stage":"ResponseComplete","verb":"delete","stageTimestamp":"2024-01-01T00:00:03Z"}
+`
+
+func gzipForTest(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	writer.Write(data)
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close gzip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func zstdForTest(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("failed to create zstd writer: %v", err)
+	}
+	writer.Write(data)
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close zstd: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// tarForTest returns a tar archive containing the given files in the order of names.
+func tarForTest(t *testing.T, names []string, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := tar.NewWriter(&buf)
+	for _, name := range names {
+		content := files[name]
+		if err := writer.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatalf("failed to write a tar header: %v", err)
+		}
+		writer.Write(content)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close tar: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func auditIDsOf(logs []*log.Log) []string {
+	result := []string{}
+	for _, l := range logs {
+		result = append(result, l.ReadStringOrDefault("auditID", ""))
+	}
+	return result
+}
+
+func TestReadAuditLogFiles(t *testing.T) {
+	apiserver1 := []byte(`{"kind":"Event","auditID":"a1","stage":"ResponseComplete","stageTimestamp":"2024-01-01T00:00:01Z"}
+{"kind":"Event","auditID":"a4","stage":"ResponseComplete","stageTimestamp":"2024-01-01T00:00:04Z"}
+`)
+	apiserver1Rotated := []byte(`{"kind":"Event","auditID":"a0","stage":"ResponseComplete","stageTimestamp":"2024-01-01T00:00:00Z"}
+`)
+	// The same request is recorded by both of kube-apiservers behind a load balancer in some environments.
+	// a3 has only the RequestReceived stage as if the kube-apiserver crashed before responding.
+	apiserver2 := []byte(`{"kind":"Event","auditID":"a2","stage":"ResponseComplete","stageTimestamp":"2024-01-01T00:00:02Z"}
+{"kind":"Event","auditID":"a4","stage":"ResponseComplete","stageTimestamp":"2024-01-01T00:00:04Z"}
+{"kind":"Event","auditID":"a3","stage":"RequestReceived","stageTimestamp":"2024-01-01T00:00:03Z"}
+`)
+	archiveFiles := map[string][]byte{
+		"apiserver-1/audit.log":                        apiserver1,
+		"apiserver-1/audit-2024-01-01T00-00-00.log.gz": gzipForTest(t, apiserver1Rotated),
+		"apiserver-2/audit.log":                        apiserver2,
+	}
+	archiveFileNames := []string{"apiserver-1/audit.log", "apiserver-1/audit-2024-01-01T00-00-00.log.gz", "apiserver-2/audit.log"}
+	archiveStats := []*logFileStats{
+		{Name: "apiserver-1/audit.log", Lines: 2, Logs: 2},
+		{Name: "apiserver-1/audit-2024-01-01T00-00-00.log.gz", Lines: 1, Logs: 1},
+		{Name: "apiserver-2/audit.log", Lines: 3, Logs: 2, Duplicated: 1},
+	}
+	testCases := []struct {
+		desc         string
+		source       []byte
+		wantAuditIDs []string
+		wantStats    []*logFileStats
+	}{
+		{
+			desc:         "plain",
+			source:       []byte(testAuditLogFile),
+			wantAuditIDs: []string{"a1", "a2", "a3"},
+			wantStats:    []*logFileStats{{Name: uploadedLogFileName, Lines: 5, Logs: 4}},
+		},
+		{
+			desc:         "gzip",
+			source:       gzipForTest(t, []byte(testAuditLogFile)),
+			wantAuditIDs: []string{"a1", "a2", "a3"},
+			wantStats:    []*logFileStats{{Name: uploadedLogFileName, Lines: 5, Logs: 4}},
+		},
+		{
+			desc:         "zstd",
+			source:       zstdForTest(t, []byte(testAuditLogFile)),
+			wantAuditIDs: []string{"a1", "a2", "a3"},
+			wantStats:    []*logFileStats{{Name: uploadedLogFileName, Lines: 5, Logs: 4}},
+		},
+		{
+			desc:         "tar archive with rotated logs from multiple kube-apiservers",
+			source:       tarForTest(t, archiveFileNames, archiveFiles),
+			wantAuditIDs: []string{"a0", "a1", "a2", "a3", "a4"},
+			wantStats:    archiveStats,
+		},
+		{
+			desc:         "tar.gz archive",
+			source:       gzipForTest(t, tarForTest(t, archiveFileNames, archiveFiles)),
+			wantAuditIDs: []string{"a0", "a1", "a2", "a3", "a4"},
+			wantStats:    archiveStats,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			progress := inspectionmetadata.NewTaskProgressMetadata("test")
+			logs, stats, err := readAuditLogFiles(context.Background(), bytes.NewReader(tc.source), nil, progress)
+			if err != nil {
+				t.Fatalf("readAuditLogFiles() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantAuditIDs, auditIDsOf(logs)); diff != "" {
+				t.Errorf("read logs mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStats, stats); diff != "" {
+				t.Errorf("stats mismatch (-want +got):\n%s", diff)
+			}
+			wantLastFile := tc.wantStats[len(tc.wantStats)-1].String()
+			if !strings.Contains(progress.Message, wantLastFile) {
+				t.Errorf("progress message %q doesn't contain the stats of the last file %q", progress.Message, wantLastFile)
+			}
+		})
+	}
+}
+
+func TestReadAuditWebhookArchives(t *testing.T) {
+	store, err := auditwebhook.NewArchiveStore(t.TempDir(), auditwebhook.ArchiveStoreOptions{})
+	if err != nil {
+		t.Fatalf("NewArchiveStore() returned an unexpected error: %v", err)
+	}
+	endTime := time.Now().UTC().Truncate(time.Second)
+	startTime := endTime.Add(-time.Hour)
+	event := func(auditID string, stage string, timestamp time.Time) string {
+		return fmt.Sprintf(`{"kind":"Event","auditID":%q,"stage":%q,"stageTimestamp":%q}`, auditID, stage, timestamp.Format(time.RFC3339))
+	}
+	// kube-apiserver can send the same event again when it retries a failed request.
+	eventLists := [][]string{
+		{event("a1", "ResponseComplete", startTime.Add(-time.Minute)), event("a2", "ResponseComplete", startTime.Add(time.Minute))},
+		{event("a3", "RequestReceived", startTime.Add(2*time.Minute)), event("a3", "ResponseComplete", startTime.Add(2*time.Minute)), event("a2", "ResponseComplete", startTime.Add(time.Minute))},
+	}
+	for _, items := range eventLists {
+		body := fmt.Sprintf(`{"kind":"EventList","apiVersion":"audit.k8s.io/v1","items":[%s]}`, strings.Join(items, ","))
+		if _, err := store.AppendEventList(strings.NewReader(body)); err != nil {
+			t.Fatalf("AppendEventList() returned an unexpected error: %v", err)
+		}
+	}
+
+	progress := inspectionmetadata.NewTaskProgressMetadata("test")
+	logs, stats, err := readAuditWebhookArchives(context.Background(), store, startTime, endTime, nil, progress)
+	if err != nil {
+		t.Fatalf("readAuditWebhookArchives() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"a2", "a3"}, auditIDsOf(logs)); diff != "" {
+		t.Errorf("read logs mismatch (-want +got):\n%s", diff)
+	}
+	if len(stats) != 1 || stats[0].Lines != 5 || stats[0].Duplicated != 1 {
+		t.Errorf("unexpected stats %v", stats)
+	}
+
+	_, _, err = readAuditWebhookArchives(context.Background(), nil, startTime, endTime, nil, progress)
+	if err == nil {
+		t.Errorf("readAuditWebhookArchives() returned no error for the nil store")
+	}
+}
+
+func TestReadAuditLogStream_InvalidLine(t *testing.T) {
+	source := strings.NewReader("{\"stage\":\"ResponseComplete\",\"stageTimestamp\":\"2024-01-01T00:00:00Z\"}\n{\"stage\":\"ResponseComplete\"\n")
+	err := readAuditLogStream(context.Background(), source, nil, func() {}, func(l *log.Log) error { return nil })
+	if err == nil {
+		t.Fatalf("readAuditLogStream() succeeded, want an error")
+	}
+	if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("error %q doesn't contain the line number", err.Error())
+	}
+}
+
+func TestReadAuditLogStream_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	source := strings.NewReader(strings.Repeat("{}\n", logContextCheckIntervalInLines))
+	err := readAuditLogStream(ctx, source, nil, func() {}, func(l *log.Log) error { return nil })
+	if err != context.Canceled {
+		t.Errorf("readAuditLogStream() returned %v, want context.Canceled", err)
+	}
+}
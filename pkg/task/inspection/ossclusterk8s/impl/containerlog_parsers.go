@@ -0,0 +1,38 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	googlecloudlogk8scontainer_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// OSSK8sContainerLogParserTailTask requires the container log parser shared with the Cloud Logging based inspections. Logs are given from ContainerLogFileReaderTask.
+var OSSK8sContainerLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	ossclusterk8s_contract.OSSK8sContainerLogParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		googlecloudlogk8scontainer_contract.LogToTimelineMapperTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Container Logs", `Gather stdout/stderr logs of containers from the uploaded `+"`/var/log/pods`"+` folders of nodes to visualize them on the timeline under an associated Pod.`, enum.LogTypeContainer, 1200, false, ossclusterk8s_contract.InspectionTypeID),
+)
@@ -0,0 +1,58 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// ContainerLogFileReaderTask reads container logs from the uploaded file. This task is used by the container log parser in googlecloudlogk8scontainer instead of the task querying Cloud Logging.
+var ContainerLogFileReaderTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	ossclusterk8s_contract.ContainerLogFileReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		ossclusterk8s_contract.InputContainerLogFilesFormTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		result := coretask.GetTaskResult(ctx, ossclusterk8s_contract.InputContainerLogFilesFormTaskID.Ref())
+
+		reader, err := result.GetReader()
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		logs, stats, err := readContainerLogFiles(ctx, reader, tp)
+		if err != nil {
+			return nil, err
+		}
+		if err := reportLogFileStats(ctx, "container log file", stats, tp); err != nil {
+			return nil, err
+		}
+		return logs, nil
+	},
+	inspectioncore_contract.InspectionTypeLabel(ossclusterk8s_contract.InspectionTypeID),
+	coretask.WithSelectionPriority(1000),
+)
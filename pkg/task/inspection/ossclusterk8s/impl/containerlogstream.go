@@ -0,0 +1,201 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/compression"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/progressutil"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+const (
+	// criTagPartial is the tag of a CRI log line split because the line was too long. The following lines are concatenated until a line with criTagFull.
+	criTagPartial = "P"
+	// criTagFull is the tag of a CRI log line ending a log.
+	criTagFull = "F"
+)
+
+// containerLogPathPattern matches the path of a container log file like `var/log/pods/<namespace>_<pod>_<uid>/<container>/0.log`.
+// Rotated files like `0.log.20240102-150405` or `0.log.20240102-150405.gz` are also matched. Namespaces and pod names can't contain `_`.
+var containerLogPathPattern = regexp.MustCompile(`(?:^|/)([^/_]+)_([^/_]+)_([^/_]+)/([^/]+)/\d+\.log(?:\.[^/]*)?$`)
+
+// criLogLine is a line of a container log file written by the container runtime in CRI format like `2024-01-02T15:04:05.000000000Z stdout F message`.
+type criLogLine struct {
+	timestamp time.Time
+	stream    string
+	tag       string
+	message   string
+}
+
+// partialContainerLog is a container log being concatenated from lines tagged with criTagPartial.
+type partialContainerLog struct {
+	id        string
+	timestamp time.Time
+	message   strings.Builder
+}
+
+// readContainerLogFiles reads container logs from the uploaded archive of `/var/log/pods` folders. Files not placed in the layout of the folder are ignored.
+// Lines split by the container runtime are concatenated into a log with the timestamp of its first line. The returned logs are sorted by their timestamps.
+func readContainerLogFiles(ctx context.Context, source io.Reader, progress *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, []*logFileStats, error) {
+	totalBytes := compression.SizeOf(source)
+	counter := &countingReader{reader: source}
+	reportProgress := func(current *logFileStats) {
+		progressutil.ReportProgressFromBytes(progress, counter.count.Load(), totalBytes)
+		if current != nil {
+			progress.Message = fmt.Sprintf("%s (%s)", progress.Message, current.String())
+		}
+	}
+	reportProgress(nil)
+
+	var logs []*log.Log
+	stats := []*logFileStats{}
+	err := compression.WalkFiles(uploadedLogFileName, counter, totalBytes, func(name string, reader io.Reader) error {
+		container, found := containerFromLogPath(name)
+		if !found {
+			return nil
+		}
+		fileStats := &logFileStats{Name: name}
+		stats = append(stats, fileStats)
+		var lastReportedBytes int64
+		partials := map[string]*partialContainerLog{}
+
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, initialLogLineBufferSizeInBytes), maxLogLineSizeInBytes)
+		lineNumber := 0
+		for scanner.Scan() {
+			lineNumber++
+			if lineNumber%logContextCheckIntervalInLines == 0 {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+			}
+			fileStats.Lines++
+			if readBytes := counter.count.Load(); readBytes-lastReportedBytes >= logProgressIntervalInBytes {
+				lastReportedBytes = readBytes
+				reportProgress(fileStats)
+			}
+			line, err := parseCRILogLine(scanner.Text())
+			if err != nil {
+				slog.WarnContext(ctx, fmt.Sprintf("ignored a line of %s at line %d: %v", name, lineNumber, err))
+				continue
+			}
+			partial, found := partials[line.stream]
+			if !found {
+				partial = &partialContainerLog{
+					id:        fmt.Sprintf("%s:%d", name, lineNumber),
+					timestamp: line.timestamp,
+				}
+			}
+			partial.message.WriteString(line.message)
+			if line.tag == criTagPartial {
+				partials[line.stream] = partial
+				continue
+			}
+			delete(partials, line.stream)
+			l, err := ossclusterk8s_contract.NewContainerLog(partial.id, partial.timestamp, container, line.stream, partial.message.String())
+			if err != nil {
+				return err
+			}
+			fileStats.Logs++
+			logs = append(logs, l)
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read %s at line %d: %w", name, lineNumber+1, err)
+		}
+		// A file can end with partial lines when it's rotated or copied while the container is writing a long line.
+		for _, stream := range slices.Sorted(maps.Keys(partials)) {
+			partial := partials[stream]
+			l, err := ossclusterk8s_contract.NewContainerLog(partial.id, partial.timestamp, container, stream, partial.message.String())
+			if err != nil {
+				return err
+			}
+			fileStats.Logs++
+			logs = append(logs, l)
+		}
+		reportProgress(fileStats)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(stats) == 0 {
+		slog.WarnContext(ctx, "no container log file was found in the uploaded file. Container log files must be placed like `<namespace>_<pod>_<uid>/<container>/0.log`")
+	}
+
+	// Use the stable sort to keep the order in the file for logs with the same timestamp.
+	slices.SortStableFunc(logs, func(a, b *log.Log) int {
+		logACommonField := log.MustGetFieldSet(a, &log.CommonFieldSet{})
+		logBCommonField := log.MustGetFieldSet(b, &log.CommonFieldSet{})
+		return logACommonField.Timestamp.Compare(logBCommonField.Timestamp)
+	})
+	return logs, stats, nil
+}
+
+// parseCRILogLine parses a line written by the container runtime in CRI format.
+func parseCRILogLine(line string) (*criLogLine, error) {
+	fields := strings.SplitN(line, " ", 4)
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("the line is not in CRI format")
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the timestamp: %w", err)
+	}
+	if fields[1] != "stdout" && fields[1] != "stderr" {
+		return nil, fmt.Errorf("unknown stream %q", fields[1])
+	}
+	// Tags can contain multiple values separated by `:` while only the partial flag is defined.
+	tag, _, _ := strings.Cut(fields[2], ":")
+	if tag != criTagPartial && tag != criTagFull {
+		return nil, fmt.Errorf("unknown tag %q", fields[2])
+	}
+	result := &criLogLine{
+		timestamp: timestamp,
+		stream:    fields[1],
+		tag:       tag,
+	}
+	if len(fields) == 4 {
+		result.message = fields[3]
+	}
+	return result, nil
+}
+
+// containerFromLogPath returns the container writing the log file from its path. It returns false when the path is not in the layout of `/var/log/pods`.
+func containerFromLogPath(name string) (ossclusterk8s_contract.ContainerLogIdentity, bool) {
+	match := containerLogPathPattern.FindStringSubmatch(path.Clean(name))
+	if match == nil {
+		return ossclusterk8s_contract.ContainerLogIdentity{}, false
+	}
+	return ossclusterk8s_contract.ContainerLogIdentity{
+		Namespace:     match[1],
+		PodName:       match[2],
+		PodUID:        match[3],
+		ContainerName: match[4],
+	}, true
+}
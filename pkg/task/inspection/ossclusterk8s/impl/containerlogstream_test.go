@@ -0,0 +1,223 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	googlecloudlogk8scontainer_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+	"github.com/google/go-cmp/cmp"
+)
+
+// containerLogForTest is the summary of a container log read by the container log parser.
+type containerLogForTest struct {
+	ID           string
+	Timestamp    time.Time
+	Severity     enum.Severity
+	ResourcePath string
+	Message      string
+}
+
+func containerLogsOf(t *testing.T, logs []*log.Log) []containerLogForTest {
+	t.Helper()
+	result := []containerLogForTest{}
+	for _, l := range logs {
+		err := l.SetFieldSetReader(&googlecloudlogk8scontainer_contract.K8sContainerLogFieldSetReader{})
+		if err != nil {
+			t.Fatalf("failed to read K8sContainerLogFieldSet: %v", err)
+		}
+		containerFieldSet := log.MustGetFieldSet(l, &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{})
+		commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+		result = append(result, containerLogForTest{
+			ID:           commonFieldSet.DisplayID,
+			Timestamp:    commonFieldSet.Timestamp,
+			Severity:     commonFieldSet.Severity,
+			ResourcePath: containerFieldSet.ResourcePath().Path,
+			Message:      containerFieldSet.Message,
+		})
+	}
+	return result
+}
+
+func TestReadContainerLogFiles(t *testing.T) {
+	nginxLog := []byte(`2024-01-01T00:00:01.000000000Z stdout F starting nginx
+2024-01-01T00:00:03.000000000Z stderr P a long line
+2024-01-01T00:00:02.000000000Z stdout F level=error msg="failed to open the file"
+2024-01-01T00:00:03.100000000Z stderr P  split by
+2024-01-01T00:00:03.200000000Z stderr F  the runtime
+not a CRI line
+2024-01-01T00:00:04.000000000Z stdout F
+2024-01-01T00:00:05.000000000Z stdout P truncated
+`)
+	corednsLog := []byte(`2024-01-01T00:00:00.500000000Z stderr F W0101 00:00:00.500000       1 warnings.go:70] deprecated API
+`)
+	testCases := []struct {
+		desc      string
+		source    []byte
+		want      []containerLogForTest
+		wantStats []*logFileStats
+	}{
+		{
+			desc: "archive of /var/log/pods folders",
+			source: tarForTest(t, []string{
+				"node-1/var/log/pods/default_nginx_0123-abcd/nginx/0.log",
+				"node-1/var/log/pods/kube-system_coredns-abc_4567-efgh/coredns/1.log.20240101-000000.gz",
+				"node-1/var/log/kubelet.log",
+			}, map[string][]byte{
+				"node-1/var/log/pods/default_nginx_0123-abcd/nginx/0.log":                                nginxLog,
+				"node-1/var/log/pods/kube-system_coredns-abc_4567-efgh/coredns/1.log.20240101-000000.gz": gzipForTest(t, corednsLog),
+				"node-1/var/log/kubelet.log":                                                             []byte("I0101 00:00:00.000000    1234 kubelet.go:1] Started kubelet\n"),
+			}),
+			want: []containerLogForTest{
+				{ID: "node-1/var/log/pods/kube-system_coredns-abc_4567-efgh/coredns/1.log.20240101-000000.gz:1", Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 500000000, time.UTC), Severity: enum.SeverityWarning, ResourcePath: "core/v1#pod#kube-system#coredns-abc#coredns", Message: "W0101 00:00:00.500000       1 warnings.go:70] deprecated API"},
+				{ID: "node-1/var/log/pods/default_nginx_0123-abcd/nginx/0.log:1", Timestamp: time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC), Severity: enum.SeverityUnknown, ResourcePath: "core/v1#pod#default#nginx#nginx", Message: "starting nginx"},
+				{ID: "node-1/var/log/pods/default_nginx_0123-abcd/nginx/0.log:3", Timestamp: time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC), Severity: enum.SeverityError, ResourcePath: "core/v1#pod#default#nginx#nginx", Message: `level=error msg="failed to open the file"`},
+				{ID: "node-1/var/log/pods/default_nginx_0123-abcd/nginx/0.log:2", Timestamp: time.Date(2024, 1, 1, 0, 0, 3, 0, time.UTC), Severity: enum.SeverityUnknown, ResourcePath: "core/v1#pod#default#nginx#nginx", Message: "a long line split by the runtime"},
+				{ID: "node-1/var/log/pods/default_nginx_0123-abcd/nginx/0.log:7", Timestamp: time.Date(2024, 1, 1, 0, 0, 4, 0, time.UTC), Severity: enum.SeverityUnknown, ResourcePath: "core/v1#pod#default#nginx#nginx", Message: ""},
+				{ID: "node-1/var/log/pods/default_nginx_0123-abcd/nginx/0.log:8", Timestamp: time.Date(2024, 1, 1, 0, 0, 5, 0, time.UTC), Severity: enum.SeverityUnknown, ResourcePath: "core/v1#pod#default#nginx#nginx", Message: "truncated"},
+			},
+			wantStats: []*logFileStats{
+				{Name: "node-1/var/log/pods/default_nginx_0123-abcd/nginx/0.log", Lines: 8, Logs: 5},
+				{Name: "node-1/var/log/pods/kube-system_coredns-abc_4567-efgh/coredns/1.log.20240101-000000.gz", Lines: 1, Logs: 1},
+			},
+		},
+		{
+			desc:      "file without archive",
+			source:    nginxLog,
+			want:      []containerLogForTest{},
+			wantStats: []*logFileStats{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			progress := inspectionmetadata.NewTaskProgressMetadata("test")
+			logs, stats, err := readContainerLogFiles(context.Background(), bytes.NewReader(tc.source), progress)
+			if err != nil {
+				t.Fatalf("readContainerLogFiles() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, containerLogsOf(t, logs)); diff != "" {
+				t.Errorf("read logs mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStats, stats); diff != "" {
+				t.Errorf("stats mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseCRILogLine(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		line    string
+		want    *criLogLine
+		wantErr bool
+	}{
+		{
+			desc: "full line",
+			line: "2024-01-02T15:04:05.123456789+09:00 stdout F hello world",
+			want: &criLogLine{timestamp: time.Date(2024, 1, 2, 6, 4, 5, 123456789, time.UTC), stream: "stdout", tag: criTagFull, message: "hello world"},
+		},
+		{
+			desc: "partial line with multiple tags",
+			line: "2024-01-02T15:04:05Z stderr P:foo hello ",
+			want: &criLogLine{timestamp: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), stream: "stderr", tag: criTagPartial, message: "hello "},
+		},
+		{
+			desc: "empty message without the trailing space",
+			line: "2024-01-02T15:04:05Z stdout F",
+			want: &criLogLine{timestamp: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), stream: "stdout", tag: criTagFull},
+		},
+		{
+			desc:    "invalid timestamp",
+			line:    "I0102 15:04:05.000000 1 a.go:1] foo",
+			wantErr: true,
+		},
+		{
+			desc:    "unknown stream",
+			line:    "2024-01-02T15:04:05Z stdin F foo",
+			wantErr: true,
+		},
+		{
+			desc:    "unknown tag",
+			line:    "2024-01-02T15:04:05Z stdout X foo",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parseCRILogLine(tc.line)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("parseCRILogLine() returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCRILogLine() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(criLogLine{}), cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+				t.Errorf("parseCRILogLine() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestContainerFromLogPath(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		name      string
+		want      ossclusterk8s_contract.ContainerLogIdentity
+		wantFound bool
+	}{
+		{
+			desc:      "log file in /var/log/pods",
+			name:      "node-1/var/log/pods/kube-system_kube-proxy-abc_0123-4567/kube-proxy/0.log",
+			want:      ossclusterk8s_contract.ContainerLogIdentity{Namespace: "kube-system", PodName: "kube-proxy-abc", PodUID: "0123-4567", ContainerName: "kube-proxy"},
+			wantFound: true,
+		},
+		{
+			desc:      "rotated log file at the root of the archive",
+			name:      "default_nginx_0123/nginx/2.log.20240102-150405",
+			want:      ossclusterk8s_contract.ContainerLogIdentity{Namespace: "default", PodName: "nginx", PodUID: "0123", ContainerName: "nginx"},
+			wantFound: true,
+		},
+		{
+			desc: "symlink in /var/log/containers",
+			name: "node-1/var/log/containers/nginx_default_nginx-0123.log",
+		},
+		{
+			desc: "node log",
+			name: "node-1/var/log/kubelet.log",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, found := containerFromLogPath(tc.name)
+			if found != tc.wantFound {
+				t.Errorf("containerFromLogPath() found = %v, want %v", found, tc.wantFound)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("containerFromLogPath() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	googlecloudlogk8scontrolplane_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8scontrolplane/contract"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// OSSK8sControlPlaneLogParserTailTask requires the control plane log parsers shared with the Cloud Logging based inspections. Logs are given from ControlPlaneLogFileReaderTask.
+var OSSK8sControlPlaneLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	ossclusterk8s_contract.OSSK8sControlPlaneLogParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		googlecloudlogk8scontrolplane_contract.SchedulerLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8scontrolplane_contract.ControllerManagerLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8scontrolplane_contract.OtherLogToTimelineMapperTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Control plane component logs", `Gather kube-scheduler, kube-controller-manager and other control plane component logs from the uploaded klog files, static Pod logs or journal exports.`, enum.LogTypeControlPlaneComponent, 9000, false, ossclusterk8s_contract.InspectionTypeID),
+)
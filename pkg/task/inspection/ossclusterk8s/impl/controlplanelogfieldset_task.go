@@ -0,0 +1,67 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	googlecloudlogk8scontrolplane_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8scontrolplane/contract"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// ControlPlaneLogCommonFieldSetReaderTask passes the control plane component logs through. Their field sets are set by ControlPlaneLogFileReaderTask.
+var ControlPlaneLogCommonFieldSetReaderTask = newControlPlaneLogFieldSetPassThroughTask(
+	ossclusterk8s_contract.ControlPlaneLogCommonFieldSetReaderTaskID,
+	googlecloudlogk8scontrolplane_contract.ListLogEntriesTaskID.Ref(),
+)
+
+// ControlPlaneSchedulerLogFieldSetReaderTask passes the scheduler logs through. Their field sets are set by ControlPlaneLogFileReaderTask.
+var ControlPlaneSchedulerLogFieldSetReaderTask = newControlPlaneLogFieldSetPassThroughTask(
+	ossclusterk8s_contract.ControlPlaneSchedulerLogFieldSetReaderTaskID,
+	googlecloudlogk8scontrolplane_contract.SchedulerLogFilterTaskID.Ref(),
+)
+
+// ControlPlaneControllerManagerLogFieldSetReaderTask passes the controller manager logs through. Their field sets are set by ControlPlaneLogFileReaderTask.
+var ControlPlaneControllerManagerLogFieldSetReaderTask = newControlPlaneLogFieldSetPassThroughTask(
+	ossclusterk8s_contract.ControlPlaneControllerManagerLogFieldSetReaderTaskID,
+	googlecloudlogk8scontrolplane_contract.ControllerManagerLogFilterTaskID.Ref(),
+)
+
+// ControlPlaneOtherLogFieldSetReaderTask passes the logs of the other control plane components through. Their field sets are set by ControlPlaneLogFileReaderTask.
+var ControlPlaneOtherLogFieldSetReaderTask = newControlPlaneLogFieldSetPassThroughTask(
+	ossclusterk8s_contract.ControlPlaneOtherLogFieldSetReaderTaskID,
+	googlecloudlogk8scontrolplane_contract.OtherLogFilterTaskID.Ref(),
+)
+
+// newControlPlaneLogFieldSetPassThroughTask returns the task used instead of the task reading field sets of control plane component logs from Cloud Logging LogEntry.
+func newControlPlaneLogFieldSetPassThroughTask(taskID taskid.TaskImplementationID[[]*log.Log], logTask taskid.TaskReference[[]*log.Log]) coretask.Task[[]*log.Log] {
+	return inspectiontaskbase.NewInspectionTask(
+		taskID,
+		[]taskid.UntypedTaskReference{logTask},
+		func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]*log.Log, error) {
+			if taskMode != inspectioncore_contract.TaskModeRun {
+				return []*log.Log{}, nil
+			}
+			return coretask.GetTaskResult(ctx, logTask), nil
+		},
+		inspectioncore_contract.InspectionTypeLabel(ossclusterk8s_contract.InspectionTypeID),
+		coretask.WithSelectionPriority(1000),
+	)
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/compression"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/progressutil"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// controlPlaneComponentNames maps the names of control plane component binaries to the component names used in the control plane log parsers.
+// Logs of the other components are ignored because they are read as node logs.
+var controlPlaneComponentNames = map[string]string{
+	"kube-apiserver":           "apiserver",
+	"kube-scheduler":           "scheduler",
+	"kube-controller-manager":  "controller-manager",
+	"cloud-controller-manager": "cloud-controller-manager",
+}
+
+// klogLinePattern matches a klog line like `I0102 15:04:05.000000    1234 file.go:12] message`.
+var klogLinePattern = regexp.MustCompile(`^([IWEF])\d{4} \d{2}:\d{2}:\d{2}\.\d{6}\s+\d+\s+([^:\s]+):(\d+)\] ?(.*)$`)
+
+// klogSeverities maps the severity letters of klog headers to severities.
+var klogSeverities = map[string]enum.Severity{
+	"I": enum.SeverityInfo,
+	"W": enum.SeverityWarning,
+	"E": enum.SeverityError,
+	"F": enum.SeverityFatal,
+}
+
+// controlPlaneLogFileFormat is the format of a control plane component log file detected from its first line.
+type controlPlaneLogFileFormat int
+
+const (
+	controlPlaneLogFileFormatUnknown controlPlaneLogFileFormat = iota
+	// controlPlaneLogFileFormatJournal is the output of `journalctl -o json`.
+	controlPlaneLogFileFormatJournal
+	// controlPlaneLogFileFormatCRI is the log of a static Pod written by the container runtime like `/var/log/pods/kube-system_kube-scheduler-node-1_<uid>/kube-scheduler/0.log`.
+	controlPlaneLogFileFormatCRI
+	// controlPlaneLogFileFormatKLog is the plain text log written by klog like `/var/log/kube-scheduler.log`.
+	controlPlaneLogFileFormatKLog
+)
+
+// controlPlaneLogEntry is a log read from a control plane component log file.
+type controlPlaneLogEntry struct {
+	id        string
+	component string
+	// timestamp is the time given by journald or the container runtime. It's zero for logs in plain klog files.
+	timestamp time.Time
+	// klogTime is the time read from the klog header used when timestamp is zero. The year is resolved after reading all files.
+	klogTime *klogEntry
+	severity enum.Severity
+	source   ossclusterk8s_contract.ControlPlaneLogSource
+	message  string
+}
+
+// readControlPlaneLogFiles reads kube-scheduler, kube-controller-manager and other control plane component logs from the uploaded archive. The archive can contain klog files, logs of static Pods in `/var/log/pods` or journal exports of `journalctl -o json`.
+// The component of a klog file is read from its file name like `kube-scheduler.log`, and the component of a static Pod log is read from the container name.
+// The returned logs have the field sets used in the control plane log parsers shared with the Cloud Logging based inspections. They are sorted by their timestamps.
+// The year of timestamps in plain klog files is resolved in the same way as readNodeLogFiles.
+func readControlPlaneLogFiles(ctx context.Context, source io.Reader, clusterName string, progress *inspectionmetadata.TaskProgressMetadata, now time.Time) ([]*log.Log, []*logFileStats, error) {
+	totalBytes := compression.SizeOf(source)
+	counter := &countingReader{reader: source}
+	reportProgress := func(current *logFileStats) {
+		progressutil.ReportProgressFromBytes(progress, counter.count.Load(), totalBytes)
+		if current != nil {
+			progress.Message = fmt.Sprintf("%s (%s)", progress.Message, current.String())
+		}
+	}
+	reportProgress(nil)
+
+	var entries []*controlPlaneLogEntry
+	var latestTimestamp time.Time
+	stats := []*logFileStats{}
+	seenCursors := map[string]struct{}{}
+	err := compression.WalkFiles(uploadedLogFileName, counter, totalBytes, func(name string, reader io.Reader) error {
+		fileStats := &logFileStats{Name: name}
+		fileComponent := componentNameFromPath(name)
+		if container, found := containerFromLogPath(name); found {
+			fileComponent = container.ContainerName
+		}
+		var lastReportedBytes int64
+		var lastEntry *controlPlaneLogEntry
+		format := controlPlaneLogFileFormatUnknown
+
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, initialLogLineBufferSizeInBytes), maxLogLineSizeInBytes)
+		lineNumber := 0
+		for scanner.Scan() {
+			lineNumber++
+			if lineNumber%logContextCheckIntervalInLines == 0 {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+			}
+			fileStats.Lines++
+			if readBytes := counter.count.Load(); readBytes-lastReportedBytes >= logProgressIntervalInBytes {
+				lastReportedBytes = readBytes
+				reportProgress(fileStats)
+			}
+			line := scanner.Bytes()
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			if format == controlPlaneLogFileFormatUnknown {
+				format = detectControlPlaneLogFileFormat(line)
+				if format != controlPlaneLogFileFormatJournal && controlPlaneComponentNames[fileComponent] == "" {
+					// Skip the file not written by control plane components.
+					return nil
+				}
+				stats = append(stats, fileStats)
+			}
+			id := fmt.Sprintf("%s:%d", name, lineNumber)
+			var entry *controlPlaneLogEntry
+			switch format {
+			case controlPlaneLogFileFormatJournal:
+				journalEntry, timestamp, err := readJournalEntry(line)
+				if err != nil {
+					return fmt.Errorf("failed to read %s at line %d: %w", name, lineNumber, err)
+				}
+				identifier, _ := journalEntry["SYSLOG_IDENTIFIER"].(string)
+				if controlPlaneComponentNames[identifier] == "" {
+					continue
+				}
+				if cursor, _ := journalEntry["__CURSOR"].(string); cursor != "" {
+					if _, found := seenCursors[cursor]; found {
+						fileStats.Duplicated++
+						continue
+					}
+					seenCursors[cursor] = struct{}{}
+				}
+				message, _ := journalEntry["MESSAGE"].(string)
+				entry = newControlPlaneLogEntry(id, identifier, message)
+				entry.timestamp = timestamp
+			case controlPlaneLogFileFormatCRI:
+				criLine, err := parseCRILogLine(string(line))
+				if err != nil {
+					slog.WarnContext(ctx, fmt.Sprintf("ignored a line of %s at line %d: %v", name, lineNumber, err))
+					continue
+				}
+				entry = newControlPlaneLogEntry(id, fileComponent, criLine.message)
+				entry.timestamp = criLine.timestamp
+			case controlPlaneLogFileFormatKLog:
+				entry = newControlPlaneLogEntry(id, fileComponent, string(line))
+				if entry.klogTime == nil {
+					// A line without the klog header is a continuation of the previous log like a stack trace.
+					if lastEntry != nil {
+						lastEntry.message += "\n" + string(line)
+					}
+					continue
+				}
+				lastEntry = entry
+			}
+			// The HTTP access logs are ignored as the query of the Cloud Logging based inspections does.
+			if entry.source.File == "httplog.go" {
+				continue
+			}
+			if entry.timestamp.After(latestTimestamp) {
+				latestTimestamp = entry.timestamp
+			}
+			fileStats.Logs++
+			entries = append(entries, entry)
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read %s at line %d: %w", name, lineNumber+1, err)
+		}
+		reportProgress(fileStats)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reference := now
+	if !latestTimestamp.IsZero() {
+		reference = latestTimestamp
+	}
+	logs := make([]*log.Log, 0, len(entries))
+	for _, entry := range entries {
+		timestamp := entry.timestamp
+		if timestamp.IsZero() {
+			timestamp = resolveKLogTimestamp(entry.klogTime, reference)
+		}
+		l, err := ossclusterk8s_contract.NewControlPlaneLog(entry.id, timestamp, clusterName, controlPlaneComponentNames[entry.component], entry.severity, entry.source, entry.message)
+		if err != nil {
+			return nil, nil, err
+		}
+		logs = append(logs, l)
+	}
+
+	// Use the stable sort to keep the order in the file for logs with the same timestamp.
+	slices.SortStableFunc(logs, func(a, b *log.Log) int {
+		logACommonField := log.MustGetFieldSet(a, &log.CommonFieldSet{})
+		logBCommonField := log.MustGetFieldSet(b, &log.CommonFieldSet{})
+		return logACommonField.Timestamp.Compare(logBCommonField.Timestamp)
+	})
+	return logs, stats, nil
+}
+
+// detectControlPlaneLogFileFormat returns the format of the file from its first non empty line.
+func detectControlPlaneLogFileFormat(line []byte) controlPlaneLogFileFormat {
+	trimmed := bytes.TrimSpace(line)
+	if trimmed[0] == '{' {
+		return controlPlaneLogFileFormatJournal
+	}
+	if _, err := parseCRILogLine(string(trimmed)); err == nil {
+		return controlPlaneLogFileFormatCRI
+	}
+	return controlPlaneLogFileFormatKLog
+}
+
+// newControlPlaneLogEntry returns the controlPlaneLogEntry with the severity, the source location and the message read from the klog line.
+// The line is used as the message with the unknown severity when it doesn't start with a klog header.
+func newControlPlaneLogEntry(id string, component string, line string) *controlPlaneLogEntry {
+	entry := &controlPlaneLogEntry{
+		id:        id,
+		component: component,
+		severity:  enum.SeverityUnknown,
+		message:   line,
+	}
+	match := klogLinePattern.FindStringSubmatch(line)
+	if match == nil {
+		return entry
+	}
+	entry.klogTime = newKLogEntry(id, line, "", component)
+	entry.severity = klogSeverities[match[1]]
+	// The pattern guarantees the line number is digits.
+	sourceLine, _ := strconv.Atoi(match[3])
+	entry.source = ossclusterk8s_contract.ControlPlaneLogSource{
+		File: match[2],
+		Line: sourceLine,
+	}
+	entry.message = match[4]
+	return entry
+}
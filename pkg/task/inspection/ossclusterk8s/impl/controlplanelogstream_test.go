@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	googlecloudlogk8scontrolplane_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8scontrolplane/contract"
+	"github.com/google/go-cmp/cmp"
+)
+
+// controlPlaneLogForTest is the summary of a control plane component log read by the control plane log parsers.
+type controlPlaneLogForTest struct {
+	ID         string
+	Timestamp  time.Time
+	Severity   enum.Severity
+	Cluster    string
+	Component  string
+	SourceFile string
+	Message    string
+	// Pod is the namespace and name of the Pod read from scheduler logs.
+	Pod string
+	// Controller is the name of the controller read from controller manager logs.
+	Controller string
+}
+
+func controlPlaneLogsOf(t *testing.T, logs []*log.Log) []controlPlaneLogForTest {
+	t.Helper()
+	result := []controlPlaneLogForTest{}
+	for _, l := range logs {
+		componentFieldSet := log.MustGetFieldSet(l, &googlecloudlogk8scontrolplane_contract.K8sControlplaneComponentFieldSet{})
+		messageFieldSet := log.MustGetFieldSet(l, &googlecloudlogk8scontrolplane_contract.K8sControlplaneCommonMessageFieldSet{})
+		commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+		summary := controlPlaneLogForTest{
+			ID:         commonFieldSet.DisplayID,
+			Timestamp:  commonFieldSet.Timestamp,
+			Severity:   commonFieldSet.Severity,
+			Cluster:    componentFieldSet.ClusterName,
+			Component:  componentFieldSet.ComponentName,
+			SourceFile: l.ReadStringOrDefault("source.file", ""),
+			Message:    messageFieldSet.Message,
+		}
+		switch componentFieldSet.ComponentParserType() {
+		case googlecloudlogk8scontrolplane_contract.ComponentParserTypeScheduler:
+			schedulerFieldSet := log.MustGetFieldSet(l, &googlecloudlogk8scontrolplane_contract.K8sSchedulerComponentFieldSet{})
+			if schedulerFieldSet.PodName != "" {
+				summary.Pod = schedulerFieldSet.PodNamespace + "/" + schedulerFieldSet.PodName
+			}
+		case googlecloudlogk8scontrolplane_contract.ComponentParserTypeControllerManager:
+			summary.Controller = log.MustGetFieldSet(l, &googlecloudlogk8scontrolplane_contract.K8sControllerManagerComponentFieldSet{}).Controller
+		}
+		result = append(result, summary)
+	}
+	return result
+}
+
+func TestReadControlPlaneLogFiles(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	schedulerLog := []byte(`I0101 00:00:01.000000       1 schedule_one.go:98] "Attempting to schedule pod" pod="default/nginx"
+I0101 00:00:01.500000       1 httplog.go:132] "HTTP" verb="GET" URI="/healthz"
+E0101 00:00:02.000000       1 schedule_one.go:1004] "Error scheduling pod; retrying" err="0/3 nodes are available" pod="default/nginx"
+goroutine 1 [running]:
+`)
+	controllerManagerLog := []byte(`2024-01-01T00:00:03.000000000Z stderr F I0101 00:00:03.000000       1 replica_set.go:676] "Finished syncing" logger="replicaset-controller" kind="ReplicaSet" key="default/nginx-abc" duration="1ms"
+2024-01-01T00:00:04.000000000Z stderr F W0101 00:00:04.000000       1 garbagecollector.go:818] failed to discover some groups
+`)
+	journal := []byte(`{"__CURSOR":"c1","__REALTIME_TIMESTAMP":"1704067200500000","SYSLOG_IDENTIFIER":"kube-apiserver","MESSAGE":"I0101 00:00:00.500000       1 controller.go:615] quota admission added evaluator"}
+{"__CURSOR":"c2","__REALTIME_TIMESTAMP":"1704067200600000","SYSLOG_IDENTIFIER":"kubelet","MESSAGE":"I0101 00:00:00.600000    1234 kubelet.go:1] Started kubelet"}
+`)
+	source := tarForTest(t, []string{
+		"master-1/var/log/kube-scheduler.log",
+		"master-1/var/log/pods/kube-system_kube-controller-manager-master-1_0123/kube-controller-manager/0.log",
+		"master-1/journal.json",
+		"master-1/var/log/kubelet.log",
+	}, map[string][]byte{
+		"master-1/var/log/kube-scheduler.log": schedulerLog,
+		"master-1/var/log/pods/kube-system_kube-controller-manager-master-1_0123/kube-controller-manager/0.log": controllerManagerLog,
+		"master-1/journal.json":        journal,
+		"master-1/var/log/kubelet.log": []byte("I0101 00:00:00.000000    1234 kubelet.go:1] Started kubelet\n"),
+	})
+	want := []controlPlaneLogForTest{
+		{ID: "master-1/journal.json:1", Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 500000000, time.UTC), Severity: enum.SeverityInfo, Cluster: "test-cluster", Component: "apiserver", SourceFile: "controller.go", Message: "quota admission added evaluator"},
+		{ID: "master-1/var/log/kube-scheduler.log:1", Timestamp: time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC), Severity: enum.SeverityInfo, Cluster: "test-cluster", Component: "scheduler", SourceFile: "schedule_one.go", Message: `"Attempting to schedule pod" pod="default/nginx"`, Pod: "default/nginx"},
+		{ID: "master-1/var/log/kube-scheduler.log:3", Timestamp: time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC), Severity: enum.SeverityError, Cluster: "test-cluster", Component: "scheduler", SourceFile: "schedule_one.go", Message: "\"Error scheduling pod; retrying\" err=\"0/3 nodes are available\" pod=\"default/nginx\"\ngoroutine 1 [running]:", Pod: "default/nginx"},
+		{ID: "master-1/var/log/pods/kube-system_kube-controller-manager-master-1_0123/kube-controller-manager/0.log:1", Timestamp: time.Date(2024, 1, 1, 0, 0, 3, 0, time.UTC), Severity: enum.SeverityInfo, Cluster: "test-cluster", Component: "controller-manager", SourceFile: "replica_set.go", Message: `"Finished syncing" logger="replicaset-controller" kind="ReplicaSet" key="default/nginx-abc" duration="1ms"`, Controller: "replicaset-controller"},
+		{ID: "master-1/var/log/pods/kube-system_kube-controller-manager-master-1_0123/kube-controller-manager/0.log:2", Timestamp: time.Date(2024, 1, 1, 0, 0, 4, 0, time.UTC), Severity: enum.SeverityWarning, Cluster: "test-cluster", Component: "controller-manager", SourceFile: "garbagecollector.go", Message: "failed to discover some groups"},
+	}
+	wantStats := []*logFileStats{
+		{Name: "master-1/var/log/kube-scheduler.log", Lines: 4, Logs: 2},
+		{Name: "master-1/var/log/pods/kube-system_kube-controller-manager-master-1_0123/kube-controller-manager/0.log", Lines: 2, Logs: 2},
+		{Name: "master-1/journal.json", Lines: 2, Logs: 1},
+	}
+
+	progress := inspectionmetadata.NewTaskProgressMetadata("test")
+	logs, stats, err := readControlPlaneLogFiles(context.Background(), bytes.NewReader(source), "test-cluster", progress, now)
+	if err != nil {
+		t.Fatalf("readControlPlaneLogFiles() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, controlPlaneLogsOf(t, logs)); diff != "" {
+		t.Errorf("read logs mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantStats, stats); diff != "" {
+		t.Errorf("stats mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -23,5 +23,7 @@ import (
 var InputAuditLogFilesTask = formtask.NewFileFormTaskBuilder(ossclusterk8s_contract.InputAuditLogFilesFormTaskID, 1000, "Audit Log Files", &upload.JSONLineUploadFileVerifier{
 	MaxLineSizeInBytes: 1024 * 1024 * 1024,
 }).
-	WithDescription(`Upload JSONLine format kube-apiserver audit log`).
+	WithDescription(`Upload JSONLine format kube-apiserver audit log. gzip or zstd compressed files are also accepted. Upload a tar or zip archive to read multiple files like rotated audit logs or audit logs from multiple kube-apiservers.
+This is not required when audit logs are read from the audit webhook archives.`).
+	WithOptional().
 	Build()
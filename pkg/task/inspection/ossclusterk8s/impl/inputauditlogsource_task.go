@@ -0,0 +1,60 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/khictx"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/formtask"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/server/upload"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+var auditLogSources = []string{ossclusterk8s_contract.AuditLogSourceUploadedFiles, ossclusterk8s_contract.AuditLogSourceAuditWebhook}
+
+// InputAuditLogSourceTask is the form to choose the source of audit logs.
+var InputAuditLogSourceTask = formtask.NewTextFormTaskBuilder(ossclusterk8s_contract.InputAuditLogSourceFormTaskID, 1100, "Audit Log Source").
+	WithDependencies([]taskid.UntypedTaskReference{
+		ossclusterk8s_contract.InputAuditLogFilesFormTaskID.Ref(),
+	}).
+	WithDescription(fmt.Sprintf("`%s` reads the uploaded audit log files. `%s` reads audit logs in the time range received by KHI running with `--audit-webhook` as the audit webhook backend of kube-apiserver.", ossclusterk8s_contract.AuditLogSourceUploadedFiles, ossclusterk8s_contract.AuditLogSourceAuditWebhook)).
+	WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		return ossclusterk8s_contract.AuditLogSourceUploadedFiles, nil
+	}).
+	WithSuggestionsConstant(auditLogSources).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		switch value {
+		case ossclusterk8s_contract.AuditLogSourceUploadedFiles:
+			uploadResult := coretask.GetTaskResult(ctx, ossclusterk8s_contract.InputAuditLogFilesFormTaskID.Ref())
+			if uploadResult.Status != upload.UploadStatusCompleted {
+				return "Upload audit log files to read them.", nil
+			}
+		case ossclusterk8s_contract.AuditLogSourceAuditWebhook:
+			if store, err := khictx.GetValue(ctx, ossclusterk8s_contract.AuditWebhookArchiveStoreContextKey); err != nil || store == nil {
+				return "The audit webhook is not enabled. Run KHI with `--audit-webhook` to receive audit logs from kube-apiserver.", nil
+			}
+		default:
+			return fmt.Sprintf("Unknown audit log source. Specify one of %v.", auditLogSources), nil
+		}
+		return "", nil
+	}).
+	Build()
@@ -0,0 +1,104 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common"
+	"github.com/GoogleCloudPlatform/khi/pkg/common/khictx"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/formtask"
+	coretask "github.com/GoogleCloudPlatform/khi/pkg/core/task"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// defaultAuditWebhookTimeRangeDuration is the length of the default time range ending at the inspection creation time.
+const defaultAuditWebhookTimeRangeDuration = time.Hour
+
+// InputAuditWebhookTimeRangeTask is the form to specify the time range of audit logs read from the audit webhook archives.
+// This form is readonly and ignored when audit logs are read from the uploaded files.
+var InputAuditWebhookTimeRangeTask = formtask.NewTextFormTaskBuilder(ossclusterk8s_contract.InputAuditWebhookTimeRangeFormTaskID, 1200, "Audit Webhook Time Range").
+	WithDependencies([]taskid.UntypedTaskReference{
+		ossclusterk8s_contract.InputAuditLogSourceFormTaskID.Ref(),
+	}).
+	WithDescription(`The time range of audit logs read from the audit webhook archives. Please input the start time and the end time in the format of RFC3339 separated with ` + "`/`" + `
+(example: 2006-01-02T15:04:05-07:00/2006-01-02T16:04:05-07:00)`).
+	WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		creationTime := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionCreationTime)
+		return formatAuditWebhookTimeRange(&ossclusterk8s_contract.AuditWebhookTimeRange{
+			StartTime: creationTime.Add(-defaultAuditWebhookTimeRangeDuration),
+			EndTime:   creationTime,
+		}), nil
+	}).
+	WithReadonlyFunc(func(ctx context.Context) (bool, error) {
+		return !isAuditWebhookSourceSelected(ctx), nil
+	}).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if !isAuditWebhookSourceSelected(ctx) {
+			return "", nil
+		}
+		if _, err := parseAuditWebhookTimeRange(value); err != nil {
+			return err.Error(), nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (*ossclusterk8s_contract.AuditWebhookTimeRange, error) {
+		if !isAuditWebhookSourceSelected(ctx) {
+			return nil, nil
+		}
+		return parseAuditWebhookTimeRange(value)
+	}).
+	Build()
+
+// isAuditWebhookSourceSelected returns true when audit logs are read from the audit webhook archives.
+func isAuditWebhookSourceSelected(ctx context.Context) bool {
+	return coretask.GetTaskResult(ctx, ossclusterk8s_contract.InputAuditLogSourceFormTaskID.Ref()) == ossclusterk8s_contract.AuditLogSourceAuditWebhook
+}
+
+// parseAuditWebhookTimeRange parses the time range in the format of `<start time>/<end time>`.
+func parseAuditWebhookTimeRange(value string) (*ossclusterk8s_contract.AuditWebhookTimeRange, error) {
+	startTimeStr, endTimeStr, found := strings.Cut(strings.TrimSpace(value), "/")
+	if !found {
+		return nil, fmt.Errorf("the time range must be the start time and the end time separated with `/`")
+	}
+	startTime, err := common.ParseTime(strings.TrimSpace(startTimeStr))
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time format. Please specify in the format of `2006-01-02T15:04:05-07:00`(RFC3339)")
+	}
+	endTime, err := common.ParseTime(strings.TrimSpace(endTimeStr))
+	if err != nil {
+		return nil, fmt.Errorf("invalid end time format. Please specify in the format of `2006-01-02T15:04:05-07:00`(RFC3339)")
+	}
+	if !startTime.Before(endTime) {
+		return nil, fmt.Errorf("the start time must be before the end time")
+	}
+	return &ossclusterk8s_contract.AuditWebhookTimeRange{
+		StartTime: startTime,
+		EndTime:   endTime,
+	}, nil
+}
+
+// formatAuditWebhookTimeRange returns the time range in the format accepted by parseAuditWebhookTimeRange.
+func formatAuditWebhookTimeRange(timeRange *ossclusterk8s_contract.AuditWebhookTimeRange) string {
+	return fmt.Sprintf("%s/%s", timeRange.StartTime.Format(time.RFC3339), timeRange.EndTime.Format(time.RFC3339))
+}
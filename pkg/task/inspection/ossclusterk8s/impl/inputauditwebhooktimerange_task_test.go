@@ -0,0 +1,97 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"testing"
+	"time"
+
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseAuditWebhookTimeRange(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		value   string
+		want    *ossclusterk8s_contract.AuditWebhookTimeRange
+		wantErr bool
+	}{
+		{
+			desc:  "valid time range",
+			value: "2024-01-01T00:00:00Z/2024-01-01T01:00:00Z",
+			want: &ossclusterk8s_contract.AuditWebhookTimeRange{
+				StartTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			desc:  "valid time range with spaces and sub-second precision",
+			value: " 2024-01-01T00:00:00.5Z / 2024-01-01T01:00:00Z ",
+			want: &ossclusterk8s_contract.AuditWebhookTimeRange{
+				StartTime: time.Date(2024, 1, 1, 0, 0, 0, 500000000, time.UTC),
+				EndTime:   time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			desc:    "without separator",
+			value:   "2024-01-01T00:00:00Z",
+			wantErr: true,
+		},
+		{
+			desc:    "invalid start time",
+			value:   "foo/2024-01-01T01:00:00Z",
+			wantErr: true,
+		},
+		{
+			desc:    "invalid end time",
+			value:   "2024-01-01T00:00:00Z/bar",
+			wantErr: true,
+		},
+		{
+			desc:    "end time before start time",
+			value:   "2024-01-01T01:00:00Z/2024-01-01T00:00:00Z",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parseAuditWebhookTimeRange(tc.value)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("parseAuditWebhookTimeRange() returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("parseAuditWebhookTimeRange() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormatAuditWebhookTimeRange(t *testing.T) {
+	want := "2024-01-01T00:00:00Z/2024-01-01T01:00:00Z"
+	got := formatAuditWebhookTimeRange(&ossclusterk8s_contract.AuditWebhookTimeRange{
+		StartTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+	})
+	if got != want {
+		t.Errorf("formatAuditWebhookTimeRange() = %q, want %q", got, want)
+	}
+}
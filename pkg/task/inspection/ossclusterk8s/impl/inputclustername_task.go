@@ -0,0 +1,49 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/formtask"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// defaultClusterName is the default cluster name of clusters created with kubeadm.
+const defaultClusterName = "kubernetes"
+
+var clusterNameValidator = regexp.MustCompile(`^[0-9A-Za-z._\-]+$`)
+
+// InputClusterNameTask is the form to specify the cluster name used in the resource paths of control plane components.
+var InputClusterNameTask = formtask.NewTextFormTaskBuilder(ossclusterk8s_contract.InputClusterNameFormTaskID, 1300, "Cluster Name").
+	WithDescription("The name of the cluster shown in the timelines of control plane components.").
+	WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		return defaultClusterName, nil
+	}).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if !clusterNameValidator.MatchString(strings.TrimSpace(value)) {
+			return "Cluster name must match `^[0-9A-Za-z._\\-]+$`", nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
@@ -0,0 +1,27 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/formtask"
+	"github.com/GoogleCloudPlatform/khi/pkg/server/upload"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+var InputControlPlaneLogFilesTask = formtask.NewFileFormTaskBuilder(ossclusterk8s_contract.InputControlPlaneLogFilesFormTaskID, 850, "Control Plane Log Files", &upload.TextLineUploadFileVerifier{
+	MaxLineSizeInBytes: maxLogLineSizeInBytes,
+}).
+	WithDescription(`Upload a tar or zip archive of kube-scheduler, kube-controller-manager or other control plane component logs. klog files named with the component like ` + "`kube-scheduler.log`" + `, static Pod logs in ` + "`/var/log/pods`" + ` and journal exports of ` + "`journalctl -o json`" + ` are accepted. Files of the other components are ignored.`).
+	Build()
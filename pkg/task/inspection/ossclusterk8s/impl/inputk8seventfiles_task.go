@@ -0,0 +1,27 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/formtask"
+	"github.com/GoogleCloudPlatform/khi/pkg/server/upload"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+var InputK8sEventFilesTask = formtask.NewFileFormTaskBuilder(ossclusterk8s_contract.InputK8sEventFilesFormTaskID, 950, "Event Files", &upload.TextLineUploadFileVerifier{
+	MaxLineSizeInBytes: maxLogLineSizeInBytes,
+}).
+	WithDescription(`Upload Kubernetes Events dumped with ` + "`kubectl get events -A -o json`" + ` or ` + "`-o yaml`" + `, or JSON lines written by event exporters. Both core/v1 and events.k8s.io/v1 Events are accepted. Upload a tar or zip archive to read multiple dumps. An Event found in multiple dumps is shown once for each count of its occurrences.`).
+	Build()
@@ -0,0 +1,271 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/compression"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/progressutil"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+	"gopkg.in/yaml.v3"
+)
+
+// k8sEventTimestampFieldPaths are the paths of fields used as the time of an occurrence of an Event in the order of priority.
+// core/v1 Events and events.k8s.io/v1 Events have different fields and the latter prefixes the fields for the compatibility with `deprecated`.
+var k8sEventTimestampFieldPaths = [][]string{
+	{"series", "lastObservedTime"},
+	{"lastTimestamp"},
+	{"deprecatedLastTimestamp"},
+	{"eventTime"},
+	{"firstTimestamp"},
+	{"deprecatedFirstTimestamp"},
+	{"metadata", "creationTimestamp"},
+}
+
+// k8sEventCountFieldPaths are the paths of fields used as the count of occurrences of an Event in the order of priority.
+var k8sEventCountFieldPaths = [][]string{
+	{"series", "count"},
+	{"count"},
+	{"deprecatedCount"},
+}
+
+// k8sEventOccurrence is an occurrence of an Event. An Event object is updated with the incremented count each time the same event happens again.
+type k8sEventOccurrence struct {
+	key       string
+	count     int
+	timestamp time.Time
+	event     map[string]any
+}
+
+// readK8sEventFiles reads Kubernetes Events from the uploaded file. The file can be a dump of `kubectl get events -o json` or `-o yaml` in core/v1 or events.k8s.io/v1, JSON lines written by event exporters or a tar or zip archive containing them.
+// An Event dumped multiple times is read once for each count of its occurrences at the time of the last occurrence. The returned logs are sorted by their timestamps.
+func readK8sEventFiles(ctx context.Context, source io.Reader, progress *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, []*logFileStats, error) {
+	totalBytes := compression.SizeOf(source)
+	counter := &countingReader{reader: source}
+	reportProgress := func(current *logFileStats) {
+		progressutil.ReportProgressFromBytes(progress, counter.count.Load(), totalBytes)
+		if current != nil {
+			progress.Message = fmt.Sprintf("%s (%s)", progress.Message, current.String())
+		}
+	}
+	reportProgress(nil)
+
+	var occurrences []*k8sEventOccurrence
+	stats := []*logFileStats{}
+	seenOccurrences := map[string]struct{}{}
+	err := compression.WalkFiles(uploadedLogFileName, counter, totalBytes, func(name string, reader io.Reader) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		fileStats := &logFileStats{Name: name}
+		stats = append(stats, fileStats)
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		fileStats.Lines = bytes.Count(data, []byte("\n"))
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			fileStats.Lines++
+		}
+		documents, err := decodeK8sEventDocuments(data)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		for _, document := range documents {
+			for _, event := range k8sEventsFromDocument(document) {
+				occurrence, err := newK8sEventOccurrence(event)
+				if err != nil {
+					slog.WarnContext(ctx, fmt.Sprintf("ignored an event in %s: %v", name, err))
+					continue
+				}
+				occurrenceKey := fmt.Sprintf("%s/%d", occurrence.key, occurrence.count)
+				if _, found := seenOccurrences[occurrenceKey]; found {
+					fileStats.Duplicated++
+					continue
+				}
+				seenOccurrences[occurrenceKey] = struct{}{}
+				fileStats.Logs++
+				occurrences = append(occurrences, occurrence)
+			}
+		}
+		reportProgress(fileStats)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Use the stable sort to keep the order in the files for occurrences with the same timestamp.
+	slices.SortStableFunc(occurrences, func(a, b *k8sEventOccurrence) int {
+		return a.timestamp.Compare(b.timestamp)
+	})
+	logs := make([]*log.Log, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		l, err := ossclusterk8s_contract.NewK8sEventLog(fmt.Sprintf("%s/%d", occurrence.key, occurrence.count), occurrence.timestamp, occurrence.event)
+		if err != nil {
+			return nil, nil, err
+		}
+		logs = append(logs, l)
+	}
+	return logs, stats, nil
+}
+
+// decodeK8sEventDocuments decodes the JSON values or YAML documents in the data. JSON lines are decoded as a sequence of JSON values.
+func decodeK8sEventDocuments(data []byte) ([]map[string]any, error) {
+	documents := []map[string]any{}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return documents, nil
+	}
+	if trimmed[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		for {
+			var document map[string]any
+			err := decoder.Decode(&document)
+			if errors.Is(err, io.EOF) {
+				return documents, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			documents = append(documents, document)
+		}
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(trimmed))
+	for {
+		var document map[string]any
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if document != nil {
+			documents = append(documents, document)
+		}
+	}
+}
+
+// k8sEventsFromDocument returns the Events in the decoded document. The document can be a list of Events, an Event or an event exporter record wrapping an Event in `event` field.
+func k8sEventsFromDocument(document map[string]any) []map[string]any {
+	if items, ok := document["items"].([]any); ok {
+		events := []map[string]any{}
+		for _, item := range items {
+			if event, ok := item.(map[string]any); ok {
+				events = append(events, event)
+			}
+		}
+		return events
+	}
+	if event, ok := document["event"].(map[string]any); ok {
+		return []map[string]any{event}
+	}
+	if _, found := document["reason"]; found {
+		return []map[string]any{document}
+	}
+	return nil
+}
+
+// newK8sEventOccurrence returns the occurrence of the Event. Fields of events.k8s.io/v1 Events are converted to the fields of core/v1 Events read by the event log parser.
+func newK8sEventOccurrence(event map[string]any) (*k8sEventOccurrence, error) {
+	normalized := maps.Clone(event)
+	normalized["kind"] = "Event"
+	if _, found := normalized["involvedObject"]; !found {
+		if regarding, found := normalized["regarding"]; found {
+			normalized["involvedObject"] = regarding
+		}
+	}
+	if _, found := normalized["message"]; !found {
+		if note, found := normalized["note"]; found {
+			normalized["message"] = note
+		}
+	}
+
+	var timestamp time.Time
+	for _, fieldPath := range k8sEventTimestampFieldPaths {
+		if value, ok := nestedField(normalized, fieldPath...).(string); ok && value != "" {
+			parsed, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the timestamp %q: %w", value, err)
+			}
+			timestamp = parsed
+			break
+		}
+	}
+	if timestamp.IsZero() {
+		return nil, fmt.Errorf("no timestamp was found in the event")
+	}
+
+	count := 1
+	for _, fieldPath := range k8sEventCountFieldPaths {
+		if value, ok := intField(nestedField(normalized, fieldPath...)); ok && value > 0 {
+			count = value
+			break
+		}
+	}
+
+	key, _ := nestedField(normalized, "metadata", "uid").(string)
+	if key == "" {
+		namespace, _ := nestedField(normalized, "metadata", "namespace").(string)
+		name, _ := nestedField(normalized, "metadata", "name").(string)
+		if name == "" {
+			return nil, fmt.Errorf("the event has neither uid nor name")
+		}
+		key = namespace + "/" + name
+	}
+	return &k8sEventOccurrence{
+		key:       key,
+		count:     count,
+		timestamp: timestamp,
+		event:     normalized,
+	}, nil
+}
+
+// nestedField returns the value at the path in the decoded JSON or YAML value. It returns nil when the field is not found.
+func nestedField(value any, fieldPath ...string) any {
+	for _, field := range fieldPath {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[field]
+	}
+	return value
+}
+
+// intField returns the integer from the number decoded from JSON or YAML.
+func intField(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	googlecloudlogk8sevent_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8sevent/contract"
+	"github.com/google/go-cmp/cmp"
+)
+
+// k8sEventLogForTest is the summary of an event log read by the event log parser.
+type k8sEventLogForTest struct {
+	ID           string
+	Timestamp    time.Time
+	Severity     enum.Severity
+	ResourcePath string
+	Reason       string
+	Message      string
+}
+
+func k8sEventLogsOf(t *testing.T, logs []*log.Log) []k8sEventLogForTest {
+	t.Helper()
+	result := []k8sEventLogForTest{}
+	for _, l := range logs {
+		err := l.SetFieldSetReader(&googlecloudlogk8sevent_contract.GCPKubernetesEventFieldSetReader{})
+		if err != nil {
+			t.Fatalf("failed to read KubernetesEventFieldSet: %v", err)
+		}
+		eventFieldSet := log.MustGetFieldSet(l, &googlecloudlogk8sevent_contract.KubernetesEventFieldSet{})
+		commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+		result = append(result, k8sEventLogForTest{
+			ID:           commonFieldSet.DisplayID,
+			Timestamp:    commonFieldSet.Timestamp,
+			Severity:     commonFieldSet.Severity,
+			ResourcePath: eventFieldSet.ResourcePath().Path,
+			Reason:       eventFieldSet.Reason,
+			Message:      eventFieldSet.Message,
+		})
+	}
+	return result
+}
+
+func TestReadK8sEventFiles(t *testing.T) {
+	eventListJSON := []byte(`{
+  "apiVersion": "v1",
+  "kind": "List",
+  "items": [
+    {
+      "apiVersion": "v1",
+      "kind": "Event",
+      "metadata": {"name": "nginx.1", "namespace": "default", "uid": "uid-1"},
+      "involvedObject": {"apiVersion": "v1", "kind": "Pod", "namespace": "default", "name": "nginx"},
+      "reason": "BackOff",
+      "message": "Back-off restarting failed container",
+      "type": "Warning",
+      "count": 3,
+      "firstTimestamp": "2024-01-01T00:00:00Z",
+      "lastTimestamp": "2024-01-01T00:03:00Z"
+    },
+    {
+      "apiVersion": "v1",
+      "kind": "Event",
+      "metadata": {"name": "nginx.2", "namespace": "default", "uid": "uid-2"},
+      "involvedObject": {"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "default", "name": "nginx"},
+      "reason": "ScalingReplicaSet",
+      "message": "Scaled up replica set nginx-abc to 1",
+      "type": "Normal",
+      "firstTimestamp": null,
+      "lastTimestamp": null,
+      "eventTime": "2024-01-01T00:01:00.000000Z"
+    }
+  ]
+}`)
+	eventListYAML := []byte(`apiVersion: v1
+kind: List
+items:
+- apiVersion: events.k8s.io/v1
+  kind: Event
+  metadata:
+    name: nginx.1
+    namespace: default
+    uid: uid-1
+  regarding:
+    apiVersion: v1
+    kind: Pod
+    namespace: default
+    name: nginx
+  reason: BackOff
+  note: Back-off restarting failed container
+  type: Warning
+  deprecatedCount: 5
+  deprecatedFirstTimestamp: "2024-01-01T00:00:00Z"
+  deprecatedLastTimestamp: "2024-01-01T00:05:00Z"
+- apiVersion: events.k8s.io/v1
+  kind: Event
+  metadata:
+    name: node-1.1
+    uid: uid-3
+  regarding:
+    kind: Node
+    name: node-1
+  reason: NodeNotReady
+  note: Node node-1 status is now NodeNotReady
+  type: Normal
+  eventTime: "2024-01-01T00:02:00.000000Z"
+  series:
+    count: 2
+    lastObservedTime: "2024-01-01T00:04:00.000000Z"
+`)
+	exporterJSONLines := []byte(`{"verb":"UPDATED","event":{"metadata":{"name":"nginx.1","namespace":"default","uid":"uid-1"},"involvedObject":{"kind":"Pod","namespace":"default","name":"nginx"},"reason":"BackOff","message":"Back-off restarting failed container","type":"Warning","count":3,"lastTimestamp":"2024-01-01T00:03:00Z"}}
+{"metadata":{"name":"nginx.1","namespace":"default","uid":"uid-1"},"involvedObject":{"kind":"Pod","namespace":"default","name":"nginx"},"reason":"BackOff","message":"Back-off restarting failed container","type":"Warning","count":4,"lastTimestamp":"2024-01-01T00:04:00Z"}
+{"metadata":{"name":"broken"},"reason":"Unknown"}
+`)
+	testCases := []struct {
+		desc      string
+		source    []byte
+		want      []k8sEventLogForTest
+		wantStats []*logFileStats
+	}{
+		{
+			desc:   "kubectl get events -o json",
+			source: eventListJSON,
+			want: []k8sEventLogForTest{
+				{ID: "uid-2/1", Timestamp: time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC), Severity: enum.SeverityInfo, ResourcePath: "apps/v1#deployment#default#nginx", Reason: "ScalingReplicaSet", Message: "Scaled up replica set nginx-abc to 1"},
+				{ID: "uid-1/3", Timestamp: time.Date(2024, 1, 1, 0, 3, 0, 0, time.UTC), Severity: enum.SeverityWarning, ResourcePath: "core/v1#pod#default#nginx", Reason: "BackOff", Message: "Back-off restarting failed container"},
+			},
+			wantStats: []*logFileStats{{Name: uploadedLogFileName, Lines: 30, Logs: 2}},
+		},
+		{
+			desc: "archive with dumps in both API versions and event exporter outputs",
+			source: tarForTest(t, []string{"events-1.json", "events-2.yaml", "exporter.jsonl.gz"}, map[string][]byte{
+				"events-1.json":     eventListJSON,
+				"events-2.yaml":     eventListYAML,
+				"exporter.jsonl.gz": gzipForTest(t, exporterJSONLines),
+			}),
+			want: []k8sEventLogForTest{
+				{ID: "uid-2/1", Timestamp: time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC), Severity: enum.SeverityInfo, ResourcePath: "apps/v1#deployment#default#nginx", Reason: "ScalingReplicaSet", Message: "Scaled up replica set nginx-abc to 1"},
+				{ID: "uid-1/3", Timestamp: time.Date(2024, 1, 1, 0, 3, 0, 0, time.UTC), Severity: enum.SeverityWarning, ResourcePath: "core/v1#pod#default#nginx", Reason: "BackOff", Message: "Back-off restarting failed container"},
+				{ID: "uid-3/2", Timestamp: time.Date(2024, 1, 1, 0, 4, 0, 0, time.UTC), Severity: enum.SeverityInfo, ResourcePath: "core/v1#node#cluster-scope#node-1", Reason: "NodeNotReady", Message: "Node node-1 status is now NodeNotReady"},
+				{ID: "uid-1/4", Timestamp: time.Date(2024, 1, 1, 0, 4, 0, 0, time.UTC), Severity: enum.SeverityWarning, ResourcePath: "core/v1#pod#default#nginx", Reason: "BackOff", Message: "Back-off restarting failed container"},
+				{ID: "uid-1/5", Timestamp: time.Date(2024, 1, 1, 0, 5, 0, 0, time.UTC), Severity: enum.SeverityWarning, ResourcePath: "core/v1#pod#default#nginx", Reason: "BackOff", Message: "Back-off restarting failed container"},
+			},
+			wantStats: []*logFileStats{
+				{Name: "events-1.json", Lines: 30, Logs: 2},
+				{Name: "events-2.yaml", Lines: 35, Logs: 2},
+				{Name: "exporter.jsonl.gz", Lines: 3, Logs: 1, Duplicated: 1},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			progress := inspectionmetadata.NewTaskProgressMetadata("test")
+			logs, stats, err := readK8sEventFiles(context.Background(), bytes.NewReader(tc.source), progress)
+			if err != nil {
+				t.Fatalf("readK8sEventFiles() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, k8sEventLogsOf(t, logs)); diff != "" {
+				t.Errorf("read logs mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStats, stats); diff != "" {
+				t.Errorf("stats mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
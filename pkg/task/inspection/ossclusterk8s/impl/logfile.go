@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/khictx"
+	"github.com/GoogleCloudPlatform/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	core_contract "github.com/GoogleCloudPlatform/khi/pkg/task/core/contract"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+const (
+	// maxLogLineSizeInBytes is the maximum size of a line in uploaded log files. This is the same limit as the upload verifiers of the forms.
+	maxLogLineSizeInBytes = 1024 * 1024 * 1024
+	// initialLogLineBufferSizeInBytes is the initial size of the line buffer. The buffer grows up to maxLogLineSizeInBytes only when a longer line is found.
+	initialLogLineBufferSizeInBytes = 64 * 1024
+	// logProgressIntervalInBytes is the minimum count of bytes read between progress reports.
+	logProgressIntervalInBytes = 1024 * 1024
+	// logContextCheckIntervalInLines is the count of lines read between checks of the context cancellation.
+	logContextCheckIntervalInLines = 1000
+)
+
+// uploadedLogFileName is the name of the uploaded file used in statistics when it is not an archive.
+const uploadedLogFileName = "uploaded file"
+
+// countingReader counts the bytes read from the underlying reader. ReadAt is available when the underlying reader implements io.ReaderAt to read zip archives.
+type countingReader struct {
+	reader io.Reader
+	count  atomic.Int64
+}
+
+// Read implements io.Reader.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count.Add(int64(n))
+	return n, err
+}
+
+// ReadAt implements io.ReaderAt.
+func (c *countingReader) ReadAt(p []byte, off int64) (int, error) {
+	readerAt, ok := c.reader.(io.ReaderAt)
+	if !ok {
+		return 0, fmt.Errorf("the underlying reader doesn't support ReadAt")
+	}
+	n, err := readerAt.ReadAt(p, off)
+	c.count.Add(int64(n))
+	return n, err
+}
+
+// logFileStats is the statistics of a log file in the uploaded file.
+type logFileStats struct {
+	// Name is the path of the file in the uploaded archive.
+	Name string
+	// Lines is the count of lines in the file.
+	Lines int
+	// Logs is the count of logs used in the inspection.
+	Logs int
+	// Duplicated is the count of logs ignored because the same log was already read from another file.
+	Duplicated int
+}
+
+// String returns the human readable summary of the statistics.
+func (s *logFileStats) String() string {
+	return fmt.Sprintf("%s: %d lines, %d logs, %d duplicated", s.Name, s.Lines, s.Logs, s.Duplicated)
+}
+
+// reportLogFileStats shows the statistics of the read files on the task progress and records them in the query metadata of the inspection to keep them visible after the inspection.
+func reportLogFileStats(ctx context.Context, fileKind string, stats []*logFileStats, progress *inspectionmetadata.TaskProgressMetadata) error {
+	lines := make([]string, 0, len(stats))
+	for _, fileStats := range stats {
+		lines = append(lines, fileStats.String())
+		slog.InfoContext(ctx, fmt.Sprintf("read %s %s", fileKind, fileStats.String()))
+	}
+	summary := strings.Join(lines, "\n")
+	progress.Update(1, fmt.Sprintf("read %d %s(s): %s", len(stats), fileKind, strings.Join(lines, ", ")))
+
+	metadata := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	queryInfo, found := typedmap.Get(metadata, inspectionmetadata.QueryMetadataKey)
+	if !found {
+		return fmt.Errorf("query metadata was not found")
+	}
+	taskID := khictx.MustGetValue(ctx, core_contract.TaskImplementationIDContextKey)
+	queryInfo.SetQuery(taskID.String(), fmt.Sprintf("%s statistics", fileKind), summary)
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/khictx"
+	"github.com/GoogleCloudPlatform/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/test"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestReportLogFileStats(t *testing.T) {
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	progress := inspectionmetadata.NewTaskProgressMetadata("test")
+	stats := []*logFileStats{
+		{Name: "audit.log", Lines: 10, Logs: 8, Duplicated: 2},
+		{Name: "audit-2024-01-01T00-00-00.000.log.gz", Lines: 5, Logs: 5, Duplicated: 0},
+	}
+
+	err := reportLogFileStats(ctx, "audit log file", stats, progress)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantProgressMessage := "read 2 audit log file(s): audit.log: 10 lines, 8 logs, 2 duplicated, audit-2024-01-01T00-00-00.000.log.gz: 5 lines, 5 logs, 0 duplicated"
+	if progress.Message != wantProgressMessage {
+		t.Errorf("progress message = %q, want %q", progress.Message, wantProgressMessage)
+	}
+	metadata := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	queryInfo, found := typedmap.Get(metadata, inspectionmetadata.QueryMetadataKey)
+	if !found {
+		t.Fatalf("query metadata was not found")
+	}
+	want := []*inspectionmetadata.QueryItem{
+		{
+			Name:  "audit log file statistics",
+			Query: "audit.log: 10 lines, 8 logs, 2 duplicated\naudit-2024-01-01T00-00-00.000.log.gz: 5 lines, 5 logs, 0 duplicated",
+		},
+	}
+	if diff := cmp.Diff(want, queryInfo.Queries, cmpopts.IgnoreFields(inspectionmetadata.QueryItem{}, "Id")); diff != "" {
+		t.Errorf("query metadata mismatch (-want +got):\n%s", diff)
+	}
+}
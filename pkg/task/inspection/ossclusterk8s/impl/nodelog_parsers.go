@@ -0,0 +1,42 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+
+	inspectiontaskbase "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/taskbase"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/task/taskid"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	googlecloudlogk8snode_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+	inspectioncore_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// OSSK8sNodeLogParserTailTask requires the node log parsers shared with the Cloud Logging based inspections. Logs are given from NodeLogFileReaderTask.
+var OSSK8sNodeLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	ossclusterk8s_contract.OSSK8sNodeLogParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		googlecloudlogk8snode_contract.ContainerdLogLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8snode_contract.KubeletLogLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8snode_contract.OtherLogLogToTimelineMapperTaskID.Ref(),
+
+		googlecloudlogk8snode_contract.ContainerIDDiscoveryTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Node Logs", `Gather kubelet, containerd and other node component logs from the uploaded journal exports or klog files.`, enum.LogTypeNode, 1100, false, ossclusterk8s_contract.InspectionTypeID),
+)
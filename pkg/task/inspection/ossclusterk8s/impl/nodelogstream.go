@@ -0,0 +1,300 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/common/compression"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/progressutil"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+const (
+	// unknownNodeName is the node name used when neither the journal entry nor the path of the file tells the node name.
+	unknownNodeName = "unknown-node"
+	// unknownComponentName is the component name used for a klog file uploaded without an archive.
+	unknownComponentName = "unknown"
+)
+
+// klogHeaderPattern matches the header of a klog line like `I0102 15:04:05.000000    1234 file.go:12] message`.
+// klog headers don't contain the year.
+var klogHeaderPattern = regexp.MustCompile(`^[IWEF](\d{2})(\d{2}) (\d{2}):(\d{2}):(\d{2})\.(\d{6})\s`)
+
+// nodeLogFileFormat is the format of a node log file detected from its first line.
+type nodeLogFileFormat int
+
+const (
+	nodeLogFileFormatUnknown nodeLogFileFormat = iota
+	// nodeLogFileFormatJournal is the output of `journalctl -o json`.
+	nodeLogFileFormatJournal
+	// nodeLogFileFormatKLog is the plain text log written by klog like `/var/log/kubelet.log`.
+	nodeLogFileFormatKLog
+)
+
+// klogEntry is a log read from a klog file. Its timestamp is resolved after reading all files because the year is missing in the klog header.
+type klogEntry struct {
+	id        string
+	nodeName  string
+	component string
+	month     time.Month
+	day       int
+	clock     time.Duration
+	message   string
+}
+
+// timestamp returns the time of the entry in the given year.
+func (k *klogEntry) timestamp(year int) time.Time {
+	return time.Date(year, k.month, k.day, 0, 0, 0, 0, time.UTC).Add(k.clock)
+}
+
+// readNodeLogFiles reads kubelet, containerd and other node component logs from the uploaded file. The file can be a journal export of `journalctl -o json`, a klog text file or a tar or zip archive containing them.
+// Logs are normalized to the shape of node logs on Cloud Logging to share the node log parsers. The returned logs are sorted by their timestamps.
+// A journal entry is read only once even when multiple files contain the entry with the same cursor.
+// klog files don't record the year, thus the year is resolved to the latest one not after the latest journal entry, or now when no journal entry is found. klog timestamps are assumed to be in UTC.
+func readNodeLogFiles(ctx context.Context, source io.Reader, progress *inspectionmetadata.TaskProgressMetadata, now time.Time) ([]*log.Log, []*logFileStats, error) {
+	totalBytes := compression.SizeOf(source)
+	counter := &countingReader{reader: source}
+	reportProgress := func(current *logFileStats) {
+		progressutil.ReportProgressFromBytes(progress, counter.count.Load(), totalBytes)
+		if current != nil {
+			progress.Message = fmt.Sprintf("%s (%s)", progress.Message, current.String())
+		}
+	}
+	reportProgress(nil)
+
+	var logs []*log.Log
+	var klogEntries []*klogEntry
+	var latestJournalTime time.Time
+	stats := []*logFileStats{}
+	seenCursors := map[string]struct{}{}
+	err := compression.WalkFiles(uploadedLogFileName, counter, totalBytes, func(name string, reader io.Reader) error {
+		fileStats := &logFileStats{Name: name}
+		stats = append(stats, fileStats)
+		nodeName := nodeNameFromPath(name)
+		component := componentNameFromPath(name)
+		var lastReportedBytes int64
+		var lastKLogEntry *klogEntry
+		format := nodeLogFileFormatUnknown
+
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, initialLogLineBufferSizeInBytes), maxLogLineSizeInBytes)
+		lineNumber := 0
+		for scanner.Scan() {
+			lineNumber++
+			if lineNumber%logContextCheckIntervalInLines == 0 {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+			}
+			fileStats.Lines++
+			if readBytes := counter.count.Load(); readBytes-lastReportedBytes >= logProgressIntervalInBytes {
+				lastReportedBytes = readBytes
+				reportProgress(fileStats)
+			}
+			line := scanner.Bytes()
+			if format == nodeLogFileFormatUnknown {
+				trimmed := bytes.TrimSpace(line)
+				if len(trimmed) == 0 {
+					continue
+				}
+				format = nodeLogFileFormatKLog
+				if trimmed[0] == '{' {
+					format = nodeLogFileFormatJournal
+				}
+			}
+			id := fmt.Sprintf("%s:%d", name, lineNumber)
+			switch format {
+			case nodeLogFileFormatJournal:
+				if len(bytes.TrimSpace(line)) == 0 {
+					continue
+				}
+				l, cursor, err := newNodeLogFromJournalEntry(id, line, nodeName)
+				if err != nil {
+					return fmt.Errorf("failed to read %s at line %d: %w", name, lineNumber, err)
+				}
+				if cursor != "" {
+					if _, found := seenCursors[cursor]; found {
+						fileStats.Duplicated++
+						continue
+					}
+					seenCursors[cursor] = struct{}{}
+				}
+				commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+				if commonFieldSet.Timestamp.After(latestJournalTime) {
+					latestJournalTime = commonFieldSet.Timestamp
+				}
+				fileStats.Logs++
+				logs = append(logs, l)
+			case nodeLogFileFormatKLog:
+				entry := newKLogEntry(id, string(line), nodeName, component)
+				if entry == nil {
+					// A line without the klog header is a continuation of the previous log like a stack trace.
+					if lastKLogEntry != nil {
+						lastKLogEntry.message += "\n" + string(line)
+					}
+					continue
+				}
+				fileStats.Logs++
+				klogEntries = append(klogEntries, entry)
+				lastKLogEntry = entry
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read %s at line %d: %w", name, lineNumber+1, err)
+		}
+		reportProgress(fileStats)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reference := now
+	if !latestJournalTime.IsZero() {
+		reference = latestJournalTime
+	}
+	for _, entry := range klogEntries {
+		l, err := ossclusterk8s_contract.NewNodeLog(entry.id, resolveKLogTimestamp(entry, reference), entry.nodeName, map[string]any{
+			"MESSAGE":           entry.message,
+			"SYSLOG_IDENTIFIER": entry.component,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		logs = append(logs, l)
+	}
+
+	// Use the stable sort to keep the order in the file for logs with the same timestamp.
+	slices.SortStableFunc(logs, func(a, b *log.Log) int {
+		logACommonField := log.MustGetFieldSet(a, &log.CommonFieldSet{})
+		logBCommonField := log.MustGetFieldSet(b, &log.CommonFieldSet{})
+		return logACommonField.Timestamp.Compare(logBCommonField.Timestamp)
+	})
+	return logs, stats, nil
+}
+
+// newNodeLogFromJournalEntry parses a line of `journalctl -o json` output and returns the log with the cursor of the entry.
+func newNodeLogFromJournalEntry(id string, line []byte, fallbackNodeName string) (*log.Log, string, error) {
+	entry, timestamp, err := readJournalEntry(line)
+	if err != nil {
+		return nil, "", err
+	}
+	nodeName, _ := entry["_HOSTNAME"].(string)
+	if nodeName == "" {
+		nodeName = fallbackNodeName
+	}
+	cursor, _ := entry["__CURSOR"].(string)
+	l, err := ossclusterk8s_contract.NewNodeLog(id, timestamp, nodeName, entry)
+	if err != nil {
+		return nil, "", err
+	}
+	return l, cursor, nil
+}
+
+// readJournalEntry parses a line of `journalctl -o json` output and returns the fields with its timestamp.
+// MESSAGE is converted to a string when journald outputs it as an array of bytes, and SYSLOG_IDENTIFIER is filled from the unit or the command name when it's missing.
+func readJournalEntry(line []byte) (map[string]any, time.Time, error) {
+	var entry map[string]any
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return nil, time.Time{}, err
+	}
+	realtime, _ := entry["__REALTIME_TIMESTAMP"].(string)
+	realtimeMicros, err := strconv.ParseInt(realtime, 10, 64)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read __REALTIME_TIMESTAMP %q: %w", realtime, err)
+	}
+	// journald outputs MESSAGE as an array of bytes when it's not a valid UTF-8 string.
+	if message, ok := entry["MESSAGE"].([]any); ok {
+		messageBytes := make([]byte, 0, len(message))
+		for _, b := range message {
+			if f, ok := b.(float64); ok {
+				messageBytes = append(messageBytes, byte(f))
+			}
+		}
+		entry["MESSAGE"] = string(messageBytes)
+	}
+	if identifier, _ := entry["SYSLOG_IDENTIFIER"].(string); identifier == "" {
+		if unit, _ := entry["_SYSTEMD_UNIT"].(string); unit != "" {
+			entry["SYSLOG_IDENTIFIER"] = strings.TrimSuffix(unit, ".service")
+		} else if command, _ := entry["_COMM"].(string); command != "" {
+			entry["SYSLOG_IDENTIFIER"] = command
+		}
+	}
+	return entry, time.UnixMicro(realtimeMicros).UTC(), nil
+}
+
+// newKLogEntry returns the klogEntry parsed from the line. It returns nil when the line doesn't start with a klog header.
+func newKLogEntry(id string, line string, nodeName string, component string) *klogEntry {
+	match := klogHeaderPattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+	numbers := make([]int, len(match)-1)
+	for i, field := range match[1:] {
+		// The pattern guarantees the fields are digits.
+		numbers[i], _ = strconv.Atoi(field)
+	}
+	return &klogEntry{
+		id:        id,
+		nodeName:  nodeName,
+		component: component,
+		month:     time.Month(numbers[0]),
+		day:       numbers[1],
+		clock:     time.Duration(numbers[2])*time.Hour + time.Duration(numbers[3])*time.Minute + time.Duration(numbers[4])*time.Second + time.Duration(numbers[5])*time.Microsecond,
+		message:   line,
+	}
+}
+
+// resolveKLogTimestamp returns the timestamp of the klog entry in the latest year not making the timestamp after the reference time.
+// A day of margin is allowed for the difference of the time zones.
+func resolveKLogTimestamp(entry *klogEntry, reference time.Time) time.Time {
+	timestamp := entry.timestamp(reference.Year())
+	if timestamp.After(reference.Add(24 * time.Hour)) {
+		return entry.timestamp(reference.Year() - 1)
+	}
+	return timestamp
+}
+
+// nodeNameFromPath returns the node name from the path of the file in the uploaded archive. Files are expected to be placed in a folder named with the node name like `node-1/kubelet.log` or `node-1/var/log/kubelet.log`.
+func nodeNameFromPath(name string) string {
+	first, _, found := strings.Cut(path.Clean(name), "/")
+	if !found || first == "." || first == ".." {
+		return unknownNodeName
+	}
+	return first
+}
+
+// componentNameFromPath returns the component name from the name of a klog file like `kubelet.log`, `kubelet.log.1` or `kube-proxy.log-20240101.gz`.
+func componentNameFromPath(name string) string {
+	if name == uploadedLogFileName {
+		return unknownComponentName
+	}
+	component, _, _ := strings.Cut(path.Base(name), ".")
+	return component
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/core/inspection/logutil"
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+	googlecloudlogk8snode_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+	"github.com/google/go-cmp/cmp"
+)
+
+// nodeLogForTest is the summary of a node log read by the node log parsers.
+type nodeLogForTest struct {
+	Timestamp time.Time
+	NodeName  string
+	Component string
+	Message   string
+}
+
+func nodeLogsOf(t *testing.T, logs []*log.Log) []nodeLogForTest {
+	t.Helper()
+	result := []nodeLogForTest{}
+	for _, l := range logs {
+		err := l.SetFieldSetReader(&googlecloudlogk8snode_contract.K8sNodeLogCommonFieldSetReader{
+			StructuredLogParser: &logutil.FallbackRawTextLogParser{},
+		})
+		if err != nil {
+			t.Fatalf("failed to read K8sNodeLogCommonFieldSet: %v", err)
+		}
+		nodeFieldSet := log.MustGetFieldSet(l, &googlecloudlogk8snode_contract.K8sNodeLogCommonFieldSet{})
+		commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+		result = append(result, nodeLogForTest{
+			Timestamp: commonFieldSet.Timestamp,
+			NodeName:  nodeFieldSet.NodeName,
+			Component: nodeFieldSet.Component,
+			Message:   nodeFieldSet.Message.Raw(),
+		})
+	}
+	return result
+}
+
+func TestReadNodeLogFiles(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	journal := []byte(`{"__CURSOR":"c1","__REALTIME_TIMESTAMP":"1704067201000000","_HOSTNAME":"node-1","SYSLOG_IDENTIFIER":"kubelet","MESSAGE":"I0101 00:00:01.000000    1234 kubelet.go:1] Started kubelet","PRIORITY":"6"}
+{"__CURSOR":"c2","__REALTIME_TIMESTAMP":"1704067202000000","_HOSTNAME":"node-1","_SYSTEMD_UNIT":"containerd.service","MESSAGE":[115,116,97,114,116,105,110,103,32,99,111,110,116,97,105,110,101,114,100],"PRIORITY":"6"}
+`)
+	// The same entry is exported twice in overlapping time ranges.
+	journalOverlapped := []byte(`{"__CURSOR":"c2","__REALTIME_TIMESTAMP":"1704067202000000","_HOSTNAME":"node-1","_SYSTEMD_UNIT":"containerd.service","MESSAGE":"starting containerd","PRIORITY":"6"}
+{"__CURSOR":"c3","__REALTIME_TIMESTAMP":"1704067203000000","_HOSTNAME":"node-2","SYSLOG_IDENTIFIER":"(kubelet)","MESSAGE":"E0101 00:00:03.000000    1234 kubelet.go:2] failed","PRIORITY":"3"}
+`)
+	kubeProxyLog := []byte(`I1231 23:59:59.000000       1 proxier.go:1] Syncing iptables rules
+I0101 00:00:00.500000       1 proxier.go:2] panic
+goroutine 1 [running]:
+`)
+	testCases := []struct {
+		desc      string
+		source    []byte
+		want      []nodeLogForTest
+		wantStats []*logFileStats
+	}{
+		{
+			desc:   "journal export",
+			source: journal,
+			want: []nodeLogForTest{
+				{Timestamp: time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC), NodeName: "node-1", Component: "kubelet", Message: "I0101 00:00:01.000000    1234 kubelet.go:1] Started kubelet"},
+				{Timestamp: time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC), NodeName: "node-1", Component: "containerd", Message: "starting containerd"},
+			},
+			wantStats: []*logFileStats{{Name: uploadedLogFileName, Lines: 2, Logs: 2}},
+		},
+		{
+			desc:   "klog file without archive",
+			source: gzipForTest(t, kubeProxyLog),
+			want: []nodeLogForTest{
+				{Timestamp: time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC), NodeName: unknownNodeName, Component: unknownComponentName, Message: "I1231 23:59:59.000000       1 proxier.go:1] Syncing iptables rules"},
+				{Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 500000000, time.UTC), NodeName: unknownNodeName, Component: unknownComponentName, Message: "I0101 00:00:00.500000       1 proxier.go:2] panic\ngoroutine 1 [running]:"},
+			},
+			wantStats: []*logFileStats{{Name: uploadedLogFileName, Lines: 3, Logs: 2}},
+		},
+		{
+			desc: "archive with journal exports and klog files from multiple nodes",
+			source: tarForTest(t, []string{"node-1/journal.json", "node-2/var/log/kube-proxy.log.1", "node-2/journal.json.gz"}, map[string][]byte{
+				"node-1/journal.json":             journal,
+				"node-2/var/log/kube-proxy.log.1": kubeProxyLog,
+				"node-2/journal.json.gz":          gzipForTest(t, journalOverlapped),
+			}),
+			want: []nodeLogForTest{
+				{Timestamp: time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC), NodeName: "node-2", Component: "kube-proxy", Message: "I1231 23:59:59.000000       1 proxier.go:1] Syncing iptables rules"},
+				{Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 500000000, time.UTC), NodeName: "node-2", Component: "kube-proxy", Message: "I0101 00:00:00.500000       1 proxier.go:2] panic\ngoroutine 1 [running]:"},
+				{Timestamp: time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC), NodeName: "node-1", Component: "kubelet", Message: "I0101 00:00:01.000000    1234 kubelet.go:1] Started kubelet"},
+				{Timestamp: time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC), NodeName: "node-1", Component: "containerd", Message: "starting containerd"},
+				{Timestamp: time.Date(2024, 1, 1, 0, 0, 3, 0, time.UTC), NodeName: "node-2", Component: "kubelet", Message: "E0101 00:00:03.000000    1234 kubelet.go:2] failed"},
+			},
+			wantStats: []*logFileStats{
+				{Name: "node-1/journal.json", Lines: 2, Logs: 2},
+				{Name: "node-2/var/log/kube-proxy.log.1", Lines: 3, Logs: 2},
+				{Name: "node-2/journal.json.gz", Lines: 2, Logs: 1, Duplicated: 1},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			progress := inspectionmetadata.NewTaskProgressMetadata("test")
+			logs, stats, err := readNodeLogFiles(context.Background(), bytes.NewReader(tc.source), progress, now)
+			if err != nil {
+				t.Fatalf("readNodeLogFiles() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, nodeLogsOf(t, logs)); diff != "" {
+				t.Errorf("read logs mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStats, stats); diff != "" {
+				t.Errorf("stats mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveKLogTimestamp(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		line      string
+		reference time.Time
+		want      time.Time
+	}{
+		{
+			desc:      "same year",
+			line:      "I0601 12:00:00.000001 1 a.go:1] foo",
+			reference: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, 6, 1, 12, 0, 0, 1000, time.UTC),
+		},
+		{
+			desc:      "previous year",
+			line:      "I1231 12:00:00.000000 1 a.go:1] foo",
+			reference: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:      "slightly after the reference time",
+			line:      "I0101 12:00:00.000000 1 a.go:1] foo",
+			reference: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			entry := newKLogEntry("id", tc.line, "node", "component")
+			if entry == nil {
+				t.Fatalf("newKLogEntry() returned nil for a klog line")
+			}
+			got := resolveKLogTimestamp(entry, tc.reference)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("resolveKLogTimestamp() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -27,6 +27,38 @@ import (
 	ossclusterk8s_contract "github.com/GoogleCloudPlatform/khi/pkg/task/inspection/ossclusterk8s/contract"
 )
 
+// streamingSubresources are subresources read with long-running requests. Requests to them are kept even when their verb is read-only to show them as operations.
+var streamingSubresources = map[string]struct{}{
+	"exec":        {},
+	"attach":      {},
+	"portforward": {},
+	"log":         {},
+	"proxy":       {},
+}
+
+// isReadOnlyRequestToIgnore returns true when the log is from a read-only request not worth showing on timelines.
+// Failed requests at the Panic stage, the requests without any response and long-running watches of a named resource are kept even when they are read-only requests.
+// Watches of collections are ignored because they have no resource to show them on.
+func isReadOnlyRequestToIgnore(l *log.Log, longRunningAuditIDs map[string]struct{}) bool {
+	verb := l.ReadStringOrDefault("verb", "")
+	if verb != "" && verb != "get" && verb != "watch" && verb != "list" {
+		return false
+	}
+	if verb == "watch" && l.ReadStringOrDefault("objectRef.name", "") != "" {
+		if _, found := longRunningAuditIDs[l.ReadStringOrDefault("auditID", "")]; found {
+			return false
+		}
+	}
+	stage := l.ReadStringOrDefault("stage", "")
+	if stage == ossclusterk8s_contract.AuditStagePanic || stage == ossclusterk8s_contract.AuditStageRequestReceived {
+		return false
+	}
+	if _, found := streamingSubresources[l.ReadStringOrDefault("objectRef.subresource", "")]; found {
+		return false
+	}
+	return true
+}
+
 var NonEventAuditLogFilterTask = inspectiontaskbase.NewProgressReportableInspectionTask(
 	ossclusterk8s_contract.NonEventAuditLogFilterTaskID,
 	[]taskid.UntypedTaskReference{
@@ -40,10 +72,16 @@ var NonEventAuditLogFilterTask = inspectiontaskbase.NewProgressReportableInspect
 
 		var auditLogs []*log.Log
 
+		longRunningAuditIDs := map[string]struct{}{}
+		for _, l := range logs {
+			if l.ReadStringOrDefault("stage", "") == ossclusterk8s_contract.AuditStageResponseStarted {
+				longRunningAuditIDs[l.ReadStringOrDefault("auditID", "")] = struct{}{}
+			}
+		}
+
 		for _, l := range logs {
-			verb := l.ReadStringOrDefault("verb", "")
 			if l.ReadStringOrDefault("kind", "") == "Event" && l.ReadStringOrDefault("responseObject.kind", "") != "Event" && l.Has("objectRef") {
-				if verb == "" || verb == "get" || verb == "watch" || verb == "list" {
+				if isReadOnlyRequestToIgnore(l, longRunningAuditIDs) {
 					continue
 				}
 				l.LogType = enum.LogTypeAudit
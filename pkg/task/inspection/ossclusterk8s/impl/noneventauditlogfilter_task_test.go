@@ -0,0 +1,103 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+)
+
+func TestIsReadOnlyRequestToIgnore(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		logYAML             string
+		longRunningAuditIDs map[string]struct{}
+		want                bool
+	}{
+		{
+			desc: "create request",
+			logYAML: `auditID: a1
+verb: create
+stage: ResponseComplete`,
+			want: false,
+		},
+		{
+			desc: "get request",
+			logYAML: `auditID: a1
+verb: get
+stage: ResponseComplete`,
+			want: true,
+		},
+		{
+			desc: "get request without response",
+			logYAML: `auditID: a1
+verb: get
+stage: RequestReceived`,
+			want: false,
+		},
+		{
+			desc: "get request to a streaming subresource",
+			logYAML: `auditID: a1
+verb: get
+stage: ResponseComplete
+objectRef:
+  subresource: log`,
+			want: false,
+		},
+		{
+			desc: "long-running watch request",
+			logYAML: `auditID: a1
+verb: watch
+objectRef:
+  name: test-pod
+stage: ResponseComplete`,
+			longRunningAuditIDs: map[string]struct{}{"a1": {}},
+			want:                false,
+		},
+		{
+			desc: "long-running watch request of a collection",
+			logYAML: `auditID: a1
+verb: watch
+objectRef:
+  resource: pods
+stage: ResponseComplete`,
+			longRunningAuditIDs: map[string]struct{}{"a1": {}},
+			want:                true,
+		},
+		{
+			desc: "watch request not logged at the ResponseStarted stage",
+			logYAML: `auditID: a1
+verb: watch
+objectRef:
+  name: test-pod
+stage: ResponseComplete`,
+			longRunningAuditIDs: map[string]struct{}{"a2": {}},
+			want:                true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := log.NewLogFromYAMLString(tc.logYAML)
+			if err != nil {
+				t.Fatalf("failed to parse log: %v", err)
+			}
+			got := isReadOnlyRequestToIgnore(l, tc.longRunningAuditIDs)
+			if got != tc.want {
+				t.Errorf("isReadOnlyRequestToIgnore() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
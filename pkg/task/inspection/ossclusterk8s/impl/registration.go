@@ -28,12 +28,32 @@ func Register(registry coreinspection.InspectionTaskRegistry) error {
 	}
 
 	return coretask.RegisterTasks(registry,
+		InputAuditLogSourceTask,
+		InputAuditWebhookTimeRangeTask,
 		InputAuditLogFilesTask,
+		AuditLogSeverityPolicyTask,
 		AuditLogFileReaderTask,
 		EventAuditLogFilterTask,
 		NonEventAuditLogFilterTask,
 		OSSK8sEventLogParserTask,
 		OSSK8sAuditLogFieldExtractorTask,
 		OSSK8sAuditLogParserTailTask,
+		InputNodeLogFilesTask,
+		NodeLogFileReaderTask,
+		OSSK8sNodeLogParserTailTask,
+		InputContainerLogFilesTask,
+		ContainerLogFileReaderTask,
+		OSSK8sContainerLogParserTailTask,
+		InputK8sEventFilesTask,
+		K8sEventFileReaderTask,
+		OSSK8sEventFileParserTailTask,
+		InputClusterNameTask,
+		InputControlPlaneLogFilesTask,
+		ControlPlaneLogFileReaderTask,
+		ControlPlaneLogCommonFieldSetReaderTask,
+		ControlPlaneSchedulerLogFieldSetReaderTask,
+		ControlPlaneControllerManagerLogFieldSetReaderTask,
+		ControlPlaneOtherLogFieldSetReaderTask,
+		OSSK8sControlPlaneLogParserTailTask,
 	)
 }
@@ -0,0 +1,552 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package elasticsearchstub provides an in-memory HTTP server compatible with the subset of the Elasticsearch and OpenSearch search API used by KHI.
+package elasticsearchstub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Document is a document stored in the stub.
+type Document struct {
+	Index  string
+	ID     string
+	Source map[string]any
+}
+
+// Server is an Elasticsearch or OpenSearch compatible HTTP server serving the given documents.
+// It supports the root API, opening and closing points in time and searches with a point in time, sort and search_after.
+// Queries are limited to `match_all`, `bool` with `filter`, `must`, `must_not` and `should`, `range`, `match_phrase`, `term`, `terms` and `exists`.
+// `match_phrase` and `term` match only the exact string value. String values parsable as RFC3339 timestamps are compared as epoch milliseconds in `range` and sort.
+type Server struct {
+	*httptest.Server
+
+	// openSearch is true when the server behaves as OpenSearch instead of Elasticsearch.
+	openSearch bool
+
+	mu         sync.Mutex
+	documents  []Document
+	pits       map[string][]int
+	pitCounter int
+	requests   []*http.Request
+}
+
+// NewServer starts the stub server behaving as Elasticsearch with the given documents. Call Close after using it.
+func NewServer(documents ...Document) *Server {
+	return newServer(false, documents)
+}
+
+// NewOpenSearchServer starts the stub server behaving as OpenSearch with the given documents. Call Close after using it.
+func NewOpenSearchServer(documents ...Document) *Server {
+	return newServer(true, documents)
+}
+
+func newServer(openSearch bool, documents []Document) *Server {
+	s := &Server{
+		openSearch: openSearch,
+		documents:  documents,
+		pits:       map[string][]int{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleInfo)
+	if openSearch {
+		mux.HandleFunc("POST /{index}/_search/point_in_time", s.handleOpenPointInTime)
+		mux.HandleFunc("DELETE /_search/point_in_time", s.handleClosePointInTime)
+	} else {
+		mux.HandleFunc("POST /{index}/_pit", s.handleOpenPointInTime)
+		mux.HandleFunc("DELETE /_pit", s.handleClosePointInTime)
+	}
+	mux.HandleFunc("POST /_search", s.handleSearch)
+	s.Server = httptest.NewServer(s.recordRequest(mux))
+	return s
+}
+
+// Requests returns the requests received by the server.
+func (s *Server) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// OpenPointInTimeCount returns the count of points in time not closed yet.
+func (s *Server) OpenPointInTimeCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.pits)
+}
+
+func (s *Server) recordRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Clone(r.Context()))
+		s.mu.Unlock()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleInfo(w http.ResponseWriter, r *http.Request) {
+	version := map[string]any{"number": "8.15.0"}
+	if s.openSearch {
+		version = map[string]any{"distribution": "opensearch", "number": "2.15.0"}
+	}
+	writeJSON(w, map[string]any{"name": "stub", "version": version})
+}
+
+func (s *Server) handleOpenPointInTime(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("keep_alive") == "" {
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "keep_alive is required")
+		return
+	}
+	patterns := strings.Split(r.PathValue("index"), ",")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var documentIndices []int
+	for i, document := range s.documents {
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, document.Index); matched {
+				documentIndices = append(documentIndices, i)
+				break
+			}
+		}
+	}
+	s.pitCounter++
+	pitID := fmt.Sprintf("pit-%d", s.pitCounter)
+	s.pits[pitID] = documentIndices
+	if s.openSearch {
+		writeJSON(w, map[string]any{"pit_id": pitID, "creation_time": time.Now().UnixMilli()})
+		return
+	}
+	writeJSON(w, map[string]any{"id": pitID})
+}
+
+func (s *Server) handleClosePointInTime(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		ID    string   `json:"id"`
+		PitID []string `json:"pit_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "parse_exception", err.Error())
+		return
+	}
+	ids := request.PitID
+	if request.ID != "" {
+		ids = append(ids, request.ID)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		if _, found := s.pits[id]; !found {
+			writeError(w, http.StatusNotFound, "search_context_missing_exception", fmt.Sprintf("no search context found for id [%s]", id))
+			return
+		}
+		delete(s.pits, id)
+	}
+	writeJSON(w, map[string]any{"succeeded": true, "num_freed": len(ids)})
+}
+
+type searchRequest struct {
+	Size        *int           `json:"size"`
+	Query       map[string]any `json:"query"`
+	Sort        []any          `json:"sort"`
+	SearchAfter []any          `json:"search_after"`
+	PIT         *struct {
+		ID string `json:"id"`
+	} `json:"pit"`
+}
+
+type sortKey struct {
+	field      string
+	descending bool
+}
+
+type hit struct {
+	Index  string         `json:"_index"`
+	ID     string         `json:"_id"`
+	Source map[string]any `json:"_source"`
+	Sort   []any          `json:"sort,omitempty"`
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	var request searchRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "parse_exception", err.Error())
+		return
+	}
+	if request.PIT == nil {
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "the stub supports only searches with a point in time")
+		return
+	}
+	sortKeys, err := parseSort(request.Sort)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "parsing_exception", err.Error())
+		return
+	}
+	size := 10
+	if request.Size != nil {
+		size = *request.Size
+	}
+
+	s.mu.Lock()
+	documentIndices, found := s.pits[request.PIT.ID]
+	documents := s.documents
+	s.mu.Unlock()
+	if !found {
+		writeError(w, http.StatusNotFound, "search_context_missing_exception", fmt.Sprintf("no search context found for id [%s]", request.PIT.ID))
+		return
+	}
+
+	hits := []hit{}
+	for _, documentIndex := range documentIndices {
+		document := documents[documentIndex]
+		matched, err := matchQuery(request.Query, document.Source)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "parsing_exception", err.Error())
+			return
+		}
+		if !matched {
+			continue
+		}
+		sortValues := make([]any, len(sortKeys))
+		for i, key := range sortKeys {
+			sortValues[i] = sortValue(key.field, documentIndex, document)
+		}
+		hits = append(hits, hit{Index: document.Index, ID: document.ID, Source: document.Source, Sort: sortValues})
+	}
+	slices.SortStableFunc(hits, func(a, b hit) int {
+		return compareSortValues(sortKeys, a.Sort, b.Sort)
+	})
+	if len(request.SearchAfter) > 0 {
+		if len(request.SearchAfter) != len(sortKeys) {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", "search_after has a different number of values from sort")
+			return
+		}
+		hits = slices.DeleteFunc(hits, func(h hit) bool {
+			return compareSortValues(sortKeys, h.Sort, request.SearchAfter) <= 0
+		})
+	}
+	if len(hits) > size {
+		hits = hits[:size]
+	}
+	writeJSON(w, map[string]any{
+		"pit_id":    request.PIT.ID,
+		"timed_out": false,
+		"hits":      map[string]any{"hits": hits},
+	})
+}
+
+// parseSort reads the sort parameter in the forms of `"field"`, `{"field": "asc"}` or `{"field": {"order": "asc"}}`.
+func parseSort(sort []any) ([]sortKey, error) {
+	var result []sortKey
+	for _, element := range sort {
+		switch element := element.(type) {
+		case string:
+			result = append(result, sortKey{field: element})
+		case map[string]any:
+			for field, order := range element {
+				orderStr, isString := order.(string)
+				if orderMap, isMap := order.(map[string]any); isMap {
+					orderStr, _ = orderMap["order"].(string)
+				} else if !isString {
+					return nil, fmt.Errorf("unsupported sort order %v", order)
+				}
+				result = append(result, sortKey{field: field, descending: orderStr == "desc"})
+			}
+		default:
+			return nil, fmt.Errorf("unsupported sort %v", element)
+		}
+	}
+	return result, nil
+}
+
+// sortValue returns the value of the field used in the sort. `_shard_doc` is the order of documents given to the server.
+func sortValue(field string, documentIndex int, document Document) any {
+	switch field {
+	case "_shard_doc":
+		return float64(documentIndex)
+	case "_id":
+		return document.ID
+	}
+	value, found := lookupField(document.Source, field)
+	if !found {
+		return nil
+	}
+	return comparableValue(value)
+}
+
+func compareSortValues(keys []sortKey, a []any, b []any) int {
+	for i, key := range keys {
+		result := compareValues(comparableValue(a[i]), comparableValue(b[i]))
+		if key.descending {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// compareValues compares numbers or strings. nil is ordered after any other values like missing values in Elasticsearch.
+func compareValues(a any, b any) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		default:
+			return -1
+		}
+	}
+	if aNumber, ok := a.(float64); ok {
+		if bNumber, ok := b.(float64); ok {
+			switch {
+			case aNumber < bNumber:
+				return -1
+			case aNumber > bNumber:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// comparableValue converts numbers to float64 and timestamps to epoch milliseconds in float64.
+func comparableValue(value any) any {
+	switch value := value.(type) {
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case float64:
+		return value
+	case json.Number:
+		f, _ := value.Float64()
+		return f
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return float64(t.UnixMilli())
+		}
+		return value
+	case time.Time:
+		return float64(value.UnixMilli())
+	default:
+		return value
+	}
+}
+
+// lookupField returns the value at the dotted path. A key containing dots matches the path as well as nested objects.
+func lookupField(source map[string]any, field string) (any, bool) {
+	if value, found := source[field]; found {
+		return value, true
+	}
+	segments := strings.Split(field, ".")
+	for i := len(segments) - 1; i > 0; i-- {
+		child, found := source[strings.Join(segments[:i], ".")]
+		if !found {
+			continue
+		}
+		childMap, isMap := child.(map[string]any)
+		if !isMap {
+			continue
+		}
+		if value, found := lookupField(childMap, strings.Join(segments[i:], ".")); found {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func matchQuery(query map[string]any, source map[string]any) (bool, error) {
+	if len(query) == 0 {
+		return true, nil
+	}
+	if len(query) != 1 {
+		return false, fmt.Errorf("a query must have exactly one clause: %v", query)
+	}
+	for kind, body := range query {
+		switch kind {
+		case "match_all":
+			return true, nil
+		case "bool":
+			return matchBool(body, source)
+		case "range":
+			return matchFieldQuery(body, source, matchRange)
+		case "match_phrase", "term":
+			return matchFieldQuery(body, source, func(value any, condition any) (bool, error) {
+				if conditionMap, isMap := condition.(map[string]any); isMap {
+					if query, found := conditionMap["query"]; found {
+						condition = query
+					} else {
+						condition = conditionMap["value"]
+					}
+				}
+				return fmt.Sprint(value) == fmt.Sprint(condition), nil
+			})
+		case "terms":
+			return matchFieldQuery(body, source, func(value any, condition any) (bool, error) {
+				conditions, isList := condition.([]any)
+				if !isList {
+					return false, fmt.Errorf("terms query must have a list of values")
+				}
+				for _, c := range conditions {
+					if fmt.Sprint(value) == fmt.Sprint(c) {
+						return true, nil
+					}
+				}
+				return false, nil
+			})
+		case "exists":
+			bodyMap, isMap := body.(map[string]any)
+			if !isMap {
+				return false, fmt.Errorf("invalid exists query %v", body)
+			}
+			field, _ := bodyMap["field"].(string)
+			value, found := lookupField(source, field)
+			return found && value != nil, nil
+		default:
+			return false, fmt.Errorf("unsupported query %q", kind)
+		}
+	}
+	return false, nil
+}
+
+func matchBool(body any, source map[string]any) (bool, error) {
+	bodyMap, isMap := body.(map[string]any)
+	if !isMap {
+		return false, fmt.Errorf("invalid bool query %v", body)
+	}
+	clauses := func(key string) []map[string]any {
+		var result []map[string]any
+		switch value := bodyMap[key].(type) {
+		case map[string]any:
+			result = append(result, value)
+		case []any:
+			for _, element := range value {
+				if elementMap, isMap := element.(map[string]any); isMap {
+					result = append(result, elementMap)
+				}
+			}
+		}
+		return result
+	}
+	for _, key := range []string{"filter", "must"} {
+		for _, clause := range clauses(key) {
+			matched, err := matchQuery(clause, source)
+			if err != nil || !matched {
+				return false, err
+			}
+		}
+	}
+	for _, clause := range clauses("must_not") {
+		matched, err := matchQuery(clause, source)
+		if err != nil || matched {
+			return false, err
+		}
+	}
+	should := clauses("should")
+	minimumShouldMatch := 0
+	if len(clauses("filter")) == 0 && len(clauses("must")) == 0 && len(should) > 0 {
+		minimumShouldMatch = 1
+	}
+	if value, found := bodyMap["minimum_should_match"].(float64); found {
+		minimumShouldMatch = int(value)
+	}
+	matchedCount := 0
+	for _, clause := range should {
+		matched, err := matchQuery(clause, source)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			matchedCount++
+		}
+	}
+	return matchedCount >= minimumShouldMatch, nil
+}
+
+// matchFieldQuery evaluates a query in the form of `{"field": condition}` with the given matcher. Documents without the field don't match.
+func matchFieldQuery(body any, source map[string]any, matcher func(value any, condition any) (bool, error)) (bool, error) {
+	bodyMap, isMap := body.(map[string]any)
+	if !isMap || len(bodyMap) != 1 {
+		return false, fmt.Errorf("invalid field query %v", body)
+	}
+	for field, condition := range bodyMap {
+		value, found := lookupField(source, field)
+		if !found || value == nil {
+			return false, nil
+		}
+		return matcher(value, condition)
+	}
+	return false, nil
+}
+
+// matchRange evaluates the range condition with `gt`, `gte`, `lt` and `lte`.
+func matchRange(value any, condition any) (bool, error) {
+	conditionMap, isMap := condition.(map[string]any)
+	if !isMap {
+		return false, fmt.Errorf("invalid range condition %v", condition)
+	}
+	compared := comparableValue(value)
+	for operator, bound := range conditionMap {
+		result := compareValues(compared, comparableValue(bound))
+		switch operator {
+		case "gt":
+			if result <= 0 {
+				return false, nil
+			}
+		case "gte":
+			if result < 0 {
+				return false, nil
+			}
+		case "lt":
+			if result >= 0 {
+				return false, nil
+			}
+		case "lte":
+			if result > 0 {
+				return false, nil
+			}
+		case "format":
+		default:
+			return false, fmt.Errorf("unsupported range operator %q", operator)
+		}
+	}
+	return true, nil
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, errorType string, reason string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"error":  map[string]any{"type": errorType, "reason": reason},
+		"status": status,
+	})
+}
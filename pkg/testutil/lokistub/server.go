@@ -0,0 +1,438 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lokistub provides an in-memory HTTP server compatible with the subset of the Grafana Loki API used by KHI.
+package lokistub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is a log line stored in the stub.
+type Entry struct {
+	Timestamp time.Time
+	Line      string
+}
+
+// Stream is a set of log lines sharing the same labels.
+type Stream struct {
+	Labels  map[string]string
+	Entries []Entry
+}
+
+// Server is a Loki compatible HTTP server serving the given streams.
+// It supports `query_range` in the forward direction and the label values API. LogQL queries are limited to stream selectors,
+// line filters (`|=`, `!=`, `|~`, `!~`), the `json` parser with parameters and label filters with `=`, `!=`, `=~` or `!~`.
+type Server struct {
+	*httptest.Server
+	// DefaultLimit is the limit used when the request doesn't specify it.
+	DefaultLimit int
+
+	mu       sync.Mutex
+	streams  []Stream
+	requests []*http.Request
+}
+
+// NewServer starts the stub server with the given streams. Call Close after using it.
+func NewServer(streams ...Stream) *Server {
+	s := &Server{
+		DefaultLimit: 100,
+		streams:      streams,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/loki/api/v1/query_range", s.handleQueryRange)
+	mux.HandleFunc("/loki/api/v1/label/{name}/values", s.handleLabelValues)
+	s.Server = httptest.NewServer(s.recordRequest(mux))
+	return s
+}
+
+// Requests returns the requests received by the server.
+func (s *Server) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+func (s *Server) recordRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Clone(r.Context()))
+		s.mu.Unlock()
+		next.ServeHTTP(w, r)
+	})
+}
+
+type queryRangeStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][]string        `json:"values"`
+}
+
+func (s *Server) handleQueryRange(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query, err := parseQuery(params.Get("query"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	start, err := parseNanoTime(params.Get("start"), time.Unix(0, 0))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	end, err := parseNanoTime(params.Get("end"), time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if direction := params.Get("direction"); direction != "" && direction != "forward" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unsupported direction %q", direction))
+		return
+	}
+	limit := s.DefaultLimit
+	if limitStr := params.Get("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	type matchedEntry struct {
+		labels map[string]string
+		entry  Entry
+	}
+	var matched []matchedEntry
+	for _, stream := range s.streams {
+		if !query.matchStream(stream.Labels) {
+			continue
+		}
+		for _, entry := range stream.Entries {
+			if entry.Timestamp.Before(start) || !entry.Timestamp.Before(end) {
+				continue
+			}
+			labels, ok := query.matchLine(stream.Labels, entry.Line)
+			if !ok {
+				continue
+			}
+			matched = append(matched, matchedEntry{labels: labels, entry: entry})
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].entry.Timestamp.Before(matched[j].entry.Timestamp)
+	})
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+
+	streams := []*queryRangeStream{}
+	streamsByLabels := map[string]*queryRangeStream{}
+	for _, m := range matched {
+		key := labelsString(m.labels)
+		stream, found := streamsByLabels[key]
+		if !found {
+			stream = &queryRangeStream{Stream: m.labels}
+			streamsByLabels[key] = stream
+			streams = append(streams, stream)
+		}
+		stream.Values = append(stream.Values, []string{strconv.FormatInt(m.entry.Timestamp.UnixNano(), 10), m.entry.Line})
+	}
+	writeJSON(w, map[string]any{
+		"status": "success",
+		"data": map[string]any{
+			"resultType": "streams",
+			"result":     streams,
+		},
+	})
+}
+
+func (s *Server) handleLabelValues(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	query := &logQuery{}
+	if queryStr := r.URL.Query().Get("query"); queryStr != "" {
+		var err error
+		query, err = parseQuery(queryStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	values := []string{}
+	for _, stream := range s.streams {
+		value, found := stream.Labels[name]
+		if !found || !query.matchStream(stream.Labels) || slices.Contains(values, value) {
+			continue
+		}
+		values = append(values, value)
+	}
+	slices.Sort(values)
+	writeJSON(w, map[string]any{
+		"status": "success",
+		"data":   values,
+	})
+}
+
+func parseNanoTime(value string, defaultTime time.Time) (time.Time, error) {
+	if value == "" {
+		return defaultTime, nil
+	}
+	nanos, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+	}
+	return time.Unix(0, nanos), nil
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+	w.Write([]byte(err.Error()))
+}
+
+func labelsString(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", key, labels[key]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// labelMatcher is a matcher on a label like `job="foo"`.
+type labelMatcher struct {
+	name     string
+	operator string
+	value    string
+	regex    *regexp.Regexp
+}
+
+func newLabelMatcher(name, operator, value string) (*labelMatcher, error) {
+	matcher := &labelMatcher{name: name, operator: operator, value: value}
+	if operator == "=~" || operator == "!~" {
+		regex, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return nil, err
+		}
+		matcher.regex = regex
+	}
+	return matcher, nil
+}
+
+func (m *labelMatcher) match(labels map[string]string) bool {
+	value := labels[m.name]
+	switch m.operator {
+	case "=":
+		return value == m.value
+	case "!=":
+		return value != m.value
+	case "=~":
+		return m.regex.MatchString(value)
+	default:
+		return !m.regex.MatchString(value)
+	}
+}
+
+// pipelineStage is a stage after the stream selector. It returns false when the line is filtered out.
+type pipelineStage func(labels map[string]string, line string) bool
+
+type logQuery struct {
+	selector []*labelMatcher
+	pipeline []pipelineStage
+}
+
+func (q *logQuery) matchStream(labels map[string]string) bool {
+	for _, matcher := range q.selector {
+		if !matcher.match(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchLine returns the labels of the line after running the pipeline and true when the line passes all stages.
+func (q *logQuery) matchLine(streamLabels map[string]string, line string) (map[string]string, bool) {
+	labels := make(map[string]string, len(streamLabels))
+	for key, value := range streamLabels {
+		labels[key] = value
+	}
+	for _, stage := range q.pipeline {
+		if !stage(labels, line) {
+			return nil, false
+		}
+	}
+	return labels, true
+}
+
+var (
+	commentPattern     = regexp.MustCompile(`(?m)^\s*#.*$|#[^"\n]*$`)
+	matcherPattern     = regexp.MustCompile(`^\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*(=~|!~|!=|=)\s*("(?:[^"\\]|\\.)*")\s*`)
+	lineFilterPattern  = regexp.MustCompile(`^\s*(\|=|!=|\|~|!~)\s*("(?:[^"\\]|\\.)*")\s*`)
+	jsonStagePattern   = regexp.MustCompile(`^\s*\|\s*json\b\s*`)
+	labelFilterPattern = regexp.MustCompile(`^\s*\|\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*(=~|!~|!=|=)\s*("(?:[^"\\]|\\.)*")\s*`)
+)
+
+func parseQuery(query string) (*logQuery, error) {
+	rest := strings.TrimSpace(commentPattern.ReplaceAllString(query, ""))
+	if !strings.HasPrefix(rest, "{") {
+		return nil, fmt.Errorf("query must start with a stream selector: %q", query)
+	}
+	rest = rest[1:]
+	result := &logQuery{}
+	for {
+		rest = strings.TrimSpace(rest)
+		if strings.HasPrefix(rest, "}") {
+			rest = rest[1:]
+			break
+		}
+		rest = strings.TrimPrefix(rest, ",")
+		match := matcherPattern.FindStringSubmatch(rest)
+		if match == nil {
+			return nil, fmt.Errorf("invalid stream selector in query: %q", query)
+		}
+		value, err := strconv.Unquote(match[3])
+		if err != nil {
+			return nil, err
+		}
+		matcher, err := newLabelMatcher(match[1], match[2], value)
+		if err != nil {
+			return nil, err
+		}
+		result.selector = append(result.selector, matcher)
+		rest = rest[len(match[0]):]
+	}
+	for {
+		rest = strings.TrimSpace(rest)
+		if rest == "" {
+			return result, nil
+		}
+		if match := lineFilterPattern.FindStringSubmatch(rest); match != nil {
+			stage, err := newLineFilterStage(match[1], match[2])
+			if err != nil {
+				return nil, err
+			}
+			result.pipeline = append(result.pipeline, stage)
+			rest = rest[len(match[0]):]
+			continue
+		}
+		if match := jsonStagePattern.FindString(rest); match != "" {
+			rest = rest[len(match):]
+			stage, consumed, err := newJSONStage(rest)
+			if err != nil {
+				return nil, err
+			}
+			result.pipeline = append(result.pipeline, stage)
+			rest = rest[consumed:]
+			continue
+		}
+		if match := labelFilterPattern.FindStringSubmatch(rest); match != nil {
+			value, err := strconv.Unquote(match[3])
+			if err != nil {
+				return nil, err
+			}
+			matcher, err := newLabelMatcher(match[1], match[2], value)
+			if err != nil {
+				return nil, err
+			}
+			result.pipeline = append(result.pipeline, func(labels map[string]string, line string) bool {
+				return matcher.match(labels)
+			})
+			rest = rest[len(match[0]):]
+			continue
+		}
+		return nil, fmt.Errorf("unsupported pipeline stage in query: %q", rest)
+	}
+}
+
+func newLineFilterStage(operator string, quotedValue string) (pipelineStage, error) {
+	value, err := strconv.Unquote(quotedValue)
+	if err != nil {
+		return nil, err
+	}
+	switch operator {
+	case "|=":
+		return func(labels map[string]string, line string) bool { return strings.Contains(line, value) }, nil
+	case "!=":
+		return func(labels map[string]string, line string) bool { return !strings.Contains(line, value) }, nil
+	}
+	regex, err := regexp.Compile(value)
+	if err != nil {
+		return nil, err
+	}
+	if operator == "|~" {
+		return func(labels map[string]string, line string) bool { return regex.MatchString(line) }, nil
+	}
+	return func(labels map[string]string, line string) bool { return !regex.MatchString(line) }, nil
+}
+
+var jsonParameterPattern = regexp.MustCompile(`^\s*,?\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*=\s*("(?:[^"\\]|\\.)*")\s*`)
+
+// newJSONStage parses the parameters of the json parser like `json a="foo.bar", b="baz"`. It returns the number of consumed bytes.
+func newJSONStage(rest string) (pipelineStage, int, error) {
+	parameters := map[string][]string{}
+	consumed := 0
+	for {
+		match := jsonParameterPattern.FindStringSubmatch(rest[consumed:])
+		if match == nil {
+			break
+		}
+		path, err := strconv.Unquote(match[2])
+		if err != nil {
+			return nil, 0, err
+		}
+		parameters[match[1]] = strings.Split(path, ".")
+		consumed += len(match[0])
+	}
+	if len(parameters) == 0 {
+		return nil, 0, fmt.Errorf("json parser without parameters is not supported in the stub")
+	}
+	return func(labels map[string]string, line string) bool {
+		var parsed map[string]any
+		if err := json.Unmarshal([]byte(line), &parsed); err != nil {
+			labels["__error__"] = "JSONParserErr"
+			return true
+		}
+		for label, path := range parameters {
+			var current any = parsed
+			for _, segment := range path {
+				object, ok := current.(map[string]any)
+				if !ok {
+					current = nil
+					break
+				}
+				current = object[segment]
+			}
+			if value, ok := current.(string); ok {
+				labels[label] = value
+			}
+		}
+		return true
+	}, consumed, nil
+}
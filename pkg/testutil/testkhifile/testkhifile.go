@@ -0,0 +1,97 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testkhifile
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	inspectionmetadata "github.com/GoogleCloudPlatform/khi/pkg/core/inspection/metadata"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/enum"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/history/resourcepath"
+	"github.com/GoogleCloudPlatform/khi/pkg/model/log"
+)
+
+// BaseTime is the timestamp of the log of an Entry with zero Offset.
+var BaseTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Entry is a log with a revision or an event written to the generated .khi file.
+type Entry struct {
+	InsertID string
+	// Severity is the severity written in the log. The log is an error log when it's `ERROR`, otherwise it's an info log.
+	Severity string
+	Summary  string
+	// Offset is the duration from BaseTime to the timestamp of the log.
+	Offset time.Duration
+	Path   resourcepath.ResourcePath
+	// Revision is added to Path when it's not nil. Otherwise an event is added. ChangeTime of the revision is overwritten with the timestamp of the log.
+	Revision *history.StagingResourceRevision
+}
+
+// Generate returns a .khi file containing the given entries. header is written as the header metadata when it's not nil.
+func Generate(t *testing.T, header *inspectionmetadata.HeaderMetadata, entries []Entry) []byte {
+	t.Helper()
+	builder := history.NewBuilder(t.TempDir())
+	logs := []*log.Log{}
+	changeSets := []*history.ChangeSet{}
+	for _, e := range entries {
+		timestamp := BaseTime.Add(e.Offset)
+		l, err := log.NewLogFromYAMLString(fmt.Sprintf("insertId: %s\nseverity: %s\ntimestamp: %q", e.InsertID, e.Severity, timestamp.Format(time.RFC3339)))
+		if err != nil {
+			t.Fatalf("failed to generate a log: %v", err)
+		}
+		severity := enum.SeverityInfo
+		if e.Severity == "ERROR" {
+			severity = enum.SeverityError
+		}
+		l.SetFieldSet(&log.CommonFieldSet{
+			DisplayID: e.InsertID,
+			Severity:  severity,
+			Timestamp: timestamp,
+		})
+		logs = append(logs, l)
+		cs := history.NewChangeSet(l)
+		if e.Summary != "" {
+			cs.SetLogSummary(e.Summary)
+		}
+		if e.Revision != nil {
+			e.Revision.ChangeTime = timestamp
+			cs.AddRevision(e.Path, e.Revision)
+		} else {
+			cs.AddEvent(e.Path)
+		}
+		changeSets = append(changeSets, cs)
+	}
+	if err := builder.SerializeLogs(t.Context(), logs, func() {}); err != nil {
+		t.Fatalf("failed to serialize logs: %v", err)
+	}
+	for _, cs := range changeSets {
+		if _, err := cs.FlushToHistory(builder); err != nil {
+			t.Fatalf("failed to flush the changeset: %v", err)
+		}
+	}
+	metadata := map[string]any{}
+	if header != nil {
+		metadata["header"] = header
+	}
+	var buf bytes.Buffer
+	if _, err := builder.Finalize(t.Context(), metadata, &buf, inspectionmetadata.NewTaskProgressMetadata("testkhifile")); err != nil {
+		t.Fatalf("failed to finalize: %v", err)
+	}
+	return buf.Bytes()
+}